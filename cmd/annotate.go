@@ -0,0 +1,41 @@
+package cmd
+
+import (
+	"OTakumi/todogo/internal/domain/model"
+	"context"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+func init() {
+	rootCmd.AddCommand(annotateCmd)
+}
+
+// annotateCmd はタスクに日時付きの注記を追加するコマンドの定義
+var annotateCmd = &cobra.Command{
+	Use:   "annotate <id> <text>...",
+	Short: "Add a timestamped note to a task",
+	Long: `Add a short, timestamped note to a task, for example to record progress:
+
+  todo_cli annotate 3 "called vendor, quote expected friday"
+
+The words after the ID are joined with spaces, so quoting is optional.
+Notes are kept in the order they were added and shown by "show".
+Use "edit --notes" to write the task's long-form description instead.`,
+	Args:         cobra.MinimumNArgs(2),
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		text, err := model.NormalizeAnnotation(strings.Join(args[1:], " "))
+		if err != nil {
+			return err
+		}
+
+		return runForEachID(cmd, args[:1], func(ctx context.Context, id string) (string, error) {
+			if _, err := taskUsecase.Annotate(ctx, id, text); err != nil {
+				return "", err
+			}
+			return "annotated", nil
+		})
+	},
+}
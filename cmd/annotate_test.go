@@ -0,0 +1,68 @@
+package cmd
+
+import (
+	"OTakumi/todogo/internal/domain/model"
+	"OTakumi/todogo/internal/repository"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// TestAnnotateCommand_AddsAnnotation はID以降の引数を空白で連結して注記を追加することを確認するテスト
+func TestAnnotateCommand_AddsAnnotation(t *testing.T) {
+	// Arrange
+	mockUsecase := new(MockTaskUsecase)
+	originalTaskUsecase := taskUsecase
+	taskUsecase = mockUsecase
+	defer func() { taskUsecase = originalTaskUsecase }()
+	stubResolveID(mockUsecase, "id-1")
+
+	mockUsecase.On("Annotate", mock.Anything, "id-1", "called vendor, quote expected").
+		Return(&model.Annotation{ID: 1, Text: "called vendor, quote expected", CreatedAt: time.Now()}, nil)
+
+	// Act
+	out, err := executeCommand("annotate", "id-1", "called", "vendor,", "quote expected")
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Contains(t, out, "id-1: annotated")
+	mockUsecase.AssertExpectations(t)
+}
+
+// TestAnnotateCommand_ErrorWhenTextEmpty は空の注記を追加しないことを確認するテスト
+func TestAnnotateCommand_ErrorWhenTextEmpty(t *testing.T) {
+	// Arrange
+	mockUsecase := new(MockTaskUsecase)
+	originalTaskUsecase := taskUsecase
+	taskUsecase = mockUsecase
+	defer func() { taskUsecase = originalTaskUsecase }()
+
+	// Act
+	_, err := executeCommand("annotate", "id-1", "  ")
+
+	// Assert
+	assert.ErrorContains(t, err, "annotation text is required")
+	mockUsecase.AssertNotCalled(t, "Annotate", mock.Anything, mock.Anything, mock.Anything)
+}
+
+// TestAnnotateCommand_ErrorWhenTaskNotFound は存在しないタスクの場合にエラーを返すことを確認するテスト
+func TestAnnotateCommand_ErrorWhenTaskNotFound(t *testing.T) {
+	// Arrange
+	mockUsecase := new(MockTaskUsecase)
+	originalTaskUsecase := taskUsecase
+	taskUsecase = mockUsecase
+	defer func() { taskUsecase = originalTaskUsecase }()
+	stubResolveID(mockUsecase, "id-1")
+
+	mockUsecase.On("Annotate", mock.Anything, "id-1", "called vendor").
+		Return(nil, &repository.TaskNotFoundError{ID: "id-1"})
+
+	// Act
+	out, err := executeCommand("annotate", "id-1", "called vendor")
+
+	// Assert
+	assert.Error(t, err)
+	assert.Contains(t, out, "id-1: error:")
+}
@@ -0,0 +1,76 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/spf13/cobra"
+)
+
+// block/unblockコマンドのフラグの値を格納する変数
+var (
+	blockOn   string
+	unblockOn string
+)
+
+func init() {
+	rootCmd.AddCommand(blockCmd)
+	rootCmd.AddCommand(unblockCmd)
+
+	blockCmd.Flags().StringVar(&blockOn, "on", "", "The task that must be completed first (required)")
+	_ = blockCmd.MarkFlagRequired("on")
+	unblockCmd.Flags().StringVar(&unblockOn, "on", "", "The task to no longer wait for (required)")
+	_ = unblockCmd.MarkFlagRequired("on")
+}
+
+// blockCmd はタスクの依存関係を追加するコマンドの定義
+var blockCmd = &cobra.Command{
+	Use:   "block <id>... --on <id>",
+	Short: "Mark tasks as blocked by another task",
+	Long: `Mark one or more tasks as blocked by the task given with --on.
+
+A blocked task is not listed by "list --ready" until every task it is blocked
+by is complete. Dependencies cannot form a cycle: a task cannot be blocked by
+a task that is (directly or indirectly) waiting for it.
+
+  todo_cli block 3 --on 2    task 3 waits for task 2
+
+Use "show" to see the full chain of tasks a task waits for and is waited on by.`,
+	Args:         cobra.MinimumNArgs(1),
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		blockedByID, err := resolveID(context.Background(), blockOn)
+		if err != nil {
+			return fmt.Errorf("invalid --on: %w", err)
+		}
+
+		return runForEachID(cmd, args, func(ctx context.Context, id string) (string, error) {
+			if err := taskUsecase.Block(ctx, id, blockedByID); err != nil {
+				return "", err
+			}
+			return "blocked by " + blockedByID, nil
+		})
+	},
+}
+
+// unblockCmd はタスクの依存関係を削除するコマンドの定義
+var unblockCmd = &cobra.Command{
+	Use:          "unblock <id>... --on <id>",
+	Short:        "Remove a blocking task",
+	Long:         `Remove the dependency of one or more tasks on the task given with --on.`,
+	Args:         cobra.MinimumNArgs(1),
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		blockedByID, err := resolveID(context.Background(), unblockOn)
+		if err != nil {
+			return fmt.Errorf("invalid --on: %w", err)
+		}
+
+		return runForEachID(cmd, args, func(ctx context.Context, id string) (string, error) {
+			if err := taskUsecase.Unblock(ctx, id, blockedByID); err != nil {
+				return "", err
+			}
+			return "no longer blocked by " + blockedByID, nil
+		})
+	},
+}
@@ -0,0 +1,65 @@
+package cmd
+
+import (
+	"OTakumi/todogo/internal/domain/service"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// TestBlockCommand_AddsDependency は--onで指定したタスクへの依存関係が追加されることを確認するテスト
+func TestBlockCommand_AddsDependency(t *testing.T) {
+	// Arrange
+	mockUsecase := new(MockTaskUsecase)
+	originalTaskUsecase := taskUsecase
+	taskUsecase = mockUsecase
+	defer func() { taskUsecase = originalTaskUsecase }()
+	defer resetFlags(blockCmd)
+	stubResolveID(mockUsecase, "id-1", "id-2", "id-3")
+
+	mockUsecase.On("Block", mock.Anything, "id-2", "id-1").Return(nil)
+	mockUsecase.On("Block", mock.Anything, "id-3", "id-1").
+		Return(fmt.Errorf("%w: id-3 -> id-1 -> id-3", service.ErrDependencyCycle))
+
+	// Act
+	out, err := executeCommand("block", "id-2", "id-3", "--on", "id-1")
+
+	// Assert
+	// 循環する依存関係のみエラーとなること
+	assert.Error(t, err)
+	assert.Contains(t, out, "id-2: blocked by id-1")
+	assert.Contains(t, out, "id-3: error: dependency would create a cycle: id-3 -> id-1 -> id-3")
+	mockUsecase.AssertExpectations(t)
+}
+
+// TestBlockCommand_RequiresOn は--onが必須であることを確認するテスト
+func TestBlockCommand_RequiresOn(t *testing.T) {
+	// Act
+	_, err := executeCommand("block", "id-2")
+
+	// Assert
+	assert.ErrorContains(t, err, `required flag(s) "on" not set`)
+}
+
+// TestUnblockCommand_RemovesDependency は依存関係が削除されることを確認するテスト
+func TestUnblockCommand_RemovesDependency(t *testing.T) {
+	// Arrange
+	mockUsecase := new(MockTaskUsecase)
+	originalTaskUsecase := taskUsecase
+	taskUsecase = mockUsecase
+	defer func() { taskUsecase = originalTaskUsecase }()
+	defer resetFlags(unblockCmd)
+	stubResolveID(mockUsecase, "id-1", "id-2")
+
+	mockUsecase.On("Unblock", mock.Anything, "id-2", "id-1").Return(nil)
+
+	// Act
+	out, err := executeCommand("unblock", "id-2", "--on", "id-1")
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Contains(t, out, "id-2: no longer blocked by id-1")
+	mockUsecase.AssertExpectations(t)
+}
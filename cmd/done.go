@@ -0,0 +1,131 @@
+package cmd
+
+import (
+	"OTakumi/todogo/internal/domain/model"
+	"OTakumi/todogo/internal/domain/service"
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/spf13/cobra"
+)
+
+// doneコマンドのフラグの値を格納する変数
+var doneCascade bool
+
+func init() {
+	rootCmd.AddCommand(doneCmd)
+	rootCmd.AddCommand(reopenCmd)
+
+	doneCmd.Flags().BoolVar(&doneCascade, "cascade", false, "Also complete all open subtasks")
+}
+
+// doneCmd はタスクを完了済みにするコマンドの定義
+var doneCmd = &cobra.Command{
+	Use:   "done <id>...",
+	Short: "Mark tasks as complete",
+	Long: `Mark one or more tasks as complete.
+
+Completing a task moves it to the first terminal status of the workflow
+("done" by default; see the status command). Tasks that are already in a
+terminal status are left unchanged and reported as such. A task whose current
+status does not allow that transition is reported as an error.
+
+A task with open subtasks cannot be completed on its own. Use --cascade to
+complete all of its open subtasks, at every level, together with it.
+
+Completing a repeating task (see "repeat") creates its next occurrence with a
+new ID and the next deadline from the rule, and reports both.`,
+	Args:         cobra.MinimumNArgs(1),
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runForEachID(cmd, args, func(ctx context.Context, id string) (string, error) {
+			msg, err := completeTask(ctx, id, doneCascade)
+			var openErr *service.OpenSubtasksError
+			if !doneCascade && errors.As(err, &openErr) {
+				return "", fmt.Errorf("%w (use --cascade to complete them as well)", err)
+			}
+			return msg, err
+		})
+	},
+}
+
+// reopenCmd は完了済みのタスクを未完了に戻すコマンドの定義
+var reopenCmd = &cobra.Command{
+	Use:   "reopen <id>...",
+	Short: "Mark tasks as incomplete",
+	Long: `Mark one or more completed tasks as incomplete again.
+
+The tasks are moved back to the initial status of the workflow ("open" by
+default). Tasks that are not complete are left unchanged and reported as such. A subtask
+cannot be reopened while its parent task is complete; reopen the parent first.
+
+This command was called "undo" in earlier versions; "undo" now reverts the
+last commands instead.`,
+	Args:         cobra.MinimumNArgs(1),
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runForEachID(cmd, args, func(ctx context.Context, id string) (string, error) {
+			return setComplete(ctx, id, false)
+		})
+	},
+}
+
+// setComplete はタスクの完了状態を変更し、結果メッセージを返す
+// 完了にする場合はワークフローの完了の状態（Workflow.Done）に、未完了に戻す場合は初期状態に変更する
+// 既に指定の状態（終了状態かどうか）であれば更新は行わない
+func setComplete(ctx context.Context, id string, complete bool) (string, error) {
+	if complete {
+		return completeTask(ctx, id, false)
+	}
+
+	task, err := taskUsecase.GetTask(ctx, id)
+	if err != nil {
+		return "", err
+	}
+	if !workflow.IsClosed(task) {
+		return "already incomplete", nil
+	}
+	if _, err := taskUsecase.SetStatus(ctx, id, workflow.Initial); err != nil {
+		return "", err
+	}
+	return "marked as incomplete", nil
+}
+
+// completeTask はタスクをワークフローの完了の状態（Workflow.Done）にし、結果メッセージを返す
+// cascade が true の場合は、配下の未完了のサブタスクも同じ操作のまとまりで完了の状態にする
+// 既に終了状態であれば更新は行わない
+func completeTask(ctx context.Context, id string, cascade bool) (string, error) {
+	task, err := taskUsecase.GetTask(ctx, id)
+	if err != nil {
+		return "", err
+	}
+	if workflow.IsClosed(task) {
+		return "already complete", nil
+	}
+
+	// 繰り返すタスクの次の締切は、設定されたタイムゾーンの暦で計算する
+	loc, err := timeLocation()
+	if err != nil {
+		return "", err
+	}
+	var next *model.Task
+	completed := 0
+	if cascade {
+		next, completed, err = taskUsecase.CompleteTaskWithSubtasks(ctx, task, loc)
+	} else {
+		next, err = taskUsecase.CompleteTask(ctx, task, loc)
+	}
+	if err != nil {
+		return "", err
+	}
+
+	msg := "marked as complete"
+	if completed > 0 {
+		msg += fmt.Sprintf(" with %d subtask(s)", completed)
+	}
+	if next != nil {
+		msg += fmt.Sprintf("; next occurrence %s due %s", next.ID, next.Deadline.In(loc).Format("2006-01-02 15:04"))
+	}
+	return msg, nil
+}
@@ -0,0 +1,159 @@
+package cmd
+
+import (
+	"OTakumi/todogo/internal/domain/model"
+	"OTakumi/todogo/internal/domain/service"
+	"OTakumi/todogo/internal/repository"
+	"testing"
+	"time"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// TestDoneCommand_MarksTasksComplete は複数のタスクを完了済みにできることを確認するテスト
+func TestDoneCommand_MarksTasksComplete(t *testing.T) {
+	// Arrange
+	mockUsecase := new(MockTaskUsecase)
+	originalTaskUsecase := taskUsecase
+	taskUsecase = mockUsecase
+	defer func() { taskUsecase = originalTaskUsecase }()
+	stubResolveID(mockUsecase, "id-1", "id-2")
+
+	mockUsecase.On("GetTask", mock.Anything, "id-1").Return(&model.Task{ID: "id-1", Title: "Task 1"}, nil)
+	mockUsecase.On("GetTask", mock.Anything, "id-2").Return(&model.Task{ID: "id-2", Title: "Task 2", Status: model.StatusDone}, nil)
+	mockUsecase.On("CompleteTask", mock.Anything, mock.MatchedBy(func(task *model.Task) bool {
+		return task.ID == "id-1"
+	}), mock.Anything).Return(nil, nil)
+
+	// Act
+	out, err := executeCommand("done", "id-1", "id-2")
+
+	// Assert
+	// 既に完了済みのタスクは更新されず、その旨が表示されること
+	assert.NoError(t, err)
+	assert.Contains(t, out, "id-1: marked as complete")
+	assert.Contains(t, out, "id-2: already complete")
+	assert.Contains(t, out, "2 succeeded, 0 failed")
+	mockUsecase.AssertExpectations(t)
+	mockUsecase.AssertNumberOfCalls(t, "CompleteTask", 1)
+}
+
+// TestDoneCommand_ReportsMissingTask は存在しないIDがID単位で報告されることを確認するテスト
+func TestDoneCommand_ReportsMissingTask(t *testing.T) {
+	// Arrange
+	mockUsecase := new(MockTaskUsecase)
+	originalTaskUsecase := taskUsecase
+	taskUsecase = mockUsecase
+	defer func() { taskUsecase = originalTaskUsecase }()
+	stubResolveID(mockUsecase, "missing", "id-1")
+
+	mockUsecase.On("GetTask", mock.Anything, "missing").Return(nil, &repository.TaskNotFoundError{ID: "missing"})
+	mockUsecase.On("GetTask", mock.Anything, "id-1").Return(&model.Task{ID: "id-1", Title: "Task 1"}, nil)
+	mockUsecase.On("CompleteTask", mock.Anything, mock.Anything, mock.Anything).Return(nil, nil)
+
+	// Act
+	out, err := executeCommand("done", "missing", "id-1")
+
+	// Assert
+	assert.Error(t, err)
+	assert.Contains(t, out, "missing: error: task not found")
+	assert.Contains(t, out, "id-1: marked as complete")
+	assert.Contains(t, out, "1 succeeded, 1 failed")
+}
+
+// TestReopenCommand_MarksTaskIncomplete は完了済みのタスクを未完了に戻せることを確認するテスト
+func TestReopenCommand_MarksTaskIncomplete(t *testing.T) {
+	// Arrange
+	mockUsecase := new(MockTaskUsecase)
+	originalTaskUsecase := taskUsecase
+	taskUsecase = mockUsecase
+	defer func() { taskUsecase = originalTaskUsecase }()
+	stubResolveID(mockUsecase, "id-1")
+
+	mockUsecase.On("GetTask", mock.Anything, "id-1").Return(&model.Task{ID: "id-1", Title: "Task 1", Status: model.StatusDone}, nil)
+	mockUsecase.On("SetStatus", mock.Anything, "id-1", model.StatusOpen).Return(&model.Task{ID: "id-1", Status: model.StatusOpen}, nil)
+
+	// Act
+	out, err := executeCommand("reopen", "id-1")
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Contains(t, out, "id-1: marked as incomplete")
+	mockUsecase.AssertExpectations(t)
+}
+
+// TestDoneCommand_RefusesOpenSubtasks は未完了のサブタスクがある場合に--cascadeを案内することを確認するテスト
+func TestDoneCommand_RefusesOpenSubtasks(t *testing.T) {
+	// Arrange
+	mockUsecase := new(MockTaskUsecase)
+	originalTaskUsecase := taskUsecase
+	taskUsecase = mockUsecase
+	defer func() { taskUsecase = originalTaskUsecase }()
+	stubResolveID(mockUsecase, "id-1")
+
+	mockUsecase.On("GetTask", mock.Anything, "id-1").Return(&model.Task{ID: "id-1", Title: "Parent"}, nil)
+	mockUsecase.On("CompleteTask", mock.Anything, mock.Anything, mock.Anything).
+		Return(nil, &service.OpenSubtasksError{ID: "id-1", Open: []string{"id-2"}})
+
+	// Act
+	out, err := executeCommand("done", "id-1")
+
+	// Assert
+	assert.Error(t, err)
+	assert.Contains(t, out, "has 1 open subtask(s)")
+	assert.Contains(t, out, "use --cascade")
+	mockUsecase.AssertNotCalled(t, "CompleteTaskWithSubtasks", mock.Anything, mock.Anything, mock.Anything)
+}
+
+// TestDoneCommand_Cascade は--cascadeで未完了のサブタスクも完了済みにすることを確認するテスト
+func TestDoneCommand_Cascade(t *testing.T) {
+	// Arrange
+	mockUsecase := new(MockTaskUsecase)
+	originalTaskUsecase := taskUsecase
+	taskUsecase = mockUsecase
+	defer func() { taskUsecase = originalTaskUsecase }()
+	stubResolveID(mockUsecase, "id-1")
+	defer resetFlags(doneCmd)
+
+	mockUsecase.On("GetTask", mock.Anything, "id-1").Return(&model.Task{ID: "id-1", Title: "Parent"}, nil)
+	mockUsecase.On("CompleteTaskWithSubtasks", mock.Anything, mock.MatchedBy(func(task *model.Task) bool {
+		return task.ID == "id-1"
+	}), mock.Anything).Return(nil, 2, nil)
+
+	// Act
+	out, err := executeCommand("done", "--cascade", "id-1")
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Contains(t, out, "id-1: marked as complete with 2 subtask(s)")
+	mockUsecase.AssertNotCalled(t, "CompleteTask", mock.Anything, mock.Anything, mock.Anything)
+	mockUsecase.AssertExpectations(t)
+}
+
+// TestDoneCommand_ReportsNextOccurrence は繰り返すタスクの完了時に次のタスクが報告されることを確認するテスト
+func TestDoneCommand_ReportsNextOccurrence(t *testing.T) {
+	// Arrange
+	mockUsecase := new(MockTaskUsecase)
+	originalTaskUsecase := taskUsecase
+	taskUsecase = mockUsecase
+	defer func() { taskUsecase = originalTaskUsecase }()
+	stubResolveID(mockUsecase, "id-1")
+	viper.Set("timezone", "Asia/Tokyo")
+	defer viper.Set("timezone", nil)
+
+	next := time.Date(2025, 6, 9, 8, 0, 0, 0, time.UTC)
+	mockUsecase.On("GetTask", mock.Anything, "id-1").Return(&model.Task{ID: "id-1", Title: "Weekly review"}, nil)
+	mockUsecase.On("CompleteTask", mock.Anything, mock.Anything, mock.MatchedBy(func(loc *time.Location) bool {
+		return loc.String() == "Asia/Tokyo"
+	})).Return(&model.Task{ID: "id-2", Title: "Weekly review", Deadline: &next}, nil)
+
+	// Act
+	out, err := executeCommand("done", "id-1")
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Contains(t, out, "id-1: marked as complete; next occurrence id-2 due 2025-06-09 17:00")
+	mockUsecase.AssertExpectations(t)
+}
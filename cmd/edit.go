@@ -0,0 +1,276 @@
+package cmd
+
+import (
+	"OTakumi/todogo/internal/domain/model"
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+// editコマンドのフラグの値を格納する変数
+var (
+	editTitle         string
+	editDue           string
+	editClearDeadline bool
+	editPriority      string
+	editProject       string
+	editClearProject  bool
+	editParent        string
+	editClearParent   bool
+	editNotes         bool
+	editStart         string
+	editClearStart    bool
+	editWait          string
+	editClearWait     bool
+	editEstimate      string
+	editClearEstimate bool
+)
+
+func init() {
+	rootCmd.AddCommand(editCmd)
+
+	editCmd.Flags().StringVarP(&editTitle, "title", "t", "", "New task title")
+	editCmd.Flags().StringVarP(&editDue, "due", "d", "", "New deadline (e.g. 2025-01-31, \"tomorrow 17:00\", \"next fri\", \"in 3 days\", eow)")
+	editCmd.Flags().BoolVar(&editClearDeadline, "clear-deadline", false, "Remove the deadline")
+	editCmd.Flags().StringVarP(&editPriority, "priority", "p", "", "New priority (none, low, medium, high, urgent)")
+	editCmd.Flags().StringVar(&editProject, "project", "", "Move to this project (e.g. work.backend.api)")
+	editCmd.Flags().BoolVar(&editClearProject, "clear-project", false, "Remove the task from its project")
+	editCmd.Flags().StringVar(&editParent, "parent", "", "Make the task a subtask of this task")
+	editCmd.Flags().BoolVar(&editClearParent, "clear-parent", false, "Make the task a top-level task")
+	editCmd.Flags().StringVar(&editStart, "start", "", "New start date; the task is hidden from list until then")
+	editCmd.Flags().BoolVar(&editClearStart, "clear-start", false, "Remove the start date")
+	editCmd.Flags().StringVar(&editWait, "wait", "", "New wait date; the task is hidden from list until then")
+	editCmd.Flags().BoolVar(&editClearWait, "clear-wait", false, "Remove the wait date")
+	editCmd.Flags().StringVar(&editEstimate, "estimate", "", "New estimate, as a duration (e.g. 2h, 90m) or story points (e.g. 5pts)")
+	editCmd.Flags().BoolVar(&editClearEstimate, "clear-estimate", false, "Remove the estimate")
+	editCmd.Flags().BoolVar(&editNotes, "notes", false, "Edit the task's description (markdown) in $VISUAL or $EDITOR")
+	editCmd.MarkFlagsMutuallyExclusive("due", "clear-deadline")
+	editCmd.MarkFlagsMutuallyExclusive("project", "clear-project")
+	editCmd.MarkFlagsMutuallyExclusive("parent", "clear-parent")
+	editCmd.MarkFlagsMutuallyExclusive("start", "clear-start")
+	editCmd.MarkFlagsMutuallyExclusive("wait", "clear-wait")
+	editCmd.MarkFlagsMutuallyExclusive("estimate", "clear-estimate")
+	editCmd.Flags().SetNormalizeFunc(deadlineFlagAlias)
+}
+
+// editCmd は既存タスクのタイトルや締切を変更するコマンドの定義
+var editCmd = &cobra.Command{
+	Use:   "edit <id>... [+tag]... [-tag]...",
+	Short: "Edit one or more tasks",
+	Long: `Edit the title, deadline, start and wait dates, priority, estimate, project, parent task, description or tags of one or more tasks.
+
+Only the fields given as flags are changed; all other fields keep their values.
+When several IDs are given, the same change is applied to each of them.
+
+Tags are added with +tag and removed with -tag, for example:
+
+  todo_cli edit 3 +urgent -someday
+
+Other tags on the task are kept. Because -tag is read as a tag, short flags
+must be given separately from their values (-t "New title", not -t"New title").
+
+--parent moves a task under another task and --clear-parent makes it a
+top-level task again. A task cannot be moved under one of its own subtasks.
+
+--notes opens the task's long-form description in your editor ($VISUAL, then
+$EDITOR, falling back to vi) as a markdown file and saves what you write back
+to the task. With several IDs the editor is opened once per task.
+Use "annotate" to add short timestamped notes instead.
+
+--start and --wait hide the task from "todo list" until the given date;
+--clear-start and --clear-wait show it again. Use "snooze" to push the wait
+date back by a duration instead.
+
+--estimate replaces the estimate, given as a duration ("2h", "90m") or in
+story points ("5pts"); --clear-estimate removes it.
+
+The deadline, start and wait dates accept ISO dates and times as well as
+relative phrases such as "tomorrow 17:00", "next fri", "in 3 days" or "eow".
+Phrases are resolved in the time zone set by the timezone config key (or
+TODOGO_TIMEZONE).`,
+	Args:         cobra.MinimumNArgs(1),
+	SilenceUsage: true,
+	// "-tag" がフラグとして解釈されないよう、実行前に引数を組み替える（separateTagArgsを参照）
+	Annotations: map[string]string{tagArgsAnnotation: "true"},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		refs, addTags, removeTags, err := splitTagArgs(args)
+		if err != nil {
+			return err
+		}
+		if len(refs) == 0 {
+			return errors.New("at least one task ID is required")
+		}
+
+		titleChanged := cmd.Flags().Changed("title")
+		deadlineChanged := cmd.Flags().Changed("due")
+		priorityChanged := cmd.Flags().Changed("priority")
+		projectChanged := cmd.Flags().Changed("project")
+		parentChanged := cmd.Flags().Changed("parent")
+		startChanged := cmd.Flags().Changed("start")
+		waitChanged := cmd.Flags().Changed("wait")
+		estimateChanged := cmd.Flags().Changed("estimate")
+		tagsChanged := len(addTags) > 0 || len(removeTags) > 0
+
+		// 変更内容が一つも指定されていない場合はエラー
+		if !titleChanged && !deadlineChanged && !editClearDeadline && !priorityChanged &&
+			!projectChanged && !editClearProject && !parentChanged && !editClearParent &&
+			!startChanged && !editClearStart && !waitChanged && !editClearWait &&
+			!estimateChanged && !editClearEstimate && !editNotes && !tagsChanged {
+			return errors.New("nothing to edit: specify --title, --due, --clear-deadline, --start, --clear-start, --wait, --clear-wait, " +
+				"--priority, --estimate, --clear-estimate, --project, --clear-project, --parent, --clear-parent, --notes, +tag or -tag")
+		}
+		if titleChanged && editTitle == "" {
+			return errors.New("title cannot be empty")
+		}
+
+		// 締切はID毎の処理の前に一度だけ解釈する
+		var deadline *time.Time
+		if deadlineChanged {
+			d, err := parseDue(editDue)
+			if err != nil {
+				return err
+			}
+			deadline = &d
+		}
+		var startAt, waitUntil *time.Time
+		if startChanged {
+			d, err := parseDue(editStart)
+			if err != nil {
+				return err
+			}
+			startAt = &d
+		}
+		if waitChanged {
+			d, err := parseDue(editWait)
+			if err != nil {
+				return err
+			}
+			waitUntil = &d
+		}
+
+		var priority model.Priority
+		if priorityChanged {
+			p, err := model.ParsePriority(editPriority)
+			if err != nil {
+				return err
+			}
+			priority = p
+		}
+
+		var estimate *model.Estimate
+		if estimateChanged {
+			e, err := parseEstimate(editEstimate)
+			if err != nil {
+				return err
+			}
+			estimate = e
+		}
+
+		var project string
+		if projectChanged {
+			p, err := model.NormalizeProject(editProject)
+			if err != nil {
+				return err
+			}
+			if p == "" {
+				return errors.New("project cannot be empty: use --clear-project to remove it")
+			}
+			project = p
+		}
+
+		var parentID string
+		if parentChanged {
+			id, err := resolveID(context.Background(), editParent)
+			if err != nil {
+				return err
+			}
+			parentID = id
+		}
+
+		return runForEachID(cmd, refs, func(ctx context.Context, id string) (string, error) {
+			task, err := taskUsecase.GetTask(ctx, id)
+			if err != nil {
+				return "", err
+			}
+
+			if titleChanged {
+				task.Title = editTitle
+			}
+			if deadlineChanged {
+				task.Deadline = deadline
+			}
+			if editClearDeadline {
+				task.Deadline = nil
+			}
+			if startChanged || editClearStart {
+				task.StartAt = startAt
+			}
+			if waitChanged || editClearWait {
+				task.WaitUntil = waitUntil
+			}
+			if priorityChanged {
+				task.Priority = priority
+			}
+			if estimateChanged || editClearEstimate {
+				task.Estimate = estimate
+			}
+			if projectChanged {
+				task.Project = project
+			}
+			if editClearProject {
+				task.Project = ""
+			}
+			if parentChanged {
+				task.ParentID = parentID
+			}
+			if editClearParent {
+				task.ParentID = ""
+			}
+			if err := task.AddTags(addTags...); err != nil {
+				return "", err
+			}
+			if err := task.RemoveTags(removeTags...); err != nil {
+				return "", err
+			}
+			// 説明はタスクごとに現在の内容をエディタで開いて編集する
+			if editNotes {
+				description, err := editText(cmd, task.Description)
+				if err != nil {
+					return "", err
+				}
+				task.Description = description
+			}
+
+			if _, err := taskUsecase.UpdateTask(ctx, task); err != nil {
+				return "", err
+			}
+			return "updated", nil
+		})
+	},
+}
+
+// splitTagArgs はeditコマンドの引数を、タスクの参照と追加するタグ（+tag）、削除するタグ（-tag）に分ける
+// タグ名はここで正規化し、不正な場合はタスクを変更する前にエラーとする
+func splitTagArgs(args []string) (refs, add, remove []string, err error) {
+	for _, arg := range args {
+		switch {
+		case strings.HasPrefix(arg, "+"):
+			add = append(add, arg[1:])
+		case strings.HasPrefix(arg, "-"):
+			remove = append(remove, arg[1:])
+		default:
+			refs = append(refs, arg)
+		}
+	}
+
+	if add, err = model.NormalizeTags(add); err != nil {
+		return nil, nil, nil, err
+	}
+	if remove, err = model.NormalizeTags(remove); err != nil {
+		return nil, nil, nil, err
+	}
+	return refs, add, remove, nil
+}
@@ -0,0 +1,436 @@
+package cmd
+
+import (
+	"OTakumi/todogo/internal/domain/model"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// TestEditCommand_UpdatesTitle はタイトルのみを変更できることを確認するテスト
+func TestEditCommand_UpdatesTitle(t *testing.T) {
+	// Arrange
+	mockUsecase := new(MockTaskUsecase)
+	originalTaskUsecase := taskUsecase
+	taskUsecase = mockUsecase
+	defer func() { taskUsecase = originalTaskUsecase }()
+	stubResolveID(mockUsecase, "id-1")
+	defer resetFlags(editCmd)
+
+	deadline := time.Now().Add(24 * time.Hour)
+	mockUsecase.On("GetTask", mock.Anything, "id-1").Return(&model.Task{ID: "id-1", Title: "Old", Deadline: &deadline}, nil)
+	// 締切は変更されずにタイトルのみが更新されること
+	mockUsecase.On("UpdateTask", mock.Anything, mock.MatchedBy(func(task *model.Task) bool {
+		return task.Title == "New" && task.Deadline != nil && task.Deadline.Equal(deadline)
+	})).Return(&model.Task{ID: "id-1", Title: "New"}, nil)
+
+	// Act
+	out, err := executeCommand("edit", "id-1", "--title", "New")
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Contains(t, out, "id-1: updated")
+	mockUsecase.AssertExpectations(t)
+}
+
+// TestEditCommand_UpdatesDeadlineForSeveralTasks は複数のタスクの締切を一度に変更できることを確認するテスト
+func TestEditCommand_UpdatesDeadlineForSeveralTasks(t *testing.T) {
+	// Arrange
+	mockUsecase := new(MockTaskUsecase)
+	originalTaskUsecase := taskUsecase
+	taskUsecase = mockUsecase
+	defer func() { taskUsecase = originalTaskUsecase }()
+	stubResolveID(mockUsecase, "id-1", "id-2")
+	defer resetFlags(editCmd)
+
+	// 時刻を省略した日付はその日の終わりとして解釈されること
+	expected := time.Date(2099, 1, 2, 23, 59, 59, 0, time.Local)
+	mockUsecase.On("GetTask", mock.Anything, "id-1").Return(&model.Task{ID: "id-1", Title: "Task 1"}, nil)
+	mockUsecase.On("GetTask", mock.Anything, "id-2").Return(&model.Task{ID: "id-2", Title: "Task 2"}, nil)
+	mockUsecase.On("UpdateTask", mock.Anything, mock.MatchedBy(func(task *model.Task) bool {
+		return task.Deadline != nil && task.Deadline.Equal(expected)
+	})).Return(&model.Task{}, nil)
+
+	// Act
+	out, err := executeCommand("edit", "id-1", "id-2", "--due", "2099-01-02")
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Contains(t, out, "2 succeeded, 0 failed")
+	mockUsecase.AssertNumberOfCalls(t, "UpdateTask", 2)
+}
+
+// TestEditCommand_ErrorWhenNothingToEdit は変更内容が指定されていない場合にエラーとなることを確認するテスト
+func TestEditCommand_ErrorWhenNothingToEdit(t *testing.T) {
+	// Arrange
+	mockUsecase := new(MockTaskUsecase)
+	originalTaskUsecase := taskUsecase
+	taskUsecase = mockUsecase
+	defer func() { taskUsecase = originalTaskUsecase }()
+	stubResolveID(mockUsecase, "id-1")
+	defer resetFlags(editCmd)
+
+	// Act
+	out, err := executeCommand("edit", "id-1")
+
+	// Assert
+	assert.Error(t, err)
+	assert.Contains(t, out, "nothing to edit")
+	mockUsecase.AssertNotCalled(t, "GetTask", mock.Anything, mock.Anything)
+}
+
+// TestEditCommand_ErrorWhenDeadlineInvalid は解釈できない締切がエラーとなることを確認するテスト
+func TestEditCommand_ErrorWhenDeadlineInvalid(t *testing.T) {
+	// Arrange
+	defer resetFlags(editCmd)
+
+	// Act
+	out, err := executeCommand("edit", "id-1", "--due", "someday")
+
+	// Assert
+	assert.Error(t, err)
+	assert.Contains(t, out, "invalid date")
+}
+
+// TestEditCommand_AcceptsDeadlineAlias は--deadlineが--dueの別名として使えることを確認するテスト
+func TestEditCommand_AcceptsDeadlineAlias(t *testing.T) {
+	// Arrange
+	mockUsecase := new(MockTaskUsecase)
+	originalTaskUsecase := taskUsecase
+	taskUsecase = mockUsecase
+	defer func() { taskUsecase = originalTaskUsecase }()
+	stubResolveID(mockUsecase, "id-1")
+	defer resetFlags(editCmd)
+
+	expected := time.Date(2099, 3, 4, 17, 0, 0, 0, time.Local)
+	mockUsecase.On("GetTask", mock.Anything, "id-1").Return(&model.Task{ID: "id-1", Title: "Task 1"}, nil)
+	mockUsecase.On("UpdateTask", mock.Anything, mock.MatchedBy(func(task *model.Task) bool {
+		return task.Deadline != nil && task.Deadline.Equal(expected)
+	})).Return(&model.Task{}, nil)
+
+	// Act
+	_, err := executeCommand("edit", "id-1", "--deadline", "2099-03-04 17:00")
+
+	// Assert
+	assert.NoError(t, err)
+	mockUsecase.AssertExpectations(t)
+}
+
+// TestEditCommand_SetsWaitAndClearsStart は待機の期限を設定し、開始日時を削除できることを確認するテスト
+func TestEditCommand_SetsWaitAndClearsStart(t *testing.T) {
+	// Arrange
+	mockUsecase := new(MockTaskUsecase)
+	originalTaskUsecase := taskUsecase
+	taskUsecase = mockUsecase
+	defer func() { taskUsecase = originalTaskUsecase }()
+	stubResolveID(mockUsecase, "id-1")
+	defer resetFlags(editCmd)
+
+	startAt := time.Date(2099, 3, 1, 9, 0, 0, 0, time.Local)
+	expected := time.Date(2099, 3, 4, 17, 0, 0, 0, time.Local)
+	mockUsecase.On("GetTask", mock.Anything, "id-1").Return(&model.Task{ID: "id-1", Title: "Task 1", StartAt: &startAt}, nil)
+	mockUsecase.On("UpdateTask", mock.Anything, mock.MatchedBy(func(task *model.Task) bool {
+		return task.StartAt == nil && task.WaitUntil != nil && task.WaitUntil.Equal(expected)
+	})).Return(&model.Task{}, nil)
+
+	// Act
+	_, err := executeCommand("edit", "id-1", "--wait", "2099-03-04 17:00", "--clear-start")
+
+	// Assert
+	assert.NoError(t, err)
+	mockUsecase.AssertExpectations(t)
+}
+
+// TestEditCommand_ChangesAndClearsEstimate は--estimateで見積もりを変更し、--clear-estimateで解除できることを確認するテスト
+func TestEditCommand_ChangesAndClearsEstimate(t *testing.T) {
+	t.Run("ポイントの見積もりを作業時間に変更する", func(t *testing.T) {
+		// Arrange
+		mockUsecase := new(MockTaskUsecase)
+		originalTaskUsecase := taskUsecase
+		taskUsecase = mockUsecase
+		defer func() { taskUsecase = originalTaskUsecase }()
+		stubResolveID(mockUsecase, "id-1")
+		defer resetFlags(editCmd)
+
+		mockUsecase.On("GetTask", mock.Anything, "id-1").Return(&model.Task{ID: "id-1", Title: "Task 1", Estimate: &model.Estimate{Points: 3}}, nil)
+		mockUsecase.On("UpdateTask", mock.Anything, mock.MatchedBy(func(task *model.Task) bool {
+			return task.Estimate != nil && *task.Estimate == model.Estimate{Duration: 2 * time.Hour}
+		})).Return(&model.Task{}, nil)
+
+		// Act
+		out, err := executeCommand("edit", "id-1", "--estimate", "2h")
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Contains(t, out, "id-1: updated")
+		mockUsecase.AssertExpectations(t)
+	})
+
+	t.Run("見積もりを解除する", func(t *testing.T) {
+		// Arrange
+		mockUsecase := new(MockTaskUsecase)
+		originalTaskUsecase := taskUsecase
+		taskUsecase = mockUsecase
+		defer func() { taskUsecase = originalTaskUsecase }()
+		stubResolveID(mockUsecase, "id-1")
+		defer resetFlags(editCmd)
+
+		mockUsecase.On("GetTask", mock.Anything, "id-1").Return(&model.Task{ID: "id-1", Title: "Task 1", Estimate: &model.Estimate{Points: 3}}, nil)
+		mockUsecase.On("UpdateTask", mock.Anything, mock.MatchedBy(func(task *model.Task) bool {
+			return task.Estimate == nil
+		})).Return(&model.Task{}, nil)
+
+		// Act
+		_, err := executeCommand("edit", "id-1", "--clear-estimate")
+
+		// Assert
+		assert.NoError(t, err)
+		mockUsecase.AssertExpectations(t)
+	})
+}
+
+// TestEditCommand_UpdatesPriority は優先度のみを変更できることを確認するテスト
+func TestEditCommand_UpdatesPriority(t *testing.T) {
+	// Arrange
+	mockUsecase := new(MockTaskUsecase)
+	originalTaskUsecase := taskUsecase
+	taskUsecase = mockUsecase
+	defer func() { taskUsecase = originalTaskUsecase }()
+	stubResolveID(mockUsecase, "id-1")
+	defer resetFlags(editCmd)
+
+	mockUsecase.On("GetTask", mock.Anything, "id-1").Return(&model.Task{ID: "id-1", Title: "Old", Priority: model.PriorityHigh}, nil)
+	// "none" を指定すると優先度が解除されること
+	mockUsecase.On("UpdateTask", mock.Anything, mock.MatchedBy(func(task *model.Task) bool {
+		return task.Title == "Old" && task.Priority == model.PriorityNone
+	})).Return(&model.Task{ID: "id-1", Title: "Old"}, nil)
+
+	// Act
+	out, err := executeCommand("edit", "id-1", "--priority", "none")
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Contains(t, out, "id-1: updated")
+	mockUsecase.AssertExpectations(t)
+}
+
+// TestEditCommand_AddsAndRemovesTags は+tagと-tagでタグを追加・削除できることを確認するテスト
+func TestEditCommand_AddsAndRemovesTags(t *testing.T) {
+	// Arrange
+	mockUsecase := new(MockTaskUsecase)
+	originalTaskUsecase := taskUsecase
+	taskUsecase = mockUsecase
+	defer func() { taskUsecase = originalTaskUsecase }()
+	stubResolveID(mockUsecase, "id-1")
+	defer resetFlags(editCmd)
+
+	mockUsecase.On("GetTask", mock.Anything, "id-1").Return(&model.Task{ID: "id-1", Title: "Old", Tags: []string{"someday", "work"}}, nil)
+	// 指定していないタグは残り、"-tag" がタイトルの短縮フラグ（-t）として解釈されないこと
+	mockUsecase.On("UpdateTask", mock.Anything, mock.MatchedBy(func(task *model.Task) bool {
+		return task.Title == "Old" && assert.ObjectsAreEqual([]string{"urgent", "work"}, task.Tags)
+	})).Return(&model.Task{ID: "id-1", Title: "Old"}, nil)
+
+	// Act
+	out, err := executeCommand("edit", "id-1", "+Urgent", "-someday", "-tag")
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Contains(t, out, "id-1: updated")
+	mockUsecase.AssertExpectations(t)
+}
+
+// TestEditCommand_TagsWithFlags はタグの指定とフラグを併用できることを確認するテスト
+func TestEditCommand_TagsWithFlags(t *testing.T) {
+	// Arrange
+	mockUsecase := new(MockTaskUsecase)
+	originalTaskUsecase := taskUsecase
+	taskUsecase = mockUsecase
+	defer func() { taskUsecase = originalTaskUsecase }()
+	stubResolveID(mockUsecase, "id-1")
+	defer resetFlags(editCmd)
+
+	mockUsecase.On("GetTask", mock.Anything, "id-1").Return(&model.Task{ID: "id-1", Title: "Old", Tags: []string{"work"}}, nil)
+	// フラグの値として指定した "-draft" はタグの削除として扱われないこと
+	mockUsecase.On("UpdateTask", mock.Anything, mock.MatchedBy(func(task *model.Task) bool {
+		return task.Title == "-draft" && assert.ObjectsAreEqual([]string{}, task.Tags)
+	})).Return(&model.Task{ID: "id-1", Title: "-draft"}, nil)
+
+	// Act
+	out, err := executeCommand("edit", "-work", "id-1", "-t", "-draft")
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Contains(t, out, "id-1: updated")
+	mockUsecase.AssertExpectations(t)
+}
+
+// TestEditCommand_ErrorWhenTagInvalid は不正なタグ名がタスクを変更する前にエラーとなることを確認するテスト
+func TestEditCommand_ErrorWhenTagInvalid(t *testing.T) {
+	// Arrange
+	mockUsecase := new(MockTaskUsecase)
+	originalTaskUsecase := taskUsecase
+	taskUsecase = mockUsecase
+	defer func() { taskUsecase = originalTaskUsecase }()
+	defer resetFlags(editCmd)
+
+	// Act
+	out, err := executeCommand("edit", "id-1", "+a,b")
+
+	// Assert
+	assert.Error(t, err)
+	assert.Contains(t, out, "invalid tag")
+	mockUsecase.AssertNotCalled(t, "GetTask", mock.Anything, mock.Anything)
+}
+
+// TestEditCommand_ChangesProject は--projectでプロジェクトを正規化して変更できることを確認するテスト
+func TestEditCommand_ChangesProject(t *testing.T) {
+	// Arrange
+	mockUsecase := new(MockTaskUsecase)
+	originalTaskUsecase := taskUsecase
+	taskUsecase = mockUsecase
+	defer func() { taskUsecase = originalTaskUsecase }()
+	stubResolveID(mockUsecase, "id-1")
+	defer resetFlags(editCmd)
+
+	mockUsecase.On("GetTask", mock.Anything, "id-1").Return(&model.Task{ID: "id-1", Title: "Old", Project: "home"}, nil)
+	mockUsecase.On("UpdateTask", mock.Anything, mock.MatchedBy(func(task *model.Task) bool {
+		return task.Title == "Old" && task.Project == "work.backend"
+	})).Return(&model.Task{ID: "id-1", Title: "Old", Project: "work.backend"}, nil)
+
+	// Act
+	out, err := executeCommand("edit", "id-1", "--project", " Work.Backend ")
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Contains(t, out, "id-1: updated")
+	mockUsecase.AssertExpectations(t)
+}
+
+// TestEditCommand_ClearsProject は--clear-projectでプロジェクトを解除できることを確認するテスト
+func TestEditCommand_ClearsProject(t *testing.T) {
+	// Arrange
+	mockUsecase := new(MockTaskUsecase)
+	originalTaskUsecase := taskUsecase
+	taskUsecase = mockUsecase
+	defer func() { taskUsecase = originalTaskUsecase }()
+	stubResolveID(mockUsecase, "id-1")
+	defer resetFlags(editCmd)
+
+	mockUsecase.On("GetTask", mock.Anything, "id-1").Return(&model.Task{ID: "id-1", Title: "Old", Project: "home"}, nil)
+	mockUsecase.On("UpdateTask", mock.Anything, mock.MatchedBy(func(task *model.Task) bool {
+		return task.Project == ""
+	})).Return(&model.Task{ID: "id-1", Title: "Old"}, nil)
+
+	// Act
+	out, err := executeCommand("edit", "id-1", "--clear-project")
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Contains(t, out, "id-1: updated")
+	mockUsecase.AssertExpectations(t)
+}
+
+// TestEditCommand_ErrorWhenProjectInvalid は不正なプロジェクト名がタスクを変更する前にエラーとなることを確認するテスト
+func TestEditCommand_ErrorWhenProjectInvalid(t *testing.T) {
+	// Arrange
+	mockUsecase := new(MockTaskUsecase)
+	originalTaskUsecase := taskUsecase
+	taskUsecase = mockUsecase
+	defer func() { taskUsecase = originalTaskUsecase }()
+	defer resetFlags(editCmd)
+
+	// Act
+	out, err := executeCommand("edit", "id-1", "--project", "work..api")
+
+	// Assert
+	assert.Error(t, err)
+	assert.Contains(t, out, "invalid project")
+	mockUsecase.AssertNotCalled(t, "GetTask", mock.Anything, mock.Anything)
+}
+
+// TestEditCommand_ChangesParent は--parentで親タスクを変更できることを確認するテスト
+func TestEditCommand_ChangesParent(t *testing.T) {
+	// Arrange
+	mockUsecase := new(MockTaskUsecase)
+	originalTaskUsecase := taskUsecase
+	taskUsecase = mockUsecase
+	defer func() { taskUsecase = originalTaskUsecase }()
+	stubResolveID(mockUsecase, "id-1", "id-2")
+	defer resetFlags(editCmd)
+
+	mockUsecase.On("GetTask", mock.Anything, "id-1").Return(&model.Task{ID: "id-1", Title: "Child"}, nil)
+	mockUsecase.On("UpdateTask", mock.Anything, mock.MatchedBy(func(task *model.Task) bool {
+		return task.ID == "id-1" && task.ParentID == "id-2"
+	})).Return(&model.Task{ID: "id-1", Title: "Child", ParentID: "id-2"}, nil)
+
+	// Act
+	out, err := executeCommand("edit", "id-1", "--parent", "id-2")
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Contains(t, out, "id-1: updated")
+	mockUsecase.AssertExpectations(t)
+}
+
+// TestEditCommand_EditsNotes は--notesで現在の説明をエディタで開き、編集結果を保存することを確認するテスト
+func TestEditCommand_EditsNotes(t *testing.T) {
+	// Arrange
+	mockUsecase := new(MockTaskUsecase)
+	originalTaskUsecase := taskUsecase
+	taskUsecase = mockUsecase
+	defer func() { taskUsecase = originalTaskUsecase }()
+	stubResolveID(mockUsecase, "id-1")
+	defer resetFlags(editCmd)
+
+	// 一時ファイルに追記するだけのエディタを使う
+	editor := filepath.Join(t.TempDir(), "editor.sh")
+	require.NoError(t, os.WriteFile(editor, []byte("#!/bin/sh\nprintf -- '- second\\n\\n' >> \"$1\"\n"), 0o755))
+	t.Setenv("VISUAL", editor)
+
+	mockUsecase.On("GetTask", mock.Anything, "id-1").Return(&model.Task{ID: "id-1", Title: "Task", Description: "## Steps\n- first\n"}, nil)
+	// 末尾の空行を取り除いて保存されること
+	mockUsecase.On("UpdateTask", mock.Anything, mock.MatchedBy(func(task *model.Task) bool {
+		return task.Title == "Task" && task.Description == "## Steps\n- first\n- second"
+	})).Return(&model.Task{ID: "id-1", Title: "Task"}, nil)
+
+	// Act
+	out, err := executeCommand("edit", "id-1", "--notes")
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Contains(t, out, "id-1: updated")
+	mockUsecase.AssertExpectations(t)
+}
+
+// TestEditCommand_NotesEditorFails はエディタが失敗した場合にタスクを更新しないことを確認するテスト
+func TestEditCommand_NotesEditorFails(t *testing.T) {
+	// Arrange
+	mockUsecase := new(MockTaskUsecase)
+	originalTaskUsecase := taskUsecase
+	taskUsecase = mockUsecase
+	defer func() { taskUsecase = originalTaskUsecase }()
+	originalEditText := editText
+	editText = func(*cobra.Command, string) (string, error) {
+		return "", errors.New("editor \"vi\" failed: exit status 1")
+	}
+	defer func() { editText = originalEditText }()
+	stubResolveID(mockUsecase, "id-1")
+	defer resetFlags(editCmd)
+
+	mockUsecase.On("GetTask", mock.Anything, "id-1").Return(&model.Task{ID: "id-1", Title: "Task"}, nil)
+
+	// Act
+	out, err := executeCommand("edit", "id-1", "--notes")
+
+	// Assert
+	assert.Error(t, err)
+	assert.Contains(t, out, "id-1: error: editor \"vi\" failed")
+	mockUsecase.AssertNotCalled(t, "UpdateTask", mock.Anything, mock.Anything)
+}
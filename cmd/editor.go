@@ -0,0 +1,65 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+// defaultEditor は環境変数 VISUAL と EDITOR が未設定の場合に使うエディタ
+const defaultEditor = "vi"
+
+// editText は text を書き込んだ一時ファイルをエディタで開き、保存された内容を返す
+// テストでエディタを起動せずに済むよう、変数として差し替えられるようにしている
+var editText = editInEditor
+
+// editInEditor は環境変数 VISUAL、EDITOR の順に指定されたエディタで text を編集する
+// エディタは "code --wait" のように引数を含めて指定でき、一時ファイルのパスは最後の引数として渡す
+// 保存された内容は、末尾の空白と前後の空行を取り除いて返す
+func editInEditor(cmd *cobra.Command, text string) (string, error) {
+	editor := os.Getenv("VISUAL")
+	if editor == "" {
+		editor = os.Getenv("EDITOR")
+	}
+	if editor == "" {
+		editor = defaultEditor
+	}
+	fields := strings.Fields(editor)
+	if len(fields) == 0 {
+		return "", errors.New("no editor configured: set VISUAL or EDITOR")
+	}
+
+	// Markdownとして編集できるよう、拡張子を .md とする
+	f, err := os.CreateTemp("", "todogo-*.md")
+	if err != nil {
+		return "", fmt.Errorf("failed to create temporary file: %w", err)
+	}
+	path := f.Name()
+	defer func() { _ = os.Remove(path) }()
+
+	if _, err := f.WriteString(text); err != nil {
+		_ = f.Close()
+		return "", fmt.Errorf("failed to write temporary file: %w", err)
+	}
+	if err := f.Close(); err != nil {
+		return "", fmt.Errorf("failed to write temporary file: %w", err)
+	}
+
+	c := exec.Command(fields[0], append(fields[1:], path)...)
+	c.Stdin = cmd.InOrStdin()
+	c.Stdout = cmd.OutOrStdout()
+	c.Stderr = cmd.ErrOrStderr()
+	if err := c.Run(); err != nil {
+		return "", fmt.Errorf("editor %q failed: %w", editor, err)
+	}
+
+	edited, err := os.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("failed to read temporary file: %w", err)
+	}
+	return strings.TrimLeft(strings.TrimRight(string(edited), " \t\r\n"), "\r\n"), nil
+}
@@ -0,0 +1,175 @@
+package cmd
+
+import (
+	"OTakumi/todogo/internal/dateparse"
+	"OTakumi/todogo/internal/domain/model"
+	"OTakumi/todogo/internal/render"
+	"OTakumi/todogo/internal/repository"
+	"OTakumi/todogo/internal/usecase"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/user"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"github.com/spf13/viper"
+)
+
+// timeLocation は日時の解釈と表示に使うタイムゾーンを返す
+// 設定ファイルまたは環境変数 TODOGO_TIMEZONE の timezone が未設定の場合はローカルタイムゾーンを使う
+func timeLocation() (*time.Location, error) {
+	name := viper.GetString("timezone")
+	if name == "" {
+		return time.Local, nil
+	}
+
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return nil, fmt.Errorf("invalid timezone %q: %w", name, err)
+	}
+	return loc, nil
+}
+
+// currentUser は作業時間とタスクの履歴の記録に使う利用者の名前を返す
+// 設定ファイルまたは環境変数 TODOGO_USER の user が未設定の場合は、OSのログインユーザー名を使う
+func currentUser() (string, error) {
+	if name := viper.GetString("user"); name != "" {
+		return name, nil
+	}
+	if u, err := user.Current(); err == nil && u.Username != "" {
+		return u.Username, nil
+	}
+	if name := os.Getenv("USER"); name != "" {
+		return name, nil
+	}
+	return "", errors.New("cannot determine the current user: set user in the config file or TODOGO_USER")
+}
+
+// commandContext はタスクを変更するコマンドで使うコンテキストを返す
+// タスクの履歴に操作した利用者を記録するため、利用者の名前が分かる場合はコンテキストに設定する
+// コマンドによる変更は undo でまとめて取り消せるよう、コマンドの内容も設定する
+func commandContext(cmd *cobra.Command) context.Context {
+	ctx := context.Background()
+	if name, err := currentUser(); err == nil {
+		ctx = repository.WithActor(ctx, name)
+	}
+	return usecase.WithCommand(ctx, describeCommand(cmd))
+}
+
+// describeCommand は undo で表示するための、コマンド名と引数、指定されたフラグを返す
+func describeCommand(cmd *cobra.Command) string {
+	parts := []string{cmd.Name()}
+	for _, arg := range cmd.Flags().Args() {
+		if strings.ContainsAny(arg, " \t\"") {
+			arg = strconv.Quote(arg)
+		}
+		parts = append(parts, arg)
+	}
+	cmd.Flags().Visit(func(f *pflag.Flag) {
+		if f.Value.Type() == "bool" && f.Value.String() == "true" {
+			parts = append(parts, "--"+f.Name)
+			return
+		}
+		parts = append(parts, "--"+f.Name+"="+f.Value.String())
+	})
+	return strings.Join(parts, " ")
+}
+
+// parseDue は--dueフラグの値を設定されたタイムゾーンの日時として解釈する
+func parseDue(s string) (time.Time, error) {
+	loc, err := timeLocation()
+	if err != nil {
+		return time.Time{}, err
+	}
+	return dateparse.New(loc, time.Now).Parse(s)
+}
+
+// parseEstimate は--estimateフラグの値を解釈する
+// "5pts" のようにポイントの単位が付いている場合はストーリーポイント、それ以外は "2h" や "90m" のような作業時間とする
+func parseEstimate(s string) (*model.Estimate, error) {
+	estimate, ok, err := model.ParseEstimatePoints(s)
+	if ok {
+		return estimate, err
+	}
+
+	d, err := dateparse.ParseDuration(s)
+	if err != nil {
+		return nil, fmt.Errorf("invalid estimate %q: use a duration such as 2h or 90m, or points such as 5pts", s)
+	}
+	estimate = &model.Estimate{Duration: d}
+	if err := estimate.Validate(); err != nil {
+		return nil, err
+	}
+	return estimate, nil
+}
+
+// deadlineFlagAlias は--deadlineを--dueの別名として受け付けるためのフラグ名の正規化関数
+func deadlineFlagAlias(f *pflag.FlagSet, name string) pflag.NormalizedName {
+	if name == "deadline" {
+		name = "due"
+	}
+	return pflag.NormalizedName(name)
+}
+
+// newRenderer は設定された出力形式（--output または設定ファイルの output）のRendererを生成する
+func newRenderer(w io.Writer) (render.Renderer, error) {
+	format, err := render.ParseFormat(viper.GetString("output"))
+	if err != nil {
+		return nil, err
+	}
+
+	loc, err := timeLocation()
+	if err != nil {
+		return nil, err
+	}
+	return render.New(format, render.Options{Location: loc, Color: render.ColorEnabled(w), Workflow: workflow})
+}
+
+// runForEachID は指定された各タスク参照をIDに解決して処理を実行し、ID単位の結果と集計を出力する
+// 処理関数は成功時に結果メッセージを返す
+// 1件でも失敗した場合は、全IDの処理を終えた後にエラーを返す
+func runForEachID(cmd *cobra.Command, refs []string, fn func(ctx context.Context, id string) (string, error)) error {
+	return runForEachRef(cmd, refs, resolveID, fn)
+}
+
+// runForEachRef は runForEachID と同様に処理を実行する
+// タスク参照は resolve でIDに解決する（ゴミ箱のタスクを指定する場合など）
+func runForEachRef(cmd *cobra.Command, refs []string, resolve func(ctx context.Context, ref string) (string, error), fn func(ctx context.Context, id string) (string, error)) error {
+	// 出力形式が不正な場合は、タスクを変更する前にエラーとする
+	r, err := newRenderer(cmd.OutOrStdout())
+	if err != nil {
+		return err
+	}
+
+	ctx := commandContext(cmd)
+
+	failed := 0
+	results := make([]render.Result, 0, len(refs))
+	for _, ref := range refs {
+		id, err := resolve(ctx, ref)
+
+		var msg string
+		if err == nil {
+			msg, err = fn(ctx, id)
+		}
+		if err != nil {
+			failed++
+		}
+		results = append(results, render.Result{Ref: ref, ID: id, Message: msg, Err: err})
+	}
+
+	if err := r.Results(cmd.OutOrStdout(), results); err != nil {
+		return fmt.Errorf("failed to write output: %w", err)
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("%d of %d task(s) failed", failed, len(refs))
+	}
+	return nil
+}
@@ -0,0 +1,124 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// TestMain は番号指定用の一覧ファイルの保存先を一時ディレクトリに差し替えてからテストを実行する
+func TestMain(m *testing.M) {
+	dir, err := os.MkdirTemp("", "todogo-cmd-test")
+	if err != nil {
+		panic(err)
+	}
+	listingPath = func() (string, error) {
+		return filepath.Join(dir, "listing"), nil
+	}
+
+	code := m.Run()
+	_ = os.RemoveAll(dir)
+	os.Exit(code)
+}
+
+// stubResolveID は指定されたIDがそのまま完全なIDとして解決されるようモックを設定する
+func stubResolveID(m *MockTaskUsecase, ids ...string) {
+	for _, id := range ids {
+		m.On("ResolveID", mock.Anything, id).Return(id, nil)
+	}
+}
+
+// resetFlags はコマンドのフラグを既定値に戻す
+// cobraはExecute間でフラグの値とChanged状態を保持するため、テスト間の干渉を防ぐために使用する
+func resetFlags(cmd *cobra.Command) {
+	resetFlagSet(cmd.Flags())
+	resetFlagSet(cmd.PersistentFlags())
+}
+
+func resetFlagSet(flags *pflag.FlagSet) {
+	flags.VisitAll(func(f *pflag.Flag) {
+		if sv, ok := f.Value.(pflag.SliceValue); ok {
+			_ = sv.Replace(nil)
+		} else {
+			_ = f.Value.Set(f.DefValue)
+		}
+		f.Changed = false
+	})
+	// pflagは引数なしで解析した場合に前回の位置引数を保持するため、空の引数で解析し直す
+	_ = flags.Parse([]string{"--"})
+}
+
+// executeCommand はrootCmdを指定の引数で実行し、出力とエラーを返す
+func executeCommand(args ...string) (string, error) {
+	buf := new(bytes.Buffer)
+	rootCmd.SetOut(buf)
+	rootCmd.SetErr(buf)
+	rootCmd.SetArgs(separateTagArgs(rootCmd, args))
+	err := rootCmd.Execute()
+	return buf.String(), err
+}
+
+// TestRunForEachID は複数ID処理の結果出力と集計を確認するテスト
+func TestRunForEachID(t *testing.T) {
+	t.Run("全て成功した場合はエラーを返さない", func(t *testing.T) {
+		// Arrange
+		mockUsecase := new(MockTaskUsecase)
+		originalTaskUsecase := taskUsecase
+		taskUsecase = mockUsecase
+		defer func() { taskUsecase = originalTaskUsecase }()
+		stubResolveID(mockUsecase, "a", "b")
+
+		buf := new(bytes.Buffer)
+		cmd := &cobra.Command{}
+		cmd.SetOut(buf)
+
+		// Act
+		err := runForEachID(cmd, []string{"a", "b"}, func(ctx context.Context, id string) (string, error) {
+			return "ok", nil
+		})
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Contains(t, buf.String(), "a: ok")
+		assert.Contains(t, buf.String(), "b: ok")
+		assert.Contains(t, buf.String(), "2 succeeded, 0 failed")
+	})
+
+	t.Run("一部が失敗しても残りのIDを処理してエラーを返す", func(t *testing.T) {
+		// Arrange
+		mockUsecase := new(MockTaskUsecase)
+		originalTaskUsecase := taskUsecase
+		taskUsecase = mockUsecase
+		defer func() { taskUsecase = originalTaskUsecase }()
+		stubResolveID(mockUsecase, "a", "b", "c")
+
+		buf := new(bytes.Buffer)
+		cmd := &cobra.Command{}
+		cmd.SetOut(buf)
+
+		var processed []string
+
+		// Act
+		err := runForEachID(cmd, []string{"a", "b", "c"}, func(ctx context.Context, id string) (string, error) {
+			processed = append(processed, id)
+			if id == "b" {
+				return "", errors.New("boom")
+			}
+			return "ok", nil
+		})
+
+		// Assert
+		assert.Error(t, err)
+		assert.Equal(t, []string{"a", "b", "c"}, processed)
+		assert.Contains(t, buf.String(), "b: error: boom")
+		assert.Contains(t, buf.String(), "2 succeeded, 1 failed")
+	})
+}
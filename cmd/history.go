@@ -0,0 +1,55 @@
+package cmd
+
+import (
+	"OTakumi/todogo/internal/repository"
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/spf13/cobra"
+)
+
+func init() {
+	rootCmd.AddCommand(historyCmd)
+}
+
+// historyCmd はタスクの履歴を表示するコマンドの定義
+var historyCmd = &cobra.Command{
+	Use:   "history <id>",
+	Short: "Show the change history of a task",
+	Long: `Show the timeline of a task: when it was created, every change to its fields
+with the old and new values, when it was completed and when it was deleted,
+together with the user who made each change.
+
+The user is taken from user in the config file or TODOGO_USER, falling back
+to the login name. Changes made before the history was recorded are not
+shown.
+
+A deleted task can no longer be found by a short ID, so pass its full ID to
+see its history.`,
+	Args:         cobra.ExactArgs(1),
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		r, err := newRenderer(cmd.OutOrStdout())
+		if err != nil {
+			return err
+		}
+
+		ctx := context.Background()
+
+		// 削除したタスクは参照から解決できないため、見つからない場合は完全なIDとして扱う
+		id, err := resolveID(ctx, args[0])
+		if errors.Is(err, repository.ErrTaskNotFound) {
+			id, err = args[0], nil
+		}
+		if err != nil {
+			return err
+		}
+
+		events, err := taskUsecase.History(ctx, id)
+		if err != nil {
+			return fmt.Errorf("failed to load history: %w", err)
+		}
+		return r.History(cmd.OutOrStdout(), events)
+	},
+}
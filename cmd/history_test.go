@@ -0,0 +1,79 @@
+package cmd
+
+import (
+	"OTakumi/todogo/internal/domain/model"
+	"OTakumi/todogo/internal/repository"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// TestHistoryCommand_ShowsTimeline はタスクの履歴を、操作した利用者とともに記録した順に表示することを確認するテスト
+func TestHistoryCommand_ShowsTimeline(t *testing.T) {
+	// Arrange
+	mockUsecase := new(MockTaskUsecase)
+	originalTaskUsecase := taskUsecase
+	taskUsecase = mockUsecase
+	defer func() { taskUsecase = originalTaskUsecase }()
+	t.Setenv("TODOGO_TIMEZONE", "UTC")
+	stubResolveID(mockUsecase, "id-1")
+
+	at := time.Date(2025, 6, 9, 9, 0, 0, 0, time.UTC)
+	mockUsecase.On("History", mock.Anything, "id-1").Return([]model.TaskEvent{
+		{ID: 1, TaskID: "id-1", Kind: model.TaskCreated, NewValue: "Write report", Actor: "alice", OccurredAt: at},
+		{ID: 2, TaskID: "id-1", Kind: model.TaskChanged, Field: "priority", NewValue: "high", Actor: "alice", OccurredAt: at.Add(time.Hour)},
+		{ID: 3, TaskID: "id-1", Kind: model.TaskCompleted, Field: "status", OldValue: "open", NewValue: "done", Actor: "bob", OccurredAt: at.Add(2 * time.Hour)},
+	}, nil)
+
+	// Act
+	out, err := executeCommand("history", "id-1")
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Contains(t, out, "2025-06-09 09:00  alice  created \"Write report\"\n")
+	assert.Contains(t, out, "2025-06-09 10:00  alice  priority: - -> high\n")
+	assert.Contains(t, out, "2025-06-09 11:00  bob    completed (status: open -> done)\n")
+	mockUsecase.AssertExpectations(t)
+}
+
+// TestHistoryCommand_DeletedTask は削除したタスクの履歴を、完全なIDで表示できることを確認するテスト
+func TestHistoryCommand_DeletedTask(t *testing.T) {
+	// Arrange
+	mockUsecase := new(MockTaskUsecase)
+	originalTaskUsecase := taskUsecase
+	taskUsecase = mockUsecase
+	defer func() { taskUsecase = originalTaskUsecase }()
+
+	mockUsecase.On("ResolveID", mock.Anything, "gone").Return("", &repository.TaskNotFoundError{ID: "gone"})
+	mockUsecase.On("History", mock.Anything, "gone").Return([]model.TaskEvent{
+		{ID: 5, TaskID: "gone", Kind: model.TaskDeleted, OldValue: "Old task", OccurredAt: time.Now()},
+	}, nil)
+
+	// Act
+	out, err := executeCommand("history", "gone")
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Contains(t, out, "deleted \"Old task\"")
+	mockUsecase.AssertExpectations(t)
+}
+
+// TestHistoryCommand_NotFound は履歴もタスクもない場合にエラーを返すことを確認するテスト
+func TestHistoryCommand_NotFound(t *testing.T) {
+	// Arrange
+	mockUsecase := new(MockTaskUsecase)
+	originalTaskUsecase := taskUsecase
+	taskUsecase = mockUsecase
+	defer func() { taskUsecase = originalTaskUsecase }()
+
+	mockUsecase.On("ResolveID", mock.Anything, "missing").Return("", &repository.TaskNotFoundError{ID: "missing"})
+	mockUsecase.On("History", mock.Anything, "missing").Return(nil, &repository.TaskNotFoundError{ID: "missing"})
+
+	// Act
+	_, err := executeCommand("history", "missing")
+
+	// Assert
+	assert.ErrorContains(t, err, "failed to load history: task not found")
+}
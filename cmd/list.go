@@ -1,91 +1,376 @@
 package cmd
 
 import (
+	"OTakumi/todogo/internal/domain/model"
+	"OTakumi/todogo/internal/domain/service"
+	"OTakumi/todogo/internal/render"
+	"OTakumi/todogo/internal/repository"
 	"context"
+	"errors"
 	"fmt"
+	"io"
 	"log"
-	"os"
-	"text/tabwriter"
-	"time"
+	"regexp"
+	"slices"
+	"strings"
 
 	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+// listコマンドのフラグの値を格納する変数
+var (
+	listStatus    string
+	listDueBefore string
+	listDueAfter  string
+	listTitle     string
+	listSort      string
+	listLimit     int
+	listAfter     string
+	listFormat    string
+	listTags      []string
+	listProject   string
+	listTree      bool
+	listReady     bool
+	listAll       bool
+	listWaiting   bool
 )
 
 // init関数でlistコマンドをrootコマンドに登録
 func init() {
 	rootCmd.AddCommand(listCmd)
+
+	// 絞り込み・並び替え・ページングのフラグ
+	listCmd.Flags().StringVar(&listStatus, "status", "", "Only tasks in these statuses, comma-separated (e.g. open,in_progress; active and closed select all open or terminal statuses)")
+	listCmd.Flags().StringVar(&listDueBefore, "due-before", "", "Only tasks due before this date (e.g. eow, \"in 3 days\")")
+	listCmd.Flags().StringVar(&listDueAfter, "due-after", "", "Only tasks due at or after this date")
+	listCmd.Flags().StringVar(&listTitle, "title", "", "Only tasks whose title contains this text (case-insensitive)")
+	// 繰り返し指定した条件はすべてを満たし、カンマ区切りの条件はいずれかを満たすタスクに絞り込む
+	listCmd.Flags().StringArrayVar(&listTags, "tag", nil, "Only tasks with this tag; comma-separated tags match any, -tag excludes (repeatable, all must match)")
+	listCmd.Flags().StringVar(&listProject, "project", "", "Only tasks in this project or its sub-projects")
+	listCmd.Flags().BoolVar(&listTree, "tree", false, "Show subtasks indented under their parent tasks")
+	listCmd.Flags().BoolVar(&listReady, "ready", false, "Only open tasks whose blocking tasks are all complete")
+	listCmd.Flags().BoolVar(&listAll, "all", false, "Include tasks with a future start or wait date")
+	listCmd.Flags().BoolVar(&listWaiting, "waiting", false, "Only tasks with a future start or wait date")
+	listCmd.MarkFlagsMutuallyExclusive("all", "waiting")
+	listCmd.Flags().StringVar(&listSort, "sort", "urgency", "Sort key (urgency, priority, created, updated, deadline, title), optionally suffixed with :asc or :desc")
+	listCmd.Flags().IntVar(&listLimit, "limit", 0, "Maximum number of tasks to show (0 for no limit)")
+	listCmd.Flags().StringVar(&listAfter, "after", "", "Show tasks after this task in the sort order (for paging)")
+
+	// 各タスクをGoのテンプレートで出力する（テンプレートの本文、または設定ファイルの templates に登録した名前）
+	listCmd.Flags().StringVar(&listFormat, "format", "", "Print each task with a Go template, or the name of a template from the config file")
 }
 
 // listCmd はタスク一覧を表示するコマンドの定義
 var listCmd = &cobra.Command{
 	Use:   "list",
-	Short: "List all tasks",
-	Long: `List all tasks in the database.
-This command retrieves and displays all tasks with their details including:
-- ID
+	Short: "List tasks",
+	Long: `List tasks in the database.
+This command retrieves and displays tasks with their details including:
+- Number (can be used in place of the ID in other commands)
+- ID (shortest unique prefix)
 - Title
+- Subtasks (completed/total, counting subtasks at every level)
+- Project
 - Deadline
-- Status (Complete/Incomplete)
-- Created date`,
+- Priority
+- Urgency
+- Tags
+- Status
+- Created date
+
+In the default table output, tasks are grouped under a heading for each status,
+in the order the statuses are defined in the workflow (see the status command).
+Numbers run on across the groups.
+
+By default the most pressing tasks come first: tasks are sorted by urgency, a
+score computed from the priority, how close the deadline is, whether the task is
+overdue and how long it has been open. Completed tasks have an urgency of 0.
+Priority and urgency sort highest first unless :asc is given.
+
+Tasks can be filtered with --status, --due-before, --due-after, --title, --tag and
+--project (which includes sub-projects: --project work also lists work.backend),
+ordered with --sort, and paged with --limit and --after. To fetch the next
+page, pass the ID of the last task shown to --after with the same filters.
+
+--status takes one or more statuses separated by commas. "active" stands for
+every status that is not terminal and "closed" for every terminal status:
+
+  todo_cli list --status in_progress,blocked
+  todo_cli list --status active
+
+--ready lists only the tasks that can be worked on now: open tasks that are
+not blocked by any open task (see the block command).
+
+Tasks whose start date (new --start) or wait date (new --wait, snooze) is in
+the future are hidden. --all includes them and --waiting lists only them.
+
+--tree shows each subtask right below its parent task, indented by its depth.
+Tasks keep the sort order among their siblings; a subtask whose parent is not
+in the list is shown at the top level. Tasks are not grouped by status in
+tree view.
+
+--tag filters by tags. Repeated --tag flags must all match, tags separated by
+commas match if any of them is present, and a tag prefixed with - excludes
+tasks that have it:
+
+  todo_cli list --tag work                 tagged work
+  todo_cli list --tag work --tag urgent    tagged both work and urgent
+  todo_cli list --tag home,errand          tagged home or errand
+  todo_cli list --tag work --tag -someday  tagged work but not someday
+
+Use the global --output flag to print the tasks as json, yaml, csv or ndjson
+for scripts. JSON and YAML output include "next_after" when more tasks may be
+available.
+
+--format prints one line per task using a Go template, for example:
+
+  todo_cli list --format '{{.ShortID}} {{.Title | truncate 30}} {{due .Deadline}}'
+
+Fields: .Number .ID .ShortID .Title .Description .Deadline .StartAt .WaitUntil .IsComplete .Status .Priority .Urgency .Tags .Project .ParentID .Subtasks .BlockedBy .RecurrenceID .Recurrence .Depth .CreatedAt .UpdatedAt
+Functions:
+  date TIME [LAYOUT]   format a time (default layout "2006-01-02 15:04")
+  relative TIME        "in 3d", "2h ago" or "now"
+  due DEADLINE         "in 3d" or "2d overdue" (empty when there is no deadline)
+  color NAME TEXT      red, green, yellow, blue, magenta, cyan, gray, bold, ... (disabled by NO_COLOR)
+  truncate N TEXT      shorten to N characters, ending with "…"
+  pad N TEXT           pad with spaces to N characters
+  join SEP LIST        join a list such as .Tags with SEP
+
+Templates can be saved under a name in the config file and referred to by name:
+
+  templates:
+    bar: '{{.ShortID}} {{color "yellow" (due .Deadline)}} {{.Title | truncate 20}}'`,
 	// RunE はlistコマンドのメイン実行関数
 	RunE: func(cmd *cobra.Command, args []string) error {
 		// データベース操作用のコンテキストを作成
 		ctx := context.Background()
 
-		// 共有されているtaskUsecaseインスタンスを使用してすべてのタスクを取得
+		r, err := listRenderer(cmd.OutOrStdout())
+		if err != nil {
+			return err
+		}
+
+		// フラグから取得条件を組み立てる
+		query, err := buildListQuery(ctx)
+		if err != nil {
+			return err
+		}
+
+		// 共有されているtaskUsecaseインスタンスを使用して条件に一致するタスクを取得
 		// taskUsecaseはmain.goで初期化され、SetupDependencies経由で注入されている
-		tasks, err := taskUsecase.FindAll(ctx)
+		tasks, err := taskUsecase.FindAll(ctx, query)
 		if err != nil {
 			return fmt.Errorf("failed to fetch tasks: %w", err)
 		}
 
-		// タスクが存在しない場合の処理
-		if len(tasks) == 0 {
-			fmt.Println("No tasks found.")
-			return nil
-		}
+		list := render.TaskList{Tasks: tasks}
 
-		// 整形されたテーブル出力のためのtabwriterを作成
-		// パラメータ: 出力先, 最小幅, タブ幅, パディング, パディング文字, フラグ
-		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		// 件数上限に達した場合は続きがある可能性があるため、次のページのカーソルを示す
+		// カーソルは並び替えの順序で最後のタスクとするため、ツリー表示の並べ替えより前に決める
+		if query.Limit > 0 && len(tasks) == query.Limit {
+			list.NextAfter = tasks[len(tasks)-1].ID
+		}
 
-		// テーブルヘッダーを出力
-		fmt.Fprintln(w, "ID\tTitle\tDeadline\tStatus\tCreated")
-		fmt.Fprintln(w, "---\t-----\t--------\t------\t-------")
+		// ツリー表示では親子関係を優先し、表形式のそれ以外の表示では状態ごとにまとめる
+		if listTree {
+			list.Tasks, list.Depths = service.ArrangeTree(tasks)
+			tasks = list.Tasks
+		} else if groupListByStatus() {
+			service.SortByStatus(tasks, workflow)
+			list.GroupByStatus = true
+		}
 
-		// 各タスクを反復処理して出力をフォーマット
-		for _, task := range tasks {
-			// ステータスの表示文字列を決定
-			status := "Incomplete"
-			if task.IsComplete {
-				status = "Complete"
+		if len(tasks) > 0 {
+			// 全タスクの中で一意となる短縮IDを取得
+			list.ShortIDs, err = taskUsecase.ShortIDs(ctx)
+			if err != nil {
+				return fmt.Errorf("failed to fetch task IDs: %w", err)
 			}
 
-			// 締切日をフォーマット（未設定の場合は"-"を表示）
-			deadlineStr := "-"
-			if task.Deadline != nil {
-				deadlineStr = task.Deadline.Format("2006-01-02")
+			// 表示順のIDを保存し、他のコマンドで番号指定できるようにする
+			// 保存に失敗しても、一覧表示自体は成功として扱う
+			listing := make([]string, 0, len(tasks))
+			for _, task := range tasks {
+				listing = append(listing, task.ID)
+			}
+			if err := saveListing(listing); err != nil {
+				log.Printf("Warning: failed to save listing: %v", err)
 			}
+		}
+
+		return r.TaskList(cmd.OutOrStdout(), list)
+	},
+}
 
-			// 各タスクの情報を整形された行として出力
-			// 各フィールドはタブで区切られ、適切に整列される
-			fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n",
-				task.ID,
-				task.Title,
-				deadlineStr,
-				status,
-				task.CreatedAt.Format(time.RFC3339),
-			)
+// taskListRenderer はタスクの一覧を出力する
+type taskListRenderer interface {
+	TaskList(w io.Writer, list render.TaskList) error
+}
+
+// listRenderer は--formatが指定されている場合はテンプレートを、それ以外は--outputの形式のRendererを返す
+func listRenderer(w io.Writer) (taskListRenderer, error) {
+	if listFormat == "" {
+		return newRenderer(w)
+	}
+
+	if format, err := render.ParseFormat(viper.GetString("output")); err != nil || format != render.Table {
+		return nil, errors.New("--format cannot be combined with --output")
+	}
+
+	text, err := formatTemplate(listFormat)
+	if err != nil {
+		return nil, err
+	}
+
+	loc, err := timeLocation()
+	if err != nil {
+		return nil, err
+	}
+	return render.NewTemplate(text, render.TemplateOptions{Location: loc, NoColor: !render.ColorEnabled(w), Workflow: workflow})
+}
+
+// groupListByStatus は一覧を状態ごとにまとめて表示するかどうかを返す
+// 見出しを付けられるのは表形式の出力のみのため、--format や構造化された形式では並び順を変えない
+func groupListByStatus() bool {
+	if listFormat != "" {
+		return false
+	}
+	format, err := render.ParseFormat(viper.GetString("output"))
+	return err == nil && format == render.Table
+}
+
+// templateNamePattern は設定ファイルに登録するテンプレートの名前の形式
+var templateNamePattern = regexp.MustCompile(`^[A-Za-z0-9_-]+$`)
+
+// formatTemplate は--formatの値からテンプレートの本文を返す
+// 名前の形式の場合は設定ファイルの templates.<名前> を参照する
+func formatTemplate(format string) (string, error) {
+	if !templateNamePattern.MatchString(format) {
+		return format, nil
+	}
+
+	text := viper.GetString("templates." + format)
+	if text == "" {
+		return "", fmt.Errorf("unknown template %q: define it under templates in the config file", format)
+	}
+	return text, nil
+}
+
+// buildListQuery はlistコマンドのフラグからタスクの取得条件を組み立てる
+func buildListQuery(ctx context.Context) (repository.TaskQuery, error) {
+	query := repository.TaskQuery{
+		TitleContains: listTitle,
+		Ready:         listReady,
+		Limit:         listLimit,
+		Waiting:       repository.WaitingHide,
+	}
+	switch {
+	case listAll:
+		query.Waiting = repository.WaitingAny
+	case listWaiting:
+		query.Waiting = repository.WaitingOnly
+	}
+
+	statuses, err := parseStatusFilter(listStatus)
+	if err != nil {
+		return query, err
+	}
+	query.Statuses = statuses
+
+	tags, excludeTags, err := parseTagFilters(listTags)
+	if err != nil {
+		return query, err
+	}
+	query.Tags = tags
+	query.ExcludeTags = excludeTags
+
+	if query.Project, err = model.NormalizeProject(listProject); err != nil {
+		return query, err
+	}
+
+	sortBy, desc, err := repository.ParseSort(listSort)
+	if err != nil {
+		return query, err
+	}
+	query.SortBy = sortBy
+	query.SortDesc = desc
+
+	if listDueBefore != "" {
+		t, err := parseDue(listDueBefore)
+		if err != nil {
+			return query, err
 		}
+		query.DeadlineBefore = &t
+	}
+	if listDueAfter != "" {
+		t, err := parseDue(listDueAfter)
+		if err != nil {
+			return query, err
+		}
+		query.DeadlineAfter = &t
+	}
 
-		// tabwriterのバッファをフラッシュして、すべての内容を標準出力に書き込む
-		if err := w.Flush(); err != nil {
-			log.Printf("Warning: failed to flush output: %v", err)
+	// カーソルは他のコマンドと同様に短縮IDや表示番号でも指定できる
+	if listAfter != "" {
+		id, err := resolveID(ctx, listAfter)
+		if err != nil {
+			return query, err
 		}
+		query.AfterID = id
+	}
 
-		// タスクの総数を表示
-		fmt.Printf("\nTotal: %d task(s)\n", len(tasks))
+	return query, query.Validate()
+}
 
-		return nil
-	},
+// parseStatusFilter は--statusの値を状態の絞り込み条件に変換する
+// カンマ区切りの各値はワークフローの状態の名前、または active（終了状態以外）と closed（終了状態）のいずれか
+func parseStatusFilter(value string) ([]model.Status, error) {
+	if strings.TrimSpace(value) == "" {
+		return nil, nil
+	}
+
+	var statuses []model.Status
+	for _, name := range strings.Split(value, ",") {
+		switch strings.ToLower(strings.TrimSpace(name)) {
+		case model.StatusGroupActive:
+			statuses = append(statuses, workflow.Active()...)
+		case model.StatusGroupClosed:
+			statuses = append(statuses, workflow.Terminal...)
+		default:
+			status, err := workflow.ParseStatus(name)
+			if err != nil {
+				return nil, err
+			}
+			statuses = append(statuses, status)
+		}
+	}
+	slices.Sort(statuses)
+	return slices.Compact(statuses), nil
+}
+
+// parseTagFilters は--tagの値をタグの絞り込み条件に変換する
+// 各値はカンマ区切りのタグのグループ（いずれかに一致）となり、"-" で始まるタグは除外するタグとなる
+func parseTagFilters(values []string) (tags [][]string, exclude []string, err error) {
+	for _, value := range values {
+		var group []string
+		for _, name := range strings.Split(value, ",") {
+			name = strings.TrimSpace(name)
+			excluded := strings.HasPrefix(name, "-")
+
+			tag, err := model.NormalizeTag(strings.TrimPrefix(name, "-"))
+			if err != nil {
+				return nil, nil, err
+			}
+			if excluded {
+				exclude = append(exclude, tag)
+			} else {
+				group = append(group, tag)
+			}
+		}
+		if len(group) > 0 {
+			tags = append(tags, group)
+		}
+	}
+	return tags, exclude, nil
 }
@@ -0,0 +1,457 @@
+package cmd
+
+import (
+	"OTakumi/todogo/internal/domain/model"
+	"OTakumi/todogo/internal/repository"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// TestListCommand_PrintsShortIDsAndNumbers は一覧に番号と短縮IDが表示されることを確認するテスト
+func TestListCommand_PrintsShortIDsAndNumbers(t *testing.T) {
+	// Arrange
+	mockUsecase := new(MockTaskUsecase)
+	originalTaskUsecase := taskUsecase
+	taskUsecase = mockUsecase
+	defer func() { taskUsecase = originalTaskUsecase }()
+
+	now := time.Now()
+	tasks := []*model.Task{
+		{ID: "f47ac10b-58cc-4372-a567-0e02b2c3d479", Title: "Task 1", CreatedAt: now},
+		{ID: "7d6d370d-a4f1-430b-06c7-d4a363341564", Title: "Task 2", CreatedAt: now},
+	}
+	mockUsecase.On("FindAll", mock.Anything, repository.TaskQuery{SortBy: repository.SortByUrgency, SortDesc: true, Waiting: repository.WaitingHide}).Return(tasks, nil)
+	mockUsecase.On("ShortIDs", mock.Anything).Return(map[string]string{
+		"f47ac10b-58cc-4372-a567-0e02b2c3d479": "f47a",
+		"7d6d370d-a4f1-430b-06c7-d4a363341564": "7d6d",
+	}, nil)
+
+	// Act
+	out, err := executeCommand("list")
+
+	// Assert
+	// 完全なIDではなく短縮IDが表示されること
+	assert.NoError(t, err)
+	assert.Contains(t, out, "1  f47a")
+	assert.Contains(t, out, "2  7d6d")
+	assert.NotContains(t, out, "f47ac10b-58cc-4372-a567-0e02b2c3d479")
+	assert.Contains(t, out, "Total: 2 task(s)")
+
+	// 表示順が番号指定用に保存されること
+	listing, err := loadListing()
+	assert.NoError(t, err)
+	assert.Equal(t, []string{tasks[0].ID, tasks[1].ID}, listing)
+}
+
+// TestListCommand_NoTasks はタスクが存在しない場合のメッセージを確認するテスト
+func TestListCommand_NoTasks(t *testing.T) {
+	// Arrange
+	mockUsecase := new(MockTaskUsecase)
+	originalTaskUsecase := taskUsecase
+	taskUsecase = mockUsecase
+	defer func() { taskUsecase = originalTaskUsecase }()
+
+	mockUsecase.On("FindAll", mock.Anything, repository.TaskQuery{SortBy: repository.SortByUrgency, SortDesc: true, Waiting: repository.WaitingHide}).Return([]*model.Task{}, nil)
+
+	// Act
+	out, err := executeCommand("list")
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Contains(t, out, "No tasks found.")
+}
+
+// TestListCommand_BuildsQueryFromFlags はフラグから取得条件が組み立てられることを確認するテスト
+func TestListCommand_BuildsQueryFromFlags(t *testing.T) {
+	// Arrange
+	mockUsecase := new(MockTaskUsecase)
+	originalTaskUsecase := taskUsecase
+	taskUsecase = mockUsecase
+	defer func() { taskUsecase = originalTaskUsecase }()
+	defer resetFlags(listCmd)
+
+	stubResolveID(mockUsecase, "cursor-id")
+	tasks := []*model.Task{
+		{ID: "id-1", Title: "Task 1"},
+		{ID: "id-2", Title: "Task 2"},
+	}
+	mockUsecase.On("FindAll", mock.Anything, mock.MatchedBy(func(q repository.TaskQuery) bool {
+		return assert.ObjectsAreEqual([]model.Status{model.StatusOpen}, q.Statuses) &&
+			q.SortBy == repository.SortByDeadline && q.SortDesc &&
+			q.Limit == 2 && q.AfterID == "cursor-id" &&
+			q.DeadlineBefore != nil && q.DeadlineBefore.Equal(time.Date(2099, 1, 1, 23, 59, 59, 0, time.Local))
+	})).Return(tasks, nil)
+	mockUsecase.On("ShortIDs", mock.Anything).Return(map[string]string{"id-1": "id-1", "id-2": "id-2"}, nil)
+
+	// Act
+	out, err := executeCommand("list", "--status", "open", "--due-before", "2099-01-01",
+		"--sort", "deadline:desc", "--limit", "2", "--after", "cursor-id")
+
+	// Assert
+	// 件数上限に達した場合は次のページの案内が表示されること
+	assert.NoError(t, err)
+	assert.Contains(t, out, "--after id-2")
+	mockUsecase.AssertExpectations(t)
+}
+
+// TestListCommand_ErrorWhenSortInvalid は不正な並び替え指定がエラーとなることを確認するテスト
+func TestListCommand_ErrorWhenSortInvalid(t *testing.T) {
+	// Arrange
+	mockUsecase := new(MockTaskUsecase)
+	originalTaskUsecase := taskUsecase
+	taskUsecase = mockUsecase
+	defer func() { taskUsecase = originalTaskUsecase }()
+	defer resetFlags(listCmd)
+
+	// Act
+	out, err := executeCommand("list", "--sort", "size")
+
+	// Assert
+	assert.Error(t, err)
+	assert.Contains(t, out, "invalid sort key")
+	mockUsecase.AssertNotCalled(t, "FindAll", mock.Anything, mock.Anything)
+}
+
+// TestListCommand_JSONOutput は--output jsonで一覧がJSONとして出力されることを確認するテスト
+func TestListCommand_JSONOutput(t *testing.T) {
+	// Arrange
+	mockUsecase := new(MockTaskUsecase)
+	originalTaskUsecase := taskUsecase
+	taskUsecase = mockUsecase
+	defer func() { taskUsecase = originalTaskUsecase }()
+	defer resetFlags(rootCmd)
+	defer resetFlags(listCmd)
+
+	now := time.Now()
+	tasks := []*model.Task{
+		{ID: "id-1", Title: "Task 1", CreatedAt: now, UpdatedAt: now},
+		{ID: "id-2", Title: "Task 2", Status: model.StatusDone, CreatedAt: now, UpdatedAt: now},
+	}
+	mockUsecase.On("FindAll", mock.Anything, repository.TaskQuery{SortBy: repository.SortByUrgency, SortDesc: true, Waiting: repository.WaitingHide, Limit: 2}).Return(tasks, nil)
+	mockUsecase.On("ShortIDs", mock.Anything).Return(map[string]string{"id-1": "i1", "id-2": "i2"}, nil)
+
+	// Act
+	out, err := executeCommand("list", "--limit", "2", "--output", "json")
+
+	// Assert
+	// 出力全体がJSONとして解釈でき、表形式の見出しや集計を含まないこと
+	assert.NoError(t, err)
+	var doc struct {
+		SchemaVersion int `json:"schema_version"`
+		Tasks         []struct {
+			ID     string `json:"id"`
+			Status string `json:"status"`
+		} `json:"tasks"`
+		NextAfter string `json:"next_after"`
+	}
+	assert.NoError(t, json.Unmarshal([]byte(out), &doc))
+	assert.Equal(t, 2, doc.SchemaVersion)
+	assert.Len(t, doc.Tasks, 2)
+	assert.Equal(t, "done", doc.Tasks[1].Status)
+	assert.Equal(t, "id-2", doc.NextAfter)
+}
+
+// TestListCommand_InvalidOutput は不正な出力形式がエラーとなることを確認するテスト
+func TestListCommand_InvalidOutput(t *testing.T) {
+	defer resetFlags(rootCmd)
+
+	// Act
+	out, err := executeCommand("list", "-o", "xml")
+
+	// Assert
+	assert.Error(t, err)
+	assert.Contains(t, out, "invalid output format")
+}
+
+// TestListCommand_FormatTemplate は--formatで指定したテンプレートと、設定ファイルに登録した名前付きテンプレートで出力されることを確認するテスト
+func TestListCommand_FormatTemplate(t *testing.T) {
+	// Arrange
+	mockUsecase := new(MockTaskUsecase)
+	originalTaskUsecase := taskUsecase
+	taskUsecase = mockUsecase
+	defer func() { taskUsecase = originalTaskUsecase }()
+	defer resetFlags(listCmd)
+
+	now := time.Now()
+	tasks := []*model.Task{
+		{ID: "id-1", Title: "Write the quarterly report", Status: model.StatusOpen, CreatedAt: now},
+		{ID: "id-2", Title: "Buy milk", Status: model.StatusDone, CreatedAt: now},
+	}
+	mockUsecase.On("FindAll", mock.Anything, repository.TaskQuery{SortBy: repository.SortByUrgency, SortDesc: true, Waiting: repository.WaitingHide}).Return(tasks, nil)
+	mockUsecase.On("ShortIDs", mock.Anything).Return(map[string]string{"id-1": "i1", "id-2": "i2"}, nil)
+
+	viper.Set("templates.short", "{{.ShortID}}={{.Status}}")
+	defer viper.Set("templates.short", nil)
+
+	t.Run("テンプレートの本文を指定する", func(t *testing.T) {
+		// Act
+		out, err := executeCommand("list", "--format", "{{.Number}}. {{.Title | truncate 10}}")
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, "1. Write the…\n2. Buy milk\n", out)
+	})
+
+	t.Run("名前付きテンプレートを指定する", func(t *testing.T) {
+		// Act
+		out, err := executeCommand("list", "--format", "short")
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, "i1=open\ni2=done\n", out)
+	})
+
+	t.Run("未登録の名前はエラーになる", func(t *testing.T) {
+		// Act
+		_, err := executeCommand("list", "--format", "missing")
+
+		// Assert
+		assert.ErrorContains(t, err, `unknown template "missing"`)
+	})
+
+	t.Run("--outputとは併用できない", func(t *testing.T) {
+		defer resetFlags(rootCmd)
+
+		// Act
+		_, err := executeCommand("list", "--format", "short", "--output", "json")
+
+		// Assert
+		assert.ErrorContains(t, err, "cannot be combined")
+	})
+}
+
+// TestListCommand_BuildsTagFilters は--tagからタグの絞り込み条件が組み立てられることを確認するテスト
+func TestListCommand_BuildsTagFilters(t *testing.T) {
+	// Arrange
+	mockUsecase := new(MockTaskUsecase)
+	originalTaskUsecase := taskUsecase
+	taskUsecase = mockUsecase
+	defer func() { taskUsecase = originalTaskUsecase }()
+	defer resetFlags(listCmd)
+
+	// 繰り返しはAND、カンマ区切りはOR、"-" で始まるタグは除外となること
+	mockUsecase.On("FindAll", mock.Anything, mock.MatchedBy(func(q repository.TaskQuery) bool {
+		return assert.ObjectsAreEqual([][]string{{"work"}, {"home", "errand"}}, q.Tags) &&
+			assert.ObjectsAreEqual([]string{"someday"}, q.ExcludeTags)
+	})).Return([]*model.Task{}, nil)
+
+	// Act
+	_, err := executeCommand("list", "--tag", "Work", "--tag", "home,errand", "--tag", "-someday")
+
+	// Assert
+	assert.NoError(t, err)
+	mockUsecase.AssertExpectations(t)
+}
+
+// TestListCommand_BuildsProjectFilter は--projectから正規化したプロジェクトの絞り込み条件が組み立てられることを確認するテスト
+func TestListCommand_BuildsProjectFilter(t *testing.T) {
+	// Arrange
+	mockUsecase := new(MockTaskUsecase)
+	originalTaskUsecase := taskUsecase
+	taskUsecase = mockUsecase
+	defer func() { taskUsecase = originalTaskUsecase }()
+	defer resetFlags(listCmd)
+
+	mockUsecase.On("FindAll", mock.Anything, mock.MatchedBy(func(q repository.TaskQuery) bool {
+		return q.Project == "work.backend"
+	})).Return([]*model.Task{}, nil)
+
+	// Act
+	_, err := executeCommand("list", "--project", "Work.Backend")
+
+	// Assert
+	assert.NoError(t, err)
+	mockUsecase.AssertExpectations(t)
+}
+
+// TestListCommand_Ready は--readyで着手可能なタスクの絞り込み条件が組み立てられることを確認するテスト
+func TestListCommand_Ready(t *testing.T) {
+	// Arrange
+	mockUsecase := new(MockTaskUsecase)
+	originalTaskUsecase := taskUsecase
+	taskUsecase = mockUsecase
+	defer func() { taskUsecase = originalTaskUsecase }()
+	defer resetFlags(listCmd)
+
+	mockUsecase.On("FindAll", mock.Anything, mock.MatchedBy(func(q repository.TaskQuery) bool {
+		return q.Ready
+	})).Return([]*model.Task{}, nil)
+
+	// Act
+	_, err := executeCommand("list", "--ready")
+
+	// Assert
+	assert.NoError(t, err)
+	mockUsecase.AssertExpectations(t)
+}
+
+// TestListCommand_Waiting は--allと--waitingで待機中のタスクの絞り込み条件が切り替わることを確認するテスト
+func TestListCommand_Waiting(t *testing.T) {
+	tests := []struct {
+		name string
+		args []string
+		want repository.WaitingFilter
+	}{
+		{name: "既定では待機中のタスクを除く", args: []string{"list"}, want: repository.WaitingHide},
+		{name: "--allでは待機中のタスクも含める", args: []string{"list", "--all"}, want: repository.WaitingAny},
+		{name: "--waitingでは待機中のタスクのみとする", args: []string{"list", "--waiting"}, want: repository.WaitingOnly},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			mockUsecase := new(MockTaskUsecase)
+			originalTaskUsecase := taskUsecase
+			taskUsecase = mockUsecase
+			defer func() { taskUsecase = originalTaskUsecase }()
+			defer resetFlags(listCmd)
+
+			mockUsecase.On("FindAll", mock.Anything, mock.MatchedBy(func(q repository.TaskQuery) bool {
+				return q.Waiting == tt.want
+			})).Return([]*model.Task{}, nil)
+
+			// Act
+			_, err := executeCommand(tt.args...)
+
+			// Assert
+			assert.NoError(t, err)
+			mockUsecase.AssertExpectations(t)
+		})
+	}
+}
+
+// TestListCommand_AllAndWaiting は--allと--waitingを同時に指定した場合にエラーとなることを確認するテスト
+func TestListCommand_AllAndWaiting(t *testing.T) {
+	// Arrange
+	mockUsecase := new(MockTaskUsecase)
+	originalTaskUsecase := taskUsecase
+	taskUsecase = mockUsecase
+	defer func() { taskUsecase = originalTaskUsecase }()
+	defer resetFlags(listCmd)
+
+	// Act
+	_, err := executeCommand("list", "--all", "--waiting")
+
+	// Assert
+	assert.Error(t, err)
+	mockUsecase.AssertNotCalled(t, "FindAll", mock.Anything, mock.Anything)
+}
+
+// TestListCommand_Tree は--treeでサブタスクが親タスクの下に字下げして表示されることを確認するテスト
+func TestListCommand_Tree(t *testing.T) {
+	// Arrange
+	mockUsecase := new(MockTaskUsecase)
+	originalTaskUsecase := taskUsecase
+	taskUsecase = mockUsecase
+	defer func() { taskUsecase = originalTaskUsecase }()
+	defer resetFlags(listCmd)
+
+	now := time.Now()
+	tasks := []*model.Task{
+		{ID: "id-child", Title: "Child", ParentID: "id-parent", CreatedAt: now},
+		{ID: "id-other", Title: "Other", CreatedAt: now},
+		{ID: "id-parent", Title: "Parent", Subtasks: model.Progress{Done: 0, Total: 1}, CreatedAt: now},
+	}
+	mockUsecase.On("FindAll", mock.Anything, mock.Anything).Return(tasks, nil)
+	mockUsecase.On("ShortIDs", mock.Anything).Return(map[string]string{
+		"id-child": "c", "id-other": "o", "id-parent": "p",
+	}, nil)
+
+	// Act
+	out, err := executeCommand("list", "--tree")
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Contains(t, out, "1  o    Other    -")
+	assert.Contains(t, out, "2  p    Parent   0/1")
+	assert.Contains(t, out, "3  c      Child  -")
+
+	// 番号指定用の表示順はツリー表示の順序となること
+	listing, err := loadListing()
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"id-other", "id-parent", "id-child"}, listing)
+}
+
+// TestListCommand_GroupsByStatus は表形式の一覧が状態ごとにまとめられ、番号が表示順に振られることを確認するテスト
+func TestListCommand_GroupsByStatus(t *testing.T) {
+	// Arrange
+	mockUsecase := new(MockTaskUsecase)
+	originalTaskUsecase := taskUsecase
+	taskUsecase = mockUsecase
+	defer func() { taskUsecase = originalTaskUsecase }()
+
+	tasks := []*model.Task{
+		{ID: "id-1", Title: "Task 1", Status: model.StatusDone},
+		{ID: "id-2", Title: "Task 2", Status: model.StatusOpen},
+		{ID: "id-3", Title: "Task 3", Status: model.StatusInProgress},
+	}
+	mockUsecase.On("FindAll", mock.Anything, mock.Anything).Return(tasks, nil)
+	mockUsecase.On("ShortIDs", mock.Anything).Return(map[string]string{"id-1": "id-1", "id-2": "id-2", "id-3": "id-3"}, nil)
+
+	// Act
+	out, err := executeCommand("list")
+
+	// Assert
+	// ワークフローに定義された状態の順に見出しが付くこと
+	assert.NoError(t, err)
+	assert.Regexp(t, `(?s)Open \(1\)\n.*1  id-2.*In progress \(1\)\n.*2  id-3.*Done \(1\)\n.*3  id-1`, out)
+
+	listing, err := loadListing()
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"id-2", "id-3", "id-1"}, listing)
+}
+
+// TestListCommand_StatusGroups は--statusの active と closed がワークフローの状態に展開されることを確認するテスト
+func TestListCommand_StatusGroups(t *testing.T) {
+	tests := []struct {
+		value string
+		want  []model.Status
+	}{
+		{"active", []model.Status{model.StatusBlocked, model.StatusInProgress, model.StatusOpen}},
+		{"closed", []model.Status{model.StatusCancelled, model.StatusDone}},
+		{"closed,Done", []model.Status{model.StatusCancelled, model.StatusDone}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			// Arrange
+			mockUsecase := new(MockTaskUsecase)
+			originalTaskUsecase := taskUsecase
+			taskUsecase = mockUsecase
+			defer func() { taskUsecase = originalTaskUsecase }()
+			defer resetFlags(listCmd)
+
+			mockUsecase.On("FindAll", mock.Anything, mock.MatchedBy(func(q repository.TaskQuery) bool {
+				return assert.ObjectsAreEqual(tt.want, q.Statuses)
+			})).Return([]*model.Task{}, nil)
+
+			// Act
+			_, err := executeCommand("list", "--status", tt.value)
+
+			// Assert
+			assert.NoError(t, err)
+			mockUsecase.AssertExpectations(t)
+		})
+	}
+}
+
+// TestListCommand_ErrorWhenStatusUnknown はワークフローにない状態で絞り込んだ場合にエラーとなることを確認するテスト
+func TestListCommand_ErrorWhenStatusUnknown(t *testing.T) {
+	// Arrange
+	mockUsecase := new(MockTaskUsecase)
+	originalTaskUsecase := taskUsecase
+	taskUsecase = mockUsecase
+	defer func() { taskUsecase = originalTaskUsecase }()
+	defer resetFlags(listCmd)
+
+	// Act
+	_, err := executeCommand("list", "--status", "open,review")
+
+	// Assert
+	assert.ErrorContains(t, err, `invalid status "review"`)
+	mockUsecase.AssertNotCalled(t, "FindAll", mock.Anything, mock.Anything)
+}
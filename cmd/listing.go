@@ -0,0 +1,69 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// listingPath は直近のlistコマンドで表示したタスクIDの一覧を保存するファイルのパスを返す
+// テストで保存先を差し替えられるよう変数として定義している
+var listingPath = func() (string, error) {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "todogo", "listing"), nil
+}
+
+// saveListing は表示順のタスクIDを保存し、次回以降のコマンドで番号指定できるようにする
+func saveListing(ids []string) error {
+	path, err := listingPath()
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(path, []byte(strings.Join(ids, "\n")), 0o644)
+}
+
+// loadListing は直近のlistコマンドで表示したタスクIDを表示順に返す
+// まだ一度もlistが実行されていない場合は空を返す
+func loadListing() ([]string, error) {
+	path, err := listingPath()
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if len(data) == 0 {
+		return nil, nil
+	}
+	return strings.Split(string(data), "\n"), nil
+}
+
+// resolveID はコマンド引数で指定されたタスクの参照を完全なIDに解決する
+// 直近のlistの表示番号（例: 3）が優先され、それ以外はIDの接頭辞として解決する
+func resolveID(ctx context.Context, ref string) (string, error) {
+	if n, err := strconv.Atoi(ref); err == nil && n > 0 {
+		listing, err := loadListing()
+		if err != nil {
+			return "", err
+		}
+		if n <= len(listing) {
+			return listing[n-1], nil
+		}
+	}
+
+	return taskUsecase.ResolveID(ctx, ref)
+}
@@ -0,0 +1,74 @@
+package cmd
+
+import (
+	"OTakumi/todogo/internal/domain/service"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// TestResolveID はタスク参照の解決規則を確認するテスト
+func TestResolveID(t *testing.T) {
+	t.Run("番号は直近の一覧の表示順で解決される", func(t *testing.T) {
+		// Arrange
+		mockUsecase := new(MockTaskUsecase)
+		originalTaskUsecase := taskUsecase
+		taskUsecase = mockUsecase
+		defer func() { taskUsecase = originalTaskUsecase }()
+
+		assert.NoError(t, saveListing([]string{"id-a", "id-b", "id-c"}))
+
+		// Act
+		id, err := resolveID(context.Background(), "3")
+
+		// Assert
+		// 番号で解決できた場合は接頭辞検索を行わないこと
+		assert.NoError(t, err)
+		assert.Equal(t, "id-c", id)
+		mockUsecase.AssertNotCalled(t, "ResolveID", mock.Anything, mock.Anything)
+	})
+
+	t.Run("一覧の範囲外の番号は接頭辞として解決される", func(t *testing.T) {
+		// Arrange
+		mockUsecase := new(MockTaskUsecase)
+		originalTaskUsecase := taskUsecase
+		taskUsecase = mockUsecase
+		defer func() { taskUsecase = originalTaskUsecase }()
+
+		assert.NoError(t, saveListing([]string{"id-a"}))
+		mockUsecase.On("ResolveID", mock.Anything, "12").Return("1234abcd", nil)
+
+		// Act
+		id, err := resolveID(context.Background(), "12")
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, "1234abcd", id)
+	})
+}
+
+// TestDoneCommand_AmbiguousPrefix は曖昧な短縮IDが候補付きのエラーになることを確認するテスト
+func TestDoneCommand_AmbiguousPrefix(t *testing.T) {
+	// Arrange
+	mockUsecase := new(MockTaskUsecase)
+	originalTaskUsecase := taskUsecase
+	taskUsecase = mockUsecase
+	defer func() { taskUsecase = originalTaskUsecase }()
+
+	mockUsecase.On("ResolveID", mock.Anything, "f4").Return("", &service.AmbiguousIDError{
+		Prefix:     "f4",
+		Candidates: []string{"f47ac10b-58cc-4372-a567-0e02b2c3d479", "f4aa0000-0000-0000-0000-000000000000"},
+	})
+
+	// Act
+	out, err := executeCommand("done", "f4")
+
+	// Assert
+	assert.Error(t, err)
+	assert.Contains(t, out, "ambiguous ID prefix \"f4\"")
+	assert.Contains(t, out, "f47ac10b-58cc-4372-a567-0e02b2c3d479")
+	assert.Contains(t, out, "f4aa0000-0000-0000-0000-000000000000")
+	mockUsecase.AssertNotCalled(t, "GetTask", mock.Anything, mock.Anything)
+}
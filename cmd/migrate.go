@@ -0,0 +1,155 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+// migrate downで全件をロールバックするかどうか
+var migrateDownAll bool
+
+func init() {
+	rootCmd.AddCommand(migrateCmd)
+	migrateCmd.AddCommand(migrateUpCmd, migrateDownCmd, migrateStatusCmd, migrateVersionCmd)
+
+	migrateDownCmd.Flags().BoolVar(&migrateDownAll, "all", false, "Roll back all applied migrations")
+}
+
+// migrateCmd はバイナリに埋め込まれたスキーマ定義をデータベースに適用するコマンドの定義
+var migrateCmd = &cobra.Command{
+	Use:   "migrate",
+	Short: "Manage the database schema",
+	Long: `Apply or roll back the database schema migrations embedded in this binary.
+
+Applied versions are recorded in the todogo_schema_migrations table. An advisory
+lock is held while migrating, so concurrent invocations run one after another.
+Databases previously migrated with the golang-migrate CLI are picked up
+automatically from its schema_migrations table.`,
+}
+
+var migrateUpCmd = &cobra.Command{
+	Use:          "up [N]",
+	Short:        "Apply all (or the next N) pending migrations",
+	Args:         cobra.MaximumNArgs(1),
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		n, err := migrationCount(args)
+		if err != nil {
+			return err
+		}
+
+		applied, err := migrator.Up(context.Background(), n)
+		for _, mig := range applied {
+			fmt.Fprintf(cmd.OutOrStdout(), "Applied %d_%s\n", mig.Version, mig.Name)
+		}
+		if err != nil {
+			return err
+		}
+
+		if len(applied) == 0 {
+			fmt.Fprintln(cmd.OutOrStdout(), "No pending migrations.")
+		}
+		return nil
+	},
+}
+
+var migrateDownCmd = &cobra.Command{
+	Use:          "down [N]",
+	Short:        "Roll back the last (or last N) applied migrations",
+	Args:         cobra.MaximumNArgs(1),
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		// 誤って全件をロールバックしないよう、既定では1件のみとする
+		n := 1
+		if len(args) > 0 {
+			if migrateDownAll {
+				return errors.New("cannot combine N with --all")
+			}
+			var err error
+			if n, err = migrationCount(args); err != nil {
+				return err
+			}
+		}
+		if migrateDownAll {
+			n = 0
+		}
+
+		rolledBack, err := migrator.Down(context.Background(), n)
+		for _, mig := range rolledBack {
+			fmt.Fprintf(cmd.OutOrStdout(), "Rolled back %d_%s\n", mig.Version, mig.Name)
+		}
+		if err != nil {
+			return err
+		}
+
+		if len(rolledBack) == 0 {
+			fmt.Fprintln(cmd.OutOrStdout(), "No applied migrations.")
+		}
+		return nil
+	},
+}
+
+var migrateStatusCmd = &cobra.Command{
+	Use:          "status",
+	Short:        "Show which migrations have been applied",
+	Args:         cobra.NoArgs,
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		statuses, err := migrator.Status(context.Background())
+		if err != nil {
+			return err
+		}
+
+		w := tabwriter.NewWriter(cmd.OutOrStdout(), 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "Version\tName\tStatus\tApplied")
+		fmt.Fprintln(w, "-------\t----\t------\t-------")
+		for _, st := range statuses {
+			status, appliedAt := "pending", "-"
+			if st.Applied {
+				status = "applied"
+				appliedAt = st.AppliedAt.Format(time.RFC3339)
+			}
+			fmt.Fprintf(w, "%d\t%s\t%s\t%s\n", st.Version, st.Name, status, appliedAt)
+		}
+		return w.Flush()
+	},
+}
+
+var migrateVersionCmd = &cobra.Command{
+	Use:          "version",
+	Short:        "Print the current schema version",
+	Args:         cobra.NoArgs,
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		version, ok, err := migrator.Version(context.Background())
+		if err != nil {
+			return err
+		}
+
+		if !ok {
+			fmt.Fprintln(cmd.OutOrStdout(), "No migrations applied.")
+			return nil
+		}
+		fmt.Fprintln(cmd.OutOrStdout(), version)
+		return nil
+	},
+}
+
+// migrationCount は引数で指定されたマイグレーションの件数を解釈する（省略時は0 = 全件）
+func migrationCount(args []string) (int, error) {
+	if len(args) == 0 {
+		return 0, nil
+	}
+
+	n, err := strconv.Atoi(args[0])
+	if err != nil || n < 1 {
+		return 0, fmt.Errorf("invalid count %q: must be a positive integer", args[0])
+	}
+	return n, nil
+}
@@ -1,15 +1,27 @@
 package cmd
 
 import (
-	"context"
+	"OTakumi/todogo/internal/domain/model"
+	"OTakumi/todogo/internal/usecase"
 	"errors"
 	"fmt"
 
 	"github.com/spf13/cobra"
 )
 
-// titleフラグの値を格納する変数
-var taskTitle string
+// newコマンドのフラグの値を格納する変数
+var (
+	taskTitle    string
+	taskDue      string
+	taskPriority string
+	taskTags     []string
+	taskProject  string
+	taskParent   string
+	taskRepeat   string
+	taskStart    string
+	taskWait     string
+	taskEstimate string
+)
 
 func init() {
 	// newコマンドをrootコマンドに追加
@@ -19,6 +31,18 @@ func init() {
 	// このフラグは必須で、タスクのタイトルを指定するために使用される
 	newCmd.Flags().StringVarP(&taskTitle, "title", "t", "", "Task title (required)")
 	newCmd.MarkFlagRequired("title")
+
+	// 締切はISO形式のほか、"tomorrow 17:00" や "next fri" のような表現でも指定できる
+	newCmd.Flags().StringVarP(&taskDue, "due", "d", "", "Deadline (e.g. 2025-01-31, \"tomorrow 17:00\", \"next fri\", \"in 3 days\", eow)")
+	newCmd.Flags().StringVarP(&taskPriority, "priority", "p", "", "Priority (none, low, medium, high, urgent)")
+	newCmd.Flags().StringSliceVar(&taskTags, "tag", nil, "Tag to attach (repeatable, or comma-separated)")
+	newCmd.Flags().StringVar(&taskProject, "project", "", "Project, with levels separated by dots (e.g. work.backend.api)")
+	newCmd.Flags().StringVar(&taskParent, "parent", "", "Create the task as a subtask of this task")
+	newCmd.Flags().StringVar(&taskRepeat, "repeat", "", "Recurrence rule (e.g. daily, \"weekly on mon,thu\", \"monthly on 15\", \"every 10 days after done\")")
+	newCmd.Flags().StringVar(&taskStart, "start", "", "Start date; the task is hidden from list until then (same formats as --due)")
+	newCmd.Flags().StringVar(&taskWait, "wait", "", "Wait date; the task is hidden from list until then (same formats as --due)")
+	newCmd.Flags().StringVar(&taskEstimate, "estimate", "", "Estimate, as a duration (e.g. 2h, 90m) or story points (e.g. 5pts)")
+	newCmd.Flags().SetNormalizeFunc(deadlineFlagAlias)
 }
 
 var newCmd = &cobra.Command{
@@ -27,6 +51,24 @@ var newCmd = &cobra.Command{
 	Long: `Create a new task with a title.
 	
 This command creates a new task in the database with the specified title.
+An optional deadline can be given with --due, either as an ISO date/time or
+as a relative phrase such as "tomorrow 17:00", "next fri", "in 3 days" or "eow".
+A priority can be given with --priority (none, low, medium, high or urgent, or
+just the first letter); it raises the task's urgency in the default list order.
+Tags can be attached with --tag, repeated or comma-separated
+(e.g. --tag work --tag urgent). Tag names are case-insensitive and stored in
+lower case. A project can be set with --project; project names are
+hierarchical, with levels separated by dots (e.g. work.backend.api).
+--parent creates the task as a subtask of another task, given by ID, ID
+prefix or list number; the parent must not be complete.
+--repeat makes the task repeating: completing it creates the next occurrence
+(see "repeat" for the rule syntax).
+--start and --wait hide the task from "todo list" until the given date (shown
+with list --all or --waiting). The start date is when work can begin; the wait
+date can later be pushed back with "snooze". Neither may be after the deadline.
+--estimate records how long the task should take, either as a duration
+("2h", "90m", "1d") or in story points ("5pts", "3 points"); "report
+estimates" later compares it with the actual time.
 The task will be created with default values for other fields.`,
 	// RunEを使用してエラーハンドリングを可能にする
 	RunE: func(cmd *cobra.Command, args []string) error {
@@ -35,22 +77,89 @@ The task will be created with default values for other fields.`,
 			return errors.New("title cannot be empty")
 		}
 
-		// コンテキストの作成（タイムアウトやキャンセレーション用）
-		ctx := context.Background()
+		r, err := newRenderer(cmd.OutOrStdout())
+		if err != nil {
+			return err
+		}
+
+		params := usecase.CreateTaskParams{Title: taskTitle, Project: taskProject}
+		if len(taskTags) > 0 {
+			params.Tags = taskTags
+		}
+
+		// 締切が指定されている場合は解釈する
+		if taskDue != "" {
+			due, err := parseDue(taskDue)
+			if err != nil {
+				return err
+			}
+			params.Deadline = &due
+		}
+
+		// 開始日時と待機の期限も締切と同じ表現で指定できる
+		if taskStart != "" {
+			startAt, err := parseDue(taskStart)
+			if err != nil {
+				return err
+			}
+			params.StartAt = &startAt
+		}
+		if taskWait != "" {
+			waitUntil, err := parseDue(taskWait)
+			if err != nil {
+				return err
+			}
+			params.WaitUntil = &waitUntil
+		}
+
+		if taskEstimate != "" {
+			estimate, err := parseEstimate(taskEstimate)
+			if err != nil {
+				return err
+			}
+			params.Estimate = estimate
+		}
+
+		if taskRepeat != "" {
+			rule, err := model.ParseRecurrence(taskRepeat)
+			if err != nil {
+				return err
+			}
+			params.Recurrence = rule
+		}
+
+		if taskPriority != "" {
+			priority, err := model.ParsePriority(taskPriority)
+			if err != nil {
+				return err
+			}
+			params.Priority = priority
+		}
+
+		// コンテキストの作成（タイムアウトやキャンセレーション用、履歴には操作した利用者を記録する）
+		ctx := commandContext(cmd)
+
+		// 親タスクは他のコマンドと同様に短縮IDや表示番号でも指定できる
+		if taskParent != "" {
+			parentID, err := resolveID(ctx, taskParent)
+			if err != nil {
+				return err
+			}
+			params.ParentID = parentID
+		}
 
 		// Usecaseレイヤーを使用してタスクを作成
 		// taskUsecaseはroot.goで定義され、SetupDependencies関数で初期化される
-		createdTask, err := taskUsecase.CreateTask(ctx, taskTitle)
+		createdTask, err := taskUsecase.CreateTask(ctx, params)
 		if err != nil {
 			// エラーをラップして上位層に返す
 			return fmt.Errorf("failed to create task: %w", err)
 		}
 
-		// 成功メッセージを出力
-		fmt.Fprintf(cmd.OutOrStdout(), "Task created successfully!\n")
-		fmt.Fprintf(cmd.OutOrStdout(), "ID: %s\n", createdTask.ID)
-		fmt.Fprintf(cmd.OutOrStdout(), "Title: %s\n", createdTask.Title)
-
-		return nil
+		// 成功メッセージと作成したタスクを出力
+		if err := r.Message(cmd.OutOrStdout(), "Task created successfully!"); err != nil {
+			return err
+		}
+		return r.Tasks(cmd.OutOrStdout(), []*model.Task{createdTask})
 	},
 }
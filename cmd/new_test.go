@@ -2,9 +2,13 @@ package cmd
 
 import (
 	"OTakumi/todogo/internal/domain/model"
+	"OTakumi/todogo/internal/domain/service"
+	"OTakumi/todogo/internal/repository"
+	"OTakumi/todogo/internal/usecase"
 	"bytes"
 	"context"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -17,11 +21,11 @@ type MockTaskUsecase struct {
 }
 
 // CreateTask はTaskUsecaseインターフェースのCreateTaskメソッドのモック実装
-// 引数: ctx - コンテキスト, title - タスクのタイトル
+// 引数: ctx - コンテキスト, params - タスクの作成内容
 // 戻り値: 作成されたタスク, エラー
-func (m *MockTaskUsecase) CreateTask(ctx context.Context, title string) (*model.Task, error) {
+func (m *MockTaskUsecase) CreateTask(ctx context.Context, params usecase.CreateTaskParams) (*model.Task, error) {
 	// Calledメソッドで呼び出しを記録し、事前に設定された戻り値を取得
-	args := m.Called(ctx, title)
+	args := m.Called(ctx, params)
 
 	// 最初の戻り値がnilの場合、nilとエラーを返す
 	if args.Get(0) == nil {
@@ -32,8 +36,40 @@ func (m *MockTaskUsecase) CreateTask(ctx context.Context, title string) (*model.
 }
 
 // FindAll はTaskUsecaseインターフェースのFindAllメソッドのモック実装
-// このテストでは使用されないが、インターフェースを満たすために必要
-func (m *MockTaskUsecase) FindAll(ctx context.Context) ([]*model.Task, error) {
+func (m *MockTaskUsecase) FindAll(ctx context.Context, query repository.TaskQuery) ([]*model.Task, error) {
+	args := m.Called(ctx, query)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*model.Task), args.Error(1)
+}
+
+// GetTask はTaskUsecaseインターフェースのGetTaskメソッドのモック実装
+func (m *MockTaskUsecase) GetTask(ctx context.Context, id string) (*model.Task, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.Task), args.Error(1)
+}
+
+// UpdateTask はTaskUsecaseインターフェースのUpdateTaskメソッドのモック実装
+func (m *MockTaskUsecase) UpdateTask(ctx context.Context, task *model.Task) (*model.Task, error) {
+	args := m.Called(ctx, task)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.Task), args.Error(1)
+}
+
+// DeleteTask はTaskUsecaseインターフェースのDeleteTaskメソッドのモック実装
+func (m *MockTaskUsecase) DeleteTask(ctx context.Context, id string) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+// Trash はTaskUsecaseインターフェースのTrashメソッドのモック実装
+func (m *MockTaskUsecase) Trash(ctx context.Context) ([]*model.Task, error) {
 	args := m.Called(ctx)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
@@ -41,6 +77,232 @@ func (m *MockTaskUsecase) FindAll(ctx context.Context) ([]*model.Task, error) {
 	return args.Get(0).([]*model.Task), args.Error(1)
 }
 
+// RestoreTask はTaskUsecaseインターフェースのRestoreTaskメソッドのモック実装
+func (m *MockTaskUsecase) RestoreTask(ctx context.Context, id string) (*model.Task, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.Task), args.Error(1)
+}
+
+// PurgeTasks はTaskUsecaseインターフェースのPurgeTasksメソッドのモック実装
+func (m *MockTaskUsecase) PurgeTasks(ctx context.Context, olderThan time.Duration) (int, error) {
+	args := m.Called(ctx, olderThan)
+	return args.Int(0), args.Error(1)
+}
+
+// ResolveID はTaskUsecaseインターフェースのResolveIDメソッドのモック実装
+func (m *MockTaskUsecase) ResolveID(ctx context.Context, ref string) (string, error) {
+	args := m.Called(ctx, ref)
+	return args.String(0), args.Error(1)
+}
+
+// DeletedShortIDs はTaskUsecaseインターフェースのDeletedShortIDsメソッドのモック実装
+func (m *MockTaskUsecase) DeletedShortIDs(ctx context.Context) (map[string]string, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(map[string]string), args.Error(1)
+}
+
+// ResolveDeletedID はTaskUsecaseインターフェースのResolveDeletedIDメソッドのモック実装
+func (m *MockTaskUsecase) ResolveDeletedID(ctx context.Context, ref string) (string, error) {
+	args := m.Called(ctx, ref)
+	return args.String(0), args.Error(1)
+}
+
+// ShortIDs はTaskUsecaseインターフェースのShortIDsメソッドのモック実装
+func (m *MockTaskUsecase) ShortIDs(ctx context.Context) (map[string]string, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(map[string]string), args.Error(1)
+}
+
+// Tags はTaskUsecaseインターフェースのTagsメソッドのモック実装
+func (m *MockTaskUsecase) Tags(ctx context.Context) ([]model.TagCount, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]model.TagCount), args.Error(1)
+}
+
+// CompleteTaskWithSubtasks はTaskUsecaseインターフェースのCompleteTaskWithSubtasksメソッドのモック実装
+func (m *MockTaskUsecase) CompleteTaskWithSubtasks(ctx context.Context, task *model.Task, loc *time.Location) (*model.Task, int, error) {
+	args := m.Called(ctx, task, loc)
+	var next *model.Task
+	if args.Get(0) != nil {
+		next = args.Get(0).(*model.Task)
+	}
+	return next, args.Int(1), args.Error(2)
+}
+
+// Block はTaskUsecaseインターフェースのBlockメソッドのモック実装
+func (m *MockTaskUsecase) Block(ctx context.Context, id, blockedByID string) error {
+	args := m.Called(ctx, id, blockedByID)
+	return args.Error(0)
+}
+
+// Unblock はTaskUsecaseインターフェースのUnblockメソッドのモック実装
+func (m *MockTaskUsecase) Unblock(ctx context.Context, id, blockedByID string) error {
+	args := m.Called(ctx, id, blockedByID)
+	return args.Error(0)
+}
+
+func (m *MockTaskUsecase) Annotate(ctx context.Context, id, text string) (*model.Annotation, error) {
+	args := m.Called(ctx, id, text)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.Annotation), args.Error(1)
+}
+
+// Search はTaskUsecaseインターフェースのSearchメソッドのモック実装
+func (m *MockTaskUsecase) Search(ctx context.Context, query string, limit int) ([]model.SearchHit, error) {
+	args := m.Called(ctx, query, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]model.SearchHit), args.Error(1)
+}
+
+// CompleteTask はTaskUsecaseインターフェースのCompleteTaskメソッドのモック実装
+func (m *MockTaskUsecase) CompleteTask(ctx context.Context, task *model.Task, loc *time.Location) (*model.Task, error) {
+	args := m.Called(ctx, task, loc)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.Task), args.Error(1)
+}
+
+// SetRecurrence はTaskUsecaseインターフェースのSetRecurrenceメソッドのモック実装
+func (m *MockTaskUsecase) SetRecurrence(ctx context.Context, id string, rule *model.Recurrence) (*model.Task, error) {
+	args := m.Called(ctx, id, rule)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.Task), args.Error(1)
+}
+
+// SetStatus はTaskUsecaseインターフェースのSetStatusメソッドのモック実装
+func (m *MockTaskUsecase) SetStatus(ctx context.Context, id string, status model.Status) (*model.Task, error) {
+	args := m.Called(ctx, id, status)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.Task), args.Error(1)
+}
+
+// Snooze はTaskUsecaseインターフェースのSnoozeメソッドのモック実装
+func (m *MockTaskUsecase) Snooze(ctx context.Context, id string, d time.Duration) (*model.Task, error) {
+	args := m.Called(ctx, id, d)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.Task), args.Error(1)
+}
+
+// Dependencies はTaskUsecaseインターフェースのDependenciesメソッドのモック実装
+func (m *MockTaskUsecase) Dependencies(ctx context.Context, id string) ([]service.ChainLink, []service.ChainLink, error) {
+	args := m.Called(ctx, id)
+	var upstream, downstream []service.ChainLink
+	if args.Get(0) != nil {
+		upstream = args.Get(0).([]service.ChainLink)
+	}
+	if args.Get(1) != nil {
+		downstream = args.Get(1).([]service.ChainLink)
+	}
+	return upstream, downstream, args.Error(2)
+}
+
+// StartTimer はTaskUsecaseインターフェースのStartTimerメソッドのモック実装
+func (m *MockTaskUsecase) StartTimer(ctx context.Context, user, id string) (*model.TimeEntry, error) {
+	args := m.Called(ctx, user, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.TimeEntry), args.Error(1)
+}
+
+// StopTimer はTaskUsecaseインターフェースのStopTimerメソッドのモック実装
+func (m *MockTaskUsecase) StopTimer(ctx context.Context, user string) (*model.TimeEntry, error) {
+	args := m.Called(ctx, user)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.TimeEntry), args.Error(1)
+}
+
+// LogTime はTaskUsecaseインターフェースのLogTimeメソッドのモック実装
+func (m *MockTaskUsecase) LogTime(ctx context.Context, user, id string, d time.Duration) (*model.TimeEntry, error) {
+	args := m.Called(ctx, user, id, d)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.TimeEntry), args.Error(1)
+}
+
+// TimeEntries はTaskUsecaseインターフェースのTimeEntriesメソッドのモック実装
+func (m *MockTaskUsecase) TimeEntries(ctx context.Context, id string) ([]model.TimeEntry, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]model.TimeEntry), args.Error(1)
+}
+
+// History はTaskUsecaseインターフェースのHistoryメソッドのモック実装
+func (m *MockTaskUsecase) History(ctx context.Context, id string) ([]model.TaskEvent, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]model.TaskEvent), args.Error(1)
+}
+
+// Undo はTaskUsecaseインターフェースのUndoメソッドのモック実装
+func (m *MockTaskUsecase) Undo(ctx context.Context, n int) ([]model.OperationBatch, error) {
+	args := m.Called(ctx, n)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]model.OperationBatch), args.Error(1)
+}
+
+// Redo はTaskUsecaseインターフェースのRedoメソッドのモック実装
+func (m *MockTaskUsecase) Redo(ctx context.Context, n int) ([]model.OperationBatch, error) {
+	args := m.Called(ctx, n)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]model.OperationBatch), args.Error(1)
+}
+
+// Timesheet はTaskUsecaseインターフェースのTimesheetメソッドのモック実装
+func (m *MockTaskUsecase) Timesheet(ctx context.Context, user string, from, to time.Time) (model.Timesheet, error) {
+	args := m.Called(ctx, user, from, to)
+	return args.Get(0).(model.Timesheet), args.Error(1)
+}
+
+// EstimateReport はTaskUsecaseインターフェースのEstimateReportメソッドのモック実装
+func (m *MockTaskUsecase) EstimateReport(ctx context.Context) (model.EstimateReport, error) {
+	args := m.Called(ctx)
+	return args.Get(0).(model.EstimateReport), args.Error(1)
+}
+
+// Projects はTaskUsecaseインターフェースのProjectsメソッドのモック実装
+func (m *MockTaskUsecase) Projects(ctx context.Context) ([]model.ProjectCount, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]model.ProjectCount), args.Error(1)
+}
+
 // TestNewCommand_CreateTaskWithTitle は正常にタスクを作成できることを確認するテスト
 // タイトルを指定してnewコマンドを実行し、期待通りの動作をすることを検証
 func TestNewCommand_CreateTaskWithTitle(t *testing.T) {
@@ -60,7 +322,7 @@ func TestNewCommand_CreateTaskWithTitle(t *testing.T) {
 		ID:    "test-id-123",
 		Title: "Test Task",
 	}
-	mockUsecase.On("CreateTask", mock.Anything, "Test Task").Return(createdTask, nil)
+	mockUsecase.On("CreateTask", mock.Anything, usecase.CreateTaskParams{Title: "Test Task"}).Return(createdTask, nil)
 
 	// コマンドの出力をキャプチャするためのバッファを作成
 	// 標準出力と標準エラー出力の両方をこのバッファにリダイレクト
@@ -142,7 +404,7 @@ func TestNewCommand_HandleUsecaseError(t *testing.T) {
 
 	// CreateTaskメソッドがエラーを返すように設定
 	// assert.AnErrorは汎用的なエラーオブジェクト
-	mockUsecase.On("CreateTask", mock.Anything, "Test Task").Return(nil, assert.AnError)
+	mockUsecase.On("CreateTask", mock.Anything, usecase.CreateTaskParams{Title: "Test Task"}).Return(nil, assert.AnError)
 
 	buf := new(bytes.Buffer)
 	rootCmd.SetOut(buf)
@@ -162,3 +424,268 @@ func TestNewCommand_HandleUsecaseError(t *testing.T) {
 	// モックが呼び出されたことを検証
 	mockUsecase.AssertExpectations(t)
 }
+
+// TestNewCommand_CreateTaskWithDue は--dueで指定した締切がUsecaseに渡されることを確認するテスト
+func TestNewCommand_CreateTaskWithDue(t *testing.T) {
+	// Arrange: テストの準備
+	mockUsecase := new(MockTaskUsecase)
+	originalTaskUsecase := taskUsecase
+	taskUsecase = mockUsecase
+	defer func() { taskUsecase = originalTaskUsecase }()
+	defer resetFlags(newCmd)
+
+	// 相対表現の締切が現在時刻を基準に解釈されること
+	before := time.Now()
+	mockUsecase.On("CreateTask", mock.Anything, mock.MatchedBy(func(params usecase.CreateTaskParams) bool {
+		if params.Title != "Test Task" || params.Deadline == nil {
+			return false
+		}
+		expected := before.Add(72 * time.Hour)
+		return params.Deadline.Sub(expected).Abs() < time.Minute
+	})).Return(&model.Task{ID: "test-id-123", Title: "Test Task"}, nil)
+
+	// Act: テスト対象の実行
+	out, err := executeCommand("new", "--title", "Test Task", "--due", "in 3 days")
+
+	// Assert: 結果の検証
+	assert.NoError(t, err)
+	assert.Contains(t, out, "Task created successfully")
+	mockUsecase.AssertExpectations(t)
+}
+
+// TestNewCommand_ErrorWhenDueInvalid は解釈できない締切がエラーとなることを確認するテスト
+func TestNewCommand_ErrorWhenDueInvalid(t *testing.T) {
+	// Arrange: テストの準備
+	mockUsecase := new(MockTaskUsecase)
+	originalTaskUsecase := taskUsecase
+	taskUsecase = mockUsecase
+	defer func() { taskUsecase = originalTaskUsecase }()
+	defer resetFlags(newCmd)
+
+	// Act: テスト対象の実行
+	out, err := executeCommand("new", "--title", "Test Task", "--due", "someday")
+
+	// Assert: 結果の検証
+	// Usecaseが呼ばれずにエラーとなること
+	assert.Error(t, err)
+	assert.Contains(t, out, "invalid date")
+	mockUsecase.AssertNotCalled(t, "CreateTask", mock.Anything, mock.Anything)
+}
+
+// TestNewCommand_CreateTaskWithPriority は--priorityで指定した優先度がUsecaseに渡されることを確認するテスト
+func TestNewCommand_CreateTaskWithPriority(t *testing.T) {
+	// Arrange: テストの準備
+	mockUsecase := new(MockTaskUsecase)
+	originalTaskUsecase := taskUsecase
+	taskUsecase = mockUsecase
+	defer func() { taskUsecase = originalTaskUsecase }()
+	defer resetFlags(newCmd)
+
+	mockUsecase.On("CreateTask", mock.Anything, usecase.CreateTaskParams{Title: "Test Task", Priority: model.PriorityHigh}).
+		Return(&model.Task{ID: "test-id-123", Title: "Test Task", Priority: model.PriorityHigh}, nil)
+
+	// Act: テスト対象の実行
+	out, err := executeCommand("new", "--title", "Test Task", "-p", "h")
+
+	// Assert: 結果の検証
+	assert.NoError(t, err)
+	assert.Contains(t, out, "Priority: high")
+	mockUsecase.AssertExpectations(t)
+}
+
+// TestNewCommand_ErrorWhenPriorityInvalid は不正な優先度がエラーとなることを確認するテスト
+func TestNewCommand_ErrorWhenPriorityInvalid(t *testing.T) {
+	// Arrange: テストの準備
+	mockUsecase := new(MockTaskUsecase)
+	originalTaskUsecase := taskUsecase
+	taskUsecase = mockUsecase
+	defer func() { taskUsecase = originalTaskUsecase }()
+	defer resetFlags(newCmd)
+
+	// Act: テスト対象の実行
+	out, err := executeCommand("new", "--title", "Test Task", "--priority", "critical")
+
+	// Assert: 結果の検証
+	assert.Error(t, err)
+	assert.Contains(t, out, "invalid priority")
+	mockUsecase.AssertNotCalled(t, "CreateTask", mock.Anything, mock.Anything)
+}
+
+// TestNewCommand_CreateTaskWithTags は--tagで指定したタグがUsecaseに渡されることを確認するテスト
+func TestNewCommand_CreateTaskWithTags(t *testing.T) {
+	// Arrange: テストの準備
+	mockUsecase := new(MockTaskUsecase)
+	originalTaskUsecase := taskUsecase
+	taskUsecase = mockUsecase
+	defer func() { taskUsecase = originalTaskUsecase }()
+	defer resetFlags(newCmd)
+
+	// 繰り返し指定したタグと、カンマ区切りで指定したタグがすべて渡されること
+	mockUsecase.On("CreateTask", mock.Anything, usecase.CreateTaskParams{Title: "Test Task", Tags: []string{"work", "urgent", "home"}}).
+		Return(&model.Task{ID: "test-id-123", Title: "Test Task", Tags: []string{"home", "urgent", "work"}}, nil)
+
+	// Act: テスト対象の実行
+	out, err := executeCommand("new", "--title", "Test Task", "--tag", "work", "--tag", "urgent,home")
+
+	// Assert: 結果の検証
+	assert.NoError(t, err)
+	assert.Contains(t, out, "Tags:     home, urgent, work")
+	mockUsecase.AssertExpectations(t)
+}
+
+// TestNewCommand_CreateTaskWithProject は--projectで指定したプロジェクトがUsecaseに渡されることを確認するテスト
+func TestNewCommand_CreateTaskWithProject(t *testing.T) {
+	// Arrange: テストの準備
+	mockUsecase := new(MockTaskUsecase)
+	originalTaskUsecase := taskUsecase
+	taskUsecase = mockUsecase
+	defer func() { taskUsecase = originalTaskUsecase }()
+	defer resetFlags(newCmd)
+
+	mockUsecase.On("CreateTask", mock.Anything, usecase.CreateTaskParams{Title: "Test Task", Project: "Work.Backend"}).
+		Return(&model.Task{ID: "test-id-123", Title: "Test Task", Project: "work.backend"}, nil)
+
+	// Act: テスト対象の実行
+	out, err := executeCommand("new", "--title", "Test Task", "--project", "Work.Backend")
+
+	// Assert: 結果の検証
+	assert.NoError(t, err)
+	assert.Contains(t, out, "Project:  work.backend")
+	mockUsecase.AssertExpectations(t)
+}
+
+// TestNewCommand_CreateSubtask は--parentで指定した親タスクのIDが解決されてUsecaseに渡されることを確認するテスト
+func TestNewCommand_CreateSubtask(t *testing.T) {
+	// Arrange: テストの準備
+	mockUsecase := new(MockTaskUsecase)
+	originalTaskUsecase := taskUsecase
+	taskUsecase = mockUsecase
+	defer func() { taskUsecase = originalTaskUsecase }()
+	defer resetFlags(newCmd)
+
+	mockUsecase.On("ResolveID", mock.Anything, "f47a").Return("f47ac10b-58cc-4372-a567-0e02b2c3d479", nil)
+	mockUsecase.On("CreateTask", mock.Anything, usecase.CreateTaskParams{Title: "Subtask", ParentID: "f47ac10b-58cc-4372-a567-0e02b2c3d479"}).
+		Return(&model.Task{ID: "test-id-123", Title: "Subtask", ParentID: "f47ac10b-58cc-4372-a567-0e02b2c3d479"}, nil)
+
+	// Act: テスト対象の実行
+	out, err := executeCommand("new", "--title", "Subtask", "--parent", "f47a")
+
+	// Assert: 結果の検証
+	assert.NoError(t, err)
+	assert.Contains(t, out, "Parent:   f47ac10b-58cc-4372-a567-0e02b2c3d479")
+	mockUsecase.AssertExpectations(t)
+}
+
+// TestNewCommand_CreateRepeatingTask は--repeatで指定したルールが解釈されてUsecaseに渡されることを確認するテスト
+func TestNewCommand_CreateRepeatingTask(t *testing.T) {
+	// Arrange: テストの準備
+	mockUsecase := new(MockTaskUsecase)
+	originalTaskUsecase := taskUsecase
+	taskUsecase = mockUsecase
+	defer func() { taskUsecase = originalTaskUsecase }()
+	defer resetFlags(newCmd)
+
+	rule := &model.Recurrence{Frequency: model.FrequencyWeekly, Interval: 1, Weekdays: []time.Weekday{time.Monday}}
+	mockUsecase.On("CreateTask", mock.Anything, usecase.CreateTaskParams{Title: "Review", Recurrence: rule}).
+		Return(&model.Task{ID: "test-id-123", Title: "Review", RecurrenceID: "rec-1", Recurrence: rule}, nil)
+
+	// Act: テスト対象の実行
+	out, err := executeCommand("new", "--title", "Review", "--repeat", "weekly on mon")
+
+	// Assert: 結果の検証
+	assert.NoError(t, err)
+	assert.Contains(t, out, "Repeat:   every week on Mon")
+	mockUsecase.AssertExpectations(t)
+}
+
+// TestNewCommand_ErrorWhenRepeatInvalid は不正なルールの場合にタスクを作成しないことを確認するテスト
+func TestNewCommand_ErrorWhenRepeatInvalid(t *testing.T) {
+	// Arrange: テストの準備
+	mockUsecase := new(MockTaskUsecase)
+	originalTaskUsecase := taskUsecase
+	taskUsecase = mockUsecase
+	defer func() { taskUsecase = originalTaskUsecase }()
+	defer resetFlags(newCmd)
+
+	// Act: テスト対象の実行
+	_, err := executeCommand("new", "--title", "Review", "--repeat", "hourly")
+
+	// Assert: 結果の検証
+	assert.ErrorContains(t, err, `invalid recurrence "hourly"`)
+	mockUsecase.AssertNotCalled(t, "CreateTask", mock.Anything, mock.Anything)
+}
+
+// TestNewCommand_CreateWaitingTask は--startと--waitで指定した日時が解釈されてUsecaseに渡されることを確認するテスト
+func TestNewCommand_CreateWaitingTask(t *testing.T) {
+	// Arrange: テストの準備
+	mockUsecase := new(MockTaskUsecase)
+	originalTaskUsecase := taskUsecase
+	taskUsecase = mockUsecase
+	defer func() { taskUsecase = originalTaskUsecase }()
+	defer resetFlags(newCmd)
+
+	startAt := time.Date(2099, 1, 10, 23, 59, 59, 0, time.Local)
+	waitUntil := time.Date(2099, 1, 20, 9, 0, 0, 0, time.Local)
+	mockUsecase.On("CreateTask", mock.Anything, mock.MatchedBy(func(p usecase.CreateTaskParams) bool {
+		return p.StartAt != nil && p.StartAt.Equal(startAt) && p.WaitUntil != nil && p.WaitUntil.Equal(waitUntil)
+	})).Return(&model.Task{ID: "test-id-123", Title: "Renew passport", StartAt: &startAt, WaitUntil: &waitUntil}, nil)
+
+	// Act: テスト対象の実行
+	out, err := executeCommand("new", "--title", "Renew passport", "--start", "2099-01-10", "--wait", "2099-01-20 09:00")
+
+	// Assert: 結果の検証
+	assert.NoError(t, err)
+	assert.Contains(t, out, "Wait:     2099-01-20 09:00")
+	mockUsecase.AssertExpectations(t)
+}
+
+// TestNewCommand_CreateTaskWithEstimate は--estimateで作業時間とポイントのいずれでも見積もりを指定できることを確認するテスト
+func TestNewCommand_CreateTaskWithEstimate(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  model.Estimate
+	}{
+		{"作業時間で指定する", "90m", model.Estimate{Duration: 90 * time.Minute}},
+		{"ポイントで指定する", "5pts", model.Estimate{Points: 5}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			mockUsecase := new(MockTaskUsecase)
+			originalTaskUsecase := taskUsecase
+			taskUsecase = mockUsecase
+			defer func() { taskUsecase = originalTaskUsecase }()
+			defer resetFlags(newCmd)
+
+			mockUsecase.On("CreateTask", mock.Anything, mock.MatchedBy(func(p usecase.CreateTaskParams) bool {
+				return p.Estimate != nil && *p.Estimate == tt.want
+			})).Return(&model.Task{ID: "test-id-123", Title: "Build API", Estimate: &tt.want}, nil)
+
+			// Act
+			out, err := executeCommand("new", "--title", "Build API", "--estimate", tt.input)
+
+			// Assert
+			assert.NoError(t, err)
+			assert.Contains(t, out, "Estimate: "+tt.want.String())
+			mockUsecase.AssertExpectations(t)
+		})
+	}
+}
+
+// TestNewCommand_ErrorWhenEstimateInvalid は不正な見積もりの場合にタスクを作成せずエラーを返すことを確認するテスト
+func TestNewCommand_ErrorWhenEstimateInvalid(t *testing.T) {
+	// Arrange
+	mockUsecase := new(MockTaskUsecase)
+	originalTaskUsecase := taskUsecase
+	taskUsecase = mockUsecase
+	defer func() { taskUsecase = originalTaskUsecase }()
+	defer resetFlags(newCmd)
+
+	// Act
+	_, err := executeCommand("new", "--title", "Build API", "--estimate", "soon")
+
+	// Assert
+	assert.ErrorContains(t, err, `invalid estimate "soon"`)
+	mockUsecase.AssertNotCalled(t, "CreateTask", mock.Anything, mock.Anything)
+}
@@ -0,0 +1,41 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/spf13/cobra"
+)
+
+func init() {
+	rootCmd.AddCommand(projectsCmd)
+}
+
+// projectsCmd はプロジェクトごとのタスクの件数と完了率を表示するコマンドの定義
+var projectsCmd = &cobra.Command{
+	Use:   "projects",
+	Short: "List projects with task counts and completion",
+	Long: `List the projects in use as a tree, in name order.
+
+Project names are hierarchical, with levels separated by dots (e.g.
+work.backend.api). For each project node the number of open and closed tasks
+and the completion percentage are shown. The counts of a project include the
+tasks of all its sub-projects, so "work" also counts the tasks in
+"work.backend". Use the global --output flag to print the counts as json, yaml,
+csv or ndjson.`,
+	Args:         cobra.NoArgs,
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		r, err := newRenderer(cmd.OutOrStdout())
+		if err != nil {
+			return err
+		}
+
+		counts, err := taskUsecase.Projects(context.Background())
+		if err != nil {
+			return fmt.Errorf("failed to fetch projects: %w", err)
+		}
+
+		return r.ProjectCounts(cmd.OutOrStdout(), counts)
+	},
+}
@@ -0,0 +1,71 @@
+package cmd
+
+import (
+	"OTakumi/todogo/internal/domain/model"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// TestProjectsCommand_PrintsProjectTree はプロジェクトごとの件数が階層に応じて表示されることを確認するテスト
+func TestProjectsCommand_PrintsProjectTree(t *testing.T) {
+	// Arrange
+	mockUsecase := new(MockTaskUsecase)
+	originalTaskUsecase := taskUsecase
+	taskUsecase = mockUsecase
+	defer func() { taskUsecase = originalTaskUsecase }()
+
+	mockUsecase.On("Projects", mock.Anything).Return([]model.ProjectCount{
+		{Name: "work", Open: 1, Closed: 3},
+		{Name: "work.backend", Open: 1, Closed: 1},
+	}, nil)
+
+	// Act
+	out, err := executeCommand("projects")
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Contains(t, out, "Project    Open  Closed  Complete")
+	assert.Contains(t, out, "work       1     3       75%")
+	assert.Contains(t, out, "  backend  1     1       50%")
+	mockUsecase.AssertExpectations(t)
+}
+
+// TestProjectsCommand_JSONOutput は--output jsonでプロジェクトの一覧がJSONとして出力されることを確認するテスト
+func TestProjectsCommand_JSONOutput(t *testing.T) {
+	// Arrange
+	mockUsecase := new(MockTaskUsecase)
+	originalTaskUsecase := taskUsecase
+	taskUsecase = mockUsecase
+	defer func() { taskUsecase = originalTaskUsecase }()
+	defer resetFlags(rootCmd)
+
+	mockUsecase.On("Projects", mock.Anything).Return([]model.ProjectCount{{Name: "work", Open: 1, Closed: 1}}, nil)
+
+	// Act
+	out, err := executeCommand("projects", "--output", "json")
+
+	// Assert
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"schema_version": 2, "projects": [{"name": "work", "open": 1, "closed": 1, "total": 2, "completion": 50}]}`, out)
+}
+
+// TestProjectsCommand_HandleUsecaseError はプロジェクトの取得に失敗した場合にエラーを返すことを確認するテスト
+func TestProjectsCommand_HandleUsecaseError(t *testing.T) {
+	// Arrange
+	mockUsecase := new(MockTaskUsecase)
+	originalTaskUsecase := taskUsecase
+	taskUsecase = mockUsecase
+	defer func() { taskUsecase = originalTaskUsecase }()
+
+	mockUsecase.On("Projects", mock.Anything).Return(nil, errors.New("database error"))
+
+	// Act
+	out, err := executeCommand("projects")
+
+	// Assert
+	assert.Error(t, err)
+	assert.Contains(t, out, "failed to fetch projects")
+}
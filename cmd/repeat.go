@@ -0,0 +1,75 @@
+package cmd
+
+import (
+	"OTakumi/todogo/internal/domain/model"
+	"context"
+
+	"github.com/spf13/cobra"
+)
+
+// repeatコマンドのフラグの値を格納する変数
+var (
+	repeatRule string
+	repeatStop bool
+)
+
+func init() {
+	rootCmd.AddCommand(repeatCmd)
+
+	repeatCmd.Flags().StringVar(&repeatRule, "rule", "", "Recurrence rule (e.g. daily, \"weekly on mon,thu\", \"monthly on 15\")")
+	repeatCmd.Flags().BoolVar(&repeatStop, "stop", false, "Stop repeating")
+	repeatCmd.MarkFlagsOneRequired("rule", "stop")
+	repeatCmd.MarkFlagsMutuallyExclusive("rule", "stop")
+}
+
+// repeatCmd はタスクの繰り返しのルールを設定・停止するコマンドの定義
+var repeatCmd = &cobra.Command{
+	Use:   "repeat <id>... (--rule <rule> | --stop)",
+	Short: "Make tasks repeat, change or stop their recurrence",
+	Long: `Set the recurrence rule of one or more tasks, or stop them repeating.
+
+When a repeating task is completed with "done", its next occurrence is created
+with a new ID, the same title, description, priority, tags, project and parent,
+and the next deadline from the rule (a start date keeps its distance to the
+deadline; a wait date is not carried over). All occurrences share one rule, so changing the rule
+of any occurrence (or stopping it with --stop) affects the whole series.
+
+A rule is one of:
+
+  daily, weekly, monthly             every day, week or month
+  every 3 days, every 2 weeks        every N days, weeks or months
+  weekly on mon,thu                  on the given weekdays
+  every 2 weeks on fri               on the given weekdays, every other week
+  monthly on 15                      on the given day of the month
+                                     (the last day in shorter months)
+  every 10 days after done           N days, weeks or months after completion
+
+or an RRULE-style rule such as "FREQ=WEEKLY;INTERVAL=2;BYDAY=MO,TH",
+"FREQ=MONTHLY;BYMONTHDAY=15" or "FREQ=DAILY;INTERVAL=10;X-FROM=COMPLETION".
+
+Calendar rules count from the task's deadline, skipping occurrences that are
+already past when the task is completed; a task without a deadline counts from
+its completion time.`,
+	Args:         cobra.MinimumNArgs(1),
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		var rule *model.Recurrence
+		if !repeatStop {
+			var err error
+			if rule, err = model.ParseRecurrence(repeatRule); err != nil {
+				return err
+			}
+		}
+
+		return runForEachID(cmd, args, func(ctx context.Context, id string) (string, error) {
+			task, err := taskUsecase.SetRecurrence(ctx, id, rule)
+			if err != nil {
+				return "", err
+			}
+			if task.Recurrence == nil {
+				return "no longer repeats", nil
+			}
+			return "repeats " + task.Recurrence.Describe(), nil
+		})
+	},
+}
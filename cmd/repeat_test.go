@@ -0,0 +1,69 @@
+package cmd
+
+import (
+	"OTakumi/todogo/internal/domain/model"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// TestRepeatCommand_SetsRule は--ruleで指定したルールが各タスクに設定されることを確認するテスト
+func TestRepeatCommand_SetsRule(t *testing.T) {
+	// Arrange
+	mockUsecase := new(MockTaskUsecase)
+	originalTaskUsecase := taskUsecase
+	taskUsecase = mockUsecase
+	defer func() { taskUsecase = originalTaskUsecase }()
+	defer resetFlags(repeatCmd)
+	stubResolveID(mockUsecase, "id-1", "id-2")
+
+	rule := &model.Recurrence{Frequency: model.FrequencyMonthly, Interval: 1, MonthDay: 15}
+	mockUsecase.On("SetRecurrence", mock.Anything, "id-1", rule).
+		Return(&model.Task{ID: "id-1", RecurrenceID: "rec-1", Recurrence: rule}, nil)
+	mockUsecase.On("SetRecurrence", mock.Anything, "id-2", rule).
+		Return(nil, errors.New("task is complete"))
+
+	// Act
+	out, err := executeCommand("repeat", "id-1", "id-2", "--rule", "monthly on 15")
+
+	// Assert
+	assert.Error(t, err)
+	assert.Contains(t, out, "id-1: repeats every month on day 15")
+	assert.Contains(t, out, "id-2: error: task is complete")
+	mockUsecase.AssertExpectations(t)
+}
+
+// TestRepeatCommand_Stop は--stopで繰り返しを停止することを確認するテスト
+func TestRepeatCommand_Stop(t *testing.T) {
+	// Arrange
+	mockUsecase := new(MockTaskUsecase)
+	originalTaskUsecase := taskUsecase
+	taskUsecase = mockUsecase
+	defer func() { taskUsecase = originalTaskUsecase }()
+	defer resetFlags(repeatCmd)
+	stubResolveID(mockUsecase, "id-1")
+
+	mockUsecase.On("SetRecurrence", mock.Anything, "id-1", (*model.Recurrence)(nil)).
+		Return(&model.Task{ID: "id-1"}, nil)
+
+	// Act
+	out, err := executeCommand("repeat", "id-1", "--stop")
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Contains(t, out, "id-1: no longer repeats")
+	mockUsecase.AssertExpectations(t)
+}
+
+// TestRepeatCommand_RequiresRuleOrStop は--ruleと--stopのどちらか一方が必須であることを確認するテスト
+func TestRepeatCommand_RequiresRuleOrStop(t *testing.T) {
+	defer resetFlags(repeatCmd)
+
+	_, err := executeCommand("repeat", "id-1")
+	assert.ErrorContains(t, err, "at least one of the flags in the group [rule stop] is required")
+
+	_, err = executeCommand("repeat", "id-1", "--rule", "daily", "--stop")
+	assert.ErrorContains(t, err, "none of the others can be")
+}
@@ -0,0 +1,58 @@
+package cmd
+
+import (
+	"OTakumi/todogo/internal/render"
+	"context"
+	"fmt"
+
+	"github.com/spf13/cobra"
+)
+
+func init() {
+	rootCmd.AddCommand(reportCmd)
+	reportCmd.AddCommand(reportEstimatesCmd)
+}
+
+// reportCmd はタスクの集計結果を出力するコマンドの親となるコマンドの定義
+var reportCmd = &cobra.Command{
+	Use:   "report",
+	Short: "Show reports about completed tasks",
+}
+
+// reportEstimatesCmd は完了したタスクの見積もりと実績を比較するコマンドの定義
+var reportEstimatesCmd = &cobra.Command{
+	Use:   "estimates",
+	Short: "Compare estimates with the actual time of completed tasks",
+	Long: `Compare the estimates of completed tasks with how long they actually took.
+
+The actual time of a task is the time tracked with "start"/"stop" and "log".
+For tasks without tracked time, the lead time from creation to completion is
+used instead; the Source column tells which one was used.
+
+The ratio is actual / estimate, so 1.50x means the task took half as long
+again as estimated. Accuracy is also totalled over all tasks, shown as
+"(all)", and per tag. Estimates in story points cannot be compared with time
+directly: the time per point is measured over all completed tasks estimated
+in points, and each ratio shows how a task or tag compares with that average.
+Only tasks in the done status are counted; cancelled tasks are left out.`,
+	Args:         cobra.NoArgs,
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		r, err := newRenderer(cmd.OutOrStdout())
+		if err != nil {
+			return err
+		}
+
+		ctx := context.Background()
+		report, err := taskUsecase.EstimateReport(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to compare estimates: %w", err)
+		}
+		shortIDs, err := taskUsecase.ShortIDs(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to fetch tasks: %w", err)
+		}
+
+		return r.EstimateReport(cmd.OutOrStdout(), render.EstimateReport{Report: report, ShortIDs: shortIDs})
+	},
+}
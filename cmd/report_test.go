@@ -0,0 +1,58 @@
+package cmd
+
+import (
+	"OTakumi/todogo/internal/domain/model"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// TestReportEstimatesCommand_ShowsAccuracy は完了したタスクの見積もりと実績、タグごとの精度を出力することを確認するテスト
+func TestReportEstimatesCommand_ShowsAccuracy(t *testing.T) {
+	// Arrange
+	mockUsecase := new(MockTaskUsecase)
+	originalTaskUsecase := taskUsecase
+	taskUsecase = mockUsecase
+	defer func() { taskUsecase = originalTaskUsecase }()
+	t.Setenv("TODOGO_TIMEZONE", "UTC")
+
+	completed := time.Date(2025, 6, 13, 17, 0, 0, 0, time.UTC)
+	mockUsecase.On("EstimateReport", mock.Anything).Return(model.EstimateReport{
+		Tasks: []model.EstimatedTask{{
+			TaskID: "id-1", Title: "Build API", Tags: []string{"backend"}, Estimate: model.Estimate{Duration: 2 * time.Hour},
+			Actual: 3 * time.Hour, Source: model.ActualTracked, CompletedAt: completed, Ratio: 1.5,
+		}},
+		Tags:    []model.EstimateAccuracy{{Tag: "backend", Kind: model.EstimateTime, Tasks: 1, Estimated: 2 * time.Hour, Actual: 3 * time.Hour, Ratio: 1.5}},
+		Overall: []model.EstimateAccuracy{{Kind: model.EstimateTime, Tasks: 1, Estimated: 2 * time.Hour, Actual: 3 * time.Hour, Ratio: 1.5}},
+	}, nil)
+	mockUsecase.On("ShortIDs", mock.Anything).Return(map[string]string{"id-1": "i1"}, nil)
+
+	// Act
+	out, err := executeCommand("report", "estimates")
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Contains(t, out, "i1  Build API  2025-06-13  2h        3h0m    tracked  1.50x")
+	assert.Contains(t, out, "backend  time  1      2h        3h0m    -          1.50x")
+	mockUsecase.AssertExpectations(t)
+}
+
+// TestReportEstimatesCommand_HandleUsecaseError は集計に失敗した場合にエラーを返すことを確認するテスト
+func TestReportEstimatesCommand_HandleUsecaseError(t *testing.T) {
+	// Arrange
+	mockUsecase := new(MockTaskUsecase)
+	originalTaskUsecase := taskUsecase
+	taskUsecase = mockUsecase
+	defer func() { taskUsecase = originalTaskUsecase }()
+
+	mockUsecase.On("EstimateReport", mock.Anything).Return(model.EstimateReport{}, errors.New("db error"))
+
+	// Act
+	_, err := executeCommand("report", "estimates")
+
+	// Assert
+	assert.ErrorContains(t, err, "failed to compare estimates: db error")
+}
@@ -0,0 +1,36 @@
+package cmd
+
+import (
+	"context"
+
+	"github.com/spf13/cobra"
+)
+
+func init() {
+	rootCmd.AddCommand(rmCmd)
+}
+
+// rmCmd はタスクを削除するコマンドの定義
+var rmCmd = &cobra.Command{
+	Use:   "rm <id>...",
+	Short: "Move tasks to the trash",
+	Long: `Delete one or more tasks by moving them to the trash.
+
+Deleted tasks no longer appear in listings, searches or counts, but keep
+their notes, dependencies and tracked time. Use "trash" to list them,
+"restore <id>" to bring one back and "purge" to delete them for good.
+Subtasks of a deleted task are listed as top-level tasks until it is restored.
+
+Each ID is deleted independently; IDs that cannot be found are reported
+and do not prevent the remaining tasks from being deleted.`,
+	Args:         cobra.MinimumNArgs(1),
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runForEachID(cmd, args, func(ctx context.Context, id string) (string, error) {
+			if err := taskUsecase.DeleteTask(ctx, id); err != nil {
+				return "", err
+			}
+			return "deleted", nil
+		})
+	},
+}
@@ -0,0 +1,131 @@
+package cmd
+
+import (
+	"OTakumi/todogo/internal/repository"
+	"bytes"
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// TestRmCommand_DeletesTasks は複数のタスクを削除し、ID単位の結果を表示することを確認するテスト
+func TestRmCommand_DeletesTasks(t *testing.T) {
+	// Arrange
+	mockUsecase := new(MockTaskUsecase)
+	originalTaskUsecase := taskUsecase
+	taskUsecase = mockUsecase
+	defer func() { taskUsecase = originalTaskUsecase }()
+	stubResolveID(mockUsecase, "id-1", "missing")
+
+	mockUsecase.On("DeleteTask", mock.Anything, "id-1").Return(nil)
+	mockUsecase.On("DeleteTask", mock.Anything, "missing").Return(&repository.TaskNotFoundError{ID: "missing"})
+
+	// Act
+	out, err := executeCommand("rm", "id-1", "missing")
+
+	// Assert
+	assert.Error(t, err)
+	assert.Contains(t, out, "id-1: deleted")
+	assert.Contains(t, out, "missing: error: task not found")
+	assert.Contains(t, out, "1 succeeded, 1 failed")
+	mockUsecase.AssertExpectations(t)
+}
+
+// TestRmCommand_RecordsActor は削除の履歴に記録するため、現在の利用者をコンテキストで渡すことを確認するテスト
+func TestRmCommand_RecordsActor(t *testing.T) {
+	// Arrange
+	mockUsecase := new(MockTaskUsecase)
+	originalTaskUsecase := taskUsecase
+	taskUsecase = mockUsecase
+	defer func() { taskUsecase = originalTaskUsecase }()
+	t.Setenv("TODOGO_USER", "alice")
+	stubResolveID(mockUsecase, "id-1")
+
+	mockUsecase.On("DeleteTask", mock.MatchedBy(func(ctx context.Context) bool {
+		return repository.ActorFrom(ctx) == "alice"
+	}), "id-1").Return(nil)
+
+	// Act
+	_, err := executeCommand("rm", "id-1")
+
+	// Assert
+	assert.NoError(t, err)
+	mockUsecase.AssertExpectations(t)
+}
+
+// TestRmCommand_RecordsCommand は複数のタスクの削除を、まとめて取り消せるように同じコマンドとして渡すことを確認するテスト
+func TestRmCommand_RecordsCommand(t *testing.T) {
+	// Arrange
+	mockUsecase := new(MockTaskUsecase)
+	originalTaskUsecase := taskUsecase
+	taskUsecase = mockUsecase
+	defer func() { taskUsecase = originalTaskUsecase }()
+	stubResolveID(mockUsecase, "id-1", "id-2")
+
+	var batches []string
+	mockUsecase.On("DeleteTask", mock.MatchedBy(func(ctx context.Context) bool {
+		_, command, ok := repository.BatchFrom(ctx)
+		return ok && command == "rm id-1 id-2"
+	}), mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+		id, _, _ := repository.BatchFrom(args.Get(0).(context.Context))
+		batches = append(batches, id)
+	})
+
+	// Act
+	_, err := executeCommand("rm", "id-1", "id-2")
+
+	// Assert
+	assert.NoError(t, err)
+	if assert.Len(t, batches, 2) {
+		assert.Equal(t, batches[0], batches[1])
+	}
+	mockUsecase.AssertExpectations(t)
+}
+
+// TestRmCommand_NDJSONOutput は--output ndjsonでID単位の結果が1行ずつ出力されることを確認するテスト
+func TestRmCommand_NDJSONOutput(t *testing.T) {
+	// Arrange
+	mockUsecase := new(MockTaskUsecase)
+	originalTaskUsecase := taskUsecase
+	taskUsecase = mockUsecase
+	defer func() { taskUsecase = originalTaskUsecase }()
+	defer resetFlags(rootCmd)
+	stubResolveID(mockUsecase, "id-1", "missing")
+
+	mockUsecase.On("DeleteTask", mock.Anything, "id-1").Return(nil)
+	mockUsecase.On("DeleteTask", mock.Anything, "missing").Return(&repository.TaskNotFoundError{ID: "missing"})
+
+	// 構造化された出力とエラーメッセージを区別するため、標準出力のみを取得する
+	stdout, stderr := new(bytes.Buffer), new(bytes.Buffer)
+	rootCmd.SetOut(stdout)
+	rootCmd.SetErr(stderr)
+	rootCmd.SetArgs([]string{"rm", "id-1", "missing", "--output", "ndjson"})
+
+	// Act
+	err := rootCmd.Execute()
+
+	// Assert
+	assert.Error(t, err)
+	lines := strings.Split(strings.TrimSpace(stdout.String()), "\n")
+	assert.Len(t, lines, 2)
+
+	type result struct {
+		SchemaVersion int    `json:"schema_version"`
+		Ref           string `json:"ref"`
+		OK            bool   `json:"ok"`
+		Error         string `json:"error"`
+	}
+	results := make([]result, len(lines))
+	for i, line := range lines {
+		assert.NoError(t, json.Unmarshal([]byte(line), &results[i]))
+	}
+	assert.True(t, results[0].OK)
+	assert.Equal(t, 2, results[0].SchemaVersion)
+	assert.False(t, results[1].OK)
+	assert.Equal(t, "missing", results[1].Ref)
+	assert.Contains(t, results[1].Error, "task not found")
+}
@@ -1,6 +1,8 @@
 package cmd
 
 import (
+	"OTakumi/todogo/internal/domain/model"
+	"OTakumi/todogo/internal/infrastructure/migration"
 	"OTakumi/todogo/internal/usecase"
 	"database/sql"
 	"fmt"
@@ -23,17 +25,24 @@ var (
 	// これらはmain関数で初期化され、各コマンドから利用される
 	db          *sql.DB
 	taskUsecase usecase.TaskUsecase
+	migrator    *migration.Migrator
+	// workflow はタスクの状態の解釈と表示に使う（設定ファイルの workflow、未設定の場合は既定のワークフロー）
+	workflow = model.DefaultWorkflow()
 )
 
 // SetupDependencies は外部から依存関係を注入するための関数
-// main関数で初期化されたDB接続とユースケースを受け取る
-func SetupDependencies(database *sql.DB, tu usecase.TaskUsecase) {
+// main関数で初期化されたDB接続とユースケース、マイグレーションの実行器、ワークフローを受け取る
+func SetupDependencies(database *sql.DB, tu usecase.TaskUsecase, m *migration.Migrator, wf *model.Workflow) {
 	db = database
 	taskUsecase = tu
+	migrator = m
+	workflow = wf
 }
 
 // Execute executes the root command.
 func Execute() error {
+	// "edit <id> -tag" の "-tag" がフラグとして解釈されないよう、引数を組み替えてから実行する
+	rootCmd.SetArgs(separateTagArgs(rootCmd, os.Args[1:]))
 	return rootCmd.Execute()
 }
 
@@ -46,8 +55,31 @@ func init() {
 	rootCmd.PersistentFlags().Bool("viper", true, "use Viper for configuration")
 	viper.BindPFlag("author", rootCmd.PersistentFlags().Lookup("author"))
 	viper.BindPFlag("useViper", rootCmd.PersistentFlags().Lookup("viper"))
+
+	// 出力形式（table, json, yaml, csv, ndjson）
+	// 設定ファイルの output または環境変数 TODOGO_OUTPUT で既定値を変更できる
+	rootCmd.PersistentFlags().StringP("output", "o", "table", "Output format (table, json, yaml, csv, ndjson)")
+	viper.BindPFlag("output", rootCmd.PersistentFlags().Lookup("output"))
+	viper.BindEnv("output", "TODOGO_OUTPUT")
 	viper.SetDefault("author", "NAME HERE <EMAIL ADDRESS>")
 	viper.SetDefault("license", "apache")
+
+	// 日時の解釈と表示に使うタイムゾーン（例: Asia/Tokyo）
+	// 未設定の場合はローカルタイムゾーンを使う
+	viper.SetDefault("timezone", "")
+	viper.BindEnv("timezone", "TODOGO_TIMEZONE")
+
+	// 作業時間を記録する利用者の名前
+	// 未設定の場合はOSのログインユーザー名を使う
+	viper.SetDefault("user", "")
+	viper.BindEnv("user", "TODOGO_USER")
+
+	// タスクの保存先（postgres または sqlite）
+	// sqliteの場合、storage.pathが未設定であれば既定の場所にデータベースファイルを作成する
+	viper.SetDefault("storage.driver", "postgres")
+	viper.SetDefault("storage.path", "")
+	viper.BindEnv("storage.driver", "TODOGO_STORAGE_DRIVER")
+	viper.BindEnv("storage.path", "TODOGO_STORAGE_PATH")
 }
 
 func initConfig() {
@@ -67,7 +99,8 @@ func initConfig() {
 
 	viper.AutomaticEnv()
 
+	// 標準出力は構造化された出力のために空けておき、診断メッセージは標準エラー出力に書く
 	if err := viper.ReadInConfig(); err == nil {
-		fmt.Println("Using config file:", viper.ConfigFileUsed())
+		fmt.Fprintln(os.Stderr, "Using config file:", viper.ConfigFileUsed())
 	}
 }
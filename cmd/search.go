@@ -0,0 +1,81 @@
+package cmd
+
+import (
+	"OTakumi/todogo/internal/render"
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+// searchLimit は表示する検索結果の最大件数
+var searchLimit int
+
+func init() {
+	rootCmd.AddCommand(searchCmd)
+
+	searchCmd.Flags().IntVarP(&searchLimit, "limit", "n", 20, "Maximum number of results (0 for no limit)")
+}
+
+// searchCmd はタスクのタイトル、説明、注記を検索するコマンドの定義
+var searchCmd = &cobra.Command{
+	Use:   "search <query>...",
+	Short: "Search task titles, notes and annotations",
+	Long: `Search the title, notes and annotations of every task and print the matches,
+most relevant first. Matched terms are highlighted in the output.
+
+All words of the query must match. With PostgreSQL the search uses the full-text
+index: words are matched whole, "quoted phrases", "or" and -word are supported,
+and all words must appear in the title and notes or in a single annotation.
+With SQLite and the in-memory storage each word is matched as a case-insensitive
+substring of the title, notes or any annotation.
+
+Title matches rank above notes, which rank above annotations. The numbers shown
+can be used with other commands, like the numbers from "todo list".
+
+Examples:
+  todo search vendor
+  todo search call vendor --limit 5
+  todo search vendor --output json`,
+	Args:         cobra.MinimumNArgs(1),
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if searchLimit < 0 {
+			return errors.New("--limit must not be negative")
+		}
+
+		r, err := newRenderer(cmd.OutOrStdout())
+		if err != nil {
+			return err
+		}
+
+		ctx := context.Background()
+		hits, err := taskUsecase.Search(ctx, strings.Join(args, " "), searchLimit)
+		if err != nil {
+			return fmt.Errorf("failed to search tasks: %w", err)
+		}
+
+		results := render.SearchResults{Hits: hits}
+		if len(hits) > 0 {
+			results.ShortIDs, err = taskUsecase.ShortIDs(ctx)
+			if err != nil {
+				return fmt.Errorf("failed to fetch task IDs: %w", err)
+			}
+
+			// 表示順のIDを保存し、他のコマンドで番号指定できるようにする
+			// 保存に失敗しても、検索自体は成功として扱う
+			listing := make([]string, 0, len(hits))
+			for _, hit := range hits {
+				listing = append(listing, hit.Task.ID)
+			}
+			if err := saveListing(listing); err != nil {
+				log.Printf("Warning: failed to save listing: %v", err)
+			}
+		}
+
+		return r.SearchResults(cmd.OutOrStdout(), results)
+	},
+}
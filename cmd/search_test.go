@@ -0,0 +1,161 @@
+package cmd
+
+import (
+	"OTakumi/todogo/internal/domain/model"
+	"errors"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// TestSearchCommand_PrintsHighlightedMatches は検索結果が一致した箇所を強調して表示されることを確認するテスト
+func TestSearchCommand_PrintsHighlightedMatches(t *testing.T) {
+	// Arrange
+	mockUsecase := new(MockTaskUsecase)
+	originalTaskUsecase := taskUsecase
+	taskUsecase = mockUsecase
+	defer func() { taskUsecase = originalTaskUsecase }()
+	t.Setenv("NO_COLOR", "1")
+
+	hits := []model.SearchHit{
+		{Task: &model.Task{ID: "task-1", Title: "Call the vendor", Status: model.StatusOpen}, Rank: 1, Matches: []model.SearchMatch{
+			{Field: model.SearchFieldTitle, Snippet: "Call the vendor", Highlights: []model.Highlight{{Start: 9, End: 15}}},
+		}},
+		{Task: &model.Task{ID: "task-2", Title: "Fix the sink", Status: model.StatusInProgress}, Rank: 0.2, Matches: []model.SearchMatch{
+			{Field: model.SearchFieldAnnotation, Snippet: "vendor is closed", Highlights: []model.Highlight{{Start: 0, End: 6}}},
+		}},
+	}
+	// 複数の引数は空白で区切った1つの検索文字列として渡す
+	mockUsecase.On("Search", mock.Anything, "call vendor", 20).Return(hits, nil)
+	mockUsecase.On("ShortIDs", mock.Anything).Return(map[string]string{"task-1": "t1", "task-2": "t2"}, nil)
+
+	// Act
+	out, err := executeCommand("search", "call", "vendor")
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Contains(t, out, "1  t1  Call the [vendor]  (Open, rank 1.00)")
+	assert.Contains(t, out, "2  t2  Fix the sink  (In progress, rank 0.20)\n    annotation: [vendor] is closed")
+	assert.Contains(t, out, "Found: 2 task(s)")
+
+	// 表示順が番号指定用に保存されること
+	listing, err := loadListing()
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"task-1", "task-2"}, listing)
+	mockUsecase.AssertExpectations(t)
+}
+
+// TestSearchCommand_NoColorWhenPiped は端末でない出力先には、NO_COLOR がなくてもエスケープシーケンスを出力しないことを確認するテスト
+func TestSearchCommand_NoColorWhenPiped(t *testing.T) {
+	// Arrange
+	mockUsecase := new(MockTaskUsecase)
+	originalTaskUsecase := taskUsecase
+	taskUsecase = mockUsecase
+	defer func() { taskUsecase = originalTaskUsecase }()
+	// NO_COLOR が設定されていない状態にする（t.Setenv によりテスト後に元に戻る）
+	t.Setenv("NO_COLOR", "")
+	require.NoError(t, os.Unsetenv("NO_COLOR"))
+
+	hits := []model.SearchHit{
+		{Task: &model.Task{ID: "task-1", Title: "Call the vendor", Status: model.StatusOpen}, Rank: 1, Matches: []model.SearchMatch{
+			{Field: model.SearchFieldTitle, Snippet: "Call the vendor", Highlights: []model.Highlight{{Start: 9, End: 15}}},
+		}},
+	}
+	mockUsecase.On("Search", mock.Anything, "vendor", 20).Return(hits, nil)
+	mockUsecase.On("ShortIDs", mock.Anything).Return(map[string]string{"task-1": "t1"}, nil)
+
+	// Act
+	out, err := executeCommand("search", "vendor")
+
+	// Assert
+	assert.NoError(t, err)
+	assert.NotContains(t, out, "\x1b[")
+	assert.Contains(t, out, "Call the [vendor]")
+}
+
+// TestSearchCommand_Limit は--limitで指定した件数が検索に渡されることを確認するテスト
+func TestSearchCommand_Limit(t *testing.T) {
+	// Arrange
+	mockUsecase := new(MockTaskUsecase)
+	originalTaskUsecase := taskUsecase
+	taskUsecase = mockUsecase
+	defer func() { taskUsecase = originalTaskUsecase }()
+	defer resetFlags(searchCmd)
+
+	mockUsecase.On("Search", mock.Anything, "vendor", 5).Return([]model.SearchHit{}, nil)
+
+	// Act
+	out, err := executeCommand("search", "vendor", "--limit", "5")
+
+	// Assert
+	// 一致しない場合は短縮IDを取得せずにその旨を表示すること
+	assert.NoError(t, err)
+	assert.Contains(t, out, "No tasks found.")
+	mockUsecase.AssertExpectations(t)
+}
+
+// TestSearchCommand_JSONOutput は--output jsonで検索結果がJSONとして出力されることを確認するテスト
+func TestSearchCommand_JSONOutput(t *testing.T) {
+	// Arrange
+	mockUsecase := new(MockTaskUsecase)
+	originalTaskUsecase := taskUsecase
+	taskUsecase = mockUsecase
+	defer func() { taskUsecase = originalTaskUsecase }()
+	defer resetFlags(rootCmd)
+
+	hits := []model.SearchHit{
+		{Task: &model.Task{ID: "task-1", Title: "Call the vendor", Status: model.StatusOpen}, Rank: 0.6079, Matches: []model.SearchMatch{
+			{Field: model.SearchFieldTitle, Snippet: "Call the vendor", Highlights: []model.Highlight{{Start: 9, End: 15}}},
+		}},
+	}
+	mockUsecase.On("Search", mock.Anything, "vendor", 20).Return(hits, nil)
+	mockUsecase.On("ShortIDs", mock.Anything).Return(map[string]string{"task-1": "t1"}, nil)
+
+	// Act
+	out, err := executeCommand("search", "vendor", "--output", "json")
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Contains(t, out, `"schema_version": 2`)
+	assert.Contains(t, out, `"rank": 0.6079`)
+	assert.Contains(t, out, `"highlights": [`)
+	assert.Contains(t, out, `"start": 9`)
+}
+
+// TestSearchCommand_InvalidLimit は負の件数を指定した場合に検索せずにエラーを返すことを確認するテスト
+func TestSearchCommand_InvalidLimit(t *testing.T) {
+	// Arrange
+	mockUsecase := new(MockTaskUsecase)
+	originalTaskUsecase := taskUsecase
+	taskUsecase = mockUsecase
+	defer func() { taskUsecase = originalTaskUsecase }()
+	defer resetFlags(searchCmd)
+
+	// Act
+	_, err := executeCommand("search", "vendor", "--limit", "-1")
+
+	// Assert
+	assert.Error(t, err)
+	mockUsecase.AssertNotCalled(t, "Search", mock.Anything, mock.Anything, mock.Anything)
+}
+
+// TestSearchCommand_HandleUsecaseError は検索に失敗した場合にエラーを返すことを確認するテスト
+func TestSearchCommand_HandleUsecaseError(t *testing.T) {
+	// Arrange
+	mockUsecase := new(MockTaskUsecase)
+	originalTaskUsecase := taskUsecase
+	taskUsecase = mockUsecase
+	defer func() { taskUsecase = originalTaskUsecase }()
+
+	mockUsecase.On("Search", mock.Anything, "vendor", 20).Return(nil, errors.New("database error"))
+
+	// Act
+	out, err := executeCommand("search", "vendor")
+
+	// Assert
+	assert.Error(t, err)
+	assert.Contains(t, out, "failed to search tasks")
+}
@@ -0,0 +1,84 @@
+package cmd
+
+import (
+	"OTakumi/todogo/internal/render"
+	"context"
+	"fmt"
+
+	"github.com/spf13/cobra"
+)
+
+func init() {
+	rootCmd.AddCommand(showCmd)
+}
+
+// showCmd はタスクの詳細を表示するコマンドの定義
+var showCmd = &cobra.Command{
+	Use:   "show <id>...",
+	Short: "Show task details",
+	Long: `Show the details of one or more tasks.
+
+Each task is printed as a block of fields, or as a single document with the
+global --output flag. IDs that cannot be found are reported individually on
+stderr and do not stop the remaining IDs from being shown.
+
+The details include the task's dependency chain: the tasks it waits for
+(upstream) and the tasks waiting for it (downstream), following dependencies
+through every level and indented by their distance from the task.
+
+The time tracked on the task with "start"/"stop" and "log" is shown as a
+total, including a timer that is still running.`,
+	Args:         cobra.MinimumNArgs(1),
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := context.Background()
+
+		r, err := newRenderer(cmd.OutOrStdout())
+		if err != nil {
+			return err
+		}
+
+		// 見つからないIDは標準エラー出力に報告し、見つかったタスクのみを出力する
+		var details []render.TaskDetail
+		failed := 0
+		for _, ref := range args {
+			detail, err := showTask(ctx, ref)
+			if err != nil {
+				failed++
+				fmt.Fprintf(cmd.ErrOrStderr(), "%s: error: %v\n", ref, err)
+				continue
+			}
+			details = append(details, detail)
+		}
+
+		if err := r.TaskDetails(cmd.OutOrStdout(), details); err != nil {
+			return fmt.Errorf("failed to write output: %w", err)
+		}
+
+		if failed > 0 {
+			return fmt.Errorf("%d of %d task(s) failed", failed, len(args))
+		}
+		return nil
+	},
+}
+
+// showTask はタスク参照をIDに解決し、対応するタスクと依存関係の連鎖を取得する
+func showTask(ctx context.Context, ref string) (render.TaskDetail, error) {
+	id, err := resolveID(ctx, ref)
+	if err != nil {
+		return render.TaskDetail{}, err
+	}
+	task, err := taskUsecase.GetTask(ctx, id)
+	if err != nil {
+		return render.TaskDetail{}, err
+	}
+	upstream, downstream, err := taskUsecase.Dependencies(ctx, id)
+	if err != nil {
+		return render.TaskDetail{}, err
+	}
+	entries, err := taskUsecase.TimeEntries(ctx, id)
+	if err != nil {
+		return render.TaskDetail{}, err
+	}
+	return render.TaskDetail{Task: task, Upstream: upstream, Downstream: downstream, TimeEntries: entries}, nil
+}
@@ -0,0 +1,92 @@
+package cmd
+
+import (
+	"OTakumi/todogo/internal/domain/model"
+	"OTakumi/todogo/internal/domain/service"
+	"OTakumi/todogo/internal/repository"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// TestShowCommand_PrintsTaskDetails はタスクの詳細が表示されることを確認するテスト
+func TestShowCommand_PrintsTaskDetails(t *testing.T) {
+	// Arrange
+	mockUsecase := new(MockTaskUsecase)
+	originalTaskUsecase := taskUsecase
+	taskUsecase = mockUsecase
+	defer func() { taskUsecase = originalTaskUsecase }()
+	stubResolveID(mockUsecase, "id-1")
+
+	mockUsecase.On("GetTask", mock.Anything, "id-1").Return(&model.Task{ID: "id-1", Title: "Write docs", Status: model.StatusOpen, CreatedAt: time.Now(), UpdatedAt: time.Now()}, nil)
+	mockUsecase.On("Dependencies", mock.Anything, "id-1").Return(nil, nil, nil)
+	ended := time.Now().Add(-time.Hour)
+	mockUsecase.On("TimeEntries", mock.Anything, "id-1").Return([]model.TimeEntry{{ID: 1, TaskID: "id-1", StartedAt: ended.Add(-45 * time.Minute), EndedAt: &ended}}, nil)
+
+	// Act
+	out, err := executeCommand("show", "id-1")
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Contains(t, out, "ID:       id-1")
+	assert.Contains(t, out, "Title:    Write docs")
+	assert.Contains(t, out, "Status:   Open")
+	assert.Contains(t, out, "Tracked:  45m\n")
+	mockUsecase.AssertExpectations(t)
+}
+
+// TestShowCommand_ReportsMissingTask は存在しないIDがエラーとして報告されることを確認するテスト
+func TestShowCommand_ReportsMissingTask(t *testing.T) {
+	// Arrange
+	mockUsecase := new(MockTaskUsecase)
+	originalTaskUsecase := taskUsecase
+	taskUsecase = mockUsecase
+	defer func() { taskUsecase = originalTaskUsecase }()
+	stubResolveID(mockUsecase, "id-1", "missing")
+
+	mockUsecase.On("GetTask", mock.Anything, "id-1").Return(&model.Task{ID: "id-1", Title: "Write docs"}, nil)
+	mockUsecase.On("Dependencies", mock.Anything, "id-1").Return(nil, nil, nil)
+	mockUsecase.On("TimeEntries", mock.Anything, "id-1").Return([]model.TimeEntry{}, nil)
+	mockUsecase.On("GetTask", mock.Anything, "missing").Return(nil, &repository.TaskNotFoundError{ID: "missing"})
+
+	// Act
+	out, err := executeCommand("show", "id-1", "missing")
+
+	// Assert
+	// 見つかったタスクは表示され、見つからないIDのみエラーとなること
+	assert.Error(t, err)
+	assert.Contains(t, out, "Title:    Write docs")
+	assert.Contains(t, out, "missing: error: task not found")
+}
+
+// TestShowCommand_PrintsDependencyChain は上流と下流のタスクが表示されることを確認するテスト
+func TestShowCommand_PrintsDependencyChain(t *testing.T) {
+	// Arrange
+	mockUsecase := new(MockTaskUsecase)
+	originalTaskUsecase := taskUsecase
+	taskUsecase = mockUsecase
+	defer func() { taskUsecase = originalTaskUsecase }()
+	stubResolveID(mockUsecase, "id-2")
+
+	build := &model.Task{ID: "id-1", Title: "Build", Status: model.StatusDone}
+	test := &model.Task{ID: "id-2", Title: "Test", Status: model.StatusOpen, BlockedBy: []string{"id-1"}}
+	deploy := &model.Task{ID: "id-3", Title: "Deploy", Status: model.StatusBlocked, BlockedBy: []string{"id-2"}}
+	mockUsecase.On("GetTask", mock.Anything, "id-2").Return(test, nil)
+	mockUsecase.On("Dependencies", mock.Anything, "id-2").Return(
+		[]service.ChainLink{{Task: build, Depth: 1}},
+		[]service.ChainLink{{Task: deploy, Depth: 1}},
+		nil,
+	)
+	mockUsecase.On("TimeEntries", mock.Anything, "id-2").Return([]model.TimeEntry{}, nil)
+
+	// Act
+	out, err := executeCommand("show", "id-2")
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Contains(t, out, "Blocked:  id-1\n")
+	assert.Contains(t, out, "Upstream:\n  id-1  Build  (Done)\nDownstream:\n  id-3  Deploy  (Blocked)\n")
+	mockUsecase.AssertExpectations(t)
+}
@@ -0,0 +1,52 @@
+package cmd
+
+import (
+	"OTakumi/todogo/internal/dateparse"
+	"context"
+
+	"github.com/spf13/cobra"
+)
+
+func init() {
+	rootCmd.AddCommand(snoozeCmd)
+}
+
+// snoozeCmd はタスクの待機の期限を先に延ばすコマンドの定義
+var snoozeCmd = &cobra.Command{
+	Use:   "snooze <id> <duration>",
+	Short: "Hide a task from the list for a while",
+	Long: `Push a task's wait date forward, hiding it from "todo list" until then:
+
+  todo_cli snooze 3 3d
+  todo_cli snooze 3 "2 weeks"
+
+The duration is a number followed by w (weeks), d (days), h, m or s, and units
+can be combined (1d12h). If the task is already waiting, the wait date is
+pushed back from its current value; otherwise it is counted from now.
+The wait date must not end up after the task's deadline.
+
+Waiting tasks are shown with "todo list --all" or "todo list --waiting".
+Use "edit --clear-wait" to show the task again right away.`,
+	Args:         cobra.ExactArgs(2),
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		d, err := dateparse.ParseDuration(args[1])
+		if err != nil {
+			return err
+		}
+
+		// 表示する日時は設定されたタイムゾーンに合わせる
+		loc, err := timeLocation()
+		if err != nil {
+			return err
+		}
+
+		return runForEachID(cmd, args[:1], func(ctx context.Context, id string) (string, error) {
+			task, err := taskUsecase.Snooze(ctx, id, d)
+			if err != nil {
+				return "", err
+			}
+			return "snoozed until " + task.WaitUntil.In(loc).Format("2006-01-02 15:04"), nil
+		})
+	},
+}
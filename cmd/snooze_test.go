@@ -0,0 +1,70 @@
+package cmd
+
+import (
+	"OTakumi/todogo/internal/domain/model"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// TestSnoozeCommand_PushesWaitDate は指定した期間がUsecaseに渡され、新しい待機の期限が表示されることを確認するテスト
+func TestSnoozeCommand_PushesWaitDate(t *testing.T) {
+	// Arrange
+	mockUsecase := new(MockTaskUsecase)
+	originalTaskUsecase := taskUsecase
+	taskUsecase = mockUsecase
+	defer func() { taskUsecase = originalTaskUsecase }()
+	stubResolveID(mockUsecase, "id-1")
+	t.Setenv("TODOGO_TIMEZONE", "UTC")
+
+	waitUntil := time.Date(2099, 3, 4, 17, 0, 0, 0, time.UTC)
+	mockUsecase.On("Snooze", mock.Anything, "id-1", 3*24*time.Hour).
+		Return(&model.Task{ID: "id-1", Title: "Task 1", WaitUntil: &waitUntil}, nil)
+
+	// Act
+	out, err := executeCommand("snooze", "id-1", "3d")
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Contains(t, out, "id-1: snoozed until 2099-03-04 17:00")
+	mockUsecase.AssertExpectations(t)
+}
+
+// TestSnoozeCommand_ErrorWhenDurationInvalid は不正な期間の場合にタスクを変更しないことを確認するテスト
+func TestSnoozeCommand_ErrorWhenDurationInvalid(t *testing.T) {
+	// Arrange
+	mockUsecase := new(MockTaskUsecase)
+	originalTaskUsecase := taskUsecase
+	taskUsecase = mockUsecase
+	defer func() { taskUsecase = originalTaskUsecase }()
+
+	// Act
+	_, err := executeCommand("snooze", "id-1", "soon")
+
+	// Assert
+	assert.ErrorContains(t, err, "invalid duration")
+	mockUsecase.AssertNotCalled(t, "Snooze", mock.Anything, mock.Anything, mock.Anything)
+}
+
+// TestSnoozeCommand_ErrorWhenAfterDeadline は待機の期限が締切を過ぎる場合にエラーを返すことを確認するテスト
+func TestSnoozeCommand_ErrorWhenAfterDeadline(t *testing.T) {
+	// Arrange
+	mockUsecase := new(MockTaskUsecase)
+	originalTaskUsecase := taskUsecase
+	taskUsecase = mockUsecase
+	defer func() { taskUsecase = originalTaskUsecase }()
+	stubResolveID(mockUsecase, "id-1")
+
+	mockUsecase.On("Snooze", mock.Anything, "id-1", 7*24*time.Hour).
+		Return(nil, errors.New("Wait date must not be after the deadline"))
+
+	// Act
+	out, err := executeCommand("snooze", "id-1", "1w")
+
+	// Assert
+	assert.Error(t, err)
+	assert.Contains(t, out, "Wait date must not be after the deadline")
+}
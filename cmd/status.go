@@ -0,0 +1,82 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/spf13/cobra"
+)
+
+func init() {
+	rootCmd.AddCommand(statusCmd)
+}
+
+// statusCmd はタスクのワークフロー上の状態を変更するコマンドの定義
+var statusCmd = &cobra.Command{
+	Use:   "status <status> <id>...",
+	Short: "Change the status of tasks",
+	Long: `Move one or more tasks to another status of the workflow:
+
+  todo_cli status in_progress 3
+  todo_cli status blocked 4 5
+
+The default workflow has the statuses open, in_progress, blocked, done and
+cancelled. New tasks start as open; done and cancelled are terminal statuses,
+which count as complete everywhere else (list --ready, subtask progress,
+urgency). Only the following changes are allowed:
+
+  open         -> in_progress, blocked, done, cancelled
+  in_progress  -> open, blocked, done, cancelled
+  blocked      -> open, in_progress, cancelled
+  done         -> open
+  cancelled    -> open
+
+A change that the workflow does not allow is reported as an error, together
+with the statuses the task can be moved to.
+
+Moving a task to the first terminal status behaves like the done command,
+including creating the next occurrence of a repeating task. "done" moves tasks
+to that status and "reopen" moves them back to the initial status.
+
+The workflow can be replaced in the config file. initial defaults to the first
+status, and the first terminal status is the one used by "done":
+
+  workflow:
+    statuses: [open, in_progress, review, done, cancelled]
+    initial: open
+    terminal: [done, cancelled]
+    transitions:
+      open: [in_progress, done, cancelled]
+      in_progress: [open, review, cancelled]
+      review: [in_progress, done]
+      done: [open]
+      cancelled: [open]`,
+	Args:         cobra.MinimumNArgs(2),
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		status, err := workflow.ParseStatus(args[0])
+		if err != nil {
+			return err
+		}
+
+		return runForEachID(cmd, args[1:], func(ctx context.Context, id string) (string, error) {
+			task, err := taskUsecase.GetTask(ctx, id)
+			if err != nil {
+				return "", err
+			}
+			if task.Status == status {
+				return fmt.Sprintf("already %s", status), nil
+			}
+
+			// 完了の状態への変更は、繰り返すタスクの次のタスクを作成するよう done コマンドと同じ処理とする
+			if status == workflow.Done() {
+				return setComplete(ctx, id, true)
+			}
+
+			if _, err := taskUsecase.SetStatus(ctx, id, status); err != nil {
+				return "", err
+			}
+			return fmt.Sprintf("changed from %s to %s", task.Status, status), nil
+		})
+	},
+}
@@ -0,0 +1,93 @@
+package cmd
+
+import (
+	"OTakumi/todogo/internal/domain/model"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// TestStatusCommand_ChangesStatus は指定した状態がUsecaseに渡され、変更前後の状態が表示されることを確認するテスト
+func TestStatusCommand_ChangesStatus(t *testing.T) {
+	// Arrange
+	mockUsecase := new(MockTaskUsecase)
+	originalTaskUsecase := taskUsecase
+	taskUsecase = mockUsecase
+	defer func() { taskUsecase = originalTaskUsecase }()
+	stubResolveID(mockUsecase, "id-1", "id-2")
+
+	mockUsecase.On("GetTask", mock.Anything, "id-1").Return(&model.Task{ID: "id-1", Status: model.StatusOpen}, nil)
+	mockUsecase.On("GetTask", mock.Anything, "id-2").Return(&model.Task{ID: "id-2", Status: model.StatusInProgress}, nil)
+	mockUsecase.On("SetStatus", mock.Anything, "id-1", model.StatusInProgress).
+		Return(&model.Task{ID: "id-1", Status: model.StatusInProgress}, nil)
+
+	// Act
+	// 状態の名前は大文字や "-" でも指定できる
+	out, err := executeCommand("status", "In-Progress", "id-1", "id-2")
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Contains(t, out, "id-1: changed from open to in_progress")
+	assert.Contains(t, out, "id-2: already in_progress")
+	mockUsecase.AssertNumberOfCalls(t, "SetStatus", 1)
+}
+
+// TestStatusCommand_ReportsIllegalTransition はワークフローで許可されていない変更がID単位で報告されることを確認するテスト
+func TestStatusCommand_ReportsIllegalTransition(t *testing.T) {
+	// Arrange
+	mockUsecase := new(MockTaskUsecase)
+	originalTaskUsecase := taskUsecase
+	taskUsecase = mockUsecase
+	defer func() { taskUsecase = originalTaskUsecase }()
+	stubResolveID(mockUsecase, "id-1")
+
+	mockUsecase.On("GetTask", mock.Anything, "id-1").Return(&model.Task{ID: "id-1", Status: model.StatusDone}, nil)
+	mockUsecase.On("SetStatus", mock.Anything, "id-1", model.StatusBlocked).Return(nil, &model.TransitionError{
+		From: model.StatusDone, To: model.StatusBlocked, Allowed: []model.Status{model.StatusOpen},
+	})
+
+	// Act
+	out, err := executeCommand("status", "blocked", "id-1")
+
+	// Assert
+	assert.Error(t, err)
+	assert.Contains(t, out, "id-1: error: cannot change status from done to blocked: allowed: open")
+}
+
+// TestStatusCommand_DoneCompletesTask は完了の状態への変更がdoneコマンドと同じ処理になることを確認するテスト
+func TestStatusCommand_DoneCompletesTask(t *testing.T) {
+	// Arrange
+	mockUsecase := new(MockTaskUsecase)
+	originalTaskUsecase := taskUsecase
+	taskUsecase = mockUsecase
+	defer func() { taskUsecase = originalTaskUsecase }()
+	stubResolveID(mockUsecase, "id-1")
+
+	mockUsecase.On("GetTask", mock.Anything, "id-1").Return(&model.Task{ID: "id-1", Status: model.StatusInProgress}, nil)
+	mockUsecase.On("CompleteTask", mock.Anything, mock.Anything, mock.Anything).Return(nil, nil)
+
+	// Act
+	out, err := executeCommand("status", "done", "id-1")
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Contains(t, out, "id-1: marked as complete")
+	mockUsecase.AssertNotCalled(t, "SetStatus", mock.Anything, mock.Anything, mock.Anything)
+}
+
+// TestStatusCommand_ErrorWhenStatusUnknown はワークフローにない状態の場合にタスクを変更しないことを確認するテスト
+func TestStatusCommand_ErrorWhenStatusUnknown(t *testing.T) {
+	// Arrange
+	mockUsecase := new(MockTaskUsecase)
+	originalTaskUsecase := taskUsecase
+	taskUsecase = mockUsecase
+	defer func() { taskUsecase = originalTaskUsecase }()
+
+	// Act
+	_, err := executeCommand("status", "review", "id-1")
+
+	// Assert
+	assert.ErrorContains(t, err, `invalid status "review"`)
+	mockUsecase.AssertNotCalled(t, "GetTask", mock.Anything, mock.Anything)
+}
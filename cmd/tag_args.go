@@ -0,0 +1,86 @@
+package cmd
+
+import (
+	"OTakumi/todogo/internal/domain/model"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+// tagArgsAnnotation は "+tag" "-tag" 形式の引数でタグを追加・削除するコマンドに付ける注釈
+const tagArgsAnnotation = "todogo/tag-args"
+
+// separateTagArgs はタグの削除を表す "-tag" 形式の引数を "--" の後ろに移し、位置引数として扱われるようにする
+// そのままではフラグとして解釈され、例えば "-tag" は "-t ag"（タイトルの指定）となってしまうため、
+// 実行前にコマンドライン引数を組み替える
+// 対象はtagArgsAnnotationが付いたコマンドのみで、フラグの値として指定された引数は移動しない
+func separateTagArgs(root *cobra.Command, args []string) []string {
+	cmd, _, err := root.Find(args)
+	if err != nil || cmd.Annotations[tagArgsAnnotation] == "" {
+		return args
+	}
+
+	var kept, removals, rest []string
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		if arg == "--" {
+			rest = args[i+1:]
+			break
+		}
+		if isTagRemovalArg(arg) {
+			removals = append(removals, arg)
+			continue
+		}
+
+		kept = append(kept, arg)
+		// 値を取るフラグの次の引数はフラグの値として残す
+		if flagTakesValue(cmd, arg) && i+1 < len(args) {
+			i++
+			kept = append(kept, args[i])
+		}
+	}
+
+	if len(removals) == 0 {
+		return args
+	}
+
+	result := append(kept, "--")
+	result = append(result, removals...)
+	return append(result, rest...)
+}
+
+// isTagRemovalArg は引数が "-tag" 形式のタグの削除の指定かどうかを判定する
+// "-t" のような1文字の短縮フラグは対象外とする
+func isTagRemovalArg(arg string) bool {
+	if len(arg) <= 2 || arg[0] != '-' || arg[1] == '-' {
+		return false
+	}
+	_, err := model.NormalizeTag(arg[1:])
+	return err == nil
+}
+
+// flagTakesValue は引数が、次の引数を値として取るフラグかどうかを判定する
+func flagTakesValue(cmd *cobra.Command, arg string) bool {
+	if strings.Contains(arg, "=") {
+		return false
+	}
+
+	var name, shorthand string
+	switch {
+	case strings.HasPrefix(arg, "--") && len(arg) > 2:
+		name = arg[2:]
+	case len(arg) == 2 && arg[0] == '-' && arg[1] != '-':
+		shorthand = arg[1:]
+	default:
+		return false
+	}
+
+	flag := cmd.Flag(name)
+	if shorthand != "" {
+		flag = cmd.Flags().ShorthandLookup(shorthand)
+		if flag == nil {
+			flag = cmd.InheritedFlags().ShorthandLookup(shorthand)
+		}
+	}
+	return flag != nil && flag.NoOptDefVal == ""
+}
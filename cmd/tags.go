@@ -0,0 +1,39 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/spf13/cobra"
+)
+
+func init() {
+	rootCmd.AddCommand(tagsCmd)
+}
+
+// tagsCmd は使用されているタグとタスクの件数を表示するコマンドの定義
+var tagsCmd = &cobra.Command{
+	Use:   "tags",
+	Short: "List tags with task counts",
+	Long: `List the tags in use, in name order.
+
+For each tag the number of open tasks and the total number of tasks (including
+completed ones) are shown. Tags that are no longer attached to any task are not
+listed. Use the global --output flag to print the counts as json, yaml, csv or
+ndjson.`,
+	Args:         cobra.NoArgs,
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		r, err := newRenderer(cmd.OutOrStdout())
+		if err != nil {
+			return err
+		}
+
+		counts, err := taskUsecase.Tags(context.Background())
+		if err != nil {
+			return fmt.Errorf("failed to fetch tags: %w", err)
+		}
+
+		return r.TagCounts(cmd.OutOrStdout(), counts)
+	},
+}
@@ -0,0 +1,70 @@
+package cmd
+
+import (
+	"OTakumi/todogo/internal/domain/model"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// TestTagsCommand_PrintsTagCounts はタグごとの件数が表示されることを確認するテスト
+func TestTagsCommand_PrintsTagCounts(t *testing.T) {
+	// Arrange
+	mockUsecase := new(MockTaskUsecase)
+	originalTaskUsecase := taskUsecase
+	taskUsecase = mockUsecase
+	defer func() { taskUsecase = originalTaskUsecase }()
+
+	mockUsecase.On("Tags", mock.Anything).Return([]model.TagCount{
+		{Name: "home", Open: 0, Total: 1},
+		{Name: "work", Open: 2, Total: 3},
+	}, nil)
+
+	// Act
+	out, err := executeCommand("tags")
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Contains(t, out, "Tag   Open  Total")
+	assert.Contains(t, out, "work  2     3")
+	mockUsecase.AssertExpectations(t)
+}
+
+// TestTagsCommand_JSONOutput は--output jsonでタグの一覧がJSONとして出力されることを確認するテスト
+func TestTagsCommand_JSONOutput(t *testing.T) {
+	// Arrange
+	mockUsecase := new(MockTaskUsecase)
+	originalTaskUsecase := taskUsecase
+	taskUsecase = mockUsecase
+	defer func() { taskUsecase = originalTaskUsecase }()
+	defer resetFlags(rootCmd)
+
+	mockUsecase.On("Tags", mock.Anything).Return([]model.TagCount{{Name: "work", Open: 2, Total: 3}}, nil)
+
+	// Act
+	out, err := executeCommand("tags", "--output", "json")
+
+	// Assert
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"schema_version": 2, "tags": [{"name": "work", "open": 2, "total": 3}]}`, out)
+}
+
+// TestTagsCommand_HandleUsecaseError はタグの取得に失敗した場合にエラーを返すことを確認するテスト
+func TestTagsCommand_HandleUsecaseError(t *testing.T) {
+	// Arrange
+	mockUsecase := new(MockTaskUsecase)
+	originalTaskUsecase := taskUsecase
+	taskUsecase = mockUsecase
+	defer func() { taskUsecase = originalTaskUsecase }()
+
+	mockUsecase.On("Tags", mock.Anything).Return(nil, errors.New("database error"))
+
+	// Act
+	out, err := executeCommand("tags")
+
+	// Assert
+	assert.Error(t, err)
+	assert.Contains(t, out, "failed to fetch tags")
+}
@@ -0,0 +1,114 @@
+package cmd
+
+import (
+	"OTakumi/todogo/internal/dateparse"
+	"OTakumi/todogo/internal/render"
+	"context"
+	"fmt"
+
+	"github.com/spf13/cobra"
+)
+
+func init() {
+	rootCmd.AddCommand(startCmd)
+	rootCmd.AddCommand(stopCmd)
+	rootCmd.AddCommand(logCmd)
+}
+
+// startCmd はタスクの作業時間の計測を開始するコマンドの定義
+var startCmd = &cobra.Command{
+	Use:   "start <id>",
+	Short: "Start tracking time on a task",
+	Long: `Start a timer on a task. The time until "todo_cli stop" is recorded as
+time spent on the task.
+
+Only one timer can run at a time for each user: stop the running timer before
+starting another one. The user is taken from the user setting in the config
+file or TODOGO_USER, falling back to the login name.`,
+	Args:         cobra.ExactArgs(1),
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		user, err := currentUser()
+		if err != nil {
+			return err
+		}
+
+		// 表示する日時は設定されたタイムゾーンに合わせる
+		loc, err := timeLocation()
+		if err != nil {
+			return err
+		}
+
+		return runForEachID(cmd, args, func(ctx context.Context, id string) (string, error) {
+			entry, err := taskUsecase.StartTimer(ctx, user, id)
+			if err != nil {
+				return "", err
+			}
+			return "timer started at " + entry.StartedAt.In(loc).Format("2006-01-02 15:04"), nil
+		})
+	},
+}
+
+// stopCmd は計測中の作業時間の記録を終了するコマンドの定義
+var stopCmd = &cobra.Command{
+	Use:   "stop",
+	Short: "Stop the running timer",
+	Long: `Stop the timer started with "todo_cli start" and record the time spent on
+its task.`,
+	Args:         cobra.NoArgs,
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		r, err := newRenderer(cmd.OutOrStdout())
+		if err != nil {
+			return err
+		}
+		user, err := currentUser()
+		if err != nil {
+			return err
+		}
+
+		entry, err := taskUsecase.StopTimer(context.Background(), user)
+		if err != nil {
+			return err
+		}
+
+		// 操作の結果として出力し、構造化された形式でも停止したタスクを分かるようにする
+		msg := "timer stopped after " + render.FormatDuration(entry.Duration(*entry.EndedAt))
+		if err := r.Results(cmd.OutOrStdout(), []render.Result{{Ref: entry.TaskID, ID: entry.TaskID, Message: msg}}); err != nil {
+			return fmt.Errorf("failed to write output: %w", err)
+		}
+		return nil
+	},
+}
+
+// logCmd はタスクに作業時間を手入力で記録するコマンドの定義
+var logCmd = &cobra.Command{
+	Use:   "log <id> <duration>",
+	Short: "Record time spent on a task",
+	Long: `Record time spent on a task without running a timer:
+
+  todo_cli log 3 45m
+  todo_cli log 3 1h30m
+
+The duration is a number followed by w (weeks), d (days), h, m or s, and units
+can be combined. The time is recorded as work that ended just now.`,
+	Args:         cobra.ExactArgs(2),
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		d, err := dateparse.ParseDuration(args[1])
+		if err != nil {
+			return err
+		}
+		user, err := currentUser()
+		if err != nil {
+			return err
+		}
+
+		return runForEachID(cmd, args[:1], func(ctx context.Context, id string) (string, error) {
+			if _, err := taskUsecase.LogTime(ctx, user, id, d); err != nil {
+				return "", err
+			}
+			return "logged " + render.FormatDuration(d), nil
+		})
+	},
+}
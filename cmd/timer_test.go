@@ -0,0 +1,132 @@
+package cmd
+
+import (
+	"OTakumi/todogo/internal/domain/model"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// TestStartCommand_StartsTimer は設定された利用者でタスクの計測を開始することを確認するテスト
+func TestStartCommand_StartsTimer(t *testing.T) {
+	// Arrange
+	mockUsecase := new(MockTaskUsecase)
+	originalTaskUsecase := taskUsecase
+	taskUsecase = mockUsecase
+	defer func() { taskUsecase = originalTaskUsecase }()
+	stubResolveID(mockUsecase, "id-1")
+	t.Setenv("TODOGO_USER", "alice")
+	t.Setenv("TODOGO_TIMEZONE", "UTC")
+
+	startedAt := time.Date(2025, 6, 9, 9, 30, 0, 0, time.UTC)
+	mockUsecase.On("StartTimer", mock.Anything, "alice", "id-1").
+		Return(&model.TimeEntry{ID: 1, TaskID: "id-1", User: "alice", StartedAt: startedAt}, nil)
+
+	// Act
+	out, err := executeCommand("start", "id-1")
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Contains(t, out, "id-1: timer started at 2025-06-09 09:30")
+	mockUsecase.AssertExpectations(t)
+}
+
+// TestStartCommand_ErrorWhenTimerRunning は既に計測中の場合に、計測中のタスクを含むエラーを表示することを確認するテスト
+func TestStartCommand_ErrorWhenTimerRunning(t *testing.T) {
+	// Arrange
+	mockUsecase := new(MockTaskUsecase)
+	originalTaskUsecase := taskUsecase
+	taskUsecase = mockUsecase
+	defer func() { taskUsecase = originalTaskUsecase }()
+	stubResolveID(mockUsecase, "id-1")
+	t.Setenv("TODOGO_USER", "alice")
+
+	running := model.TimeEntry{ID: 1, TaskID: "id-2", User: "alice", StartedAt: time.Now()}
+	mockUsecase.On("StartTimer", mock.Anything, "alice", "id-1").Return(nil, &model.TimerRunningError{Entry: running})
+
+	// Act
+	out, err := executeCommand("start", "id-1")
+
+	// Assert
+	assert.Error(t, err)
+	assert.Contains(t, out, "id-1: error: timer already running on task id-2")
+}
+
+// TestStopCommand_StopsTimer は計測を終了したタスクと作業時間を表示することを確認するテスト
+func TestStopCommand_StopsTimer(t *testing.T) {
+	// Arrange
+	mockUsecase := new(MockTaskUsecase)
+	originalTaskUsecase := taskUsecase
+	taskUsecase = mockUsecase
+	defer func() { taskUsecase = originalTaskUsecase }()
+	t.Setenv("TODOGO_USER", "alice")
+
+	ended := time.Now()
+	mockUsecase.On("StopTimer", mock.Anything, "alice").
+		Return(&model.TimeEntry{ID: 1, TaskID: "id-1", User: "alice", StartedAt: ended.Add(-75 * time.Minute), EndedAt: &ended}, nil)
+
+	// Act
+	out, err := executeCommand("stop")
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Contains(t, out, "id-1: timer stopped after 1h15m")
+	mockUsecase.AssertExpectations(t)
+}
+
+// TestStopCommand_ErrorWhenNoTimerRunning は計測中でない場合にエラーを返すことを確認するテスト
+func TestStopCommand_ErrorWhenNoTimerRunning(t *testing.T) {
+	// Arrange
+	mockUsecase := new(MockTaskUsecase)
+	originalTaskUsecase := taskUsecase
+	taskUsecase = mockUsecase
+	defer func() { taskUsecase = originalTaskUsecase }()
+	t.Setenv("TODOGO_USER", "alice")
+
+	mockUsecase.On("StopTimer", mock.Anything, "alice").Return(nil, model.ErrNoTimerRunning)
+
+	// Act
+	_, err := executeCommand("stop")
+
+	// Assert
+	assert.ErrorIs(t, err, model.ErrNoTimerRunning)
+}
+
+// TestLogCommand_LogsTime は指定した作業時間がUsecaseに渡されることを確認するテスト
+func TestLogCommand_LogsTime(t *testing.T) {
+	// Arrange
+	mockUsecase := new(MockTaskUsecase)
+	originalTaskUsecase := taskUsecase
+	taskUsecase = mockUsecase
+	defer func() { taskUsecase = originalTaskUsecase }()
+	stubResolveID(mockUsecase, "id-1")
+	t.Setenv("TODOGO_USER", "alice")
+
+	mockUsecase.On("LogTime", mock.Anything, "alice", "id-1", 45*time.Minute).Return(&model.TimeEntry{ID: 1}, nil)
+
+	// Act
+	out, err := executeCommand("log", "id-1", "45m")
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Contains(t, out, "id-1: logged 45m")
+	mockUsecase.AssertExpectations(t)
+}
+
+// TestLogCommand_ErrorWhenDurationInvalid は不正な作業時間の場合に記録しないことを確認するテスト
+func TestLogCommand_ErrorWhenDurationInvalid(t *testing.T) {
+	// Arrange
+	mockUsecase := new(MockTaskUsecase)
+	originalTaskUsecase := taskUsecase
+	taskUsecase = mockUsecase
+	defer func() { taskUsecase = originalTaskUsecase }()
+
+	// Act
+	_, err := executeCommand("log", "id-1", "a while")
+
+	// Assert
+	assert.ErrorContains(t, err, "invalid duration")
+	mockUsecase.AssertNotCalled(t, "LogTime", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
@@ -0,0 +1,69 @@
+package cmd
+
+import (
+	"OTakumi/todogo/internal/dateparse"
+	"OTakumi/todogo/internal/render"
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+func init() {
+	timesheetCmd.Flags().Bool("week", false, "Total the current week, from Monday (default)")
+	timesheetCmd.Flags().Bool("day", false, "Total today")
+	timesheetCmd.Flags().String("user", "", "Total the time of this user instead of the current user")
+	timesheetCmd.MarkFlagsMutuallyExclusive("week", "day")
+	rootCmd.AddCommand(timesheetCmd)
+}
+
+// timesheetCmd は期間内の作業時間をタスクとプロジェクトごとに集計するコマンドの定義
+var timesheetCmd = &cobra.Command{
+	Use:   "timesheet",
+	Short: "Show the time tracked this week per task and project",
+	Long: `Total the time tracked with "start"/"stop" and "log" over the current week
+(Monday to Sunday) or, with --day, over today.
+
+The hours are listed per task, longest first, and per project. The time of a
+project includes that of its sub-projects, so "work" also counts the time
+spent on tasks in "work.backend". Time entries that cross the start of the
+period only count the part inside it, and a running timer counts up to now.
+Days start at midnight in the configured timezone.`,
+	Args:         cobra.NoArgs,
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		r, err := newRenderer(cmd.OutOrStdout())
+		if err != nil {
+			return err
+		}
+
+		user, _ := cmd.Flags().GetString("user")
+		if user == "" {
+			if user, err = currentUser(); err != nil {
+				return err
+			}
+		}
+
+		loc, err := timeLocation()
+		if err != nil {
+			return err
+		}
+		from, to := dateparse.WeekRange(time.Now().In(loc))
+		if day, _ := cmd.Flags().GetBool("day"); day {
+			from, to = dateparse.DayRange(time.Now().In(loc))
+		}
+
+		ctx := context.Background()
+		sheet, err := taskUsecase.Timesheet(ctx, user, from, to)
+		if err != nil {
+			return fmt.Errorf("failed to total time entries: %w", err)
+		}
+		shortIDs, err := taskUsecase.ShortIDs(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to fetch tasks: %w", err)
+		}
+
+		return r.Timesheet(cmd.OutOrStdout(), render.Timesheet{Sheet: sheet, ShortIDs: shortIDs})
+	},
+}
@@ -0,0 +1,96 @@
+package cmd
+
+import (
+	"OTakumi/todogo/internal/domain/model"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// TestTimesheetCommand_TotalsCurrentWeek は今週の月曜日からの1週間を集計することを確認するテスト
+func TestTimesheetCommand_TotalsCurrentWeek(t *testing.T) {
+	// Arrange
+	mockUsecase := new(MockTaskUsecase)
+	originalTaskUsecase := taskUsecase
+	taskUsecase = mockUsecase
+	defer func() {
+		taskUsecase = originalTaskUsecase
+		resetFlags(timesheetCmd)
+	}()
+	t.Setenv("TODOGO_USER", "alice")
+	t.Setenv("TODOGO_TIMEZONE", "UTC")
+
+	var from time.Time
+	mockUsecase.On("Timesheet", mock.Anything, "alice", mock.MatchedBy(func(at time.Time) bool {
+		from = at
+		return at.Weekday() == time.Monday && at.Hour() == 0 && at.Minute() == 0
+	}), mock.MatchedBy(func(at time.Time) bool {
+		return at.Equal(from.AddDate(0, 0, 7))
+	})).Return(model.Timesheet{
+		User:     "alice",
+		Tasks:    []model.TimesheetTask{{TaskID: "id-1", Title: "Write docs", Project: "work", Duration: 90 * time.Minute}},
+		Projects: []model.TimesheetProject{{Name: "work", Duration: 90 * time.Minute}},
+		Total:    90 * time.Minute,
+	}, nil)
+	mockUsecase.On("ShortIDs", mock.Anything).Return(map[string]string{"id-1": "i1"}, nil)
+
+	// Act
+	out, err := executeCommand("timesheet", "--week")
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Contains(t, out, "i1  Write docs  work     1h30m  1.50")
+	assert.Contains(t, out, "Total: 1h30m (1.50 hours)")
+	mockUsecase.AssertExpectations(t)
+}
+
+// TestTimesheetCommand_TotalsDayOfGivenUser は --day と --user で今日の指定した利用者の作業時間を集計することを確認するテスト
+func TestTimesheetCommand_TotalsDayOfGivenUser(t *testing.T) {
+	// Arrange
+	mockUsecase := new(MockTaskUsecase)
+	originalTaskUsecase := taskUsecase
+	taskUsecase = mockUsecase
+	defer func() {
+		taskUsecase = originalTaskUsecase
+		resetFlags(timesheetCmd)
+	}()
+	t.Setenv("TODOGO_TIMEZONE", "UTC")
+
+	today := time.Now().UTC()
+	midnight := time.Date(today.Year(), today.Month(), today.Day(), 0, 0, 0, 0, time.UTC)
+	mockUsecase.On("Timesheet", mock.Anything, "bob", mock.MatchedBy(func(at time.Time) bool {
+		return at.Equal(midnight)
+	}), mock.MatchedBy(func(at time.Time) bool {
+		return at.Equal(midnight.AddDate(0, 0, 1))
+	})).Return(model.Timesheet{User: "bob", From: midnight, To: midnight.AddDate(0, 0, 1)}, nil)
+	mockUsecase.On("ShortIDs", mock.Anything).Return(map[string]string{}, nil)
+
+	// Act
+	out, err := executeCommand("timesheet", "--day", "--user", "bob")
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Contains(t, out, "No time tracked.")
+	mockUsecase.AssertExpectations(t)
+}
+
+// TestTimesheetCommand_ErrorWhenWeekAndDay は --week と --day を同時に指定できないことを確認するテスト
+func TestTimesheetCommand_ErrorWhenWeekAndDay(t *testing.T) {
+	// Arrange
+	mockUsecase := new(MockTaskUsecase)
+	originalTaskUsecase := taskUsecase
+	taskUsecase = mockUsecase
+	defer func() {
+		taskUsecase = originalTaskUsecase
+		resetFlags(timesheetCmd)
+	}()
+
+	// Act
+	_, err := executeCommand("timesheet", "--week", "--day")
+
+	// Assert
+	assert.Error(t, err)
+	mockUsecase.AssertNotCalled(t, "Timesheet", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
@@ -0,0 +1,103 @@
+package cmd
+
+import (
+	"OTakumi/todogo/internal/dateparse"
+	"OTakumi/todogo/internal/render"
+	"context"
+	"fmt"
+
+	"github.com/spf13/cobra"
+)
+
+func init() {
+	purgeCmd.Flags().String("older-than", "30d", "Only delete tasks that have been in the trash longer than this (e.g. 30d, 2w; 0 empties the trash)")
+	rootCmd.AddCommand(trashCmd)
+	rootCmd.AddCommand(restoreCmd)
+	rootCmd.AddCommand(purgeCmd)
+}
+
+// trashCmd はゴミ箱のタスクを一覧表示するコマンドの定義
+var trashCmd = &cobra.Command{
+	Use:   "trash",
+	Short: "List deleted tasks",
+	Long: `List the tasks moved to the trash by "rm", most recently deleted first.
+
+The IDs shown are the shortest prefixes that are unique among the tasks in
+the trash; pass one to "restore" to bring the task back. Tasks stay in the
+trash until they are removed with "purge".`,
+	Args:         cobra.NoArgs,
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		r, err := newRenderer(cmd.OutOrStdout())
+		if err != nil {
+			return err
+		}
+
+		ctx := context.Background()
+		tasks, err := taskUsecase.Trash(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to fetch deleted tasks: %w", err)
+		}
+
+		trash := render.Trash{Tasks: tasks}
+		if len(tasks) > 0 {
+			if trash.ShortIDs, err = taskUsecase.DeletedShortIDs(ctx); err != nil {
+				return fmt.Errorf("failed to fetch task IDs: %w", err)
+			}
+		}
+		return r.Trash(cmd.OutOrStdout(), trash)
+	},
+}
+
+// restoreCmd はゴミ箱のタスクを元に戻すコマンドの定義
+var restoreCmd = &cobra.Command{
+	Use:   "restore <id>...",
+	Short: "Bring deleted tasks back from the trash",
+	Long: `Take one or more tasks out of the trash, with the notes, dependencies and
+tracked time they had when they were deleted.
+
+IDs are matched against the tasks in the trash, as listed by "trash"; list
+numbers cannot be used. Subtasks of a restored task move back under it.`,
+	Args:         cobra.MinimumNArgs(1),
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runForEachRef(cmd, args, taskUsecase.ResolveDeletedID, func(ctx context.Context, id string) (string, error) {
+			if _, err := taskUsecase.RestoreTask(ctx, id); err != nil {
+				return "", err
+			}
+			return "restored", nil
+		})
+	},
+}
+
+// purgeCmd はゴミ箱のタスクを完全に削除するコマンドの定義
+var purgeCmd = &cobra.Command{
+	Use:   "purge",
+	Short: "Permanently delete tasks from the trash",
+	Long: `Permanently delete the tasks that have been in the trash for longer than
+--older-than (30 days by default), together with their notes, dependencies
+and tracked time. Use --older-than 0 to empty the trash.
+
+Purged tasks cannot be restored, and undoing their deletion only brings back
+their fields and tags. The purge is recorded in the task history.`,
+	Args:         cobra.NoArgs,
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		r, err := newRenderer(cmd.OutOrStdout())
+		if err != nil {
+			return err
+		}
+
+		value, _ := cmd.Flags().GetString("older-than")
+		olderThan, err := dateparse.ParseDuration(value)
+		if err != nil {
+			return fmt.Errorf("invalid --older-than %q: %w", value, err)
+		}
+
+		n, err := taskUsecase.PurgeTasks(commandContext(cmd), olderThan)
+		if err != nil {
+			return fmt.Errorf("failed to purge tasks: %w", err)
+		}
+		return r.Message(cmd.OutOrStdout(), fmt.Sprintf("Purged %d task(s) from the trash.", n))
+	},
+}
@@ -0,0 +1,136 @@
+package cmd
+
+import (
+	"OTakumi/todogo/internal/domain/model"
+	"OTakumi/todogo/internal/repository"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// TestTrashCommand_ListsDeletedTasks はゴミ箱のタスクが短縮IDと削除した日時と共に表示されることを確認するテスト
+func TestTrashCommand_ListsDeletedTasks(t *testing.T) {
+	// Arrange
+	mockUsecase := new(MockTaskUsecase)
+	originalTaskUsecase := taskUsecase
+	taskUsecase = mockUsecase
+	defer func() { taskUsecase = originalTaskUsecase }()
+	t.Setenv("TODOGO_TIMEZONE", "UTC")
+
+	deletedAt := time.Date(2025, 1, 24, 9, 30, 0, 0, time.UTC)
+	mockUsecase.On("Trash", mock.Anything).Return([]*model.Task{
+		{ID: "7d6d370d-a4f1-430b-06c7-d4a363341564", Title: "Old report", Status: model.StatusOpen, DeletedAt: &deletedAt},
+	}, nil)
+	mockUsecase.On("DeletedShortIDs", mock.Anything).Return(map[string]string{"7d6d370d-a4f1-430b-06c7-d4a363341564": "7d6d"}, nil)
+
+	// Act
+	out, err := executeCommand("trash")
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Contains(t, out, "7d6d  Old report")
+	assert.Contains(t, out, "2025-01-24 09:30")
+	assert.Contains(t, out, "Total: 1 task(s) in the trash")
+	mockUsecase.AssertNotCalled(t, "ShortIDs", mock.Anything)
+	mockUsecase.AssertExpectations(t)
+}
+
+// TestTrashCommand_EmptyTrash はゴミ箱が空の場合にその旨を表示することを確認するテスト
+func TestTrashCommand_EmptyTrash(t *testing.T) {
+	// Arrange
+	mockUsecase := new(MockTaskUsecase)
+	originalTaskUsecase := taskUsecase
+	taskUsecase = mockUsecase
+	defer func() { taskUsecase = originalTaskUsecase }()
+
+	mockUsecase.On("Trash", mock.Anything).Return([]*model.Task{}, nil)
+
+	// Act
+	out, err := executeCommand("trash")
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, "Trash is empty.\n", out)
+}
+
+// TestRestoreCommand_RestoresFromTrash はゴミ箱のタスクの中からIDを解決して元に戻すことを確認するテスト
+func TestRestoreCommand_RestoresFromTrash(t *testing.T) {
+	// Arrange
+	mockUsecase := new(MockTaskUsecase)
+	originalTaskUsecase := taskUsecase
+	taskUsecase = mockUsecase
+	defer func() { taskUsecase = originalTaskUsecase }()
+
+	mockUsecase.On("ResolveDeletedID", mock.Anything, "7d6d").Return("id-1", nil)
+	mockUsecase.On("ResolveDeletedID", mock.Anything, "zz").Return("", &repository.TaskNotFoundError{ID: "zz"})
+	mockUsecase.On("RestoreTask", mock.Anything, "id-1").Return(&model.Task{ID: "id-1"}, nil)
+
+	// Act
+	out, err := executeCommand("restore", "7d6d", "zz")
+
+	// Assert
+	assert.Error(t, err)
+	assert.Contains(t, out, "7d6d (id-1): restored")
+	assert.Contains(t, out, "zz: error: task not found")
+	assert.Contains(t, out, "1 succeeded, 1 failed")
+	mockUsecase.AssertNotCalled(t, "ResolveID", mock.Anything, mock.Anything)
+	mockUsecase.AssertExpectations(t)
+}
+
+// TestPurgeCommand_OlderThan は--older-thanで指定した期間を日単位で解釈して削除することを確認するテスト
+func TestPurgeCommand_OlderThan(t *testing.T) {
+	t.Run("指定した期間より前に削除したタスクを完全に削除する", func(t *testing.T) {
+		// Arrange
+		mockUsecase := new(MockTaskUsecase)
+		originalTaskUsecase := taskUsecase
+		taskUsecase = mockUsecase
+		defer func() { taskUsecase = originalTaskUsecase }()
+		defer resetFlags(purgeCmd)
+
+		mockUsecase.On("PurgeTasks", mock.Anything, 7*24*time.Hour).Return(3, nil)
+
+		// Act
+		out, err := executeCommand("purge", "--older-than", "7d")
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, "Purged 3 task(s) from the trash.\n", out)
+		mockUsecase.AssertExpectations(t)
+	})
+
+	t.Run("指定しない場合は30日より前に削除したタスクを対象とする", func(t *testing.T) {
+		// Arrange
+		mockUsecase := new(MockTaskUsecase)
+		originalTaskUsecase := taskUsecase
+		taskUsecase = mockUsecase
+		defer func() { taskUsecase = originalTaskUsecase }()
+
+		mockUsecase.On("PurgeTasks", mock.Anything, 30*24*time.Hour).Return(0, nil)
+
+		// Act
+		out, err := executeCommand("purge")
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, "Purged 0 task(s) from the trash.\n", out)
+		mockUsecase.AssertExpectations(t)
+	})
+
+	t.Run("期間として解釈できない場合はタスクを削除しない", func(t *testing.T) {
+		// Arrange
+		mockUsecase := new(MockTaskUsecase)
+		originalTaskUsecase := taskUsecase
+		taskUsecase = mockUsecase
+		defer func() { taskUsecase = originalTaskUsecase }()
+		defer resetFlags(purgeCmd)
+
+		// Act
+		_, err := executeCommand("purge", "--older-than", "soon")
+
+		// Assert
+		assert.ErrorContains(t, err, "invalid --older-than")
+		mockUsecase.AssertNotCalled(t, "PurgeTasks", mock.Anything, mock.Anything)
+	})
+}
@@ -0,0 +1,112 @@
+package cmd
+
+import (
+	"OTakumi/todogo/internal/domain/model"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/spf13/cobra"
+)
+
+// undo、redoコマンドのフラグの値を格納する変数
+var (
+	undoCount int
+	redoCount int
+)
+
+func init() {
+	rootCmd.AddCommand(undoCmd)
+	rootCmd.AddCommand(redoCmd)
+
+	undoCmd.Flags().IntVarP(&undoCount, "count", "n", 1, "Number of commands to undo")
+	redoCmd.Flags().IntVarP(&redoCount, "count", "n", 1, "Number of commands to redo")
+}
+
+// undoCmd は直近のコマンドによるタスクの変更を取り消すコマンドの定義
+var undoCmd = &cobra.Command{
+	Use:   "undo",
+	Short: "Undo the last commands that changed tasks",
+	Long: `Revert the tasks created, changed or deleted by the last command, or by the
+last N commands with --count. A command that changed several tasks, such as
+"rm 3 4" or "done --cascade", is undone as a whole.
+
+Tasks are restored to exactly the state they were in before the command,
+whatever the workflow allows. Undoing a deletion takes the task out of the
+trash with its notes, dependencies and tracked time; if it has been purged
+since, only its fields and tags are brought back.
+
+If a task was changed in the meantime by a command that cannot be undone, or
+by another user, nothing is undone and the conflicting task is reported.
+Undone commands can be replayed with "redo" until another change is made.
+
+Marking tasks as incomplete is done with "reopen", which was called "undo"
+in earlier versions.`,
+	Args: func(cmd *cobra.Command, args []string) error {
+		// 以前の undo <id> と取り違えて、意図せず複数のコマンドを取り消さないようにする
+		if len(args) > 0 {
+			return errors.New(`undo takes no task IDs: use "reopen <id>..." to mark tasks as incomplete, or --count to undo several commands`)
+		}
+		return nil
+	},
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		batches, err := taskUsecase.Undo(commandContext(cmd), undoCount)
+		if errors.Is(err, model.ErrConflict) {
+			return fmt.Errorf("nothing was undone: %w", err)
+		}
+		if err != nil {
+			return fmt.Errorf("failed to undo: %w", err)
+		}
+		return printBatches(cmd.OutOrStdout(), "Undid", "Nothing to undo.", batches)
+	},
+}
+
+// redoCmd は取り消したコマンドをやり直すコマンドの定義
+var redoCmd = &cobra.Command{
+	Use:   "redo",
+	Short: "Redo the last undone commands",
+	Long: `Replay the command most recently reverted by "undo", or the last N undone
+commands with --count.
+
+Making any other change to tasks after undoing discards the commands that can
+be redone. If a task was changed in the meantime, nothing is redone and the
+conflicting task is reported.`,
+	Args:         cobra.NoArgs,
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		batches, err := taskUsecase.Redo(commandContext(cmd), redoCount)
+		if errors.Is(err, model.ErrConflict) {
+			return fmt.Errorf("nothing was redone: %w", err)
+		}
+		if err != nil {
+			return fmt.Errorf("failed to redo: %w", err)
+		}
+		return printBatches(cmd.OutOrStdout(), "Redid", "Nothing to redo.", batches)
+	},
+}
+
+// printBatches は取り消した、またはやり直したコマンドを1行ずつ出力する
+func printBatches(w io.Writer, verb, none string, batches []model.OperationBatch) error {
+	if len(batches) == 0 {
+		_, err := fmt.Fprintln(w, none)
+		return err
+	}
+
+	loc, err := timeLocation()
+	if err != nil {
+		return err
+	}
+	for _, b := range batches {
+		command := "an earlier change"
+		if b.Command != "" {
+			command = `"` + b.Command + `"`
+		}
+		_, err := fmt.Fprintf(w, "%s %s from %s (%d task change(s))\n",
+			verb, command, b.RecordedAt.In(loc).Format("2006-01-02 15:04"), len(b.Operations))
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
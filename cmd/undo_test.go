@@ -0,0 +1,111 @@
+package cmd
+
+import (
+	"OTakumi/todogo/internal/domain/model"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// TestUndoCommand_UndoesLastCommand は直近のコマンドを取り消し、取り消したコマンドを表示することを確認するテスト
+func TestUndoCommand_UndoesLastCommand(t *testing.T) {
+	// Arrange
+	mockUsecase := new(MockTaskUsecase)
+	originalTaskUsecase := taskUsecase
+	taskUsecase = mockUsecase
+	defer func() { taskUsecase = originalTaskUsecase }()
+	t.Setenv("TODOGO_TIMEZONE", "UTC")
+
+	recordedAt := time.Date(2025, 6, 9, 9, 30, 0, 0, time.UTC)
+	mockUsecase.On("Undo", mock.Anything, 1).Return([]model.OperationBatch{
+		{ID: "b1", Command: "rm 3 4", RecordedAt: recordedAt, Operations: make([]model.Operation, 2)},
+	}, nil)
+
+	// Act
+	out, err := executeCommand("undo")
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Contains(t, out, `Undid "rm 3 4" from 2025-06-09 09:30 (2 task change(s))`)
+	mockUsecase.AssertExpectations(t)
+}
+
+// TestUndoCommand_Count は--countで指定した件数のコマンドを取り消すことを確認するテスト
+func TestUndoCommand_Count(t *testing.T) {
+	// Arrange
+	mockUsecase := new(MockTaskUsecase)
+	originalTaskUsecase := taskUsecase
+	taskUsecase = mockUsecase
+	defer func() { taskUsecase = originalTaskUsecase }()
+	defer resetFlags(undoCmd)
+
+	mockUsecase.On("Undo", mock.Anything, 3).Return([]model.OperationBatch{}, nil)
+
+	// Act
+	out, err := executeCommand("undo", "-n", "3")
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Contains(t, out, "Nothing to undo.")
+	mockUsecase.AssertExpectations(t)
+}
+
+// TestUndoCommand_RejectsTaskIDs は以前の undo <id> の使い方を reopen に案内することを確認するテスト
+func TestUndoCommand_RejectsTaskIDs(t *testing.T) {
+	// Arrange
+	mockUsecase := new(MockTaskUsecase)
+	originalTaskUsecase := taskUsecase
+	taskUsecase = mockUsecase
+	defer func() { taskUsecase = originalTaskUsecase }()
+	defer resetFlags(undoCmd)
+
+	// Act
+	_, err := executeCommand("undo", "3")
+
+	// Assert
+	assert.ErrorContains(t, err, `use "reopen <id>..."`)
+	mockUsecase.AssertNotCalled(t, "Undo", mock.Anything, mock.Anything)
+}
+
+// TestUndoCommand_Conflict は記録後に変更されたタスクがある場合に、何も取り消さなかったことを報告するテスト
+func TestUndoCommand_Conflict(t *testing.T) {
+	// Arrange
+	mockUsecase := new(MockTaskUsecase)
+	originalTaskUsecase := taskUsecase
+	taskUsecase = mockUsecase
+	defer func() { taskUsecase = originalTaskUsecase }()
+
+	mockUsecase.On("Undo", mock.Anything, 1).
+		Return(nil, &model.ConflictError{TaskID: "id-1", Command: "edit 1", Reason: "title changed"})
+
+	// Act
+	_, err := executeCommand("undo")
+
+	// Assert
+	assert.EqualError(t, err, `nothing was undone: conflict on task id-1: title changed since "edit 1" was recorded`)
+}
+
+// TestRedoCommand_RedoesUndoneCommand は取り消したコマンドをやり直すことを確認するテスト
+func TestRedoCommand_RedoesUndoneCommand(t *testing.T) {
+	// Arrange
+	mockUsecase := new(MockTaskUsecase)
+	originalTaskUsecase := taskUsecase
+	taskUsecase = mockUsecase
+	defer func() { taskUsecase = originalTaskUsecase }()
+	t.Setenv("TODOGO_TIMEZONE", "UTC")
+
+	recordedAt := time.Date(2025, 6, 9, 9, 30, 0, 0, time.UTC)
+	mockUsecase.On("Redo", mock.Anything, 1).Return([]model.OperationBatch{
+		{ID: "b1", Command: "done 3", RecordedAt: recordedAt, Operations: make([]model.Operation, 1)},
+	}, nil)
+
+	// Act
+	out, err := executeCommand("redo")
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Contains(t, out, `Redid "done 3" from 2025-06-09 09:30 (1 task change(s))`)
+	mockUsecase.AssertExpectations(t)
+}
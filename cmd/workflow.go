@@ -0,0 +1,67 @@
+package cmd
+
+import (
+	"OTakumi/todogo/internal/domain/model"
+	"fmt"
+
+	"github.com/spf13/viper"
+)
+
+// workflowConfig は設定ファイルの workflow の形式
+//
+//	workflow:
+//	  statuses: [open, in_progress, review, done, cancelled]
+//	  initial: open
+//	  terminal: [done, cancelled]
+//	  transitions:
+//	    open: [in_progress, done, cancelled]
+//	    in_progress: [open, review, cancelled]
+//	    review: [in_progress, done]
+//	    done: [open]
+//	    cancelled: [open]
+type workflowConfig struct {
+	Statuses    []string            `mapstructure:"statuses"`
+	Initial     string              `mapstructure:"initial"`
+	Terminal    []string            `mapstructure:"terminal"`
+	Transitions map[string][]string `mapstructure:"transitions"`
+}
+
+// LoadWorkflow は設定ファイルの workflow からタスクの状態のワークフローを読み込む
+// 設定されていない場合は既定のワークフロー（model.DefaultWorkflow）を返す
+// initial を省略した場合は statuses の先頭を初期状態とする
+func LoadWorkflow() (*model.Workflow, error) {
+	if !viper.IsSet("workflow") {
+		return model.DefaultWorkflow(), nil
+	}
+
+	var cfg workflowConfig
+	if err := viper.UnmarshalKey("workflow", &cfg); err != nil {
+		return nil, fmt.Errorf("invalid workflow config: %w", err)
+	}
+
+	wf := &model.Workflow{
+		Statuses:    toStatuses(cfg.Statuses),
+		Initial:     model.Status(cfg.Initial),
+		Terminal:    toStatuses(cfg.Terminal),
+		Transitions: make(map[model.Status][]model.Status, len(cfg.Transitions)),
+	}
+	if wf.Initial == "" && len(wf.Statuses) > 0 {
+		wf.Initial = wf.Statuses[0]
+	}
+	for from, targets := range cfg.Transitions {
+		wf.Transitions[model.Status(from)] = toStatuses(targets)
+	}
+
+	if err := wf.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid workflow config: %w", err)
+	}
+	return wf, nil
+}
+
+func toStatuses(names []string) []model.Status {
+	statuses := make([]model.Status, len(names))
+	for i, name := range names {
+		statuses[i] = model.Status(name)
+	}
+	return statuses
+}
@@ -0,0 +1,52 @@
+package cmd
+
+import (
+	"OTakumi/todogo/internal/domain/model"
+	"testing"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestLoadWorkflow は設定ファイルの workflow からワークフローを読み込めることを確認するテスト
+func TestLoadWorkflow(t *testing.T) {
+	t.Run("設定がない場合は既定のワークフローを返す", func(t *testing.T) {
+		wf, err := LoadWorkflow()
+
+		assert.NoError(t, err)
+		assert.Equal(t, model.DefaultWorkflow(), wf)
+	})
+
+	t.Run("設定された状態と状態遷移を読み込み、初期状態の省略時は先頭の状態とする", func(t *testing.T) {
+		viper.Set("workflow", map[string]any{
+			"statuses": []string{"todo", "review", "done"},
+			"terminal": []string{"done"},
+			"transitions": map[string]any{
+				"todo":   []string{"review"},
+				"review": []string{"todo", "done"},
+			},
+		})
+		defer viper.Set("workflow", nil)
+
+		wf, err := LoadWorkflow()
+
+		assert.NoError(t, err)
+		assert.Equal(t, model.Status("todo"), wf.Initial)
+		assert.Equal(t, []model.Status{"done"}, wf.Terminal)
+		assert.NoError(t, wf.CheckTransition("review", "done"))
+		assert.ErrorIs(t, wf.CheckTransition("todo", "done"), model.ErrIllegalTransition)
+	})
+
+	t.Run("定義されていない状態への状態遷移はエラーとする", func(t *testing.T) {
+		viper.Set("workflow", map[string]any{
+			"statuses":    []string{"todo", "done"},
+			"terminal":    []string{"done"},
+			"transitions": map[string]any{"todo": []string{"doing"}},
+		})
+		defer viper.Set("workflow", nil)
+
+		_, err := LoadWorkflow()
+
+		assert.ErrorContains(t, err, "invalid workflow config")
+	})
+}
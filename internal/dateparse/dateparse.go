@@ -0,0 +1,347 @@
+// Package dateparse は締切などの日時指定に使う、ISO形式と自然言語による表現を解釈する
+//
+// 受け付ける表現の例:
+//
+//	2025-01-31, 2025-01-31 17:00, 2025-01-31T17:00:00+09:00
+//	now, today, tomorrow, yesterday
+//	fri, friday, next fri, this fri
+//	next week, next month
+//	in 3 days, in 2 weeks, in 1 month, in 5 hours, 3d, 2w
+//	eod, eow, eom, eoy
+//
+// 日付を表す表現の後ろには時刻（17:00, 9:30am, 5pm, noon, midnight）を続けられる。
+// 時刻を省略した日付はその日の終わり（23:59:59）として扱う。
+// 週は月曜日に始まり、eowはその週の日曜日の終わりを表す。
+package dateparse
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// absoluteLayouts は日時として直接解釈する書式
+// 時刻を含まない書式は dateOnly=true として扱い、その日の終わりを補う
+var absoluteLayouts = []struct {
+	layout   string
+	dateOnly bool
+}{
+	{time.RFC3339, false},
+	{"2006-01-02T15:04:05", false},
+	{"2006-01-02T15:04", false},
+	{"2006-01-02 15:04:05", false},
+	{"2006-01-02 15:04", false},
+	{"2006-01-02", true},
+	{"2006/01/02", true},
+}
+
+var weekdays = map[string]time.Weekday{
+	"sun": time.Sunday, "sunday": time.Sunday,
+	"mon": time.Monday, "monday": time.Monday,
+	"tue": time.Tuesday, "tues": time.Tuesday, "tuesday": time.Tuesday,
+	"wed": time.Wednesday, "wednesday": time.Wednesday,
+	"thu": time.Thursday, "thur": time.Thursday, "thurs": time.Thursday, "thursday": time.Thursday,
+	"fri": time.Friday, "friday": time.Friday,
+	"sat": time.Saturday, "saturday": time.Saturday,
+}
+
+var (
+	clockPattern    = regexp.MustCompile(`^(\d{1,2})(?::(\d{2}))?(am|pm)?$`)
+	relativePattern = regexp.MustCompile(`^(\d+)\s*([a-z]+)$`)
+)
+
+// Parser は現在時刻とタイムゾーンを基準に日時表現を解釈する
+type Parser struct {
+	loc *time.Location
+	now func() time.Time
+}
+
+// New は指定のタイムゾーンと時計を使うParserを生成する
+// locがnilの場合はローカルタイムゾーン、nowがnilの場合はtime.Nowを使う
+func New(loc *time.Location, now func() time.Time) *Parser {
+	if loc == nil {
+		loc = time.Local
+	}
+	if now == nil {
+		now = time.Now
+	}
+	return &Parser{loc: loc, now: now}
+}
+
+// Parse は日時表現を解釈し、Parserのタイムゾーンにおける日時を返す
+func (p *Parser) Parse(input string) (time.Time, error) {
+	s := strings.Join(strings.Fields(strings.ToLower(input)), " ")
+	if s == "" {
+		return time.Time{}, fmt.Errorf("empty date")
+	}
+
+	// ISO形式は"T"や"Z"の大文字を区別するため、小文字化する前の入力で解釈する
+	for _, l := range absoluteLayouts {
+		if t, err := time.ParseInLocation(l.layout, strings.TrimSpace(input), p.loc); err == nil {
+			if l.dateOnly {
+				return endOfDay(t), nil
+			}
+			return t.In(p.loc), nil
+		}
+	}
+
+	now := p.now().In(p.loc)
+
+	// 末尾の時刻指定を取り出す
+	words := strings.Fields(s)
+	hour, minute, hasClock := 0, 0, false
+	if h, m, ok := parseClock(words[len(words)-1]); ok {
+		hour, minute, hasClock = h, m, true
+		words = words[:len(words)-1]
+	}
+	phrase := strings.Join(words, " ")
+
+	// 時刻のみが指定された場合は今日のその時刻
+	if phrase == "" {
+		return atClock(now, hour, minute), nil
+	}
+
+	day, exact, err := p.parsePhrase(phrase, now)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid date %q: %w", input, err)
+	}
+
+	switch {
+	case hasClock:
+		return atClock(day, hour, minute), nil
+	case exact:
+		return day, nil
+	default:
+		return endOfDay(day), nil
+	}
+}
+
+// parsePhrase は時刻を除いた日付表現を解釈する
+// exact=true の場合、返す日時は時刻まで確定しており、その日の終わりへの補正は行わない
+func (p *Parser) parsePhrase(phrase string, now time.Time) (t time.Time, exact bool, err error) {
+	today := startOfDay(now)
+
+	for _, l := range absoluteLayouts {
+		if !l.dateOnly {
+			continue
+		}
+		if t, err := time.ParseInLocation(l.layout, phrase, p.loc); err == nil {
+			return t, false, nil
+		}
+	}
+
+	switch phrase {
+	case "now":
+		return now, true, nil
+	case "today", "eod":
+		return today, false, nil
+	case "tomorrow", "tmrw", "tmr":
+		return today.AddDate(0, 0, 1), false, nil
+	case "yesterday":
+		return today.AddDate(0, 0, -1), false, nil
+	case "eow":
+		return startOfWeek(today).AddDate(0, 0, 6), false, nil
+	case "eom":
+		return time.Date(now.Year(), now.Month()+1, 0, 0, 0, 0, 0, p.loc), false, nil
+	case "eoy":
+		return time.Date(now.Year(), time.December, 31, 0, 0, 0, 0, p.loc), false, nil
+	case "next week":
+		return startOfWeek(today).AddDate(0, 0, 7), false, nil
+	case "next month":
+		return time.Date(now.Year(), now.Month()+1, 1, 0, 0, 0, 0, p.loc), false, nil
+	}
+
+	words := strings.Fields(phrase)
+
+	// 曜日指定: fri / this fri / next fri
+	if wd, ok := weekdays[words[len(words)-1]]; ok {
+		switch {
+		case len(words) == 1, len(words) == 2 && words[0] == "this":
+			return nextWeekday(today, wd, true), false, nil
+		case len(words) == 2 && words[0] == "next":
+			return nextWeekday(today, wd, false), false, nil
+		}
+	}
+
+	// 相対指定: in 3 days / 3 days / 3d
+	rel := strings.TrimPrefix(phrase, "in ")
+	if m := relativePattern.FindStringSubmatch(rel); m != nil {
+		n, _ := strconv.Atoi(m[1])
+		switch normalizeUnit(m[2]) {
+		case "minute":
+			return now.Add(time.Duration(n) * time.Minute), true, nil
+		case "hour":
+			return now.Add(time.Duration(n) * time.Hour), true, nil
+		case "day":
+			return now.AddDate(0, 0, n), true, nil
+		case "week":
+			return now.AddDate(0, 0, 7*n), true, nil
+		case "month":
+			return addMonths(now, n), true, nil
+		case "year":
+			return addMonths(now, 12*n), true, nil
+		}
+	}
+
+	return time.Time{}, false, fmt.Errorf("unrecognized date expression")
+}
+
+// ParseDuration は "3d", "2w", "1h30m", "3 days" のような期間表現を解釈する
+// 日と週を単位として使える点以外は time.ParseDuration と同様
+func ParseDuration(input string) (time.Duration, error) {
+	s := strings.ReplaceAll(strings.ToLower(strings.TrimSpace(input)), " ", "")
+	if s == "" {
+		return 0, fmt.Errorf("empty duration")
+	}
+	if d, err := time.ParseDuration(s); err == nil {
+		return d, nil
+	}
+
+	var total time.Duration
+	rest := s
+	for rest != "" {
+		i := 0
+		for i < len(rest) && rest[i] >= '0' && rest[i] <= '9' {
+			i++
+		}
+		j := i
+		for j < len(rest) && rest[j] >= 'a' && rest[j] <= 'z' {
+			j++
+		}
+		if i == 0 || j == i {
+			return 0, fmt.Errorf("invalid duration %q", input)
+		}
+
+		n, _ := strconv.Atoi(rest[:i])
+		var unit time.Duration
+		switch normalizeUnit(rest[i:j]) {
+		case "minute":
+			unit = time.Minute
+		case "hour":
+			unit = time.Hour
+		case "day":
+			unit = 24 * time.Hour
+		case "week":
+			unit = 7 * 24 * time.Hour
+		default:
+			return 0, fmt.Errorf("invalid duration %q: unknown unit %q", input, rest[i:j])
+		}
+		total += time.Duration(n) * unit
+		rest = rest[j:]
+	}
+	return total, nil
+}
+
+// normalizeUnit は単位の表記揺れを正規化する
+func normalizeUnit(u string) string {
+	switch u {
+	case "m", "min", "mins", "minute", "minutes":
+		return "minute"
+	case "h", "hr", "hrs", "hour", "hours":
+		return "hour"
+	case "d", "day", "days":
+		return "day"
+	case "w", "wk", "wks", "week", "weeks":
+		return "week"
+	case "mo", "month", "months":
+		return "month"
+	case "y", "yr", "yrs", "year", "years":
+		return "year"
+	}
+	return ""
+}
+
+// parseClock は "17:00", "9:30am", "5pm", "noon", "midnight" のような時刻表現を解釈する
+// 単独の数字は日数などと区別できないため、時刻としては扱わない
+func parseClock(s string) (hour, minute int, ok bool) {
+	switch s {
+	case "noon":
+		return 12, 0, true
+	case "midnight":
+		return 0, 0, true
+	}
+
+	m := clockPattern.FindStringSubmatch(s)
+	if m == nil || (m[2] == "" && m[3] == "") {
+		return 0, 0, false
+	}
+
+	hour, _ = strconv.Atoi(m[1])
+	if m[2] != "" {
+		minute, _ = strconv.Atoi(m[2])
+	}
+
+	switch m[3] {
+	case "am":
+		if hour < 1 || hour > 12 {
+			return 0, 0, false
+		}
+		if hour == 12 {
+			hour = 0
+		}
+	case "pm":
+		if hour < 1 || hour > 12 {
+			return 0, 0, false
+		}
+		if hour != 12 {
+			hour += 12
+		}
+	}
+
+	if hour > 23 || minute > 59 {
+		return 0, 0, false
+	}
+	return hour, minute, true
+}
+
+// WeekRange は t を含む週の期間として、月曜日の0時と翌週の月曜日の0時を返す
+// 日付の境界は t のタイムゾーンで判定する
+func WeekRange(t time.Time) (from, to time.Time) {
+	from = startOfWeek(t)
+	return from, from.AddDate(0, 0, 7)
+}
+
+// DayRange は t を含む日の期間として、その日の0時と翌日の0時を返す
+// 日付の境界は t のタイムゾーンで判定する
+func DayRange(t time.Time) (from, to time.Time) {
+	from = startOfDay(t)
+	return from, from.AddDate(0, 0, 1)
+}
+
+// nextWeekday は基準日以降で最初に指定の曜日となる日を返す
+// includeToday=false の場合、基準日当日は含めず翌日以降から探す
+func nextWeekday(from time.Time, wd time.Weekday, includeToday bool) time.Time {
+	days := (int(wd) - int(from.Weekday()) + 7) % 7
+	if days == 0 && !includeToday {
+		days = 7
+	}
+	return from.AddDate(0, 0, days)
+}
+
+// addMonths は t の n か月後の同じ日の同じ時刻を返す
+// time.Time.AddDate と異なり翌月に繰り越さず、月末より後の日はその月の末日とする（1/31 の1か月後は 2/28）
+func addMonths(t time.Time, n int) time.Time {
+	first := time.Date(t.Year(), t.Month()+time.Month(n), 1, t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), t.Location())
+	last := first.AddDate(0, 1, -1).Day()
+	return first.AddDate(0, 0, min(t.Day(), last)-1)
+}
+
+// startOfWeek は指定日を含む週の月曜日を返す
+func startOfWeek(t time.Time) time.Time {
+	offset := (int(t.Weekday()) + 6) % 7
+	return startOfDay(t).AddDate(0, 0, -offset)
+}
+
+func startOfDay(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+}
+
+func endOfDay(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 23, 59, 59, 0, t.Location())
+}
+
+func atClock(t time.Time, hour, minute int) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), hour, minute, 0, 0, t.Location())
+}
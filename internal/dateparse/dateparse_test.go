@@ -0,0 +1,207 @@
+package dateparse_test
+
+import (
+	"OTakumi/todogo/internal/dateparse"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParser_Parse(t *testing.T) {
+	tokyo, err := time.LoadLocation("Asia/Tokyo")
+	require.NoError(t, err)
+
+	// 基準時刻: 2025-01-15（水）10:30 JST
+	now := time.Date(2025, 1, 15, 10, 30, 0, 0, tokyo)
+	parser := dateparse.New(tokyo, func() time.Time { return now })
+
+	date := func(y int, m time.Month, d, h, min, sec int) time.Time {
+		return time.Date(y, m, d, h, min, sec, 0, tokyo)
+	}
+
+	tests := []struct {
+		input string
+		want  time.Time
+	}{
+		// ISO形式
+		{"2025-02-01", date(2025, 2, 1, 23, 59, 59)},
+		{"2025/02/01", date(2025, 2, 1, 23, 59, 59)},
+		{"2025-02-01 17:00", date(2025, 2, 1, 17, 0, 0)},
+		{"2025-02-01T17:00", date(2025, 2, 1, 17, 0, 0)},
+		{"2025-02-01 5pm", date(2025, 2, 1, 17, 0, 0)},
+		{"2025-02-01T08:00:00Z", date(2025, 2, 1, 17, 0, 0)},
+
+		// 日単位の表現
+		{"now", now},
+		{"today", date(2025, 1, 15, 23, 59, 59)},
+		{"eod", date(2025, 1, 15, 23, 59, 59)},
+		{"tomorrow", date(2025, 1, 16, 23, 59, 59)},
+		{"tomorrow 17:00", date(2025, 1, 16, 17, 0, 0)},
+		{"Tomorrow  9:30am", date(2025, 1, 16, 9, 30, 0)},
+		{"yesterday noon", date(2025, 1, 14, 12, 0, 0)},
+		{"17:00", date(2025, 1, 15, 17, 0, 0)},
+
+		// 曜日
+		{"fri", date(2025, 1, 17, 23, 59, 59)},
+		{"friday 12pm", date(2025, 1, 17, 12, 0, 0)},
+		{"next fri", date(2025, 1, 17, 23, 59, 59)},
+		{"wed", date(2025, 1, 15, 23, 59, 59)},
+		{"this wed", date(2025, 1, 15, 23, 59, 59)},
+		{"next wed", date(2025, 1, 22, 23, 59, 59)},
+		{"mon", date(2025, 1, 20, 23, 59, 59)},
+
+		// 相対指定
+		{"in 3 days", date(2025, 1, 18, 10, 30, 0)},
+		{"in 3 days 17:00", date(2025, 1, 18, 17, 0, 0)},
+		{"3d", date(2025, 1, 18, 10, 30, 0)},
+		{"in 2 weeks", date(2025, 1, 29, 10, 30, 0)},
+		{"in 1 month", date(2025, 2, 15, 10, 30, 0)},
+		{"in 5 hours", date(2025, 1, 15, 15, 30, 0)},
+		{"in 30 min", date(2025, 1, 15, 11, 0, 0)},
+
+		// 期間の終わり
+		{"eow", date(2025, 1, 19, 23, 59, 59)},
+		{"eow 18:00", date(2025, 1, 19, 18, 0, 0)},
+		{"eom", date(2025, 1, 31, 23, 59, 59)},
+		{"eoy", date(2025, 12, 31, 23, 59, 59)},
+		{"next week", date(2025, 1, 20, 23, 59, 59)},
+		{"next month", date(2025, 2, 1, 23, 59, 59)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := parser.Parse(tt.input)
+
+			require.NoError(t, err)
+			assert.True(t, tt.want.Equal(got), "want %v, got %v", tt.want, got)
+			assert.Equal(t, tokyo, got.Location())
+		})
+	}
+}
+
+func TestParser_Parse_Invalid(t *testing.T) {
+	now := time.Date(2025, 1, 15, 10, 30, 0, 0, time.UTC)
+	parser := dateparse.New(time.UTC, func() time.Time { return now })
+
+	tests := []string{
+		"",
+		"someday",
+		"next",
+		"in three days",
+		"tomorrow 25:00",
+		"tomorrow 13pm",
+		"2025-13-01",
+	}
+
+	for _, input := range tests {
+		t.Run(input, func(t *testing.T) {
+			_, err := parser.Parse(input)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestParser_Parse_TimeZone(t *testing.T) {
+	// 同じ瞬間でも、タイムゾーンによって「明日」が指す日付が異なること
+	now := time.Date(2025, 1, 15, 20, 0, 0, 0, time.UTC) // 東京では1/16 05:00
+
+	tokyo, err := time.LoadLocation("Asia/Tokyo")
+	require.NoError(t, err)
+
+	utcParser := dateparse.New(time.UTC, func() time.Time { return now })
+	tokyoParser := dateparse.New(tokyo, func() time.Time { return now })
+
+	utcTomorrow, err := utcParser.Parse("tomorrow")
+	require.NoError(t, err)
+	tokyoTomorrow, err := tokyoParser.Parse("tomorrow")
+	require.NoError(t, err)
+
+	assert.Equal(t, 16, utcTomorrow.Day())
+	assert.Equal(t, 17, tokyoTomorrow.Day())
+}
+
+// 月や年の単位の相対指定は、月末より後の日を翌月に繰り越さずにその月の末日とする
+func TestParser_Parse_MonthEnd(t *testing.T) {
+	tokyo, err := time.LoadLocation("Asia/Tokyo")
+	require.NoError(t, err)
+
+	date := func(y int, m time.Month, d, h, min int) time.Time {
+		return time.Date(y, m, d, h, min, 0, 0, tokyo)
+	}
+
+	tests := []struct {
+		now   time.Time
+		input string
+		want  time.Time
+	}{
+		{date(2025, 1, 31, 10, 30), "in 1 month", date(2025, 2, 28, 10, 30)},
+		{date(2024, 1, 31, 10, 30), "in 1 month", date(2024, 2, 29, 10, 30)},
+		{date(2025, 1, 31, 10, 30), "in 3 months", date(2025, 4, 30, 10, 30)},
+		{date(2025, 3, 31, 10, 30), "in 1 month 17:00", date(2025, 4, 30, 17, 0)},
+		{date(2025, 1, 31, 10, 30), "next month", date(2025, 2, 1, 23, 59)},
+		{date(2024, 2, 29, 10, 30), "in 1 year", date(2025, 2, 28, 10, 30)},
+		{date(2024, 2, 29, 10, 30), "in 4 years", date(2028, 2, 29, 10, 30)},
+		{date(2024, 2, 29, 10, 30), "next month", date(2024, 3, 1, 23, 59)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.now.Format("2006-01-02")+" "+tt.input, func(t *testing.T) {
+			parser := dateparse.New(tokyo, func() time.Time { return tt.now })
+
+			got, err := parser.Parse(tt.input)
+
+			require.NoError(t, err)
+			assert.True(t, tt.want.Equal(got.Truncate(time.Minute)), "want %v, got %v", tt.want, got)
+		})
+	}
+}
+
+func TestParseDuration(t *testing.T) {
+	tests := []struct {
+		input string
+		want  time.Duration
+	}{
+		{"45m", 45 * time.Minute},
+		{"1h30m", 90 * time.Minute},
+		{"3d", 72 * time.Hour},
+		{"2w", 14 * 24 * time.Hour},
+		{"1d12h", 36 * time.Hour},
+		{"3 days", 72 * time.Hour},
+		{"30min", 30 * time.Minute},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := dateparse.ParseDuration(tt.input)
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+
+	for _, input := range []string{"", "3", "d", "3 fortnights", "1mo"} {
+		t.Run("invalid "+input, func(t *testing.T) {
+			_, err := dateparse.ParseDuration(input)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestWeekRange(t *testing.T) {
+	tokyo := time.FixedZone("JST", 9*60*60)
+
+	// 日曜日は前の月曜日から始まる週に含めること
+	from, to := dateparse.WeekRange(time.Date(2025, 6, 15, 23, 30, 0, 0, tokyo))
+
+	assert.Equal(t, time.Date(2025, 6, 9, 0, 0, 0, 0, tokyo), from)
+	assert.Equal(t, time.Date(2025, 6, 16, 0, 0, 0, 0, tokyo), to)
+}
+
+func TestDayRange(t *testing.T) {
+	from, to := dateparse.DayRange(time.Date(2025, 6, 15, 23, 30, 0, 0, time.UTC))
+
+	assert.Equal(t, time.Date(2025, 6, 15, 0, 0, 0, 0, time.UTC), from)
+	assert.Equal(t, time.Date(2025, 6, 16, 0, 0, 0, 0, time.UTC), to)
+}
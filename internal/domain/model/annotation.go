@@ -0,0 +1,27 @@
+package model
+
+import (
+	"errors"
+	"strings"
+	"time"
+)
+
+// Annotation はタスクに追記する日時付きの注記
+// "業者に電話した" のような経過の記録に使い、追加した後は変更しない
+type Annotation struct {
+	// ID は注記の連番（同じ日時の注記の順序にも使う）
+	ID int64
+	// Text は注記の本文
+	Text string
+	// CreatedAt は注記を追加した日時
+	CreatedAt time.Time
+}
+
+// NormalizeAnnotation は注記の本文の前後の空白を取り除き、空でないことを確認する
+func NormalizeAnnotation(text string) (string, error) {
+	text = strings.TrimSpace(text)
+	if text == "" {
+		return "", errors.New("annotation text is required")
+	}
+	return text, nil
+}
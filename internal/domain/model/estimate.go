@@ -0,0 +1,92 @@
+package model
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// EstimateKind は見積もりの単位の種類
+type EstimateKind string
+
+const (
+	// EstimateTime は作業時間による見積もり
+	EstimateTime EstimateKind = "time"
+	// EstimatePoints はストーリーポイントによる見積もり
+	EstimatePoints EstimateKind = "points"
+)
+
+// Estimate はタスクの見積もり
+// 作業時間とストーリーポイントのいずれか一方のみを設定する
+type Estimate struct {
+	// Duration は作業時間による見積もり（ポイントで見積もった場合は0）
+	Duration time.Duration
+	// Points はストーリーポイントによる見積もり（作業時間で見積もった場合は0）
+	Points float64
+}
+
+// Kind は見積もりの単位の種類を返す
+func (e Estimate) Kind() EstimateKind {
+	if e.Points > 0 {
+		return EstimatePoints
+	}
+	return EstimateTime
+}
+
+// Validate は作業時間とポイントのいずれか一方のみが正の値で設定されていることを検証する
+func (e Estimate) Validate() error {
+	switch {
+	case e.Duration < 0 || e.Points < 0:
+		return errors.New("estimate must be positive")
+	case e.Duration > 0 && e.Points > 0:
+		return errors.New("estimate must be either a duration or points, not both")
+	case e.Duration == 0 && e.Points == 0:
+		return errors.New("estimate must be positive")
+	}
+	return nil
+}
+
+// String は見積もりを ParseEstimatePoints や期間の指定として再度解釈できる形式で返す（"1h30m"、"5pts" など）
+func (e Estimate) String() string {
+	if e.Kind() == EstimatePoints {
+		return strconv.FormatFloat(e.Points, 'f', -1, 64) + "pts"
+	}
+
+	d := e.Duration.Truncate(time.Minute)
+	h, m := int(d/time.Hour), int(d%time.Hour/time.Minute)
+	switch {
+	case h == 0:
+		return fmt.Sprintf("%dm", m)
+	case m == 0:
+		return fmt.Sprintf("%dh", h)
+	default:
+		return fmt.Sprintf("%dh%dm", h, m)
+	}
+}
+
+// pointSuffixes はストーリーポイントとして解釈する単位の表記（長いものから順に照合する）
+var pointSuffixes = []string{"points", "point", "pts", "pt", "sp", "p"}
+
+// ParseEstimatePoints は "5pts" や "3 points" のようなストーリーポイントによる見積もりを解釈する
+// ポイントの単位が付いていない場合は ok=false を返し、作業時間として解釈するかは呼び出し側で判断する
+func ParseEstimatePoints(s string) (estimate *Estimate, ok bool, err error) {
+	s = strings.ToLower(strings.TrimSpace(s))
+	for _, suffix := range pointSuffixes {
+		number, found := strings.CutSuffix(s, suffix)
+		if !found {
+			continue
+		}
+		points, err := strconv.ParseFloat(strings.TrimSpace(number), 64)
+		if err != nil {
+			return nil, true, fmt.Errorf("invalid estimate %q: points must be a number", s)
+		}
+		e := &Estimate{Points: points}
+		if err := e.Validate(); err != nil {
+			return nil, true, err
+		}
+		return e, true, nil
+	}
+	return nil, false, nil
+}
@@ -0,0 +1,58 @@
+package model
+
+import "time"
+
+// ActualSource は見積もりと比較した実績の種類
+type ActualSource string
+
+const (
+	// ActualTracked は記録された作業時間の合計
+	ActualTracked ActualSource = "tracked"
+	// ActualLeadTime は作成から完了までの時間（作業時間の記録がない場合に使う）
+	ActualLeadTime ActualSource = "lead_time"
+)
+
+// EstimateReport は完了したタスクの見積もりと実績を比較したもの
+type EstimateReport struct {
+	// Tasks は見積もりのある完了したタスク（完了の新しい順）
+	Tasks []EstimatedTask
+	// Tags はタグと見積もりの種類ごとの精度（タグの名前順、同じタグでは作業時間、ポイントの順）
+	// タグのないタスクはタグごとの精度には含めず、全体の精度にのみ含める
+	Tags []EstimateAccuracy
+	// Overall は見積もりの種類ごとの全体の精度（作業時間、ポイントの順）
+	Overall []EstimateAccuracy
+}
+
+// EstimatedTask は1件のタスクの見積もりと実績
+type EstimatedTask struct {
+	TaskID   string
+	Title    string
+	Tags     []string
+	Estimate Estimate
+	// Actual は実績の時間（Source が示す方法で求めたもの）
+	Actual time.Duration
+	Source ActualSource
+	// CompletedAt はタスクが完了した日時
+	CompletedAt time.Time
+	// Ratio は見積もりに対する実績の比（1より大きい場合は見積もりより時間がかかったことを表す）
+	// ポイントの見積もりは、全体の1ポイントあたりの実績の時間を基準とした比とする
+	Ratio float64
+}
+
+// EstimateAccuracy はタスクの集まりについての見積もりの精度
+type EstimateAccuracy struct {
+	// Tag はタグの名前（全体の精度の場合は空）
+	Tag  string
+	Kind EstimateKind
+	// Tasks は集計したタスクの件数
+	Tasks int
+	// Estimated と Points は見積もりの合計（Kind に応じていずれか一方のみを設定する）
+	Estimated time.Duration
+	Points    float64
+	// Actual は実績の時間の合計
+	Actual time.Duration
+	// PerPoint は1ポイントあたりの実績の時間（ポイントの見積もりの場合のみ設定する）
+	PerPoint time.Duration
+	// Ratio は見積もりの合計に対する実績の合計の比（EstimatedTask.Ratio を参照）
+	Ratio float64
+}
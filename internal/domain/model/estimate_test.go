@@ -0,0 +1,71 @@
+package model_test
+
+import (
+	"OTakumi/todogo/internal/domain/model"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseEstimatePoints(t *testing.T) {
+	tests := []struct {
+		input string
+		want  float64
+	}{
+		{"5pts", 5},
+		{"3 points", 3},
+		{"1 point", 1},
+		{"0.5SP", 0.5},
+		{"8p", 8},
+	}
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			estimate, ok, err := model.ParseEstimatePoints(tt.input)
+
+			require.NoError(t, err)
+			assert.True(t, ok)
+			assert.Equal(t, &model.Estimate{Points: tt.want}, estimate)
+			assert.Equal(t, model.EstimatePoints, estimate.Kind())
+		})
+	}
+
+	t.Run("ポイントの単位がない場合は解釈しない", func(t *testing.T) {
+		estimate, ok, err := model.ParseEstimatePoints("3h")
+
+		assert.NoError(t, err)
+		assert.False(t, ok)
+		assert.Nil(t, estimate)
+	})
+
+	t.Run("不正な値の場合はエラーを返す", func(t *testing.T) {
+		for _, input := range []string{"fivepts", "0pts", "-2pts"} {
+			_, ok, err := model.ParseEstimatePoints(input)
+
+			assert.True(t, ok, input)
+			assert.Error(t, err, input)
+		}
+	})
+}
+
+func TestEstimate_String(t *testing.T) {
+	assert.Equal(t, "5pts", model.Estimate{Points: 5}.String())
+	assert.Equal(t, "0.5pts", model.Estimate{Points: 0.5}.String())
+	assert.Equal(t, "3h", model.Estimate{Duration: 3 * time.Hour}.String())
+	assert.Equal(t, "1h30m", model.Estimate{Duration: 90 * time.Minute}.String())
+	assert.Equal(t, "45m", model.Estimate{Duration: 45 * time.Minute}.String())
+}
+
+func TestEstimate_Validate(t *testing.T) {
+	assert.NoError(t, model.Estimate{Duration: time.Hour}.Validate())
+	assert.NoError(t, model.Estimate{Points: 3}.Validate())
+	assert.ErrorContains(t, model.Estimate{}.Validate(), "must be positive")
+	assert.ErrorContains(t, model.Estimate{Duration: -time.Hour}.Validate(), "must be positive")
+	assert.ErrorContains(t, model.Estimate{Duration: time.Hour, Points: 3}.Validate(), "not both")
+
+	// タスクの検証でも見積もりを検証すること
+	task := model.NewTask("id-1", "Task")
+	task.Estimate = &model.Estimate{}
+	assert.Error(t, task.Validate())
+}
@@ -0,0 +1,152 @@
+package model
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// OperationKind は取り消しのために記録するタスクの操作の種類
+type OperationKind string
+
+const (
+	// OperationCreate はタスクの作成
+	OperationCreate OperationKind = "create"
+	// OperationUpdate はタスクの更新
+	OperationUpdate OperationKind = "update"
+	// OperationDelete はタスクの削除
+	OperationDelete OperationKind = "delete"
+)
+
+// Operation は取り消し（undo）とやり直し（redo）のために記録する、1件のタスクの保存
+// 変更前と変更後のタスクを保持し、取り消す場合は変更後から変更前に、やり直す場合は変更前から変更後に戻す
+// 保持するのは履歴に記録する項目（taskFields）と作成日時、完了日時のみで、
+// 依存関係、注記、作業時間の記録はタスクの保存とは別に追加するため含めない
+type Operation struct {
+	ID int64
+	// BatchID は同じコマンドで行った操作に共通するID（取り消しとやり直しはコマンドの単位で行う）
+	BatchID string
+	// Command は操作を行ったコマンド（"rm 3 4" など、不明な場合は空）
+	Command string
+	Kind    OperationKind
+	TaskID  string
+	// Before は変更前のタスク（作成の場合はnil）、After は変更後のタスク（削除の場合はnil）
+	Before *Task
+	After  *Task
+	// Undone は取り消し済みかどうか（取り消し済みの操作はやり直しの対象となる）
+	Undone     bool
+	RecordedAt time.Time
+}
+
+// NewOperation は before から after へのタスクの保存を、取り消しのために記録する操作に変換する
+// before がnilの場合は作成、after がnilの場合は削除、それ以外の場合は更新とする
+func NewOperation(before, after *Task) Operation {
+	switch {
+	case before == nil:
+		return Operation{Kind: OperationCreate, TaskID: after.ID, After: after}
+	case after == nil:
+		return Operation{Kind: OperationDelete, TaskID: before.ID, Before: before}
+	default:
+		return Operation{Kind: OperationUpdate, TaskID: after.ID, Before: before, After: after}
+	}
+}
+
+// Expected は操作を取り消す（undo がtrue）またはやり直す前の、タスクのあるべき状態を返す（存在しない場合はnil）
+func (op Operation) Expected(undo bool) *Task {
+	if undo {
+		return op.After
+	}
+	return op.Before
+}
+
+// Target は操作を取り消した（undo がtrue）またはやり直した後の、タスクの状態を返す（存在しない場合はnil）
+func (op Operation) Target(undo bool) *Task {
+	if undo {
+		return op.Before
+	}
+	return op.After
+}
+
+// Check は現在のタスク（存在しない場合はnil）が、操作を取り消すまたはやり直す前のあるべき状態かを確認する
+// 操作の記録後にタスクが変更されていた場合は、ErrConflict として判定できる *ConflictError を返す
+func (op Operation) Check(current *Task, undo bool) error {
+	expected := op.Expected(undo)
+	conflict := &ConflictError{TaskID: op.TaskID, Command: op.Command}
+	switch {
+	case expected == nil && current == nil:
+		return nil
+	case expected == nil:
+		conflict.Reason = "a task with the same ID exists"
+	case current == nil:
+		conflict.Reason = "the task was deleted"
+	default:
+		fields := ChangedFields(expected, current)
+		if len(fields) == 0 {
+			return nil
+		}
+		conflict.Reason = strings.Join(fields, ", ") + " changed"
+	}
+	return conflict
+}
+
+// ChangedFields は before と after で値が異なる、履歴に記録する項目の名前を記録する順に返す
+func ChangedFields(before, after *Task) []string {
+	var fields []string
+	for _, f := range taskFields {
+		if f.value(before) != f.value(after) {
+			fields = append(fields, f.name)
+		}
+	}
+	return fields
+}
+
+// ErrConflict は取り消しまたはやり直しの対象のタスクが、操作の記録後に変更されていることを表すエラー
+// errors.Is で判定できるよう、ConflictError はこのエラーとして扱われる
+var ErrConflict = errors.New("operation conflict")
+
+// ConflictError は変更されていたタスクのIDと、その内容を保持するエラー型
+type ConflictError struct {
+	TaskID  string
+	Command string
+	Reason  string
+}
+
+func (e *ConflictError) Error() string {
+	command := "the operation"
+	if e.Command != "" {
+		command = `"` + e.Command + `"`
+	}
+	return fmt.Sprintf("conflict on task %s: %s since %s was recorded", e.TaskID, e.Reason, command)
+}
+
+// Is は errors.Is(err, ErrConflict) を成立させるための実装
+func (e *ConflictError) Is(target error) bool {
+	return target == ErrConflict
+}
+
+// OperationBatch は同じコマンドで行った操作のまとまり
+type OperationBatch struct {
+	ID         string
+	Command    string
+	RecordedAt time.Time
+	// Operations はまとまりに含まれる操作（記録した順）
+	Operations []Operation
+}
+
+// GroupOperations は記録した順の操作を、同じコマンドで行った操作ごとにまとめる
+// まとまりは最初の操作を記録した順に並べる
+func GroupOperations(ops []Operation) []OperationBatch {
+	var batches []OperationBatch
+	index := make(map[string]int)
+	for _, op := range ops {
+		i, ok := index[op.BatchID]
+		if !ok {
+			i = len(batches)
+			index[op.BatchID] = i
+			batches = append(batches, OperationBatch{ID: op.BatchID, Command: op.Command, RecordedAt: op.RecordedAt})
+		}
+		batches[i].Operations = append(batches[i].Operations, op)
+	}
+	return batches
+}
@@ -0,0 +1,59 @@
+package model_test
+
+import (
+	"OTakumi/todogo/internal/domain/model"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOperation_Check(t *testing.T) {
+	before := &model.Task{ID: "id-1", Title: "Write report", Status: model.StatusOpen}
+	after := &model.Task{ID: "id-1", Title: "Write report", Status: model.StatusDone}
+	update := model.NewOperation(before, after)
+	update.Command = "done 1"
+
+	t.Run("取り消しは変更後、やり直しは変更前の状態と比較する", func(t *testing.T) {
+		assert.NoError(t, update.Check(after, true))
+		assert.NoError(t, update.Check(before, false))
+		assert.Equal(t, before, update.Target(true))
+		assert.Equal(t, after, update.Target(false))
+	})
+
+	t.Run("記録後に変更された項目を返す", func(t *testing.T) {
+		current := *after
+		current.Title = "Write the report"
+
+		err := update.Check(&current, true)
+
+		var conflict *model.ConflictError
+		require.True(t, errors.As(err, &conflict))
+		assert.True(t, errors.Is(err, model.ErrConflict))
+		assert.Equal(t, "id-1", conflict.TaskID)
+		assert.EqualError(t, err, `conflict on task id-1: title changed since "done 1" was recorded`)
+	})
+
+	t.Run("作成の取り消しは削除済みの場合、削除の取り消しは存在する場合に競合とする", func(t *testing.T) {
+		assert.ErrorContains(t, model.NewOperation(nil, after).Check(nil, true), "the task was deleted")
+		assert.ErrorContains(t, model.NewOperation(before, nil).Check(before, true), "a task with the same ID exists")
+		assert.NoError(t, model.NewOperation(before, nil).Check(nil, true))
+	})
+}
+
+func TestGroupOperations(t *testing.T) {
+	ops := []model.Operation{
+		{ID: 1, BatchID: "a", Command: "rm 1 2", TaskID: "id-1"},
+		{ID: 2, BatchID: "a", Command: "rm 1 2", TaskID: "id-2"},
+		{ID: 3, BatchID: "b", Command: "new", TaskID: "id-3"},
+	}
+
+	batches := model.GroupOperations(ops)
+
+	require.Len(t, batches, 2)
+	assert.Equal(t, "rm 1 2", batches[0].Command)
+	assert.Equal(t, ops[:2], batches[0].Operations)
+	assert.Equal(t, ops[2:], batches[1].Operations)
+	assert.Empty(t, model.GroupOperations(nil))
+}
@@ -0,0 +1,60 @@
+package model
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Priority はタスクの優先度
+// 値が大きいほど優先度が高く、ゼロ値は優先度が設定されていないことを表す
+type Priority int
+
+const (
+	PriorityNone Priority = iota
+	PriorityLow
+	PriorityMedium
+	PriorityHigh
+	PriorityUrgent
+)
+
+// Priorities は指定可能な優先度の一覧（低い順）
+var Priorities = []Priority{PriorityNone, PriorityLow, PriorityMedium, PriorityHigh, PriorityUrgent}
+
+var priorityNames = map[Priority]string{
+	PriorityNone:   "none",
+	PriorityLow:    "low",
+	PriorityMedium: "medium",
+	PriorityHigh:   "high",
+	PriorityUrgent: "urgent",
+}
+
+func (p Priority) String() string {
+	if name, ok := priorityNames[p]; ok {
+		return name
+	}
+	return fmt.Sprintf("Priority(%d)", int(p))
+}
+
+// IsValid は定義済みの優先度かどうかを判定する
+func (p Priority) IsValid() bool {
+	_, ok := priorityNames[p]
+	return ok
+}
+
+// ParsePriority は "high" のような優先度の名前を解釈する（大文字小文字は区別しない）
+// 名前の先頭1文字（"h" など）でも指定できる
+func ParsePriority(s string) (Priority, error) {
+	s = strings.ToLower(strings.TrimSpace(s))
+	for _, p := range Priorities {
+		name := priorityNames[p]
+		if s == name || (len(s) == 1 && strings.HasPrefix(name, s)) {
+			return p, nil
+		}
+	}
+
+	names := make([]string, len(Priorities))
+	for i, p := range Priorities {
+		names[i] = priorityNames[p]
+	}
+	return PriorityNone, fmt.Errorf("invalid priority %q: must be one of %s", s, strings.Join(names, ", "))
+}
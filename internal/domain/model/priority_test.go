@@ -0,0 +1,55 @@
+package model_test
+
+import (
+	"OTakumi/todogo/internal/domain/model"
+	"testing"
+)
+
+func TestParsePriority(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    model.Priority
+		wantErr bool
+	}{
+		{input: "none", want: model.PriorityNone},
+		{input: "low", want: model.PriorityLow},
+		{input: "Medium", want: model.PriorityMedium},
+		{input: "h", want: model.PriorityHigh},
+		{input: "URGENT", want: model.PriorityUrgent},
+		{input: "critical", wantErr: true},
+		{input: "", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := model.ParsePriority(tt.input)
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("expected an error for %q, but got nil", tt.input)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("expected %v, but got %v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestTask_Validate_Priority(t *testing.T) {
+	t.Run("定義されていない優先度の場合、エラーが返されること", func(t *testing.T) {
+		// Arrange
+		task := model.Task{Title: "Testing Go", Priority: model.Priority(9)}
+
+		// Act
+		err := task.Validate()
+
+		// Assert
+		if err == nil {
+			t.Error("Expected an error, but got nil")
+		}
+	})
+}
@@ -0,0 +1,103 @@
+package model
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// ProjectSeparator はプロジェクト名の階層の区切り文字
+const ProjectSeparator = "."
+
+// maxProjectLength はプロジェクト名の最大文字数
+const maxProjectLength = 255
+
+// projectSegmentPattern はプロジェクト名の各階層の名前として使える形式
+var projectSegmentPattern = regexp.MustCompile(`^[\p{L}\p{N}_][\p{L}\p{N}_-]*$`)
+
+// ProjectCount はプロジェクトと、そのプロジェクトに属するタスクの件数
+type ProjectCount struct {
+	Name string
+	// Open は未完了のタスクの件数
+	Open int
+	// Closed は完了済みのタスクの件数
+	Closed int
+}
+
+// Total は完了済みを含むすべてのタスクの件数を返す
+func (c ProjectCount) Total() int {
+	return c.Open + c.Closed
+}
+
+// Completion は完了済みのタスクの割合を百分率で返す（タスクがない場合は0）
+func (c ProjectCount) Completion() float64 {
+	if c.Total() == 0 {
+		return 0
+	}
+	return float64(c.Closed) / float64(c.Total()) * 100
+}
+
+// NormalizeProject はプロジェクト名を正規化する（前後の空白を除き、小文字にする）
+// 空文字列はプロジェクトなしを表すため、そのまま返す
+func NormalizeProject(s string) (string, error) {
+	name := strings.ToLower(strings.TrimSpace(s))
+	if name == "" {
+		return "", nil
+	}
+	if err := validateProject(name); err != nil {
+		return "", err
+	}
+	return name, nil
+}
+
+// ProjectAncestors はプロジェクト自身と、その上位のプロジェクトの名前を上位から順に返す
+// 例: "work.backend.api" → ["work", "work.backend", "work.backend.api"]
+func ProjectAncestors(name string) []string {
+	if name == "" {
+		return nil
+	}
+
+	segments := strings.Split(name, ProjectSeparator)
+	names := make([]string, len(segments))
+	for i := range segments {
+		names[i] = strings.Join(segments[:i+1], ProjectSeparator)
+	}
+	return names
+}
+
+// InProject はプロジェクトnameが、プロジェクトparentそのものかその配下のプロジェクトかどうかを判定する
+func InProject(name, parent string) bool {
+	return name == parent || strings.HasPrefix(name, parent+ProjectSeparator)
+}
+
+// CompareProjects はプロジェクト名を階層ごとに比較する
+// 上位のプロジェクトが配下のプロジェクトより先に並ぶ（"work" < "work.api" < "work-archive"）
+func CompareProjects(a, b string) int {
+	as, bs := strings.Split(a, ProjectSeparator), strings.Split(b, ProjectSeparator)
+	for i := 0; i < len(as) && i < len(bs); i++ {
+		if c := strings.Compare(as[i], bs[i]); c != 0 {
+			return c
+		}
+	}
+	return len(as) - len(bs)
+}
+
+// validateProject はプロジェクト名が正規化済みで、各階層の名前が使える形式であることを確認する
+func validateProject(name string) error {
+	if name == "" {
+		return errors.New("project cannot be empty")
+	}
+	if len([]rune(name)) > maxProjectLength {
+		return fmt.Errorf("invalid project %q: must be at most %d characters", name, maxProjectLength)
+	}
+	if name != strings.ToLower(name) {
+		return fmt.Errorf("invalid project %q: must be lower case", name)
+	}
+	for _, segment := range strings.Split(name, ProjectSeparator) {
+		if !projectSegmentPattern.MatchString(segment) {
+			return fmt.Errorf("invalid project %q: each part separated by %q must start with a letter, digit or _ and contain only those or -", name, ProjectSeparator)
+		}
+	}
+	return nil
+}
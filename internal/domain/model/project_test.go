@@ -0,0 +1,79 @@
+package model_test
+
+import (
+	"OTakumi/todogo/internal/domain/model"
+	"reflect"
+	"sort"
+	"testing"
+)
+
+func TestNormalizeProject(t *testing.T) {
+	t.Run("小文字にして前後の空白を除くこと", func(t *testing.T) {
+		name, err := model.NormalizeProject(" Work.Backend.API ")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if name != "work.backend.api" {
+			t.Errorf("expected work.backend.api, but got %q", name)
+		}
+	})
+
+	t.Run("プロジェクト名として使えない形式の場合、エラーが返されること", func(t *testing.T) {
+		for _, name := range []string{"work..api", ".work", "work.", "two words", "work/api", "-work"} {
+			if _, err := model.NormalizeProject(name); err == nil {
+				t.Errorf("expected an error for %q, but got nil", name)
+			}
+		}
+	})
+}
+
+func TestProjectAncestors(t *testing.T) {
+	got := model.ProjectAncestors("work.backend.api")
+
+	want := []string{"work", "work.backend", "work.backend.api"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("expected %v, but got %v", want, got)
+	}
+}
+
+func TestInProject(t *testing.T) {
+	tests := []struct {
+		name   string
+		parent string
+		want   bool
+	}{
+		{name: "work", parent: "work", want: true},
+		{name: "work.backend", parent: "work", want: true},
+		{name: "workshop", parent: "work", want: false},
+		{name: "work", parent: "work.backend", want: false},
+	}
+
+	for _, tt := range tests {
+		if got := model.InProject(tt.name, tt.parent); got != tt.want {
+			t.Errorf("InProject(%q, %q) = %v, want %v", tt.name, tt.parent, got, tt.want)
+		}
+	}
+}
+
+func TestCompareProjects(t *testing.T) {
+	names := []string{"work-archive", "work.backend", "home", "work"}
+
+	sort.Slice(names, func(i, j int) bool {
+		return model.CompareProjects(names[i], names[j]) < 0
+	})
+
+	// 上位のプロジェクトの直後に配下のプロジェクトが並ぶこと
+	want := []string{"home", "work", "work.backend", "work-archive"}
+	if !reflect.DeepEqual(names, want) {
+		t.Errorf("expected %v, but got %v", want, names)
+	}
+}
+
+func TestProjectCount_Completion(t *testing.T) {
+	if got := (model.ProjectCount{Open: 1, Closed: 3}).Completion(); got != 75 {
+		t.Errorf("expected 75, but got %v", got)
+	}
+	if got := (model.ProjectCount{}).Completion(); got != 0 {
+		t.Errorf("expected 0, but got %v", got)
+	}
+}
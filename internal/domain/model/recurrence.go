@@ -0,0 +1,304 @@
+package model
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Frequency は繰り返しの単位
+type Frequency string
+
+const (
+	FrequencyDaily   Frequency = "daily"
+	FrequencyWeekly  Frequency = "weekly"
+	FrequencyMonthly Frequency = "monthly"
+)
+
+// frequencyUnits は繰り返しの単位ごとの、短縮形で使う単位の名前
+var frequencyUnits = map[Frequency]string{
+	FrequencyDaily:   "day",
+	FrequencyWeekly:  "week",
+	FrequencyMonthly: "month",
+}
+
+// Recurrence はタスクの繰り返しのルール
+// 繰り返すタスクは共通のルール（テンプレート）を参照し、完了すると次の締切の新しいタスクが作成される
+type Recurrence struct {
+	Frequency Frequency
+	// Interval は繰り返しの間隔（1以上、2なら隔日・隔週・隔月）
+	Interval int
+	// Weekdays は週単位で繰り返す曜日（月曜始まりの順、空の場合は締切と同じ曜日）
+	Weekdays []time.Weekday
+	// MonthDay は月単位で繰り返す日（1〜31、0の場合は締切と同じ日）
+	// 月末より後の日を指定した場合は、その月の末日とする
+	MonthDay int
+	// AfterCompletion は暦ではなく、完了した日から間隔を数えて次の締切とするかどうか
+	AfterCompletion bool
+}
+
+// weekdayCodes はRRULE形式で使う曜日の略称
+var weekdayCodes = map[time.Weekday]string{
+	time.Monday:    "MO",
+	time.Tuesday:   "TU",
+	time.Wednesday: "WE",
+	time.Thursday:  "TH",
+	time.Friday:    "FR",
+	time.Saturday:  "SA",
+	time.Sunday:    "SU",
+}
+
+// Validate はルールが有効かどうかを検証する
+func (r Recurrence) Validate() error {
+	if _, ok := frequencyUnits[r.Frequency]; !ok {
+		return fmt.Errorf("invalid recurrence frequency %q", r.Frequency)
+	}
+	if r.Interval < 1 {
+		return errors.New("recurrence interval must be at least 1")
+	}
+	if len(r.Weekdays) > 0 && r.Frequency != FrequencyWeekly {
+		return errors.New("weekdays can only be set for a weekly recurrence")
+	}
+	for _, d := range r.Weekdays {
+		if d < time.Sunday || d > time.Saturday {
+			return fmt.Errorf("invalid weekday %d", int(d))
+		}
+	}
+	if r.MonthDay != 0 {
+		if r.Frequency != FrequencyMonthly {
+			return errors.New("day of month can only be set for a monthly recurrence")
+		}
+		if r.MonthDay < 1 || r.MonthDay > 31 {
+			return fmt.Errorf("invalid day of month %d: must be between 1 and 31", r.MonthDay)
+		}
+	}
+	if r.AfterCompletion && (len(r.Weekdays) > 0 || r.MonthDay != 0) {
+		return errors.New("a recurrence counted from completion cannot have weekdays or a day of month")
+	}
+	return nil
+}
+
+// String はルールをRRULE形式（"FREQ=WEEKLY;INTERVAL=2;BYDAY=MO,TH" など）で返す
+// ParseRecurrence で同じルールに戻すことができ、保存にもこの形式を使う
+func (r Recurrence) String() string {
+	parts := []string{"FREQ=" + strings.ToUpper(string(r.Frequency))}
+	if r.Interval > 1 {
+		parts = append(parts, "INTERVAL="+strconv.Itoa(r.Interval))
+	}
+	if len(r.Weekdays) > 0 {
+		codes := make([]string, len(r.Weekdays))
+		for i, d := range r.Weekdays {
+			codes[i] = weekdayCodes[d]
+		}
+		parts = append(parts, "BYDAY="+strings.Join(codes, ","))
+	}
+	if r.MonthDay != 0 {
+		parts = append(parts, "BYMONTHDAY="+strconv.Itoa(r.MonthDay))
+	}
+	if r.AfterCompletion {
+		parts = append(parts, "X-FROM=COMPLETION")
+	}
+	return strings.Join(parts, ";")
+}
+
+// Describe はルールを "every 2 weeks on Mon, Thu" のような表示用の文で返す
+func (r Recurrence) Describe() string {
+	unit := frequencyUnits[r.Frequency]
+	s := "every " + unit
+	if r.Interval > 1 {
+		s = fmt.Sprintf("every %d %ss", r.Interval, unit)
+	}
+
+	if len(r.Weekdays) > 0 {
+		names := make([]string, len(r.Weekdays))
+		for i, d := range r.Weekdays {
+			names[i] = d.String()[:3]
+		}
+		s += " on " + strings.Join(names, ", ")
+	}
+	if r.MonthDay != 0 {
+		s += fmt.Sprintf(" on day %d", r.MonthDay)
+	}
+	if r.AfterCompletion {
+		s += " after completion"
+	}
+	return s
+}
+
+// ParseRecurrence は繰り返しのルールを解釈する（大文字小文字は区別しない）
+// 次の短縮形と、RRULE形式（"FREQ=WEEKLY;BYDAY=MO,TH" など）を受け付ける
+//
+//	daily, weekly, monthly
+//	every 3 days, every 2 weeks, every month
+//	weekly on mon,thu / every 2 weeks on fri
+//	monthly on 15
+//	every 10 days after done
+func ParseRecurrence(s string) (*Recurrence, error) {
+	s = strings.TrimSpace(s)
+	var (
+		r   *Recurrence
+		err error
+	)
+	if strings.Contains(s, "=") {
+		r, err = parseRRule(s)
+	} else {
+		r, err = parseRecurrenceShorthand(s)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("invalid recurrence %q: %w", s, err)
+	}
+
+	slices.SortFunc(r.Weekdays, func(a, b time.Weekday) int {
+		return mondayFirst(a) - mondayFirst(b)
+	})
+	r.Weekdays = slices.Compact(r.Weekdays)
+
+	if err := r.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid recurrence %q: %w", s, err)
+	}
+	return r, nil
+}
+
+// mondayFirst は月曜日を0とする曜日の順序を返す
+func mondayFirst(d time.Weekday) int {
+	return (int(d) + 6) % 7
+}
+
+func parseRecurrenceShorthand(s string) (*Recurrence, error) {
+	fields := strings.Fields(strings.ToLower(s))
+	r := &Recurrence{Interval: 1}
+
+	// 末尾の "after done" は完了した日から数えることを表す
+	if n := len(fields); n >= 2 && fields[n-2] == "after" && (fields[n-1] == "done" || fields[n-1] == "completion") {
+		r.AfterCompletion = true
+		fields = fields[:n-2]
+	}
+
+	// "on" 以降は曜日または日の指定
+	var on string
+	if i := slices.Index(fields, "on"); i >= 0 {
+		on = strings.Join(fields[i+1:], "")
+		fields = fields[:i]
+		if on == "" {
+			return nil, errors.New(`missing value after "on"`)
+		}
+	}
+
+	switch {
+	case len(fields) == 1:
+		for freq := range frequencyUnits {
+			if fields[0] == string(freq) {
+				r.Frequency = freq
+			}
+		}
+		if r.Frequency == "" {
+			return nil, errors.New("expected daily, weekly, monthly or every <n> <unit>")
+		}
+	case (len(fields) == 2 || len(fields) == 3) && fields[0] == "every":
+		if len(fields) == 3 {
+			n, err := strconv.Atoi(fields[1])
+			if err != nil || n < 1 {
+				return nil, fmt.Errorf("invalid interval %q", fields[1])
+			}
+			r.Interval = n
+		}
+		unit := strings.TrimSuffix(fields[len(fields)-1], "s")
+		for freq, name := range frequencyUnits {
+			if unit == name {
+				r.Frequency = freq
+			}
+		}
+		if r.Frequency == "" {
+			return nil, fmt.Errorf("unknown unit %q: must be days, weeks or months", fields[len(fields)-1])
+		}
+	default:
+		return nil, errors.New("expected daily, weekly, monthly or every <n> <unit>")
+	}
+
+	if on == "" {
+		return r, nil
+	}
+	switch r.Frequency {
+	case FrequencyWeekly:
+		for _, name := range strings.Split(on, ",") {
+			d, err := parseWeekday(name)
+			if err != nil {
+				return nil, err
+			}
+			r.Weekdays = append(r.Weekdays, d)
+		}
+	case FrequencyMonthly:
+		day, err := strconv.Atoi(on)
+		if err != nil {
+			return nil, fmt.Errorf("invalid day of month %q", on)
+		}
+		r.MonthDay = day
+	default:
+		return nil, errors.New(`"on" can only be used with a weekly or monthly recurrence`)
+	}
+	return r, nil
+}
+
+// parseWeekday は "mon"、"monday"、"mo" のような曜日の名前を解釈する
+func parseWeekday(s string) (time.Weekday, error) {
+	s = strings.ToLower(s)
+	for d := time.Sunday; d <= time.Saturday; d++ {
+		name := strings.ToLower(d.String())
+		if s == name || s == name[:3] || s == strings.ToLower(weekdayCodes[d]) {
+			return d, nil
+		}
+	}
+	return 0, fmt.Errorf("unknown weekday %q", s)
+}
+
+func parseRRule(s string) (*Recurrence, error) {
+	s = strings.TrimPrefix(strings.ToUpper(s), "RRULE:")
+	r := &Recurrence{Interval: 1}
+
+	for _, part := range strings.Split(s, ";") {
+		key, value, ok := strings.Cut(strings.TrimSpace(part), "=")
+		if !ok {
+			return nil, fmt.Errorf("expected KEY=VALUE, got %q", part)
+		}
+
+		switch key {
+		case "FREQ":
+			r.Frequency = Frequency(strings.ToLower(value))
+		case "INTERVAL":
+			n, err := strconv.Atoi(value)
+			if err != nil {
+				return nil, fmt.Errorf("invalid interval %q", value)
+			}
+			r.Interval = n
+		case "BYDAY":
+			for _, code := range strings.Split(value, ",") {
+				d, err := parseWeekday(code)
+				if err != nil {
+					return nil, err
+				}
+				r.Weekdays = append(r.Weekdays, d)
+			}
+		case "BYMONTHDAY":
+			n, err := strconv.Atoi(value)
+			if err != nil {
+				return nil, fmt.Errorf("invalid day of month %q", value)
+			}
+			r.MonthDay = n
+		case "X-FROM":
+			if value != "COMPLETION" {
+				return nil, fmt.Errorf("invalid X-FROM %q: must be COMPLETION", value)
+			}
+			r.AfterCompletion = true
+		default:
+			return nil, fmt.Errorf("unsupported rule part %q", key)
+		}
+	}
+
+	if r.Frequency == "" {
+		return nil, errors.New("FREQ is required")
+	}
+	return r, nil
+}
@@ -0,0 +1,107 @@
+package model_test
+
+import (
+	"OTakumi/todogo/internal/domain/model"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseRecurrence(t *testing.T) {
+	tests := []struct {
+		input    string
+		want     model.Recurrence
+		wantRule string
+		wantText string
+	}{
+		{
+			input:    "daily",
+			want:     model.Recurrence{Frequency: model.FrequencyDaily, Interval: 1},
+			wantRule: "FREQ=DAILY",
+			wantText: "every day",
+		},
+		{
+			input:    "every 3 days",
+			want:     model.Recurrence{Frequency: model.FrequencyDaily, Interval: 3},
+			wantRule: "FREQ=DAILY;INTERVAL=3",
+			wantText: "every 3 days",
+		},
+		{
+			input:    "Weekly on thu, mon",
+			want:     model.Recurrence{Frequency: model.FrequencyWeekly, Interval: 1, Weekdays: []time.Weekday{time.Monday, time.Thursday}},
+			wantRule: "FREQ=WEEKLY;BYDAY=MO,TH",
+			wantText: "every week on Mon, Thu",
+		},
+		{
+			input:    "every 2 weeks on sunday",
+			want:     model.Recurrence{Frequency: model.FrequencyWeekly, Interval: 2, Weekdays: []time.Weekday{time.Sunday}},
+			wantRule: "FREQ=WEEKLY;INTERVAL=2;BYDAY=SU",
+			wantText: "every 2 weeks on Sun",
+		},
+		{
+			input:    "monthly on 31",
+			want:     model.Recurrence{Frequency: model.FrequencyMonthly, Interval: 1, MonthDay: 31},
+			wantRule: "FREQ=MONTHLY;BYMONTHDAY=31",
+			wantText: "every month on day 31",
+		},
+		{
+			input:    "every 10 days after done",
+			want:     model.Recurrence{Frequency: model.FrequencyDaily, Interval: 10, AfterCompletion: true},
+			wantRule: "FREQ=DAILY;INTERVAL=10;X-FROM=COMPLETION",
+			wantText: "every 10 days after completion",
+		},
+		{
+			input:    "FREQ=WEEKLY;INTERVAL=2;BYDAY=MO,TH",
+			want:     model.Recurrence{Frequency: model.FrequencyWeekly, Interval: 2, Weekdays: []time.Weekday{time.Monday, time.Thursday}},
+			wantRule: "FREQ=WEEKLY;INTERVAL=2;BYDAY=MO,TH",
+			wantText: "every 2 weeks on Mon, Thu",
+		},
+		{
+			input:    "rrule:freq=monthly;bymonthday=15",
+			want:     model.Recurrence{Frequency: model.FrequencyMonthly, Interval: 1, MonthDay: 15},
+			wantRule: "FREQ=MONTHLY;BYMONTHDAY=15",
+			wantText: "every month on day 15",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := model.ParseRecurrence(tt.input)
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, *got)
+			assert.Equal(t, tt.wantRule, got.String())
+			assert.Equal(t, tt.wantText, got.Describe())
+
+			// 保存した形式から同じルールに戻せること
+			parsed, err := model.ParseRecurrence(got.String())
+			require.NoError(t, err)
+			assert.Equal(t, *got, *parsed)
+		})
+	}
+}
+
+func TestParseRecurrence_Invalid(t *testing.T) {
+	inputs := []string{
+		"",
+		"hourly",
+		"every 0 days",
+		"every 2 years",
+		"daily on mon",
+		"weekly on someday",
+		"monthly on 32",
+		"weekly on mon after done",
+		"FREQ=YEARLY",
+		"INTERVAL=2",
+		"FREQ=DAILY;BYDAY=MO",
+	}
+
+	for _, input := range inputs {
+		t.Run(input, func(t *testing.T) {
+			_, err := model.ParseRecurrence(input)
+			assert.Error(t, err)
+		})
+	}
+}
@@ -0,0 +1,56 @@
+package model
+
+import (
+	"errors"
+	"strings"
+)
+
+// SearchField は検索語に一致したタスクの項目
+type SearchField string
+
+const (
+	SearchFieldTitle       SearchField = "title"
+	SearchFieldDescription SearchField = "description"
+	SearchFieldAnnotation  SearchField = "annotation"
+)
+
+// SearchHit は検索に一致したタスクと、一致した箇所
+type SearchHit struct {
+	Task *Task
+	// Rank は関連度（大きいほど上位、値の尺度はリポジトリの実装によって異なる）
+	Rank float64
+	// Matches は一致した項目ごとの抜粋（タイトル、説明、注記の順）
+	Matches []SearchMatch
+}
+
+// SearchMatch は検索語に一致した項目の抜粋
+type SearchMatch struct {
+	Field SearchField
+	// Snippet は一致した箇所の前後を含む抜粋（改行は空白に置き換える）
+	Snippet string
+	// Highlights は Snippet のうち検索語に一致した範囲（昇順、重複しない）
+	Highlights []Highlight
+}
+
+// Highlight は文字列中の範囲（バイト単位のオフセット、End は含まない）
+type Highlight struct {
+	Start int
+	End   int
+}
+
+// SearchTerms は検索文字列を空白で区切り、小文字にして重複を除いた検索語を返す
+// 検索語が1つもない場合はエラーを返す
+func SearchTerms(query string) ([]string, error) {
+	var terms []string
+	seen := make(map[string]bool)
+	for _, term := range strings.Fields(strings.ToLower(query)) {
+		if !seen[term] {
+			seen[term] = true
+			terms = append(terms, term)
+		}
+	}
+	if len(terms) == 0 {
+		return nil, errors.New("search query is required")
+	}
+	return terms, nil
+}
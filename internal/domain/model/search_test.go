@@ -0,0 +1,26 @@
+package model_test
+
+import (
+	"OTakumi/todogo/internal/domain/model"
+	"reflect"
+	"testing"
+)
+
+func TestSearchTerms(t *testing.T) {
+	t.Run("空白で区切り、小文字にして重複を除くこと", func(t *testing.T) {
+		terms, err := model.SearchTerms("  Vendor call\tvendor ")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		want := []string{"vendor", "call"}
+		if !reflect.DeepEqual(terms, want) {
+			t.Errorf("expected %v, but got %v", want, terms)
+		}
+	})
+
+	t.Run("検索語がない場合、エラーが返されること", func(t *testing.T) {
+		if _, err := model.SearchTerms(" \n "); err == nil {
+			t.Error("expected an error, but got nil")
+		}
+	})
+}
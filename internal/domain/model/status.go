@@ -0,0 +1,245 @@
+package model
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+)
+
+// Status はワークフロー上のタスクの状態
+type Status string
+
+// 既定のワークフローの状態
+const (
+	StatusOpen       Status = "open"
+	StatusInProgress Status = "in_progress"
+	StatusBlocked    Status = "blocked"
+	StatusDone       Status = "done"
+	StatusCancelled  Status = "cancelled"
+)
+
+// Label は "in_progress" を "In progress" のように表示用の文字列に変換する
+func (s Status) Label() string {
+	label := strings.ReplaceAll(string(s), "_", " ")
+	if label == "" {
+		return label
+	}
+	return strings.ToUpper(label[:1]) + label[1:]
+}
+
+// 状態のグループを表す名前（状態の名前としては使えない）
+const (
+	// StatusGroupActive は終了状態以外のすべての状態を表す
+	StatusGroupActive = "active"
+	// StatusGroupClosed はすべての終了状態を表す
+	StatusGroupClosed = "closed"
+)
+
+// statusNamePattern は状態の名前の形式（小文字の英字で始まり、小文字の英数字と _ を含む）
+var statusNamePattern = regexp.MustCompile(`^[a-z][a-z0-9_]*$`)
+
+// ErrIllegalTransition はワークフローで許可されていない状態の変更であることを表すエラー
+// errors.Is で判定できるよう、TransitionError はこのエラーとして扱われる
+var ErrIllegalTransition = errors.New("illegal status transition")
+
+// TransitionError は許可されていない状態の変更の、変更前後の状態を保持するエラー型
+type TransitionError struct {
+	From Status
+	To   Status
+	// Allowed は変更前の状態から変更できる状態
+	Allowed []Status
+}
+
+func (e *TransitionError) Error() string {
+	if len(e.Allowed) == 0 {
+		return fmt.Sprintf("cannot change status from %s to %s: %s allows no transitions", e.From, e.To, e.From)
+	}
+	return fmt.Sprintf("cannot change status from %s to %s: allowed: %s", e.From, e.To, joinStatuses(e.Allowed))
+}
+
+// Is は errors.Is(err, ErrIllegalTransition) を成立させるための実装
+func (e *TransitionError) Is(target error) bool {
+	return target == ErrIllegalTransition
+}
+
+// Workflow はタスクの状態と、状態の間で許可された変更（状態遷移）の定義
+type Workflow struct {
+	// Statuses はすべての状態（一覧で状態ごとにまとめて表示する際の順序）
+	Statuses []Status
+	// Initial は作成したタスクの状態
+	Initial Status
+	// Terminal は作業が残っていない状態（完了や取り消し）
+	// 終了状態のタスクは完了済みとして扱い、先頭の状態は done コマンドで変更する状態となる
+	Terminal []Status
+	// Transitions は各状態から変更できる状態
+	Transitions map[Status][]Status
+}
+
+// DefaultWorkflow は設定がない場合に使うワークフローを返す
+// 終了状態からは open に戻すことができる
+func DefaultWorkflow() *Workflow {
+	return &Workflow{
+		Statuses: []Status{StatusOpen, StatusInProgress, StatusBlocked, StatusDone, StatusCancelled},
+		Initial:  StatusOpen,
+		Terminal: []Status{StatusDone, StatusCancelled},
+		Transitions: map[Status][]Status{
+			StatusOpen:       {StatusInProgress, StatusBlocked, StatusDone, StatusCancelled},
+			StatusInProgress: {StatusOpen, StatusBlocked, StatusDone, StatusCancelled},
+			StatusBlocked:    {StatusOpen, StatusInProgress, StatusCancelled},
+			StatusDone:       {StatusOpen},
+			StatusCancelled:  {StatusOpen},
+		},
+	}
+}
+
+// Validate はワークフローの定義が正しいかを検証する
+//   - 状態の名前が正しい形式で、重複せず、グループを表す名前でないこと
+//   - 初期状態が終了状態以外の定義済みの状態であること
+//   - 終了状態が1つ以上あり、いずれも定義済みの状態であること
+//   - 状態遷移の変更前と変更後がいずれも定義済みの状態であること
+func (w *Workflow) Validate() error {
+	if len(w.Statuses) == 0 {
+		return errors.New("workflow must define at least one status")
+	}
+	seen := make(map[Status]bool, len(w.Statuses))
+	for _, s := range w.Statuses {
+		if !statusNamePattern.MatchString(string(s)) {
+			return fmt.Errorf("invalid status name %q: must start with a lowercase letter and contain only lowercase letters, digits and _", s)
+		}
+		if s == StatusGroupActive || s == StatusGroupClosed {
+			return fmt.Errorf("invalid status name %q: reserved for filtering", s)
+		}
+		if seen[s] {
+			return fmt.Errorf("duplicate status %q", s)
+		}
+		seen[s] = true
+	}
+
+	if !seen[w.Initial] {
+		return fmt.Errorf("initial status %q is not defined", w.Initial)
+	}
+	if len(w.Terminal) == 0 {
+		return errors.New("workflow must define at least one terminal status")
+	}
+	for _, s := range w.Terminal {
+		if !seen[s] {
+			return fmt.Errorf("terminal status %q is not defined", s)
+		}
+	}
+	if w.IsTerminal(w.Initial) {
+		return fmt.Errorf("initial status %q must not be terminal", w.Initial)
+	}
+
+	for from, targets := range w.Transitions {
+		if !seen[from] {
+			return fmt.Errorf("transition from undefined status %q", from)
+		}
+		for _, to := range targets {
+			if !seen[to] {
+				return fmt.Errorf("transition from %q to undefined status %q", from, to)
+			}
+		}
+	}
+	return nil
+}
+
+// Has は定義済みの状態かどうかを判定する
+func (w *Workflow) Has(s Status) bool {
+	return slices.Contains(w.Statuses, s)
+}
+
+// CheckStatus は定義済みの状態かどうかを検証する
+func (w *Workflow) CheckStatus(s Status) error {
+	if !w.Has(s) {
+		return fmt.Errorf("unknown status %q: must be one of %s", s, joinStatuses(w.Statuses))
+	}
+	return nil
+}
+
+// IsTerminal は終了状態（完了済みとして扱う状態）かどうかを判定する
+func (w *Workflow) IsTerminal(s Status) bool {
+	return slices.Contains(w.Terminal, s)
+}
+
+// IsClosed はタスクが終了状態かどうかを判定する
+func (w *Workflow) IsClosed(task *Task) bool {
+	return w.IsTerminal(task.Status)
+}
+
+// CompletedAt は before のタスクの状態を status に変更した際の完了日時を返す（before がnilの場合は作成）
+// 終了状態に変更した場合は now、終了状態の間で変更した場合は変更前の完了日時、終了状態以外に変更した場合は nil とする
+func (w *Workflow) CompletedAt(before *Task, status Status, now time.Time) *time.Time {
+	if !w.IsTerminal(status) {
+		return nil
+	}
+	if before != nil && w.IsClosed(before) {
+		return before.CompletedAt
+	}
+	return &now
+}
+
+// Done は done コマンドで変更する終了状態を返す
+func (w *Workflow) Done() Status {
+	return w.Terminal[0]
+}
+
+// Active は終了状態以外の状態を定義の順に返す
+func (w *Workflow) Active() []Status {
+	var active []Status
+	for _, s := range w.Statuses {
+		if !w.IsTerminal(s) {
+			active = append(active, s)
+		}
+	}
+	return active
+}
+
+// CheckTransition は from の状態から to の状態に変更できるかを検証する
+// 同じ状態への変更は常に許可する
+// 許可されていない場合は ErrIllegalTransition として判定できる *TransitionError を返す
+// from がワークフローにない状態（設定から削除された状態など）の場合は、定義済みのどの状態にも変更できる
+func (w *Workflow) CheckTransition(from, to Status) error {
+	if err := w.CheckStatus(to); err != nil {
+		return err
+	}
+	if from == to || !w.Has(from) {
+		return nil
+	}
+	allowed := w.Transitions[from]
+	if !slices.Contains(allowed, to) {
+		return &TransitionError{From: from, To: to, Allowed: allowed}
+	}
+	return nil
+}
+
+// Transition はタスクの状態を to に変更する
+// 許可されていない変更の場合はタスクを変更せずにエラーを返す
+func (w *Workflow) Transition(task *Task, to Status) error {
+	if err := w.CheckTransition(task.Status, to); err != nil {
+		return err
+	}
+	task.Status = to
+	return nil
+}
+
+// ParseStatus は状態の名前を解釈する（大文字小文字は区別せず、"-" と空白は "_" として扱う）
+func (w *Workflow) ParseStatus(s string) (Status, error) {
+	name := strings.ToLower(strings.TrimSpace(s))
+	name = strings.NewReplacer("-", "_", " ", "_").Replace(name)
+	status := Status(name)
+	if !w.Has(status) {
+		return "", fmt.Errorf("invalid status %q: must be one of %s", s, joinStatuses(w.Statuses))
+	}
+	return status, nil
+}
+
+func joinStatuses(statuses []Status) string {
+	names := make([]string, len(statuses))
+	for i, s := range statuses {
+		names[i] = string(s)
+	}
+	return strings.Join(names, ", ")
+}
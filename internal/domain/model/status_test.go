@@ -0,0 +1,173 @@
+package model_test
+
+import (
+	"OTakumi/todogo/internal/domain/model"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestWorkflow_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		modify  func(w *model.Workflow)
+		wantErr bool
+	}{
+		{name: "既定のワークフローは正しい", modify: func(w *model.Workflow) {}},
+		{name: "状態がない", modify: func(w *model.Workflow) { w.Statuses = nil }, wantErr: true},
+		{name: "状態の名前が不正", modify: func(w *model.Workflow) { w.Statuses = append(w.Statuses, "In Review") }, wantErr: true},
+		{name: "状態の名前がグループを表す名前", modify: func(w *model.Workflow) { w.Statuses = append(w.Statuses, "closed") }, wantErr: true},
+		{name: "状態が重複している", modify: func(w *model.Workflow) { w.Statuses = append(w.Statuses, model.StatusOpen) }, wantErr: true},
+		{name: "初期状態が未定義", modify: func(w *model.Workflow) { w.Initial = "todo" }, wantErr: true},
+		{name: "初期状態が終了状態", modify: func(w *model.Workflow) { w.Initial = model.StatusDone }, wantErr: true},
+		{name: "終了状態がない", modify: func(w *model.Workflow) { w.Terminal = nil }, wantErr: true},
+		{name: "終了状態が未定義", modify: func(w *model.Workflow) { w.Terminal = []model.Status{"archived"} }, wantErr: true},
+		{
+			name:    "状態遷移の変更後が未定義",
+			modify:  func(w *model.Workflow) { w.Transitions[model.StatusOpen] = []model.Status{"review"} },
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := model.DefaultWorkflow()
+			tt.modify(w)
+
+			err := w.Validate()
+			if tt.wantErr && err == nil {
+				t.Error("expected an error, but got nil")
+			}
+			if !tt.wantErr && err != nil {
+				t.Errorf("did not expect an error, but got: %v", err)
+			}
+		})
+	}
+}
+
+func TestWorkflow_Transition(t *testing.T) {
+	w := model.DefaultWorkflow()
+
+	t.Run("許可された状態に変更する", func(t *testing.T) {
+		task := &model.Task{Status: model.StatusOpen}
+		if err := w.Transition(task, model.StatusInProgress); err != nil {
+			t.Fatalf("did not expect an error, but got: %v", err)
+		}
+		if task.Status != model.StatusInProgress {
+			t.Errorf("expected Status to be in_progress, but got %v", task.Status)
+		}
+	})
+
+	t.Run("許可されていない状態への変更は型付きのエラーとなる", func(t *testing.T) {
+		task := &model.Task{Status: model.StatusBlocked}
+		err := w.Transition(task, model.StatusDone)
+
+		var transitionErr *model.TransitionError
+		if !errors.As(err, &transitionErr) {
+			t.Fatalf("expected a TransitionError, but got %v", err)
+		}
+		if transitionErr.From != model.StatusBlocked || transitionErr.To != model.StatusDone {
+			t.Errorf("unexpected transition in error: %s -> %s", transitionErr.From, transitionErr.To)
+		}
+		if !errors.Is(err, model.ErrIllegalTransition) {
+			t.Error("expected the error to be ErrIllegalTransition")
+		}
+		// 変更できない場合はタスクの状態を変更しないこと
+		if task.Status != model.StatusBlocked {
+			t.Errorf("expected Status to stay blocked, but got %v", task.Status)
+		}
+	})
+
+	t.Run("同じ状態への変更は許可する", func(t *testing.T) {
+		task := &model.Task{Status: model.StatusDone}
+		if err := w.Transition(task, model.StatusDone); err != nil {
+			t.Errorf("did not expect an error, but got: %v", err)
+		}
+	})
+
+	t.Run("未定義の状態には変更できない", func(t *testing.T) {
+		task := &model.Task{Status: model.StatusOpen}
+		err := w.Transition(task, "review")
+		if err == nil || errors.Is(err, model.ErrIllegalTransition) {
+			t.Errorf("expected an unknown status error, but got %v", err)
+		}
+	})
+
+	t.Run("ワークフローから削除された状態からは定義済みのどの状態にも変更できる", func(t *testing.T) {
+		task := &model.Task{Status: "review"}
+		if err := w.Transition(task, model.StatusDone); err != nil {
+			t.Errorf("did not expect an error, but got: %v", err)
+		}
+	})
+}
+
+func TestWorkflow_ParseStatus(t *testing.T) {
+	w := model.DefaultWorkflow()
+
+	tests := []struct {
+		input   string
+		want    model.Status
+		wantErr bool
+	}{
+		{input: "open", want: model.StatusOpen},
+		{input: "In-Progress", want: model.StatusInProgress},
+		{input: " in progress ", want: model.StatusInProgress},
+		{input: "Cancelled", want: model.StatusCancelled},
+		{input: "review", wantErr: true},
+		{input: "", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := w.ParseStatus(tt.input)
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("expected an error for %q, but got nil", tt.input)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("expected %v, but got %v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestStatus_Label(t *testing.T) {
+	if got := model.StatusInProgress.Label(); got != "In progress" {
+		t.Errorf("expected %q, but got %q", "In progress", got)
+	}
+}
+
+func TestWorkflow_CompletedAt(t *testing.T) {
+	w := model.DefaultWorkflow()
+	now := time.Date(2025, 6, 9, 9, 0, 0, 0, time.UTC)
+	earlier := now.Add(-time.Hour)
+	open := &model.Task{Status: model.StatusOpen}
+	done := &model.Task{Status: model.StatusDone, CompletedAt: &earlier}
+
+	tests := []struct {
+		name   string
+		before *model.Task
+		status model.Status
+		want   *time.Time
+	}{
+		{"終了状態で作成する", nil, model.StatusDone, &now},
+		{"終了状態以外で作成する", nil, model.StatusOpen, nil},
+		{"終了状態に変更する", open, model.StatusCancelled, &now},
+		{"終了状態の間で変更する", done, model.StatusCancelled, &earlier},
+		{"終了状態から再開する", done, model.StatusOpen, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := w.CompletedAt(tt.before, tt.status, now)
+			if (got == nil) != (tt.want == nil) || (got != nil && !got.Equal(*tt.want)) {
+				t.Errorf("expected %v, but got %v", tt.want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,22 @@
+package model
+
+import "fmt"
+
+// Progress はサブタスクの進捗
+// 子だけでなく、配下のすべての階層のサブタスクを数える
+type Progress struct {
+	// Done は完了済みのサブタスクの件数
+	Done int
+	// Total はすべてのサブタスクの件数
+	Total int
+}
+
+// HasSubtasks はサブタスクが1件以上あるかどうかを返す
+func (p Progress) HasSubtasks() bool {
+	return p.Total > 0
+}
+
+// String は進捗を "3/5" の形式で返す
+func (p Progress) String() string {
+	return fmt.Sprintf("%d/%d", p.Done, p.Total)
+}
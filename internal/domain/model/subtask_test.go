@@ -0,0 +1,26 @@
+package model_test
+
+import (
+	"OTakumi/todogo/internal/domain/model"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProgress_String(t *testing.T) {
+	assert.Equal(t, "3/5", model.Progress{Done: 3, Total: 5}.String())
+	assert.False(t, model.Progress{}.HasSubtasks())
+}
+
+func TestTask_Validate_Parent(t *testing.T) {
+	t.Run("自身を親タスクに指定した場合、エラーが返されること", func(t *testing.T) {
+		// Arrange
+		task := model.Task{ID: "id-1", Title: "Testing Go", ParentID: "id-1"}
+
+		// Act
+		err := task.ValidateUpdate(&task)
+
+		// Assert
+		assert.ErrorContains(t, err, "own parent")
+	})
+}
@@ -0,0 +1,131 @@
+package model
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// maxTagLength はタグ名の最大文字数
+const maxTagLength = 64
+
+// tagPattern はタグ名として使える形式
+// 空白やカンマを含まず、"+" や "-" で始まらないこと（コマンドラインでの追加・削除の指定と区別するため）
+var tagPattern = regexp.MustCompile(`^[\p{L}\p{N}_][\p{L}\p{N}_.:/-]*$`)
+
+// TagCount はタグと、そのタグが付いたタスクの件数
+type TagCount struct {
+	Name string
+	// Open は未完了のタスクの件数
+	Open int
+	// Total は完了済みを含むすべてのタスクの件数
+	Total int
+}
+
+// NormalizeTag はタグ名を正規化する（前後の空白を除き、小文字にする）
+// タグ名として使えない形式の場合はエラーを返す
+func NormalizeTag(s string) (string, error) {
+	tag := strings.ToLower(strings.TrimSpace(s))
+	if err := validateTag(tag); err != nil {
+		return "", err
+	}
+	return tag, nil
+}
+
+// NormalizeTags は複数のタグ名を正規化し、重複を除いて名前順に並べて返す
+func NormalizeTags(tags []string) ([]string, error) {
+	seen := make(map[string]bool, len(tags))
+	result := make([]string, 0, len(tags))
+	for _, s := range tags {
+		tag, err := NormalizeTag(s)
+		if err != nil {
+			return nil, err
+		}
+		if seen[tag] {
+			continue
+		}
+		seen[tag] = true
+		result = append(result, tag)
+	}
+	sort.Strings(result)
+	return result, nil
+}
+
+// HasTag はタスクに指定したタグが付いているかどうかを判定する
+func (t *Task) HasTag(tag string) bool {
+	for _, tg := range t.Tags {
+		if tg == tag {
+			return true
+		}
+	}
+	return false
+}
+
+// AddTags はタスクにタグを追加する（既に付いているタグは無視する）
+func (t *Task) AddTags(tags ...string) error {
+	merged, err := NormalizeTags(append(append([]string(nil), t.Tags...), tags...))
+	if err != nil {
+		return err
+	}
+	t.Tags = merged
+	return nil
+}
+
+// RemoveTags はタスクからタグを外す（付いていないタグは無視する）
+func (t *Task) RemoveTags(tags ...string) error {
+	remove, err := NormalizeTags(tags)
+	if err != nil {
+		return err
+	}
+
+	kept := make([]string, 0, len(t.Tags))
+	for _, tag := range t.Tags {
+		if !containsString(remove, tag) {
+			kept = append(kept, tag)
+		}
+	}
+	t.Tags = kept
+	return nil
+}
+
+// validateTags はタグ名がすべて正規化済みで、重複していないことを確認する
+func validateTags(tags []string) error {
+	seen := make(map[string]bool, len(tags))
+	for _, tag := range tags {
+		if err := validateTag(tag); err != nil {
+			return err
+		}
+		if tag != strings.ToLower(tag) {
+			return fmt.Errorf("invalid tag %q: must be lower case", tag)
+		}
+		if seen[tag] {
+			return fmt.Errorf("duplicate tag %q", tag)
+		}
+		seen[tag] = true
+	}
+	return nil
+}
+
+func validateTag(tag string) error {
+	if tag == "" {
+		return errors.New("tag cannot be empty")
+	}
+	if len([]rune(tag)) > maxTagLength {
+		return fmt.Errorf("invalid tag %q: must be at most %d characters", tag, maxTagLength)
+	}
+	if !tagPattern.MatchString(tag) {
+		return fmt.Errorf("invalid tag %q: must start with a letter, digit or _ and contain only those or . : / -", tag)
+	}
+	return nil
+}
+
+func containsString(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
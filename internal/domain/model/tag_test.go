@@ -0,0 +1,63 @@
+package model_test
+
+import (
+	"OTakumi/todogo/internal/domain/model"
+	"reflect"
+	"testing"
+)
+
+func TestNormalizeTags(t *testing.T) {
+	t.Run("小文字にして重複を除き、名前順に並べること", func(t *testing.T) {
+		// Act
+		tags, err := model.NormalizeTags([]string{" Work", "home", "work", "v1.2", "team/backend"})
+
+		// Assert
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		want := []string{"home", "team/backend", "v1.2", "work"}
+		if !reflect.DeepEqual(tags, want) {
+			t.Errorf("expected %v, but got %v", want, tags)
+		}
+	})
+
+	t.Run("タグ名として使えない形式の場合、エラーが返されること", func(t *testing.T) {
+		for _, tag := range []string{"", "two words", "-minus", "+plus", "a,b", ".hidden"} {
+			if _, err := model.NormalizeTags([]string{tag}); err == nil {
+				t.Errorf("expected an error for %q, but got nil", tag)
+			}
+		}
+	})
+}
+
+func TestTask_AddTagsAndRemoveTags(t *testing.T) {
+	// Arrange
+	task := &model.Task{Title: "Testing Go", Tags: []string{"work"}}
+
+	// Act
+	if err := task.AddTags("Urgent", "work"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := task.RemoveTags("WORK", "missing"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// Assert
+	if !reflect.DeepEqual(task.Tags, []string{"urgent"}) {
+		t.Errorf("expected [urgent], but got %v", task.Tags)
+	}
+	if !task.HasTag("urgent") || task.HasTag("work") {
+		t.Errorf("unexpected HasTag result for %v", task.Tags)
+	}
+}
+
+func TestTask_Validate_Tags(t *testing.T) {
+	t.Run("正規化されていない、または重複したタグの場合、エラーが返されること", func(t *testing.T) {
+		for _, tags := range [][]string{{"Work"}, {"work", "work"}, {"two words"}} {
+			task := model.Task{Title: "Testing Go", Tags: tags}
+			if err := task.Validate(); err == nil {
+				t.Errorf("expected an error for %v, but got nil", tags)
+			}
+		}
+	})
+}
@@ -2,25 +2,50 @@ package model
 
 import (
 	"errors"
+	"fmt"
 	"time"
 )
 
 type Task struct {
-	ID         string
-	Title      string
-	Deadline   *time.Time // NULLを許可するためポインタ型
-	IsComplete bool
-	CreatedAt  time.Time
-	UpdatedAt  time.Time
+	ID    string
+	Title string
+	// Description はタイトルに収まらない詳細（Markdown、未設定の場合は空）
+	Description string
+	Deadline    *time.Time // NULLを許可するためポインタ型
+	// StartAt は着手できるようになる日時（未設定の場合はすぐに着手できる）
+	// WaitUntil は先送りした日時（snooze で設定する、未設定の場合は先送りしていない）
+	// いずれかが未来のタスクは待機中として、既定の一覧には表示しない
+	StartAt   *time.Time
+	WaitUntil *time.Time
+	// Status はワークフロー上の状態（終了状態かどうかは Workflow.IsTerminal で判定する）
+	Status    Status
+	Priority  Priority
+	Tags      []string // 正規化済みのタグ名（名前順）
+	Project   string   // 所属するプロジェクトの名前（"work.backend" のようにドットで階層を区切る、未設定の場合は空）
+	ParentID  string   // 親タスクのID（サブタスクでない場合は空）
+	Subtasks  Progress // 配下のサブタスクの進捗（保存はせず、取得時に算出する）
+	BlockedBy []string // 先に完了する必要があるタスクのID（ID順、TaskRepository.AddDependency で保存する）
+	// RecurrenceID は繰り返しのルール（テンプレート）のID（繰り返さないタスクの場合は空）
+	// 同じ繰り返しから作成されたタスクは同じルールを参照するため、ルールの変更や停止は一箇所で行える
+	RecurrenceID string
+	Recurrence   *Recurrence  // RecurrenceID が指すルール（保存は TaskRepository.SaveRecurrence で行う）
+	Annotations  []Annotation // 日時付きの注記（追加順、TaskRepository.AddAnnotation で保存する）
+	Estimate     *Estimate    // 見積もり（作業時間またはストーリーポイントのいずれか、未設定の場合はnil）
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+	// CompletedAt は終了状態に変更した日時（終了状態でない場合はnil、保存時に Workflow.CompletedAt で設定する）
+	CompletedAt *time.Time
+	// DeletedAt はゴミ箱に移した日時（削除していない場合はnil、ゴミ箱のタスクを取得した場合にのみ設定される）
+	DeletedAt *time.Time
 }
 
 func NewTask(id string, title string) *Task {
 	return &Task{
-		ID:         id,
-		Title:      title,
-		IsComplete: false,
-		CreatedAt:  time.Now(),
-		UpdatedAt:  time.Now(),
+		ID:        id,
+		Title:     title,
+		Status:    StatusOpen,
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
 	}
 }
 
@@ -34,5 +59,132 @@ func (t *Task) Validate() error {
 		return errors.New("Deadline must be in the future")
 	}
 
+	if err := t.validateWait(); err != nil {
+		return err
+	}
+
+	if !t.Priority.IsValid() {
+		return fmt.Errorf("invalid priority %d", int(t.Priority))
+	}
+
+	if t.Estimate != nil {
+		if err := t.Estimate.Validate(); err != nil {
+			return err
+		}
+	}
+
+	if err := validateTags(t.Tags); err != nil {
+		return err
+	}
+
+	if t.Project != "" {
+		if err := validateProject(t.Project); err != nil {
+			return err
+		}
+	}
+
+	if t.ParentID != "" && t.ParentID == t.ID {
+		return errors.New("task cannot be its own parent")
+	}
+
 	return nil
 }
+
+// ValidateUpdate は既存タスクを更新する際の検証を行う
+// 期限切れのタスクでも完了や編集ができるよう、締切が変更された場合のみ未来であることを確認する
+func (t *Task) ValidateUpdate(original *Task) error {
+	if t.Title == "" {
+		return errors.New("Title is required")
+	}
+
+	if t.Deadline != nil && t.Deadline.Before(time.Now()) && !sameDeadline(t.Deadline, original.Deadline) {
+		return errors.New("Deadline must be in the future")
+	}
+
+	if err := t.validateWait(); err != nil {
+		return err
+	}
+
+	if !t.Priority.IsValid() {
+		return fmt.Errorf("invalid priority %d", int(t.Priority))
+	}
+
+	if t.Estimate != nil {
+		if err := t.Estimate.Validate(); err != nil {
+			return err
+		}
+	}
+
+	if err := validateTags(t.Tags); err != nil {
+		return err
+	}
+
+	if t.Project != "" {
+		if err := validateProject(t.Project); err != nil {
+			return err
+		}
+	}
+
+	if t.ParentID != "" && t.ParentID == t.ID {
+		return errors.New("task cannot be its own parent")
+	}
+
+	return nil
+}
+
+// validateWait は開始日時と待機日時が締切より後でないことを確認する
+// 締切より後まで待機すると、一覧に表示される前に期限切れとなるため
+func (t *Task) validateWait() error {
+	if t.Deadline == nil {
+		return nil
+	}
+	if t.StartAt != nil && t.StartAt.After(*t.Deadline) {
+		return errors.New("Start date must not be after the deadline")
+	}
+	if t.WaitUntil != nil && t.WaitUntil.After(*t.Deadline) {
+		return errors.New("Wait date must not be after the deadline")
+	}
+	return nil
+}
+
+// HiddenUntil は待機が終わり、着手できるようになる日時を返す
+// 開始日時と待機日時のうち遅い方とし、いずれも未設定の場合はnilを返す
+func (t *Task) HiddenUntil() *time.Time {
+	switch {
+	case t.StartAt == nil:
+		return t.WaitUntil
+	case t.WaitUntil == nil || t.StartAt.After(*t.WaitUntil):
+		return t.StartAt
+	default:
+		return t.WaitUntil
+	}
+}
+
+// IsWaiting は now の時点でタスクが待機中（開始日時または待機日時が未来）かどうかを判定する
+func (t *Task) IsWaiting(now time.Time) bool {
+	until := t.HiddenUntil()
+	return until != nil && until.After(now)
+}
+
+// Snooze は待機日時を d だけ先に延ばす
+// 待機中の場合は現在の待機日時から、そうでない場合は now から延ばす
+func (t *Task) Snooze(d time.Duration, now time.Time) error {
+	if d <= 0 {
+		return errors.New("snooze duration must be positive")
+	}
+	base := now
+	if t.WaitUntil != nil && t.WaitUntil.After(now) {
+		base = *t.WaitUntil
+	}
+	until := base.Add(d)
+	t.WaitUntil = &until
+	return nil
+}
+
+// sameDeadline は2つの締切日時が同じかどうかを判定する
+func sameDeadline(a, b *time.Time) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return a.Equal(*b)
+}
@@ -0,0 +1,130 @@
+package model
+
+import (
+	"strings"
+	"time"
+)
+
+// TaskEventKind は履歴に記録するタスクの操作の種類
+type TaskEventKind string
+
+const (
+	// TaskCreated はタスクの作成
+	TaskCreated TaskEventKind = "created"
+	// TaskChanged はタスクの項目の変更
+	TaskChanged TaskEventKind = "changed"
+	// TaskCompleted は終了状態への状態の変更
+	TaskCompleted TaskEventKind = "completed"
+	// TaskDeleted はタスクの削除
+	TaskDeleted TaskEventKind = "deleted"
+	// TaskRestored はゴミ箱からのタスクの復元
+	TaskRestored TaskEventKind = "restored"
+	// TaskPurged はゴミ箱のタスクの完全な削除
+	TaskPurged TaskEventKind = "purged"
+)
+
+// TaskEvent はタスクの履歴（監査ログ）に記録する1件の操作
+// 項目の変更は、変更した項目ごとに1件の記録とする
+type TaskEvent struct {
+	ID     int64
+	TaskID string
+	Kind   TaskEventKind
+	// Field は変更した項目の名前（作成、削除、復元、完全な削除の場合は空、完了の場合は status）
+	Field string
+	// OldValue と NewValue は変更前と変更後の値（taskFields の形式、未設定の場合は空）
+	// 作成と復元の場合は NewValue に、削除と完全な削除の場合は OldValue にタイトルを設定する
+	OldValue string
+	NewValue string
+	// Actor は操作した利用者の名前（不明な場合は空）
+	Actor      string
+	OccurredAt time.Time
+}
+
+// taskField は履歴に記録するタスクの項目と、その値を文字列に変換する関数
+type taskField struct {
+	name  string
+	value func(t *Task) string
+}
+
+// taskFields は履歴に記録するタスクの項目（記録する順序）
+// 日時はUTCのRFC 3339形式、タグは空白区切りとする
+// 依存関係と注記はタスクの保存とは別に追加し、サブタスクの進捗は保存しないため、いずれも記録しない
+var taskFields = []taskField{
+	{"title", func(t *Task) string { return t.Title }},
+	{"status", func(t *Task) string { return string(t.Status) }},
+	{"deadline", func(t *Task) string { return eventTime(t.Deadline) }},
+	{"start_at", func(t *Task) string { return eventTime(t.StartAt) }},
+	{"wait_until", func(t *Task) string { return eventTime(t.WaitUntil) }},
+	{"priority", func(t *Task) string {
+		if t.Priority == PriorityNone {
+			return ""
+		}
+		return t.Priority.String()
+	}},
+	{"estimate", func(t *Task) string {
+		if t.Estimate == nil {
+			return ""
+		}
+		return t.Estimate.String()
+	}},
+	{"tags", func(t *Task) string { return strings.Join(t.Tags, " ") }},
+	{"project", func(t *Task) string { return t.Project }},
+	{"parent_id", func(t *Task) string { return t.ParentID }},
+	{"recurrence_id", func(t *Task) string { return t.RecurrenceID }},
+	{"description", func(t *Task) string { return t.Description }},
+}
+
+func eventTime(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return t.UTC().Format(time.RFC3339)
+}
+
+// NewTaskEvents は before から after へのタスクの変更を、履歴に記録する操作に変換する
+//   - before がnilの場合は作成、after がnilの場合は削除として1件を返す
+//   - それ以外の場合は変更した項目ごとに1件を返し、変更がない場合は空とする
+//   - 状態を終了状態に変更した場合は、状態の変更を完了として記録する
+func NewTaskEvents(before, after *Task, wf *Workflow, actor string, at time.Time) []TaskEvent {
+	switch {
+	case before == nil && after == nil:
+		return nil
+	case before == nil:
+		return []TaskEvent{{TaskID: after.ID, Kind: TaskCreated, NewValue: after.Title, Actor: actor, OccurredAt: at}}
+	case after == nil:
+		return []TaskEvent{{TaskID: before.ID, Kind: TaskDeleted, OldValue: before.Title, Actor: actor, OccurredAt: at}}
+	}
+
+	var events []TaskEvent
+	for _, f := range taskFields {
+		oldValue, newValue := f.value(before), f.value(after)
+		if oldValue == newValue {
+			continue
+		}
+		kind := TaskChanged
+		if f.name == "status" && wf.IsClosed(after) && !wf.IsClosed(before) {
+			kind = TaskCompleted
+		}
+		events = append(events, TaskEvent{
+			TaskID:     after.ID,
+			Kind:       kind,
+			Field:      f.name,
+			OldValue:   oldValue,
+			NewValue:   newValue,
+			Actor:      actor,
+			OccurredAt: at,
+		})
+	}
+	return events
+}
+
+// NewTrashEvent はゴミ箱からの復元（TaskRestored）または完全な削除（TaskPurged）を、履歴に記録する操作に変換する
+func NewTrashEvent(kind TaskEventKind, task *Task, actor string, at time.Time) TaskEvent {
+	event := TaskEvent{TaskID: task.ID, Kind: kind, Actor: actor, OccurredAt: at}
+	if kind == TaskPurged {
+		event.OldValue = task.Title
+	} else {
+		event.NewValue = task.Title
+	}
+	return event
+}
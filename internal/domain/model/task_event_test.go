@@ -0,0 +1,55 @@
+package model_test
+
+import (
+	"OTakumi/todogo/internal/domain/model"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewTaskEvents(t *testing.T) {
+	w := model.DefaultWorkflow()
+	at := time.Date(2025, 6, 9, 9, 0, 0, 0, time.UTC)
+	deadline := time.Date(2025, 6, 30, 18, 0, 0, 0, time.FixedZone("JST", 9*60*60))
+	before := &model.Task{ID: "id-1", Title: "Write report", Status: model.StatusOpen, Tags: []string{"work"}}
+
+	t.Run("作成と削除はタイトルを記録する", func(t *testing.T) {
+		assert.Equal(t, []model.TaskEvent{
+			{TaskID: "id-1", Kind: model.TaskCreated, NewValue: "Write report", Actor: "alice", OccurredAt: at},
+		}, model.NewTaskEvents(nil, before, w, "alice", at))
+		assert.Equal(t, []model.TaskEvent{
+			{TaskID: "id-1", Kind: model.TaskDeleted, OldValue: "Write report", Actor: "alice", OccurredAt: at},
+		}, model.NewTaskEvents(before, nil, w, "alice", at))
+	})
+
+	t.Run("変更した項目ごとに記録し、終了状態への変更は完了とする", func(t *testing.T) {
+		after := *before
+		after.Status = model.StatusDone
+		after.Deadline = &deadline
+		after.Tags = []string{"home", "work"}
+
+		events := model.NewTaskEvents(before, &after, w, "", at)
+
+		assert.Equal(t, []model.TaskEvent{
+			{TaskID: "id-1", Kind: model.TaskCompleted, Field: "status", OldValue: "open", NewValue: "done", OccurredAt: at},
+			{TaskID: "id-1", Kind: model.TaskChanged, Field: "deadline", NewValue: "2025-06-30T09:00:00Z", OccurredAt: at},
+			{TaskID: "id-1", Kind: model.TaskChanged, Field: "tags", OldValue: "work", NewValue: "home work", OccurredAt: at},
+		}, events)
+	})
+
+	t.Run("終了状態の間の変更と再開は完了としない", func(t *testing.T) {
+		done := *before
+		done.Status = model.StatusDone
+		cancelled := done
+		cancelled.Status = model.StatusCancelled
+
+		assert.Equal(t, model.TaskChanged, model.NewTaskEvents(&done, &cancelled, w, "", at)[0].Kind)
+		assert.Equal(t, model.TaskChanged, model.NewTaskEvents(&done, before, w, "", at)[0].Kind)
+	})
+
+	t.Run("変更がない場合は記録しない", func(t *testing.T) {
+		same := *before
+		assert.Empty(t, model.NewTaskEvents(before, &same, w, "", at))
+	})
+}
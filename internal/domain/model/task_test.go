@@ -26,9 +26,9 @@ func TestNewTask(t *testing.T) {
 			t.Errorf("expected Title to be '%s', but got '%s'", title, task.Title)
 		}
 
-		// 作成直後なので、IsCompleteがfalseになっていること
-		if task.IsComplete != false {
-			t.Errorf("expected Completed to be false, but got %v", task.IsComplete)
+		// 作成直後なので、Statusがopenになっていること
+		if task.Status != model.StatusOpen {
+			t.Errorf("expected Status to be open, but got %v", task.Status)
 		}
 
 		// CreatedAtに作成日時が設定されていること
@@ -77,3 +77,150 @@ func TestTask_Validate(t *testing.T) {
 		}
 	})
 }
+
+func TestTask_ValidateUpdate(t *testing.T) {
+	t.Run("締切が変更されていなければ過去の締切でもnilが返されること", func(t *testing.T) {
+		// Arrange
+		past := time.Now().Add(-time.Hour)
+		original := model.Task{Title: "Test Task", Deadline: &past}
+		task := model.Task{Title: "Test Task", Deadline: &past, Status: model.StatusDone}
+
+		// Act
+		err := task.ValidateUpdate(&original)
+
+		// Assert
+		if err != nil {
+			t.Errorf("did not expect an error, but got: %v", err)
+		}
+	})
+
+	t.Run("締切を過去の日時に変更した場合、エラーが返されること", func(t *testing.T) {
+		// Arrange
+		past := time.Now().Add(-time.Hour)
+		original := model.Task{Title: "Test Task"}
+		task := model.Task{Title: "Test Task", Deadline: &past}
+
+		// Act
+		err := task.ValidateUpdate(&original)
+
+		// Assert
+		if err == nil {
+			t.Error("Expected an error, but got nil")
+		}
+	})
+
+	t.Run("タイトルを空に変更した場合、エラーが返されること", func(t *testing.T) {
+		// Arrange
+		original := model.Task{Title: "Test Task"}
+		task := model.Task{Title: ""}
+
+		// Act
+		err := task.ValidateUpdate(&original)
+
+		// Assert
+		if err == nil {
+			t.Error("Expected an error, but got nil")
+		}
+	})
+}
+
+func TestTask_Validate_WaitDates(t *testing.T) {
+	deadline := time.Now().Add(48 * time.Hour)
+	before := deadline.Add(-time.Hour)
+	after := deadline.Add(time.Hour)
+
+	tests := []struct {
+		name    string
+		task    model.Task
+		wantErr bool
+	}{
+		{name: "締切より前の開始日時と待機日時は受け付ける", task: model.Task{Title: "Test Task", Deadline: &deadline, StartAt: &before, WaitUntil: &before}},
+		{name: "締切と同じ待機日時は受け付ける", task: model.Task{Title: "Test Task", Deadline: &deadline, WaitUntil: &deadline}},
+		{name: "締切がない場合はいつまでも待機できる", task: model.Task{Title: "Test Task", StartAt: &after, WaitUntil: &after}},
+		{name: "締切より後の待機日時はエラー", task: model.Task{Title: "Test Task", Deadline: &deadline, WaitUntil: &after}, wantErr: true},
+		{name: "締切より後の開始日時はエラー", task: model.Task{Title: "Test Task", Deadline: &deadline, StartAt: &after}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// 作成時と更新時のどちらでも同じ検証を行うこと
+			if err := tt.task.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err := tt.task.ValidateUpdate(&tt.task); (err != nil) != tt.wantErr {
+				t.Errorf("ValidateUpdate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestTask_IsWaiting(t *testing.T) {
+	now := time.Date(2025, 1, 10, 9, 0, 0, 0, time.UTC)
+	past := now.Add(-time.Hour)
+	future := now.Add(time.Hour)
+	later := now.Add(2 * time.Hour)
+
+	tests := []struct {
+		name      string
+		task      model.Task
+		want      bool
+		wantUntil *time.Time
+	}{
+		{name: "開始日時と待機日時がない場合は待機しない", task: model.Task{}},
+		{name: "過去の開始日時は待機しない", task: model.Task{StartAt: &past}, wantUntil: &past},
+		{name: "未来の開始日時は待機する", task: model.Task{StartAt: &future}, want: true, wantUntil: &future},
+		{name: "未来の待機日時は待機する", task: model.Task{WaitUntil: &future}, want: true, wantUntil: &future},
+		{name: "遅い方の日時まで待機する", task: model.Task{StartAt: &later, WaitUntil: &future}, want: true, wantUntil: &later},
+		{name: "待機日時が過ぎても開始日時が未来なら待機する", task: model.Task{StartAt: &future, WaitUntil: &past}, want: true, wantUntil: &future},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.task.IsWaiting(now); got != tt.want {
+				t.Errorf("IsWaiting() = %v, want %v", got, tt.want)
+			}
+			if got := tt.task.HiddenUntil(); got != tt.wantUntil {
+				t.Errorf("HiddenUntil() = %v, want %v", got, tt.wantUntil)
+			}
+		})
+	}
+}
+
+func TestTask_Snooze(t *testing.T) {
+	now := time.Date(2025, 1, 10, 9, 0, 0, 0, time.UTC)
+
+	t.Run("待機していない場合は現在時刻から延ばす", func(t *testing.T) {
+		past := now.Add(-24 * time.Hour)
+		task := model.Task{WaitUntil: &past}
+
+		if err := task.Snooze(72*time.Hour, now); err != nil {
+			t.Fatalf("did not expect an error, but got: %v", err)
+		}
+		if want := now.Add(72 * time.Hour); !task.WaitUntil.Equal(want) {
+			t.Errorf("expected WaitUntil to be %v, but got %v", want, task.WaitUntil)
+		}
+	})
+
+	t.Run("待機中の場合は待機日時から延ばす", func(t *testing.T) {
+		future := now.Add(24 * time.Hour)
+		task := model.Task{WaitUntil: &future}
+
+		if err := task.Snooze(72*time.Hour, now); err != nil {
+			t.Fatalf("did not expect an error, but got: %v", err)
+		}
+		if want := now.Add(96 * time.Hour); !task.WaitUntil.Equal(want) {
+			t.Errorf("expected WaitUntil to be %v, but got %v", want, task.WaitUntil)
+		}
+	})
+
+	t.Run("正でない期間はエラー", func(t *testing.T) {
+		task := model.Task{}
+
+		if err := task.Snooze(0, now); err == nil {
+			t.Error("Expected an error, but got nil")
+		}
+		if task.WaitUntil != nil {
+			t.Errorf("expected WaitUntil to be unchanged, but got %v", task.WaitUntil)
+		}
+	})
+}
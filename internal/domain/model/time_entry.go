@@ -0,0 +1,105 @@
+package model
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// TimeEntry はタスクに費やした作業時間の記録
+// start と stop で計測した区間と、log で手入力した区間のどちらも同じ形式で記録する
+type TimeEntry struct {
+	// ID は記録の連番
+	ID int64
+	// TaskID は作業したタスクのID
+	TaskID string
+	// User は作業した利用者の名前（計測中の記録は利用者ごとに1件まで）
+	User string
+	// StartedAt は作業を始めた日時
+	StartedAt time.Time
+	// EndedAt は作業を終えた日時（計測中の場合はnil）
+	EndedAt *time.Time
+}
+
+// Running は計測中の記録かどうかを判定する
+func (e TimeEntry) Running() bool {
+	return e.EndedAt == nil
+}
+
+// Duration は記録の作業時間を返す（計測中の場合は now までの時間）
+func (e TimeEntry) Duration(now time.Time) time.Duration {
+	return e.Overlap(e.StartedAt, e.end(now))
+}
+
+// Overlap は記録のうち from から to の直前までの期間に含まれる作業時間を返す
+// 計測中の記録は now まで続いているものとして扱うため、to には現在時刻以前を指定すること
+func (e TimeEntry) Overlap(from, to time.Time) time.Duration {
+	start := e.StartedAt
+	if start.Before(from) {
+		start = from
+	}
+	end := to
+	if e.EndedAt != nil && e.EndedAt.Before(to) {
+		end = *e.EndedAt
+	}
+	if !end.After(start) {
+		return 0
+	}
+	return end.Sub(start)
+}
+
+func (e TimeEntry) end(now time.Time) time.Time {
+	if e.EndedAt != nil {
+		return *e.EndedAt
+	}
+	return now
+}
+
+// Validate は記録の内容が正しいかを検証する
+func (e TimeEntry) Validate() error {
+	if e.TaskID == "" {
+		return errors.New("time entry task ID is required")
+	}
+	if strings.TrimSpace(e.User) == "" {
+		return errors.New("time entry user is required")
+	}
+	if e.StartedAt.IsZero() {
+		return errors.New("time entry start time is required")
+	}
+	if e.EndedAt != nil && e.EndedAt.Before(e.StartedAt) {
+		return errors.New("time entry must not end before it starts")
+	}
+	return nil
+}
+
+// TotalDuration は記録の作業時間の合計を返す（計測中の記録は now までの時間を含める）
+func TotalDuration(entries []TimeEntry, now time.Time) time.Duration {
+	var total time.Duration
+	for _, e := range entries {
+		total += e.Duration(now)
+	}
+	return total
+}
+
+// ErrTimerRunning は利用者の計測中の記録が既にあることを表すエラー
+// errors.Is で判定できるよう、TimerRunningError はこのエラーとして扱われる
+var ErrTimerRunning = errors.New("timer already running")
+
+// TimerRunningError は既に計測中の記録を保持するエラー型
+type TimerRunningError struct {
+	Entry TimeEntry
+}
+
+func (e *TimerRunningError) Error() string {
+	return fmt.Sprintf("timer already running on task %s since %s; stop it first",
+		e.Entry.TaskID, e.Entry.StartedAt.Format("2006-01-02 15:04"))
+}
+
+// Is は errors.Is(err, ErrTimerRunning) を成立させるための実装
+func (e *TimerRunningError) Is(target error) bool {
+	return target == ErrTimerRunning
+}
+
+// ErrNoTimerRunning は利用者の計測中の記録がないことを表すエラー
+var ErrNoTimerRunning = errors.New("no timer running")
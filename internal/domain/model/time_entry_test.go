@@ -0,0 +1,66 @@
+package model_test
+
+import (
+	"OTakumi/todogo/internal/domain/model"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTimeEntry_Duration(t *testing.T) {
+	start := time.Date(2025, 6, 9, 9, 0, 0, 0, time.UTC)
+	end := start.Add(90 * time.Minute)
+	now := start.Add(3 * time.Hour)
+
+	t.Run("終了した記録は開始から終了までの時間を返す", func(t *testing.T) {
+		entry := model.TimeEntry{StartedAt: start, EndedAt: &end}
+
+		assert.False(t, entry.Running())
+		assert.Equal(t, 90*time.Minute, entry.Duration(now))
+	})
+
+	t.Run("計測中の記録は現在時刻までの時間を返す", func(t *testing.T) {
+		entry := model.TimeEntry{StartedAt: start}
+
+		assert.True(t, entry.Running())
+		assert.Equal(t, 3*time.Hour, entry.Duration(now))
+	})
+
+	t.Run("合計には計測中の記録を含める", func(t *testing.T) {
+		entries := []model.TimeEntry{{StartedAt: start, EndedAt: &end}, {StartedAt: end}}
+
+		assert.Equal(t, 3*time.Hour, model.TotalDuration(entries, now))
+	})
+}
+
+func TestTimeEntry_Overlap(t *testing.T) {
+	start := time.Date(2025, 6, 9, 9, 0, 0, 0, time.UTC)
+	end := start.Add(2 * time.Hour)
+	entry := model.TimeEntry{StartedAt: start, EndedAt: &end}
+
+	tests := []struct {
+		name     string
+		from, to time.Time
+		want     time.Duration
+	}{
+		{"期間に含まれる", start.Add(-time.Hour), end.Add(time.Hour), 2 * time.Hour},
+		{"期間の開始をまたぐ", start.Add(30 * time.Minute), end.Add(time.Hour), 90 * time.Minute},
+		{"期間の終了をまたぐ", start.Add(-time.Hour), start.Add(45 * time.Minute), 45 * time.Minute},
+		{"期間と重ならない", end, end.Add(time.Hour), 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, entry.Overlap(tt.from, tt.to))
+		})
+	}
+}
+
+func TestTimeEntry_Validate(t *testing.T) {
+	start := time.Date(2025, 6, 9, 9, 0, 0, 0, time.UTC)
+	before := start.Add(-time.Minute)
+
+	assert.NoError(t, model.TimeEntry{TaskID: "id-1", User: "alice", StartedAt: start}.Validate())
+	assert.ErrorContains(t, model.TimeEntry{TaskID: "id-1", StartedAt: start}.Validate(), "user is required")
+	assert.ErrorContains(t, model.TimeEntry{TaskID: "id-1", User: "alice", StartedAt: start, EndedAt: &before}.Validate(), "end before")
+}
@@ -0,0 +1,33 @@
+package model
+
+import "time"
+
+// Timesheet は期間内の作業時間をタスクとプロジェクトごとに集計したもの
+type Timesheet struct {
+	// User は集計した利用者の名前
+	User string
+	// From から To の直前までを集計の期間とする
+	From time.Time
+	To   time.Time
+	// Tasks はタスクごとの作業時間（作業時間の長い順、期間内に作業のないタスクは含めない）
+	Tasks []TimesheetTask
+	// Projects はプロジェクトごとの作業時間（上位のプロジェクトに配下のプロジェクトの時間を合算し、階層順に並べる）
+	// プロジェクトに属さないタスクの時間は、名前が空のプロジェクトとして最後に含める
+	Projects []TimesheetProject
+	// Total は期間内の作業時間の合計
+	Total time.Duration
+}
+
+// TimesheetTask はタスクごとの作業時間
+type TimesheetTask struct {
+	TaskID   string
+	Title    string
+	Project  string
+	Duration time.Duration
+}
+
+// TimesheetProject はプロジェクトごとの作業時間
+type TimesheetProject struct {
+	Name     string
+	Duration time.Duration
+}
@@ -0,0 +1,143 @@
+package service
+
+import (
+	"OTakumi/todogo/internal/domain/model"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrDependencyCycle は依存関係をたどるとタスク自身に戻る指定であることを表すエラー
+var ErrDependencyCycle = errors.New("dependency would create a cycle")
+
+// ChainLink は依存関係の連鎖に含まれるタスク
+type ChainLink struct {
+	Task *model.Task
+	// Depth は起点のタスクからの距離（直接の依存関係は1）
+	Depth int
+}
+
+// DependencyGraph はタスク同士の依存関係（あるタスクより先に完了する必要があるタスク）
+type DependencyGraph struct {
+	tasks    map[string]*model.Task
+	blocking map[string][]*model.Task
+}
+
+// NewDependencyGraph はタスクの一覧と、各タスクの BlockedBy から依存関係を組み立てる
+// 後続のタスクの順序は一覧での順序を維持する
+func NewDependencyGraph(tasks []*model.Task) *DependencyGraph {
+	g := &DependencyGraph{
+		tasks:    make(map[string]*model.Task, len(tasks)),
+		blocking: make(map[string][]*model.Task),
+	}
+	for _, task := range tasks {
+		g.tasks[task.ID] = task
+	}
+	for _, task := range tasks {
+		for _, id := range task.BlockedBy {
+			g.blocking[id] = append(g.blocking[id], task)
+		}
+	}
+	return g
+}
+
+// ValidateDependency は taskID のタスクが blockedByID のタスクの完了を待つ依存関係を追加できるかを検証する
+//   - 両方のタスクが存在し、同じタスクでないこと
+//   - 追加しても依存関係が循環しないこと
+func (g *DependencyGraph) ValidateDependency(taskID, blockedByID string) error {
+	for _, id := range []string{taskID, blockedByID} {
+		if _, ok := g.tasks[id]; !ok {
+			return fmt.Errorf("task %s not found", id)
+		}
+	}
+	if taskID == blockedByID {
+		return errors.New("task cannot be blocked by itself")
+	}
+
+	// 先行するタスクの上流にタスク自身が現れれば、追加すると循環となる
+	if path := g.path(blockedByID, taskID); path != nil {
+		return fmt.Errorf("%w: %s", ErrDependencyCycle, strings.Join(append([]string{taskID}, path...), " -> "))
+	}
+	return nil
+}
+
+// path は from のタスクから BlockedBy をたどって to のタスクに至る経路のIDを返す（至らない場合はnil）
+func (g *DependencyGraph) path(from, to string) []string {
+	seen := map[string]bool{}
+	var walk func(id string) []string
+	walk = func(id string) []string {
+		if id == to {
+			return []string{id}
+		}
+		if seen[id] || g.tasks[id] == nil {
+			return nil
+		}
+		seen[id] = true
+		for _, next := range g.tasks[id].BlockedBy {
+			if rest := walk(next); rest != nil {
+				return append([]string{id}, rest...)
+			}
+		}
+		return nil
+	}
+	return walk(from)
+}
+
+// Upstream はタスクより先に完了する必要があるすべてのタスクを、依存関係をたどった順で返す
+// 複数の経路でたどれるタスクは、最初に現れた位置にのみ含める
+func (g *DependencyGraph) Upstream(id string) []ChainLink {
+	return g.chain(id, func(task *model.Task) []*model.Task {
+		var blockers []*model.Task
+		for _, blockerID := range task.BlockedBy {
+			if blocker, ok := g.tasks[blockerID]; ok {
+				blockers = append(blockers, blocker)
+			}
+		}
+		return blockers
+	})
+}
+
+// Downstream はタスクの完了を（間接的に）待っているすべてのタスクを、依存関係をたどった順で返す
+func (g *DependencyGraph) Downstream(id string) []ChainLink {
+	return g.chain(id, func(task *model.Task) []*model.Task {
+		return g.blocking[task.ID]
+	})
+}
+
+func (g *DependencyGraph) chain(id string, next func(task *model.Task) []*model.Task) []ChainLink {
+	start, ok := g.tasks[id]
+	if !ok {
+		return nil
+	}
+
+	var result []ChainLink
+	seen := map[string]bool{id: true}
+	var walk func(task *model.Task, depth int)
+	walk = func(task *model.Task, depth int) {
+		for _, n := range next(task) {
+			if seen[n.ID] {
+				continue
+			}
+			seen[n.ID] = true
+			result = append(result, ChainLink{Task: n, Depth: depth})
+			walk(n, depth+1)
+		}
+	}
+	walk(start, 1)
+	return result
+}
+
+// IsReady はタスクが未完了で、先に完了する必要があるタスクがすべて完了済みかどうかを判定する
+// 完了済みかどうかはワークフローの終了状態かどうかで判定する
+func (g *DependencyGraph) IsReady(id string, wf *model.Workflow) bool {
+	task, ok := g.tasks[id]
+	if !ok || wf.IsClosed(task) {
+		return false
+	}
+	for _, blockerID := range task.BlockedBy {
+		if blocker, ok := g.tasks[blockerID]; ok && !wf.IsClosed(blocker) {
+			return false
+		}
+	}
+	return true
+}
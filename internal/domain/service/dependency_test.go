@@ -0,0 +1,81 @@
+package service_test
+
+import (
+	"OTakumi/todogo/internal/domain/model"
+	"OTakumi/todogo/internal/domain/service"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// dependencyFixture は次の依存関係のタスクを返す（矢印の先のタスクの完了を待つ）
+//
+//	deploy -> test -> build (完了)
+//	deploy -> docs
+//	test   -> lint
+//	other
+func dependencyFixture() []*model.Task {
+	return []*model.Task{
+		{ID: "build", Title: "build", Status: model.StatusDone},
+		{ID: "lint", Title: "lint"},
+		{ID: "test", Title: "test", BlockedBy: []string{"build", "lint"}},
+		{ID: "docs", Title: "docs"},
+		{ID: "deploy", Title: "deploy", BlockedBy: []string{"docs", "test"}},
+		{ID: "other", Title: "other"},
+	}
+}
+
+func chainIDs(links []service.ChainLink) []string {
+	ids := make([]string, len(links))
+	for i, link := range links {
+		ids[i] = link.Task.ID
+	}
+	return ids
+}
+
+func TestDependencyGraph_Chains(t *testing.T) {
+	g := service.NewDependencyGraph(dependencyFixture())
+
+	t.Run("上流のタスクを依存関係をたどった順で返す", func(t *testing.T) {
+		upstream := g.Upstream("deploy")
+
+		assert.Equal(t, []string{"docs", "test", "build", "lint"}, chainIDs(upstream))
+		assert.Equal(t, []int{1, 1, 2, 2}, []int{upstream[0].Depth, upstream[1].Depth, upstream[2].Depth, upstream[3].Depth})
+	})
+
+	t.Run("下流のタスクを依存関係をたどった順で返す", func(t *testing.T) {
+		assert.Equal(t, []string{"test", "deploy"}, chainIDs(g.Downstream("build")))
+		assert.Empty(t, g.Downstream("deploy"))
+		assert.Empty(t, g.Upstream("other"))
+	})
+
+	t.Run("先行するタスクがすべて完了済みの未完了のタスクのみ着手可能とする", func(t *testing.T) {
+		assert.True(t, g.IsReady("lint", model.DefaultWorkflow()))
+		assert.True(t, g.IsReady("other", model.DefaultWorkflow()))
+		assert.False(t, g.IsReady("test", model.DefaultWorkflow()))
+		assert.False(t, g.IsReady("build", model.DefaultWorkflow()))
+	})
+}
+
+func TestDependencyGraph_ValidateDependency(t *testing.T) {
+	g := service.NewDependencyGraph(dependencyFixture())
+
+	t.Run("依存関係を追加できること", func(t *testing.T) {
+		assert.NoError(t, g.ValidateDependency("other", "deploy"))
+	})
+
+	t.Run("存在しないタスクを指定した場合、エラーが返されること", func(t *testing.T) {
+		assert.ErrorContains(t, g.ValidateDependency("other", "missing"), "task missing not found")
+	})
+
+	t.Run("タスク自身を指定した場合、エラーが返されること", func(t *testing.T) {
+		assert.ErrorContains(t, g.ValidateDependency("other", "other"), "blocked by itself")
+	})
+
+	t.Run("下流のタスクを指定した場合、循環する経路を含むエラーが返されること", func(t *testing.T) {
+		err := g.ValidateDependency("lint", "deploy")
+
+		assert.ErrorIs(t, err, service.ErrDependencyCycle)
+		assert.ErrorContains(t, err, "lint -> deploy -> test -> lint")
+	})
+}
@@ -0,0 +1,129 @@
+package service
+
+import (
+	"OTakumi/todogo/internal/domain/model"
+	"sort"
+	"time"
+)
+
+// BuildEstimateReport は見積もりのある完了したタスクについて、見積もりと実績を比較する
+//   - 完了したタスクはワークフローの done の状態のタスクとし、取り消したタスクなどは含めない
+//   - 実績は作業時間の記録がある場合はその合計、ない場合は作成から完了までの時間とする
+//   - 完了日時が記録されていないタスクは、最後に更新された日時を完了日時とする
+//   - ポイントの見積もりは作業時間と直接比べられないため、全体の1ポイントあたりの実績の時間を基準として比を求める
+func BuildEstimateReport(tasks []*model.Task, entries []model.TimeEntry, wf *model.Workflow) model.EstimateReport {
+	tracked := make(map[string]time.Duration)
+	for _, e := range entries {
+		// 完了したタスクに計測中の記録が残っている場合は数えない
+		if e.EndedAt != nil {
+			tracked[e.TaskID] += e.Duration(*e.EndedAt)
+		}
+	}
+
+	var report model.EstimateReport
+	for _, task := range tasks {
+		if task.Estimate == nil || task.Status != wf.Done() {
+			continue
+		}
+		completedAt := task.UpdatedAt
+		if task.CompletedAt != nil {
+			completedAt = *task.CompletedAt
+		}
+		row := model.EstimatedTask{
+			TaskID:      task.ID,
+			Title:       task.Title,
+			Tags:        append([]string{}, task.Tags...),
+			Estimate:    *task.Estimate,
+			CompletedAt: completedAt,
+		}
+		if d, ok := tracked[task.ID]; ok {
+			row.Actual, row.Source = d, model.ActualTracked
+		} else {
+			row.Actual, row.Source = completedAt.Sub(task.CreatedAt), model.ActualLeadTime
+		}
+		report.Tasks = append(report.Tasks, row)
+	}
+
+	overall := map[model.EstimateKind]*model.EstimateAccuracy{}
+	perTag := map[string]map[model.EstimateKind]*model.EstimateAccuracy{}
+	for _, row := range report.Tasks {
+		addAccuracy(overall, "", row)
+		for _, tag := range row.Tags {
+			if perTag[tag] == nil {
+				perTag[tag] = map[model.EstimateKind]*model.EstimateAccuracy{}
+			}
+			addAccuracy(perTag[tag], tag, row)
+		}
+	}
+
+	// ポイントの比の基準とする、全体の1ポイントあたりの実績の時間
+	var perPoint time.Duration
+	if acc, ok := overall[model.EstimatePoints]; ok {
+		perPoint = finishAccuracy(acc, 0)
+	}
+
+	for i := range report.Tasks {
+		row := &report.Tasks[i]
+		row.Ratio = ratio(row.Actual, row.Estimate.Duration, row.Estimate.Points, perPoint)
+	}
+	for _, kind := range []model.EstimateKind{model.EstimateTime, model.EstimatePoints} {
+		if acc, ok := overall[kind]; ok {
+			finishAccuracy(acc, perPoint)
+			report.Overall = append(report.Overall, *acc)
+		}
+	}
+
+	tags := make([]string, 0, len(perTag))
+	for tag := range perTag {
+		tags = append(tags, tag)
+	}
+	sort.Strings(tags)
+	for _, tag := range tags {
+		for _, kind := range []model.EstimateKind{model.EstimateTime, model.EstimatePoints} {
+			if acc, ok := perTag[tag][kind]; ok {
+				finishAccuracy(acc, perPoint)
+				report.Tags = append(report.Tags, *acc)
+			}
+		}
+	}
+
+	sort.SliceStable(report.Tasks, func(i, j int) bool {
+		return report.Tasks[i].CompletedAt.After(report.Tasks[j].CompletedAt)
+	})
+	return report
+}
+
+// addAccuracy はタスクの見積もりと実績を、見積もりの種類ごとの集計に加える
+func addAccuracy(accs map[model.EstimateKind]*model.EstimateAccuracy, tag string, row model.EstimatedTask) {
+	kind := row.Estimate.Kind()
+	acc, ok := accs[kind]
+	if !ok {
+		acc = &model.EstimateAccuracy{Tag: tag, Kind: kind}
+		accs[kind] = acc
+	}
+	acc.Tasks++
+	acc.Estimated += row.Estimate.Duration
+	acc.Points += row.Estimate.Points
+	acc.Actual += row.Actual
+}
+
+// finishAccuracy は集計した合計から1ポイントあたりの時間と比を求め、1ポイントあたりの時間を返す
+func finishAccuracy(acc *model.EstimateAccuracy, basePerPoint time.Duration) time.Duration {
+	if acc.Kind == model.EstimatePoints && acc.Points > 0 {
+		acc.PerPoint = time.Duration(float64(acc.Actual) / acc.Points)
+	}
+	acc.Ratio = ratio(acc.Actual, acc.Estimated, acc.Points, basePerPoint)
+	return acc.PerPoint
+}
+
+// ratio は見積もりに対する実績の比を返す（比を求められない場合は0）
+// ポイントの見積もりは、1ポイントあたり basePerPoint の時間がかかるものとして比べる
+func ratio(actual, estimated time.Duration, points float64, basePerPoint time.Duration) float64 {
+	if points > 0 {
+		estimated = time.Duration(points * float64(basePerPoint))
+	}
+	if estimated <= 0 {
+		return 0
+	}
+	return float64(actual) / float64(estimated)
+}
@@ -0,0 +1,75 @@
+package service_test
+
+import (
+	"OTakumi/todogo/internal/domain/model"
+	"OTakumi/todogo/internal/domain/service"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuildEstimateReport(t *testing.T) {
+	created := time.Date(2025, 6, 9, 9, 0, 0, 0, time.UTC)
+	at := func(hours int) time.Time { return created.Add(time.Duration(hours) * time.Hour) }
+	ended := func(hours int) *time.Time { t := at(hours); return &t }
+	wf := model.DefaultWorkflow()
+
+	tasks := []*model.Task{
+		// 記録された作業時間（3h）を実績とする
+		{ID: "docs", Title: "Write docs", Status: model.StatusDone, Tags: []string{"docs"}, Estimate: &model.Estimate{Duration: 2 * time.Hour}, CreatedAt: created, UpdatedAt: at(48)},
+		// 記録がないため、作成から完了までの時間（4h）を実績とする（完了後の更新日時は使わない）
+		{ID: "faq", Title: "Update FAQ", Status: model.StatusDone, Tags: []string{"docs", "web"}, Estimate: &model.Estimate{Duration: 4 * time.Hour}, CreatedAt: created, UpdatedAt: at(50), CompletedAt: ended(4)},
+		{ID: "api", Title: "Build API", Status: model.StatusDone, Tags: []string{"web"}, Estimate: &model.Estimate{Points: 2}, CreatedAt: created, UpdatedAt: at(8)},
+		{ID: "ui", Title: "Build UI", Status: model.StatusDone, Estimate: &model.Estimate{Points: 3}, CreatedAt: created, UpdatedAt: at(12)},
+		// 見積もりがない、または完了していないタスクは含めない
+		{ID: "plain", Title: "No estimate", Status: model.StatusDone, CreatedAt: created, UpdatedAt: at(1)},
+		{ID: "open", Title: "Still open", Status: model.StatusOpen, Estimate: &model.Estimate{Duration: time.Hour}, CreatedAt: created, UpdatedAt: at(1)},
+		{ID: "dropped", Title: "Dropped", Status: model.StatusCancelled, Estimate: &model.Estimate{Duration: time.Hour}, CreatedAt: created, UpdatedAt: at(1)},
+	}
+	entries := []model.TimeEntry{
+		{TaskID: "docs", StartedAt: at(1), EndedAt: ended(3)},
+		{TaskID: "docs", StartedAt: at(24), EndedAt: ended(25)},
+		{TaskID: "api", StartedAt: at(1), EndedAt: ended(7)},
+		{TaskID: "ui", StartedAt: at(1), EndedAt: ended(5)},
+		// 計測中の記録は数えない
+		{TaskID: "ui", StartedAt: at(6)},
+	}
+
+	report := service.BuildEstimateReport(tasks, entries, wf)
+
+	t.Run("完了した見積もりのあるタスクを完了の新しい順に並べる", func(t *testing.T) {
+		ids := make([]string, len(report.Tasks))
+		for i, row := range report.Tasks {
+			ids[i] = row.TaskID
+		}
+		assert.Equal(t, []string{"docs", "ui", "api", "faq"}, ids)
+
+		assert.Equal(t, model.ActualTracked, report.Tasks[0].Source)
+		assert.Equal(t, 3*time.Hour, report.Tasks[0].Actual)
+		assert.InDelta(t, 1.5, report.Tasks[0].Ratio, 1e-9)
+		assert.Equal(t, model.ActualLeadTime, report.Tasks[3].Source)
+		assert.Equal(t, 4*time.Hour, report.Tasks[3].Actual)
+		assert.InDelta(t, 1.0, report.Tasks[3].Ratio, 1e-9)
+	})
+
+	t.Run("ポイントの見積もりは全体の1ポイントあたりの時間を基準とする", func(t *testing.T) {
+		// 全体では5ポイントに10時間かかったため、1ポイントあたり2時間
+		require.Len(t, report.Overall, 2)
+		assert.Equal(t, model.EstimateAccuracy{Kind: model.EstimateTime, Tasks: 2, Estimated: 6 * time.Hour, Actual: 7 * time.Hour, Ratio: 7.0 / 6.0}, report.Overall[0])
+		assert.Equal(t, model.EstimateAccuracy{Kind: model.EstimatePoints, Tasks: 2, Points: 5, Actual: 10 * time.Hour, PerPoint: 2 * time.Hour, Ratio: 1}, report.Overall[1])
+
+		// api は2ポイントに6時間（基準の4時間の1.5倍）、ui は3ポイントに4時間
+		assert.InDelta(t, 1.5, report.Tasks[2].Ratio, 1e-9)
+		assert.InDelta(t, 4.0/6.0, report.Tasks[1].Ratio, 1e-9)
+	})
+
+	t.Run("タグと見積もりの種類ごとに精度を集計する", func(t *testing.T) {
+		assert.Equal(t, []model.EstimateAccuracy{
+			{Tag: "docs", Kind: model.EstimateTime, Tasks: 2, Estimated: 6 * time.Hour, Actual: 7 * time.Hour, Ratio: 7.0 / 6.0},
+			{Tag: "web", Kind: model.EstimateTime, Tasks: 1, Estimated: 4 * time.Hour, Actual: 4 * time.Hour, Ratio: 1},
+			{Tag: "web", Kind: model.EstimatePoints, Tasks: 1, Points: 2, Actual: 6 * time.Hour, PerPoint: 3 * time.Hour, Ratio: 1.5},
+		}, report.Tags)
+	})
+}
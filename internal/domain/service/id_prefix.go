@@ -0,0 +1,73 @@
+package service
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// AmbiguousIDError は短縮IDが複数のタスクに一致したことを表すエラー
+type AmbiguousIDError struct {
+	Prefix     string
+	Candidates []string
+}
+
+func (e *AmbiguousIDError) Error() string {
+	return fmt.Sprintf("ambiguous ID prefix %q matches %d tasks: %s",
+		e.Prefix, len(e.Candidates), strings.Join(e.Candidates, ", "))
+}
+
+// MatchIDPrefix は候補IDの中から指定の接頭辞に一致するものを返す
+// 完全一致するIDが存在する場合は、そのIDのみを返す
+func MatchIDPrefix(ids []string, prefix string) []string {
+	prefix = strings.ToLower(prefix)
+	if prefix == "" {
+		return nil
+	}
+
+	var matches []string
+	for _, id := range ids {
+		lower := strings.ToLower(id)
+		if lower == prefix {
+			return []string{id}
+		}
+		if strings.HasPrefix(lower, prefix) {
+			matches = append(matches, id)
+		}
+	}
+	sort.Strings(matches)
+	return matches
+}
+
+// ShortestUniquePrefixes は各IDについて、他のIDと区別できる最短の接頭辞を返す
+// gitの短縮ハッシュと同様に、接頭辞はminLen文字未満にはならない
+func ShortestUniquePrefixes(ids []string, minLen int) map[string]string {
+	sorted := make([]string, len(ids))
+	copy(sorted, ids)
+	sort.Strings(sorted)
+
+	prefixes := make(map[string]string, len(sorted))
+	for i, id := range sorted {
+		// ソート済みであれば、共通接頭辞が最も長くなるのは隣接するIDのいずれか
+		n := 0
+		if i > 0 {
+			n = max(n, commonPrefixLen(id, sorted[i-1]))
+		}
+		if i < len(sorted)-1 {
+			n = max(n, commonPrefixLen(id, sorted[i+1]))
+		}
+
+		length := min(max(n+1, minLen), len(id))
+		prefixes[id] = id[:length]
+	}
+	return prefixes
+}
+
+// commonPrefixLen は2つの文字列の共通接頭辞の長さを返す
+func commonPrefixLen(a, b string) int {
+	n := 0
+	for n < len(a) && n < len(b) && a[n] == b[n] {
+		n++
+	}
+	return n
+}
@@ -0,0 +1,70 @@
+package service_test
+
+import (
+	"OTakumi/todogo/internal/domain/service"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMatchIDPrefix(t *testing.T) {
+	ids := []string{
+		"f47ac10b-58cc-4372-a567-0e02b2c3d479",
+		"f4aa0000-0000-0000-0000-000000000000",
+		"7d6d370d-a4f1-430b-06c7-d4a363341564",
+	}
+
+	tests := []struct {
+		name   string
+		prefix string
+		want   []string
+	}{
+		{name: "一意な接頭辞は1件に一致する", prefix: "7d", want: []string{"7d6d370d-a4f1-430b-06c7-d4a363341564"}},
+		{name: "曖昧な接頭辞は全ての候補に一致する", prefix: "f4", want: []string{ids[0], ids[1]}},
+		{name: "大文字でも一致する", prefix: "F47A", want: []string{ids[0]}},
+		{name: "一致しない接頭辞は空を返す", prefix: "zz", want: nil},
+		{name: "空の接頭辞は何にも一致しない", prefix: "", want: nil},
+		{name: "完全一致が優先される", prefix: ids[1], want: []string{ids[1]}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, service.MatchIDPrefix(ids, tt.prefix))
+		})
+	}
+}
+
+func TestShortestUniquePrefixes(t *testing.T) {
+	t.Run("隣接するIDと区別できる長さになること", func(t *testing.T) {
+		// Arrange
+		ids := []string{"abcdef12", "abcdff34", "12345678"}
+
+		// Act
+		prefixes := service.ShortestUniquePrefixes(ids, 1)
+
+		// Assert
+		assert.Equal(t, "abcde", prefixes["abcdef12"])
+		assert.Equal(t, "abcdf", prefixes["abcdff34"])
+		assert.Equal(t, "1", prefixes["12345678"])
+	})
+
+	t.Run("最小長より短くならないこと", func(t *testing.T) {
+		// Arrange
+		ids := []string{"abcdef12", "12345678"}
+
+		// Act
+		prefixes := service.ShortestUniquePrefixes(ids, 4)
+
+		// Assert
+		assert.Equal(t, "abcd", prefixes["abcdef12"])
+		assert.Equal(t, "1234", prefixes["12345678"])
+	})
+
+	t.Run("最小長がIDより長い場合はID全体を返すこと", func(t *testing.T) {
+		// Act
+		prefixes := service.ShortestUniquePrefixes([]string{"ab"}, 4)
+
+		// Assert
+		assert.Equal(t, "ab", prefixes["ab"])
+	})
+}
@@ -0,0 +1,32 @@
+package service
+
+import (
+	"OTakumi/todogo/internal/domain/model"
+	"sort"
+)
+
+// RollUpProjects はプロジェクトごとのタスクの件数を、上位のプロジェクトにも合算する
+// タスクが直接属していない上位のプロジェクトも結果に含め、階層順（上位のプロジェクトの直後に配下のプロジェクト）に並べて返す
+func RollUpProjects(counts []model.ProjectCount) []model.ProjectCount {
+	byName := make(map[string]*model.ProjectCount)
+	for _, c := range counts {
+		for _, name := range model.ProjectAncestors(c.Name) {
+			node, ok := byName[name]
+			if !ok {
+				node = &model.ProjectCount{Name: name}
+				byName[name] = node
+			}
+			node.Open += c.Open
+			node.Closed += c.Closed
+		}
+	}
+
+	result := make([]model.ProjectCount, 0, len(byName))
+	for _, node := range byName {
+		result = append(result, *node)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return model.CompareProjects(result[i].Name, result[j].Name) < 0
+	})
+	return result
+}
@@ -0,0 +1,34 @@
+package service_test
+
+import (
+	"OTakumi/todogo/internal/domain/model"
+	"OTakumi/todogo/internal/domain/service"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRollUpProjects(t *testing.T) {
+	t.Run("上位のプロジェクトに件数を合算し、階層順に並べる", func(t *testing.T) {
+		counts := []model.ProjectCount{
+			{Name: "work.backend.api", Open: 2, Closed: 1},
+			{Name: "home", Open: 1},
+			{Name: "work", Open: 1, Closed: 1},
+			{Name: "work.frontend", Closed: 2},
+		}
+
+		got := service.RollUpProjects(counts)
+
+		assert.Equal(t, []model.ProjectCount{
+			{Name: "home", Open: 1},
+			{Name: "work", Open: 3, Closed: 4},
+			{Name: "work.backend", Open: 2, Closed: 1},
+			{Name: "work.backend.api", Open: 2, Closed: 1},
+			{Name: "work.frontend", Closed: 2},
+		}, got)
+	})
+
+	t.Run("プロジェクトがない場合は空を返す", func(t *testing.T) {
+		assert.Empty(t, service.RollUpProjects(nil))
+	})
+}
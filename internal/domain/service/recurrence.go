@@ -0,0 +1,80 @@
+package service
+
+import (
+	"OTakumi/todogo/internal/domain/model"
+	"slices"
+	"time"
+)
+
+// NextDeadline は繰り返すタスクが completedAt に完了したときの、次のタスクの締切を返す
+// 日付の計算は completedAt のタイムゾーンで行い、時刻は元の締切の時刻を維持する
+//   - 暦による繰り返しは、元の締切からルールに従って進め、完了日時より後になる最初の日時とする
+//     期限を過ぎてから完了した場合も、過ぎた回の分は作成しない
+//   - 完了日から数える繰り返し（AfterCompletion）は、完了した日から間隔の分だけ進めた日とする
+//   - 締切のないタスクは、完了日時を元の締切とみなす
+func NextDeadline(rule model.Recurrence, deadline *time.Time, completedAt time.Time) time.Time {
+	base := completedAt
+	if deadline != nil {
+		base = deadline.In(completedAt.Location())
+	}
+
+	if rule.AfterCompletion {
+		from := time.Date(completedAt.Year(), completedAt.Month(), completedAt.Day(),
+			base.Hour(), base.Minute(), base.Second(), base.Nanosecond(), completedAt.Location())
+		return advance(rule, from)
+	}
+
+	next := advance(rule, base)
+	for !next.After(completedAt) {
+		next = advance(rule, next)
+	}
+	return next
+}
+
+// advance はルールに従って t の次の回の日時を返す
+func advance(rule model.Recurrence, t time.Time) time.Time {
+	switch rule.Frequency {
+	case model.FrequencyWeekly:
+		if len(rule.Weekdays) == 0 {
+			return t.AddDate(0, 0, 7*rule.Interval)
+		}
+		// t と同じ週、または間隔の分だけ離れた週にある、指定された曜日のうち最初の日
+		for d := 1; d <= 7*rule.Interval+7; d++ {
+			c := t.AddDate(0, 0, d)
+			if slices.Contains(rule.Weekdays, c.Weekday()) && weeksBetween(t, c)%rule.Interval == 0 {
+				return c
+			}
+		}
+		return t.AddDate(0, 0, 7*rule.Interval)
+	case model.FrequencyMonthly:
+		if rule.MonthDay == 0 {
+			return monthDate(t, t.Year(), t.Month()+time.Month(rule.Interval), t.Day())
+		}
+		// 締切が指定された日より前であれば、同じ月の指定された日とする
+		for k := 0; ; k++ {
+			c := monthDate(t, t.Year(), t.Month()+time.Month(k*rule.Interval), rule.MonthDay)
+			if c.After(t) {
+				return c
+			}
+		}
+	default:
+		return t.AddDate(0, 0, rule.Interval)
+	}
+}
+
+// monthDate は指定した年月日の、clock と同じ時刻の日時を返す
+// 月末より後の日は、その月の末日とする
+func monthDate(clock time.Time, year int, month time.Month, day int) time.Time {
+	first := time.Date(year, month, 1, clock.Hour(), clock.Minute(), clock.Second(), clock.Nanosecond(), clock.Location())
+	last := first.AddDate(0, 1, -1).Day()
+	return first.AddDate(0, 0, min(day, last)-1)
+}
+
+// weeksBetween は月曜始まりの週で数えた、a の週から b の週までの週数を返す
+func weeksBetween(a, b time.Time) int {
+	start := func(t time.Time) time.Time {
+		y, m, d := t.Date()
+		return time.Date(y, m, d-(int(t.Weekday())+6)%7, 0, 0, 0, 0, time.UTC)
+	}
+	return int(start(b).Sub(start(a)).Hours()/24) / 7
+}
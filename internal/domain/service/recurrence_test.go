@@ -0,0 +1,112 @@
+package service_test
+
+import (
+	"OTakumi/todogo/internal/domain/model"
+	"OTakumi/todogo/internal/domain/service"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNextDeadline(t *testing.T) {
+	jst := time.FixedZone("JST", 9*60*60)
+	at := func(month time.Month, day, hour int) time.Time {
+		return time.Date(2025, month, day, hour, 0, 0, 0, jst)
+	}
+	ptr := func(v time.Time) *time.Time { return &v }
+
+	weekly := model.Recurrence{Frequency: model.FrequencyWeekly, Interval: 1}
+	monThu := model.Recurrence{Frequency: model.FrequencyWeekly, Interval: 1, Weekdays: []time.Weekday{time.Monday, time.Thursday}}
+	biweeklyMon := model.Recurrence{Frequency: model.FrequencyWeekly, Interval: 2, Weekdays: []time.Weekday{time.Monday}}
+	monthly31 := model.Recurrence{Frequency: model.FrequencyMonthly, Interval: 1, MonthDay: 31}
+
+	// 2025-06-02 は月曜日
+	tests := []struct {
+		name        string
+		rule        model.Recurrence
+		deadline    *time.Time
+		completedAt time.Time
+		want        time.Time
+	}{
+		{
+			name:        "毎日: 締切の翌日",
+			rule:        model.Recurrence{Frequency: model.FrequencyDaily, Interval: 1},
+			deadline:    ptr(at(6, 2, 17)),
+			completedAt: at(6, 2, 9),
+			want:        at(6, 3, 17),
+		},
+		{
+			name:        "期限を過ぎて完了した場合は、完了日時より後の最初の回",
+			rule:        weekly,
+			deadline:    ptr(at(6, 2, 17)),
+			completedAt: at(6, 20, 9),
+			want:        at(6, 23, 17),
+		},
+		{
+			name:        "曜日指定: 同じ週の次の曜日",
+			rule:        monThu,
+			deadline:    ptr(at(6, 2, 17)),
+			completedAt: at(6, 2, 9),
+			want:        at(6, 5, 17),
+		},
+		{
+			name:        "曜日指定: 翌週の最初の曜日",
+			rule:        monThu,
+			deadline:    ptr(at(6, 5, 17)),
+			completedAt: at(6, 5, 9),
+			want:        at(6, 9, 17),
+		},
+		{
+			name:        "隔週: 間の週は飛ばす",
+			rule:        biweeklyMon,
+			deadline:    ptr(at(6, 2, 17)),
+			completedAt: at(6, 2, 9),
+			want:        at(6, 16, 17),
+		},
+		{
+			name:        "毎月の指定日: 月末より後の日はその月の末日",
+			rule:        monthly31,
+			deadline:    ptr(at(5, 31, 17)),
+			completedAt: at(5, 31, 9),
+			want:        at(6, 30, 17),
+		},
+		{
+			name:        "毎月の指定日: 締切が指定日より前であれば同じ月",
+			rule:        model.Recurrence{Frequency: model.FrequencyMonthly, Interval: 1, MonthDay: 15},
+			deadline:    ptr(at(6, 10, 17)),
+			completedAt: at(6, 10, 9),
+			want:        at(6, 15, 17),
+		},
+		{
+			name:        "完了日から数える: 締切ではなく完了した日から進め、締切の時刻を維持する",
+			rule:        model.Recurrence{Frequency: model.FrequencyDaily, Interval: 10, AfterCompletion: true},
+			deadline:    ptr(at(6, 2, 17)),
+			completedAt: at(6, 8, 20),
+			want:        at(6, 18, 17),
+		},
+		{
+			name:        "締切がない場合は完了日時から進める",
+			rule:        weekly,
+			deadline:    nil,
+			completedAt: at(6, 4, 9),
+			want:        at(6, 11, 9),
+		},
+		{
+			name:        "締切は完了日時のタイムゾーンで計算する",
+			rule:        model.Recurrence{Frequency: model.FrequencyDaily, Interval: 1},
+			deadline:    ptr(at(6, 2, 8).UTC()),
+			completedAt: at(6, 2, 7),
+			want:        at(6, 3, 8),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := service.NextDeadline(tt.rule, tt.deadline, tt.completedAt)
+
+			assert.True(t, tt.want.Equal(got), "expected %v, but got %v", tt.want, got)
+			assert.Equal(t, jst, got.Location())
+		})
+	}
+}
@@ -0,0 +1,162 @@
+package service
+
+import (
+	"OTakumi/todogo/internal/domain/model"
+	"sort"
+	"strings"
+	"unicode/utf8"
+)
+
+// 部分一致による検索で、項目ごとに一致1件あたり加算する関連度
+// PostgreSQLの ts_rank の既定の重み（A: 1.0, B: 0.4, C: 0.2）に合わせ、タイトルの一致を最も重く扱う
+var searchWeights = map[model.SearchField]float64{
+	model.SearchFieldTitle:       1.0,
+	model.SearchFieldDescription: 0.4,
+	model.SearchFieldAnnotation:  0.2,
+}
+
+// 抜粋の長さ（バイト数）
+const (
+	// snippetWidth は説明や注記の抜粋の最大の長さ
+	snippetWidth = 160
+	// snippetLead は抜粋に含める、最初の一致より前の部分の長さ
+	snippetLead = 40
+)
+
+// snippetEllipsis は抜粋で省略した部分を表す文字列
+const snippetEllipsis = "…"
+
+// MatchSubstring はタスクのタイトル、説明、注記から検索語を大文字小文字を区別せずに部分一致で探す
+// すべての検索語がいずれかの項目に含まれる場合に一致とし、一致した回数に項目ごとの重みを掛けて関連度とする
+// 全文検索の機能を持たないリポジトリが、検索の代替として使う
+func MatchSubstring(task *model.Task, terms []string) (model.SearchHit, bool) {
+	hit := model.SearchHit{Task: task}
+	found := make(map[string]bool, len(terms))
+
+	add := func(field model.SearchField, text string) {
+		highlights, counts := findTerms(text, terms)
+		if len(highlights) == 0 {
+			return
+		}
+		for term, n := range counts {
+			found[term] = true
+			hit.Rank += searchWeights[field] * float64(n)
+		}
+		snippet, highlights := excerpt(text, highlights, field != model.SearchFieldTitle)
+		hit.Matches = append(hit.Matches, model.SearchMatch{Field: field, Snippet: snippet, Highlights: highlights})
+	}
+
+	add(model.SearchFieldTitle, task.Title)
+	add(model.SearchFieldDescription, task.Description)
+	for _, a := range task.Annotations {
+		add(model.SearchFieldAnnotation, a.Text)
+	}
+
+	if len(found) < len(terms) {
+		return model.SearchHit{}, false
+	}
+	return hit, true
+}
+
+// findTerms は text に含まれる検索語の範囲を、重なる範囲をまとめて昇順で返す
+// 併せて、検索語ごとの一致の回数を返す
+func findTerms(text string, terms []string) ([]model.Highlight, map[string]int) {
+	var highlights []model.Highlight
+	counts := make(map[string]int)
+	for _, term := range terms {
+		for i := 0; i+len(term) <= len(text); {
+			if strings.EqualFold(text[i:i+len(term)], term) {
+				highlights = append(highlights, model.Highlight{Start: i, End: i + len(term)})
+				counts[term]++
+				i += len(term)
+				continue
+			}
+			_, size := utf8.DecodeRuneInString(text[i:])
+			i += size
+		}
+	}
+	return mergeHighlights(highlights), counts
+}
+
+// mergeHighlights は範囲を昇順に並べ、重なる範囲や隣接する範囲をまとめる
+func mergeHighlights(highlights []model.Highlight) []model.Highlight {
+	if len(highlights) == 0 {
+		return nil
+	}
+	sort.Slice(highlights, func(i, j int) bool {
+		return highlights[i].Start < highlights[j].Start
+	})
+
+	merged := []model.Highlight{highlights[0]}
+	for _, h := range highlights[1:] {
+		last := &merged[len(merged)-1]
+		if h.Start <= last.End {
+			last.End = max(last.End, h.End)
+			continue
+		}
+		merged = append(merged, h)
+	}
+	return merged
+}
+
+// excerpt は改行を空白に置き換えた text の抜粋と、抜粋の中での一致の範囲を返す
+// truncate が true で text が長い場合は、最初の一致の少し前から snippetWidth までを切り出す
+func excerpt(text string, highlights []model.Highlight, truncate bool) (string, []model.Highlight) {
+	// 改行は1バイトの空白に置き換えるため、範囲は変わらない
+	text = strings.Map(func(r rune) rune {
+		if r == '\n' || r == '\r' {
+			return ' '
+		}
+		return r
+	}, text)
+	if !truncate || len(text) <= snippetWidth {
+		return text, highlights
+	}
+
+	start := runeStart(text, max(0, highlights[0].Start-snippetLead))
+	end := runeStart(text, min(len(text), start+snippetWidth))
+	// 最初の一致は途中で切らない
+	end = max(end, highlights[0].End)
+
+	snippet := text[start:end]
+	offset := -start
+	if start > 0 {
+		snippet = snippetEllipsis + snippet
+		offset += len(snippetEllipsis)
+	}
+	if end < len(text) {
+		snippet += snippetEllipsis
+	}
+
+	var shifted []model.Highlight
+	for _, h := range highlights {
+		if h.Start >= end {
+			break
+		}
+		shifted = append(shifted, model.Highlight{Start: h.Start + offset, End: min(h.End, end) + offset})
+	}
+	return snippet, shifted
+}
+
+// runeStart は i 以前で最も近い文字の先頭の位置を返す
+func runeStart(s string, i int) int {
+	for i > 0 && i < len(s) && !utf8.RuneStart(s[i]) {
+		i--
+	}
+	return i
+}
+
+// SortSearchHits は検索結果を関連度の高い順に並べる
+// 関連度が同じ場合は更新日時の新しい順、さらにIDの順とする
+func SortSearchHits(hits []model.SearchHit) {
+	sort.SliceStable(hits, func(i, j int) bool {
+		a, b := hits[i], hits[j]
+		if a.Rank != b.Rank {
+			return a.Rank > b.Rank
+		}
+		if !a.Task.UpdatedAt.Equal(b.Task.UpdatedAt) {
+			return a.Task.UpdatedAt.After(b.Task.UpdatedAt)
+		}
+		return a.Task.ID < b.Task.ID
+	})
+}
@@ -0,0 +1,88 @@
+package service_test
+
+import (
+	"OTakumi/todogo/internal/domain/model"
+	"OTakumi/todogo/internal/domain/service"
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf8"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// highlighted は抜粋の一致した範囲を [ ] で囲んだ文字列を返す
+func highlighted(m model.SearchMatch) string {
+	var b strings.Builder
+	last := 0
+	for _, h := range m.Highlights {
+		b.WriteString(m.Snippet[last:h.Start] + "[" + m.Snippet[h.Start:h.End] + "]")
+		last = h.End
+	}
+	b.WriteString(m.Snippet[last:])
+	return b.String()
+}
+
+func TestMatchSubstring(t *testing.T) {
+	task := &model.Task{
+		ID:          "id-1",
+		Title:       "Call the Vendor",
+		Description: "Ask the vendor about\nthe delivery date",
+		Annotations: []model.Annotation{
+			{Text: "left a voicemail"},
+			{Text: "vendor called back"},
+		},
+	}
+
+	t.Run("大文字小文字を区別せず、一致した項目ごとに抜粋と一致の範囲を返す", func(t *testing.T) {
+		hit, ok := service.MatchSubstring(task, []string{"vendor", "call"})
+
+		require.True(t, ok)
+		require.Len(t, hit.Matches, 3)
+		assert.Equal(t, model.SearchFieldTitle, hit.Matches[0].Field)
+		assert.Equal(t, "[Call] the [Vendor]", highlighted(hit.Matches[0]))
+		assert.Equal(t, model.SearchFieldDescription, hit.Matches[1].Field)
+		assert.Equal(t, "Ask the [vendor] about the delivery date", highlighted(hit.Matches[1]))
+		assert.Equal(t, model.SearchFieldAnnotation, hit.Matches[2].Field)
+		assert.Equal(t, "[vendor] [call]ed back", highlighted(hit.Matches[2]))
+
+		// タイトル 2件 x 1.0、説明 1件 x 0.4、注記 2件 x 0.2
+		assert.InDelta(t, 2.8, hit.Rank, 1e-9)
+	})
+
+	t.Run("いずれかの検索語がどの項目にも含まれない場合は一致しない", func(t *testing.T) {
+		_, ok := service.MatchSubstring(task, []string{"vendor", "invoice"})
+
+		assert.False(t, ok)
+	})
+
+	t.Run("長い説明は最初の一致の前後を切り出す", func(t *testing.T) {
+		long := &model.Task{
+			Title:       "Report",
+			Description: strings.Repeat("あ", 100) + " needle " + strings.Repeat("い", 100),
+		}
+
+		hit, ok := service.MatchSubstring(long, []string{"needle"})
+
+		require.True(t, ok)
+		snippet := highlighted(hit.Matches[0])
+		assert.True(t, strings.HasPrefix(snippet, "…"), snippet)
+		assert.True(t, strings.HasSuffix(snippet, "…"), snippet)
+		assert.Contains(t, snippet, " [needle] ")
+		assert.True(t, utf8.ValidString(snippet))
+	})
+}
+
+func TestSortSearchHits(t *testing.T) {
+	now := time.Now()
+	hits := []model.SearchHit{
+		{Task: &model.Task{ID: "a", UpdatedAt: now}, Rank: 0.4},
+		{Task: &model.Task{ID: "b", UpdatedAt: now}, Rank: 1.0},
+		{Task: &model.Task{ID: "c", UpdatedAt: now.Add(time.Hour)}, Rank: 0.4},
+	}
+
+	service.SortSearchHits(hits)
+
+	assert.Equal(t, []string{"b", "c", "a"}, []string{hits[0].Task.ID, hits[1].Task.ID, hits[2].Task.ID})
+}
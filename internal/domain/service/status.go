@@ -0,0 +1,29 @@
+package service
+
+import (
+	"OTakumi/todogo/internal/domain/model"
+	"slices"
+	"sort"
+)
+
+// SortByStatus はタスクを状態ごとにまとめ、ワークフローに定義された状態の順に並べ替える
+// 同じ状態のタスクは元の順序を保つ
+// ワークフローにない状態（設定から削除された状態など）のタスクは、状態の名前順で最後にまとめる
+func SortByStatus(tasks []*model.Task, wf *model.Workflow) {
+	rank := func(s model.Status) int {
+		if i := slices.Index(wf.Statuses, s); i >= 0 {
+			return i
+		}
+		return len(wf.Statuses)
+	}
+	sort.SliceStable(tasks, func(i, j int) bool {
+		ri, rj := rank(tasks[i].Status), rank(tasks[j].Status)
+		if ri != rj {
+			return ri < rj
+		}
+		if ri == len(wf.Statuses) {
+			return tasks[i].Status < tasks[j].Status
+		}
+		return false
+	})
+}
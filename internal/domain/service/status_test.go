@@ -0,0 +1,38 @@
+package service_test
+
+import (
+	"OTakumi/todogo/internal/domain/model"
+	"OTakumi/todogo/internal/domain/service"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSortByStatus(t *testing.T) {
+	t.Run("ワークフローの状態の順にまとめ、同じ状態では元の順序を保つ", func(t *testing.T) {
+		tasks := []*model.Task{
+			{ID: "a", Status: model.StatusDone},
+			{ID: "b", Status: model.StatusOpen},
+			{ID: "c", Status: model.StatusInProgress},
+			{ID: "d", Status: model.StatusOpen},
+			{ID: "e", Status: model.StatusDone},
+		}
+
+		service.SortByStatus(tasks, model.DefaultWorkflow())
+
+		assert.Equal(t, []string{"b", "d", "c", "a", "e"}, taskIDs(tasks))
+	})
+
+	t.Run("ワークフローにない状態は名前順で最後にまとめる", func(t *testing.T) {
+		tasks := []*model.Task{
+			{ID: "a", Status: "review"},
+			{ID: "b", Status: model.StatusDone},
+			{ID: "c", Status: "archived"},
+			{ID: "d", Status: model.StatusOpen},
+		}
+
+		service.SortByStatus(tasks, model.DefaultWorkflow())
+
+		assert.Equal(t, []string{"d", "b", "c", "a"}, taskIDs(tasks))
+	})
+}
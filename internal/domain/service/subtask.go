@@ -0,0 +1,152 @@
+package service
+
+import (
+	"OTakumi/todogo/internal/domain/model"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// OpenSubtasksError は未完了のサブタスクがあるタスクを完了しようとしたことを表すエラー
+type OpenSubtasksError struct {
+	ID string
+	// Open は未完了のサブタスクのID
+	Open []string
+}
+
+func (e *OpenSubtasksError) Error() string {
+	return fmt.Sprintf("task %s has %d open subtask(s): %s", e.ID, len(e.Open), strings.Join(e.Open, ", "))
+}
+
+// ErrParentCycle は親タスクをたどるとタスク自身に戻る指定であることを表すエラー
+var ErrParentCycle = errors.New("parent would create a cycle: a task cannot be under its own subtask")
+
+// TaskTree はタスクの親子関係
+type TaskTree struct {
+	tasks    map[string]*model.Task
+	children map[string][]*model.Task
+}
+
+// NewTaskTree はタスクの一覧から親子関係を組み立てる
+// 子の順序は一覧での順序を維持する
+func NewTaskTree(tasks []*model.Task) *TaskTree {
+	tree := &TaskTree{
+		tasks:    make(map[string]*model.Task, len(tasks)),
+		children: make(map[string][]*model.Task),
+	}
+	for _, task := range tasks {
+		tree.tasks[task.ID] = task
+	}
+	for _, task := range tasks {
+		if task.ParentID != "" {
+			tree.children[task.ParentID] = append(tree.children[task.ParentID], task)
+		}
+	}
+	return tree
+}
+
+// Descendants は配下のすべてのサブタスクを、親が子より先に並ぶ順で返す
+func (t *TaskTree) Descendants(id string) []*model.Task {
+	var result []*model.Task
+	var walk func(id string)
+	walk = func(id string) {
+		for _, child := range t.children[id] {
+			result = append(result, child)
+			walk(child.ID)
+		}
+	}
+	walk(id)
+	return result
+}
+
+// Progress は配下のすべてのサブタスクの進捗を返す（終了状態のサブタスクを完了済みとして数える）
+func (t *TaskTree) Progress(id string, wf *model.Workflow) model.Progress {
+	var p model.Progress
+	for _, task := range t.Descendants(id) {
+		p.Total++
+		if wf.IsClosed(task) {
+			p.Done++
+		}
+	}
+	return p
+}
+
+// Validate は作成・更新後のタスクが親子関係の規則を満たしているかを検証する
+// ツリーには変更前の状態のタスクを含める（作成時は含まない）
+//   - 親タスクが存在し、親をたどってタスク自身に戻らないこと
+//   - 未完了のタスクの親が完了済みでないこと
+//   - 完了済みのタスクに未完了のサブタスクがないこと
+//
+// 完了済みかどうかはワークフローの終了状態かどうかで判定する
+// 親タスクがツリーに含まれない（ゴミ箱にある）場合も、変更前から親が同じであれば最上位のタスクとして扱う
+func (t *TaskTree) Validate(task *model.Task, wf *model.Workflow) error {
+	if task.ParentID != "" {
+		parent, ok := t.tasks[task.ParentID]
+		if !ok {
+			if before, ok := t.tasks[task.ID]; !ok || before.ParentID != task.ParentID {
+				return fmt.Errorf("parent task %s not found", task.ParentID)
+			}
+		}
+
+		// 親タスクの祖先をたどり、タスク自身が現れれば循環となる
+		seen := map[string]bool{}
+		for id := task.ParentID; id != ""; id = t.tasks[id].ParentID {
+			if id == task.ID {
+				return ErrParentCycle
+			}
+			if seen[id] || t.tasks[id] == nil {
+				break
+			}
+			seen[id] = true
+		}
+
+		if parent != nil && !wf.IsClosed(task) && wf.IsClosed(parent) {
+			return fmt.Errorf("parent task %s is complete: an open task cannot be under a completed task", parent.ID)
+		}
+	}
+
+	if wf.IsClosed(task) {
+		var open []string
+		for _, sub := range t.Descendants(task.ID) {
+			if !wf.IsClosed(sub) {
+				open = append(open, sub.ID)
+			}
+		}
+		if len(open) > 0 {
+			return &OpenSubtasksError{ID: task.ID, Open: open}
+		}
+	}
+
+	return nil
+}
+
+// ArrangeTree はタスクの一覧を、各タスクの直後にそのサブタスクが並ぶ順に並べ替える
+// 兄弟の間では元の一覧での順序を維持する
+// 親タスクが一覧に含まれないタスクは最上位として扱い、各タスクの階層の深さ（最上位は0）を併せて返す
+func ArrangeTree(tasks []*model.Task) ([]*model.Task, map[string]int) {
+	tree := NewTaskTree(tasks)
+	result := make([]*model.Task, 0, len(tasks))
+	depths := make(map[string]int, len(tasks))
+
+	var walk func(task *model.Task, depth int)
+	walk = func(task *model.Task, depth int) {
+		if _, done := depths[task.ID]; done {
+			return
+		}
+		result = append(result, task)
+		depths[task.ID] = depth
+		for _, child := range tree.children[task.ID] {
+			walk(child, depth+1)
+		}
+	}
+	for _, task := range tasks {
+		if _, listed := tree.tasks[task.ParentID]; !listed {
+			walk(task, 0)
+		}
+	}
+	// 親子関係が循環している場合でも、すべてのタスクを含める
+	for _, task := range tasks {
+		walk(task, 0)
+	}
+	return result, depths
+}
@@ -0,0 +1,91 @@
+package service_test
+
+import (
+	"OTakumi/todogo/internal/domain/model"
+	"OTakumi/todogo/internal/domain/service"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// subtaskFixture は次の親子関係のタスクを返す
+//
+//	root
+//	├── a (完了)
+//	│   └── a1 (完了)
+//	└── b
+//	    └── b1
+//	other
+func subtaskFixture() []*model.Task {
+	return []*model.Task{
+		{ID: "root", Title: "root"},
+		{ID: "a", Title: "a", ParentID: "root", Status: model.StatusDone},
+		{ID: "b", Title: "b", ParentID: "root"},
+		{ID: "a1", Title: "a1", ParentID: "a", Status: model.StatusDone},
+		{ID: "b1", Title: "b1", ParentID: "b"},
+		{ID: "other", Title: "other"},
+	}
+}
+
+func TestTaskTree_DescendantsAndProgress(t *testing.T) {
+	tree := service.NewTaskTree(subtaskFixture())
+
+	assert.Equal(t, []string{"a", "a1", "b", "b1"}, taskIDs(tree.Descendants("root")))
+	assert.Equal(t, model.Progress{Done: 2, Total: 4}, tree.Progress("root", model.DefaultWorkflow()))
+	assert.Equal(t, model.Progress{Done: 0, Total: 1}, tree.Progress("b", model.DefaultWorkflow()))
+	assert.Equal(t, model.Progress{}, tree.Progress("other", model.DefaultWorkflow()))
+}
+
+func TestTaskTree_Validate(t *testing.T) {
+	tree := service.NewTaskTree(subtaskFixture())
+
+	t.Run("親タスクを変更できること", func(t *testing.T) {
+		assert.NoError(t, tree.Validate(&model.Task{ID: "b", Title: "b", ParentID: "other"}, model.DefaultWorkflow()))
+	})
+
+	t.Run("存在しない親タスクを指定した場合、エラーが返されること", func(t *testing.T) {
+		assert.ErrorContains(t, tree.Validate(&model.Task{ID: "new", Title: "new", ParentID: "missing"}, model.DefaultWorkflow()), "not found")
+	})
+
+	t.Run("親タスクがツリーにない場合も、親を変更していなければ更新できること", func(t *testing.T) {
+		// ゴミ箱にある親のサブタスクは、変更前から親が同じであれば編集できる
+		orphan := service.NewTaskTree([]*model.Task{{ID: "child", Title: "child", ParentID: "trashed"}})
+		assert.NoError(t, orphan.Validate(&model.Task{ID: "child", Title: "renamed", ParentID: "trashed", Status: model.StatusDone}, model.DefaultWorkflow()))
+		assert.ErrorContains(t, orphan.Validate(&model.Task{ID: "other", Title: "other", ParentID: "trashed"}, model.DefaultWorkflow()), "not found")
+	})
+
+	t.Run("配下のサブタスクを親タスクに指定した場合、循環としてエラーが返されること", func(t *testing.T) {
+		assert.ErrorIs(t, tree.Validate(&model.Task{ID: "root", Title: "root", ParentID: "b1"}, model.DefaultWorkflow()), service.ErrParentCycle)
+	})
+
+	t.Run("完了済みのタスクの下に未完了のタスクを置いた場合、エラーが返されること", func(t *testing.T) {
+		assert.ErrorContains(t, tree.Validate(&model.Task{ID: "new", Title: "new", ParentID: "a"}, model.DefaultWorkflow()), "is complete")
+	})
+
+	t.Run("未完了のサブタスクがあるタスクを完了した場合、エラーが返されること", func(t *testing.T) {
+		err := tree.Validate(&model.Task{ID: "root", Title: "root", Status: model.StatusDone}, model.DefaultWorkflow())
+
+		var openErr *service.OpenSubtasksError
+		if assert.ErrorAs(t, err, &openErr) {
+			assert.Equal(t, []string{"b", "b1"}, openErr.Open)
+		}
+	})
+}
+
+func TestArrangeTree(t *testing.T) {
+	t.Run("各タスクの直後にサブタスクを並べ、深さを返す", func(t *testing.T) {
+		tasks, depths := service.ArrangeTree(subtaskFixture())
+
+		assert.Equal(t, []string{"root", "a", "a1", "b", "b1", "other"}, taskIDs(tasks))
+		assert.Equal(t, map[string]int{"root": 0, "a": 1, "a1": 2, "b": 1, "b1": 2, "other": 0}, depths)
+	})
+
+	t.Run("親タスクが一覧にない場合は最上位として扱う", func(t *testing.T) {
+		all := subtaskFixture()
+		tasks, depths := service.ArrangeTree([]*model.Task{all[4], all[2], all[5]})
+
+		assert.Equal(t, []string{"b", "b1", "other"}, taskIDs(tasks))
+		assert.Equal(t, 0, depths["b"])
+		assert.Equal(t, 1, depths["b1"])
+	})
+}
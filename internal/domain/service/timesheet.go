@@ -0,0 +1,81 @@
+package service
+
+import (
+	"OTakumi/todogo/internal/domain/model"
+	"sort"
+	"time"
+)
+
+// BuildTimesheet は from から to の直前までの期間に含まれる作業時間を、タスクとプロジェクトごとに集計する
+// 期間をまたぐ記録は期間内の部分のみを数え、計測中の記録は now まで続いているものとして扱う
+// tasks に含まれないタスクの記録は集計しない
+func BuildTimesheet(entries []model.TimeEntry, tasks []*model.Task, from, to, now time.Time) model.Timesheet {
+	// 計測中の記録が期間の終わりまで続いているものとして数えないよう、現在時刻までで集計する
+	end := to
+	if now.Before(end) {
+		end = now
+	}
+
+	byID := make(map[string]*model.Task, len(tasks))
+	for _, task := range tasks {
+		byID[task.ID] = task
+	}
+
+	sheet := model.Timesheet{From: from, To: to}
+	perTask := make(map[string]*model.TimesheetTask)
+	for _, e := range entries {
+		task, ok := byID[e.TaskID]
+		if !ok {
+			continue
+		}
+		d := e.Overlap(from, end)
+		if d <= 0 {
+			continue
+		}
+		row, ok := perTask[task.ID]
+		if !ok {
+			row = &model.TimesheetTask{TaskID: task.ID, Title: task.Title, Project: task.Project}
+			perTask[task.ID] = row
+		}
+		row.Duration += d
+		sheet.Total += d
+	}
+
+	perProject := make(map[string]*model.TimesheetProject)
+	for _, row := range perTask {
+		sheet.Tasks = append(sheet.Tasks, *row)
+
+		// プロジェクトの時間は上位のプロジェクトにも合算する
+		names := model.ProjectAncestors(row.Project)
+		if row.Project == "" {
+			names = []string{""}
+		}
+		for _, name := range names {
+			p, ok := perProject[name]
+			if !ok {
+				p = &model.TimesheetProject{Name: name}
+				perProject[name] = p
+			}
+			p.Duration += row.Duration
+		}
+	}
+	for _, p := range perProject {
+		sheet.Projects = append(sheet.Projects, *p)
+	}
+
+	sort.Slice(sheet.Tasks, func(i, j int) bool {
+		if sheet.Tasks[i].Duration != sheet.Tasks[j].Duration {
+			return sheet.Tasks[i].Duration > sheet.Tasks[j].Duration
+		}
+		return sheet.Tasks[i].TaskID < sheet.Tasks[j].TaskID
+	})
+	// プロジェクトに属さないタスクの時間は最後に並べる
+	sort.Slice(sheet.Projects, func(i, j int) bool {
+		a, b := sheet.Projects[i].Name, sheet.Projects[j].Name
+		if a == "" || b == "" {
+			return b == "" && a != ""
+		}
+		return model.CompareProjects(a, b) < 0
+	})
+	return sheet
+}
@@ -0,0 +1,63 @@
+package service_test
+
+import (
+	"OTakumi/todogo/internal/domain/model"
+	"OTakumi/todogo/internal/domain/service"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuildTimesheet(t *testing.T) {
+	from := time.Date(2025, 6, 9, 0, 0, 0, 0, time.UTC)
+	to := from.AddDate(0, 0, 7)
+	at := func(day, hour int) time.Time { return from.AddDate(0, 0, day).Add(time.Duration(hour) * time.Hour) }
+	ended := func(day, hour int) *time.Time { t := at(day, hour); return &t }
+
+	tasks := []*model.Task{
+		{ID: "api", Title: "Build API", Project: "work.backend"},
+		{ID: "ui", Title: "Build UI", Project: "work.frontend"},
+		{ID: "mow", Title: "Mow the lawn"},
+	}
+
+	t.Run("タスクごとに長い順、プロジェクトごとに上位へ合算して集計する", func(t *testing.T) {
+		entries := []model.TimeEntry{
+			{TaskID: "api", StartedAt: at(0, 9), EndedAt: ended(0, 12)},
+			{TaskID: "api", StartedAt: at(1, 9), EndedAt: ended(1, 10)},
+			{TaskID: "ui", StartedAt: at(2, 9), EndedAt: ended(2, 11)},
+			{TaskID: "mow", StartedAt: at(3, 9), EndedAt: ended(3, 10)},
+		}
+
+		sheet := service.BuildTimesheet(entries, tasks, from, to, to.Add(time.Hour))
+
+		assert.Equal(t, []model.TimesheetTask{
+			{TaskID: "api", Title: "Build API", Project: "work.backend", Duration: 4 * time.Hour},
+			{TaskID: "ui", Title: "Build UI", Project: "work.frontend", Duration: 2 * time.Hour},
+			{TaskID: "mow", Title: "Mow the lawn", Duration: time.Hour},
+		}, sheet.Tasks)
+		assert.Equal(t, []model.TimesheetProject{
+			{Name: "work", Duration: 6 * time.Hour},
+			{Name: "work.backend", Duration: 4 * time.Hour},
+			{Name: "work.frontend", Duration: 2 * time.Hour},
+			{Name: "", Duration: time.Hour},
+		}, sheet.Projects)
+		assert.Equal(t, 7*time.Hour, sheet.Total)
+	})
+
+	t.Run("期間外の部分は数えず、計測中の記録は現在時刻までとする", func(t *testing.T) {
+		entries := []model.TimeEntry{
+			{TaskID: "api", StartedAt: from.Add(-2 * time.Hour), EndedAt: ended(0, 1)},
+			{TaskID: "ui", StartedAt: at(2, 9)},
+			{TaskID: "mow", StartedAt: at(-2, 9), EndedAt: ended(-2, 10)},
+		}
+
+		sheet := service.BuildTimesheet(entries, tasks, from, to, at(2, 10))
+
+		assert.Equal(t, time.Hour, sheet.Tasks[0].Duration)
+		assert.Equal(t, time.Hour, sheet.Tasks[1].Duration)
+		assert.Len(t, sheet.Tasks, 2)
+		assert.Equal(t, 2*time.Hour, sheet.Total)
+		assert.Equal(t, to, sheet.To)
+	})
+}
@@ -0,0 +1,123 @@
+package service
+
+import (
+	"OTakumi/todogo/internal/domain/model"
+	"sort"
+	"strings"
+	"time"
+)
+
+// 緊急度の算出に使う係数
+// Taskwarriorの urgency を参考に、優先度・締切の近さ・経過日数・期限切れの各要素を重み付けして合算する
+const (
+	// urgencyDueCoefficient は締切の近さに掛ける係数
+	urgencyDueCoefficient = 12.0
+
+	// urgencyOverdueCoefficient は締切を過ぎたタスクに加算する値
+	urgencyOverdueCoefficient = 4.0
+
+	// urgencyAgeCoefficient は作成からの経過日数に掛ける係数
+	urgencyAgeCoefficient = 2.0
+
+	// urgencyDueHorizon はこれより先の締切を一律に「遠い」とみなす期間
+	urgencyDueHorizon = 14 * 24 * time.Hour
+
+	// urgencyAgeHorizon は経過日数の要素が最大になるまでの期間
+	urgencyAgeHorizon = 365 * 24 * time.Hour
+)
+
+// priorityUrgency は優先度ごとに加算する値
+var priorityUrgency = map[model.Priority]float64{
+	model.PriorityNone:   0,
+	model.PriorityLow:    1.8,
+	model.PriorityMedium: 3.9,
+	model.PriorityHigh:   6.0,
+	model.PriorityUrgent: 9.0,
+}
+
+// Urgency はタスクの緊急度を算出する
+// 値が大きいほど早く着手すべきタスクであることを表し、ワークフローの終了状態のタスクは0となる
+func Urgency(task *model.Task, wf *model.Workflow, now time.Time) float64 {
+	if wf.IsClosed(task) {
+		return 0
+	}
+
+	u := priorityUrgency[task.Priority]
+	if task.Deadline != nil {
+		u += urgencyDueCoefficient * dueFactor(*task.Deadline, now)
+		if task.Deadline.Before(now) {
+			u += urgencyOverdueCoefficient
+		}
+	}
+	u += urgencyAgeCoefficient * ageFactor(task.CreatedAt, now)
+
+	return u
+}
+
+// dueFactor は締切の近さを0.2〜1.0の値で返す
+// 締切までurgencyDueHorizon以上ある場合は0.2、締切に近づくにつれて線形に増え、締切以降は1.0となる
+func dueFactor(deadline, now time.Time) float64 {
+	remaining := deadline.Sub(now)
+	switch {
+	case remaining <= 0:
+		return 1.0
+	case remaining >= urgencyDueHorizon:
+		return 0.2
+	default:
+		return 0.2 + 0.8*(1-float64(remaining)/float64(urgencyDueHorizon))
+	}
+}
+
+// ageFactor は作成からの経過日数を0〜1.0の値で返す
+func ageFactor(created, now time.Time) float64 {
+	age := now.Sub(created)
+	switch {
+	case age <= 0:
+		return 0
+	case age >= urgencyAgeHorizon:
+		return 1.0
+	default:
+		return float64(age) / float64(urgencyAgeHorizon)
+	}
+}
+
+// SortByUrgency はタスクを緊急度の昇順（descがtrueの場合は降順）に並び替える
+// 緊急度が同じ場合はIDで順序を確定させる
+func SortByUrgency(tasks []*model.Task, wf *model.Workflow, now time.Time, desc bool) {
+	less := UrgencyLess(tasks, wf, now, desc)
+	sort.SliceStable(tasks, func(i, j int) bool {
+		return less(tasks[i], tasks[j])
+	})
+}
+
+// UrgencyLess は緊急度の順でaがbより前に並ぶかどうかを判定する関数を返す
+// 並び替えの間に緊急度を何度も算出しないよう、渡されたタスクの緊急度をあらかじめ計算しておく
+func UrgencyLess(tasks []*model.Task, wf *model.Workflow, now time.Time, desc bool) func(a, b *model.Task) bool {
+	scores := make(map[string]float64, len(tasks))
+	for _, task := range tasks {
+		scores[task.ID] = Urgency(task, wf, now)
+	}
+	score := func(task *model.Task) float64 {
+		if s, ok := scores[task.ID]; ok {
+			return s
+		}
+		return Urgency(task, wf, now)
+	}
+
+	return func(a, b *model.Task) bool {
+		sa, sb := score(a), score(b)
+		c := 0
+		switch {
+		case sa < sb:
+			c = -1
+		case sa > sb:
+			c = 1
+		default:
+			c = strings.Compare(a.ID, b.ID)
+		}
+		if desc {
+			return c > 0
+		}
+		return c < 0
+	}
+}
@@ -0,0 +1,74 @@
+package service_test
+
+import (
+	"OTakumi/todogo/internal/domain/model"
+	"OTakumi/todogo/internal/domain/service"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUrgency(t *testing.T) {
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	day := 24 * time.Hour
+	at := func(d time.Duration) *time.Time {
+		v := now.Add(d)
+		return &v
+	}
+
+	tests := []struct {
+		name string
+		task *model.Task
+		want float64
+	}{
+		{name: "要素がない場合は0", task: &model.Task{CreatedAt: now}, want: 0},
+		{name: "優先度のみ", task: &model.Task{Priority: model.PriorityHigh, CreatedAt: now}, want: 6.0},
+		{name: "締切が遠い", task: &model.Task{Deadline: at(30 * day), CreatedAt: now}, want: 12.0 * 0.2},
+		{name: "締切まで1週間", task: &model.Task{Deadline: at(7 * day), CreatedAt: now}, want: 12.0 * 0.6},
+		{name: "期限切れ", task: &model.Task{Deadline: at(-day), CreatedAt: now}, want: 12.0 + 4.0},
+		{name: "作成から半年", task: &model.Task{CreatedAt: now.Add(-365 * day / 2)}, want: 1.0},
+		{name: "作成から2年", task: &model.Task{CreatedAt: now.Add(-2 * 365 * day)}, want: 2.0},
+		{name: "完了済み", task: &model.Task{Priority: model.PriorityUrgent, Deadline: at(-day), Status: model.StatusDone}, want: 0},
+		{name: "取り消し済み", task: &model.Task{Priority: model.PriorityUrgent, Status: model.StatusCancelled}, want: 0},
+		{name: "作業中", task: &model.Task{Priority: model.PriorityHigh, CreatedAt: now, Status: model.StatusInProgress}, want: 6.0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.InDelta(t, tt.want, service.Urgency(tt.task, model.DefaultWorkflow(), now), 1e-9)
+		})
+	}
+}
+
+func TestSortByUrgency(t *testing.T) {
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	overdue := now.Add(-time.Hour)
+
+	tasks := []*model.Task{
+		{ID: "b", CreatedAt: now},
+		{ID: "a", CreatedAt: now},
+		{ID: "high", Priority: model.PriorityHigh, CreatedAt: now},
+		{ID: "overdue", Deadline: &overdue, CreatedAt: now},
+	}
+
+	t.Run("降順では緊急度の高いタスクが先頭に並び、同値の場合はIDの降順となる", func(t *testing.T) {
+		service.SortByUrgency(tasks, model.DefaultWorkflow(), now, true)
+
+		assert.Equal(t, []string{"overdue", "high", "b", "a"}, taskIDs(tasks))
+	})
+
+	t.Run("昇順では緊急度の低いタスクが先頭に並ぶ", func(t *testing.T) {
+		service.SortByUrgency(tasks, model.DefaultWorkflow(), now, false)
+
+		assert.Equal(t, []string{"a", "b", "high", "overdue"}, taskIDs(tasks))
+	})
+}
+
+func taskIDs(tasks []*model.Task) []string {
+	ids := make([]string, len(tasks))
+	for i, task := range tasks {
+		ids[i] = task.ID
+	}
+	return ids
+}
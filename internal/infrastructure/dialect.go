@@ -0,0 +1,72 @@
+package infrastructure
+
+import (
+	"errors"
+	"time"
+
+	"github.com/lib/pq"
+	"modernc.org/sqlite"
+	sqlite3 "modernc.org/sqlite/lib"
+)
+
+// dialect はデータベースごとのSQLの差異を吸収する
+// プレースホルダはどのデータベースでも $1, $2, ... の形式を使う
+type dialect struct {
+	// lockRowClause は更新対象の行をロックするためにSELECT文の末尾に付ける句
+	lockRowClause string
+
+	// noDeadline は締切が未設定のタスクの並び替えに使う、どの締切よりも後の値
+	noDeadline string
+
+	// utcTimes は時刻をUTCに変換してから保存するかどうか
+	// 時刻を文字列として保存するデータベースで、文字列の比較が時刻の比較と一致するようにする
+	utcTimes bool
+
+	// fullTextSearch はPostgreSQLの全文検索（tsvector）で検索するかどうか
+	// 使わない場合は、検索語の部分一致で検索する
+	fullTextSearch bool
+}
+
+var (
+	postgresDialect = dialect{
+		lockRowClause:  " FOR UPDATE",
+		noDeadline:     "'infinity'::timestamptz",
+		fullTextSearch: true,
+	}
+
+	// SQLiteは書き込み時にデータベース全体をロックするため、行ロックの句は不要
+	sqliteDialect = dialect{
+		noDeadline: "'9999-12-31'",
+		utcTimes:   true,
+	}
+)
+
+// timeValue はデータベースに渡す時刻の値を返す
+func (d dialect) timeValue(t time.Time) time.Time {
+	if d.utcTimes {
+		return t.UTC()
+	}
+	return t
+}
+
+// deadlineValue はデータベースに渡す締切などの省略可能な日時の値を返す
+// 未設定の場合はNULLとなる
+func (d dialect) deadlineValue(t *time.Time) any {
+	if t == nil {
+		return nil
+	}
+	return d.timeValue(*t)
+}
+
+// isUniqueViolation はデータベースの一意制約の違反によるエラーかどうかを返す
+func isUniqueViolation(err error) bool {
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) {
+		return pqErr.Code == "23505"
+	}
+	var sqliteErr *sqlite.Error
+	if errors.As(err, &sqliteErr) {
+		return sqliteErr.Code() == sqlite3.SQLITE_CONSTRAINT_UNIQUE
+	}
+	return false
+}
@@ -0,0 +1,984 @@
+package infrastructure
+
+import (
+	"OTakumi/todogo/internal/domain/model"
+	"OTakumi/todogo/internal/domain/service"
+	"OTakumi/todogo/internal/repository"
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// memoryTaskRepository はタスクをメモリ上に保持するリポジトリ
+// テストや一時的な利用を想定しており、プロセスの終了と共に内容は失われる
+type memoryTaskRepository struct {
+	mu    sync.RWMutex
+	tasks map[string]*model.Task
+	// trash はゴミ箱のタスク（DeletedAt を設定して、tasks とは別に保持する）
+	trash       map[string]*model.Task
+	recurrences map[string]model.Recurrence
+	// annotationSeq は最後に追加した注記のID（データベースの連番と同様に、削除しても再利用しない）
+	annotationSeq int64
+	// timeEntries は作業時間の記録（追加順）、timeEntrySeq は最後に追加した記録のID
+	timeEntries  []model.TimeEntry
+	timeEntrySeq int64
+	// events はタスクの履歴（記録順、削除したタスクの履歴も残す）、eventSeq は最後に記録した履歴のID
+	events   []model.TaskEvent
+	eventSeq int64
+	// operations は取り消しのために記録した操作（記録順）、operationSeq は最後に記録した操作のID
+	operations   []model.Operation
+	operationSeq int64
+	// workflow は状態の検証と、完了済み（終了状態）かどうかの判定に使う
+	workflow *model.Workflow
+}
+
+// NewMemoryTaskRepository はメモリ上にタスクを保存するリポジトリを生成する
+// 複数のゴルーチンから同時に利用できる
+func NewMemoryTaskRepository(wf *model.Workflow) repository.TaskRepository {
+	return &memoryTaskRepository{
+		tasks:       make(map[string]*model.Task),
+		trash:       make(map[string]*model.Task),
+		recurrences: make(map[string]model.Recurrence),
+		workflow:    wf,
+	}
+}
+
+func (r *memoryTaskRepository) FindAll(ctx context.Context, q repository.TaskQuery) ([]*model.Task, error) {
+	if err := q.ValidateStored(); err != nil {
+		return nil, fmt.Errorf("invalid query: %w", err)
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	less := taskLess(q.EffectiveSortBy(), q.SortDesc)
+
+	// キーセットページング: カーソルのタスクが存在しない場合は何も返さない
+	var cursor *model.Task
+	if q.AfterID != "" {
+		var ok bool
+		if cursor, ok = r.tasks[q.AfterID]; !ok {
+			return nil, nil
+		}
+	}
+
+	var graph *service.DependencyGraph
+	if q.Ready {
+		graph = service.NewDependencyGraph(r.all())
+	}
+
+	now := time.Now()
+	var tasks []*model.Task
+	for _, task := range r.tasks {
+		if !matchesQuery(task, q, now) {
+			continue
+		}
+		if graph != nil && !graph.IsReady(task.ID, r.workflow) {
+			continue
+		}
+		if cursor != nil && !less(cursor, task) {
+			continue
+		}
+		tasks = append(tasks, task)
+	}
+
+	sort.Slice(tasks, func(i, j int) bool {
+		return less(tasks[i], tasks[j])
+	})
+
+	if q.Limit > 0 && len(tasks) > q.Limit {
+		tasks = tasks[:q.Limit]
+	}
+
+	// 保持しているタスクを呼び出し元が変更できないよう、コピーを返す
+	tree := r.tree()
+	result := make([]*model.Task, 0, len(tasks))
+	for _, task := range tasks {
+		c := r.load(task)
+		c.Subtasks = tree.Progress(task.ID, r.workflow)
+		result = append(result, c)
+	}
+	return result, nil
+}
+
+func (r *memoryTaskRepository) FindByID(ctx context.Context, id string) (*model.Task, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	task, ok := r.tasks[id]
+	if !ok {
+		return nil, &repository.TaskNotFoundError{ID: id}
+	}
+	c := r.load(task)
+	c.Subtasks = r.tree().Progress(id, r.workflow)
+	return c, nil
+}
+
+func (r *memoryTaskRepository) Create(ctx context.Context, task *model.Task) (*model.Task, error) {
+	// コンテキストの確認
+	select {
+	case <-ctx.Done():
+		return nil, fmt.Errorf("context cancelled before task creation: %w", ctx.Err())
+	default:
+	}
+
+	// バリデーション
+	if err := task.Validate(); err != nil {
+		return nil, fmt.Errorf("validation failed: %w", err)
+	}
+
+	// 依存関係は AddDependency で、注記は AddAnnotation でのみ追加する
+	newTask := copyTask(task)
+	newTask.BlockedBy = []string{}
+	newTask.Annotations = []model.Annotation{}
+
+	// 状態が未指定の場合は初期状態とし、ワークフローにない状態は受け付けない
+	if newTask.Status == "" {
+		newTask.Status = r.workflow.Initial
+	}
+	if err := r.workflow.CheckStatus(newTask.Status); err != nil {
+		return nil, fmt.Errorf("validation failed: %w", err)
+	}
+
+	// IDの処理
+	if newTask.ID == "" {
+		newTask.ID = uuid.New().String()
+	} else if _, err := uuid.Parse(newTask.ID); err != nil {
+		return nil, fmt.Errorf("invalid task ID format: %w", err)
+	}
+
+	// タイムスタンプの設定
+	now := time.Now()
+	newTask.CreatedAt = now
+	newTask.UpdatedAt = now
+	newTask.CompletedAt = r.workflow.CompletedAt(nil, newTask.Status, now)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.exists(newTask.ID) {
+		return nil, fmt.Errorf("failed to insert task: task %s already exists", newTask.ID)
+	}
+	if err := r.checkReferences(newTask); err != nil {
+		return nil, err
+	}
+	r.tasks[newTask.ID] = newTask
+	r.recordEvents(model.NewTaskEvents(nil, newTask, r.workflow, repository.ActorFrom(ctx), now))
+	r.recordOperation(ctx, nil, newTask, now)
+
+	return r.load(newTask), nil
+}
+
+func (r *memoryTaskRepository) Update(ctx context.Context, task *model.Task) (*model.Task, error) {
+	updated, err := r.UpdateAll(ctx, []*model.Task{task})
+	if err != nil {
+		return nil, err
+	}
+	return updated[0], nil
+}
+
+func (r *memoryTaskRepository) UpdateAll(ctx context.Context, tasks []*model.Task) ([]*model.Task, error) {
+	// コンテキストの確認
+	select {
+	case <-ctx.Done():
+		return nil, fmt.Errorf("context cancelled before task update: %w", ctx.Err())
+	default:
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	// データベースのトランザクションと同様に、すべての更新を検証してから保存する
+	// 同じタスクを複数回更新する場合は、先の更新の結果を変更前の状態とする
+	now := time.Now()
+	staged := make(map[string]*model.Task, len(tasks))
+	befores := make([]*model.Task, 0, len(tasks))
+	afters := make([]*model.Task, 0, len(tasks))
+	for _, task := range tasks {
+		current, ok := staged[task.ID]
+		if !ok {
+			if current, ok = r.tasks[task.ID]; !ok {
+				return nil, &repository.TaskNotFoundError{ID: task.ID}
+			}
+		}
+		updatedTask, err := r.prepareUpdate(current, task, now)
+		if err != nil {
+			return nil, err
+		}
+		staged[task.ID] = updatedTask
+		befores = append(befores, current)
+		afters = append(afters, updatedTask)
+	}
+
+	for i, updatedTask := range afters {
+		current := befores[i]
+		r.tasks[updatedTask.ID] = updatedTask
+		r.recordEvents(model.NewTaskEvents(current, updatedTask, r.workflow, repository.ActorFrom(ctx), now))
+		// 項目を変更しなかった場合は、取り消す操作がないため記録しない
+		if len(model.ChangedFields(current, updatedTask)) > 0 {
+			r.recordOperation(ctx, current, updatedTask, now)
+		}
+	}
+
+	// サブタスクの進捗は、すべての更新を保存してから読み込む
+	updated := make([]*model.Task, 0, len(afters))
+	for _, updatedTask := range afters {
+		updated = append(updated, r.load(updatedTask))
+	}
+	return updated, nil
+}
+
+// prepareUpdate は current のタスクを task の内容で更新する場合の、更新後のタスクを検証して返す
+// 呼び出し元でロックを取得していること
+func (r *memoryTaskRepository) prepareUpdate(current, task *model.Task, now time.Time) (*model.Task, error) {
+	// バリデーション
+	if err := task.ValidateUpdate(current); err != nil {
+		return nil, fmt.Errorf("validation failed: %w", err)
+	}
+
+	if err := r.checkReferences(task); err != nil {
+		return nil, err
+	}
+
+	// 作成日時、依存関係、注記と、未指定の場合の状態は既存の値を維持する
+	updatedTask := copyTask(task)
+	updatedTask.BlockedBy = append([]string{}, current.BlockedBy...)
+	updatedTask.Annotations = append([]model.Annotation{}, current.Annotations...)
+	updatedTask.CreatedAt = current.CreatedAt
+	updatedTask.UpdatedAt = now
+	if updatedTask.Status == "" {
+		updatedTask.Status = current.Status
+	}
+
+	// 状態の変更は、ワークフローで許可された状態遷移のみを受け付ける
+	if err := r.workflow.CheckTransition(current.Status, updatedTask.Status); err != nil {
+		return nil, fmt.Errorf("validation failed: %w", err)
+	}
+	updatedTask.CompletedAt = r.workflow.CompletedAt(current, updatedTask.Status, now)
+	return updatedTask, nil
+}
+
+func (r *memoryTaskRepository) Delete(ctx context.Context, id string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	current, ok := r.tasks[id]
+	if !ok {
+		return &repository.TaskNotFoundError{ID: id}
+	}
+	now := time.Now()
+	r.trashTask(id, now)
+	r.recordEvents(model.NewTaskEvents(current, nil, r.workflow, repository.ActorFrom(ctx), now))
+	r.recordOperation(ctx, current, nil, now)
+
+	return nil
+}
+
+// exists はタスクがゴミ箱を含めて存在するかどうかを返す（データベースの主キーと外部キーの制約と同様に判定する）
+// 呼び出し元でロックを取得していること
+func (r *memoryTaskRepository) exists(id string) bool {
+	_, ok := r.tasks[id]
+	if !ok {
+		_, ok = r.trash[id]
+	}
+	return ok
+}
+
+// trashTask はタスクをゴミ箱に移す
+// データベースと同様にサブタスクの親子関係、注記、依存関係、作業時間の記録は元に戻す場合のために残す
+// 呼び出し元でロックを取得していること
+func (r *memoryTaskRepository) trashTask(id string, at time.Time) {
+	task := r.tasks[id]
+	delete(r.tasks, id)
+	task.DeletedAt = copyTime(&at)
+	r.trash[id] = task
+}
+
+// untrashTask はゴミ箱のタスクを元に戻す
+// 呼び出し元でロックを取得していること
+func (r *memoryTaskRepository) untrashTask(id string) *model.Task {
+	task := r.trash[id]
+	delete(r.trash, id)
+	task.DeletedAt = nil
+	r.tasks[id] = task
+	return task
+}
+
+// detachSubtasks はゴミ箱のタスクを含め、id のタスクのサブタスクを最上位のタスクとする
+// 呼び出し元でロックを取得していること
+func (r *memoryTaskRepository) detachSubtasks(id string) {
+	for _, tasks := range []map[string]*model.Task{r.tasks, r.trash} {
+		for _, task := range tasks {
+			if task.ParentID == id {
+				task.ParentID = ""
+			}
+		}
+	}
+}
+
+// removeTask はゴミ箱のタスクを完全に削除し、データベースの外部キー制約と同様に関連する値を変更する
+// 呼び出し元でロックを取得していること
+func (r *memoryTaskRepository) removeTask(id string) {
+	delete(r.trash, id)
+
+	// データベースの外部キー制約（ON DELETE SET NULL）と同様に、サブタスクは最上位のタスクとなる
+	// 依存関係は外部キー制約（ON DELETE CASCADE）と同様に削除する
+	// 注記はタスクと共に保持しているため、タスクと共に削除される
+	r.detachSubtasks(id)
+	for _, tasks := range []map[string]*model.Task{r.tasks, r.trash} {
+		for _, task := range tasks {
+			task.BlockedBy = removeID(task.BlockedBy, id)
+		}
+	}
+	// 作業時間の記録は外部キー制約（ON DELETE CASCADE）と同様に削除する
+	r.timeEntries = slices.DeleteFunc(r.timeEntries, func(e model.TimeEntry) bool {
+		return e.TaskID == id
+	})
+}
+
+// recordEvents はタスクの履歴に連番のIDを振って記録する
+// 呼び出し元でロックを取得していること
+func (r *memoryTaskRepository) recordEvents(events []model.TaskEvent) {
+	for _, event := range events {
+		r.eventSeq++
+		event.ID = r.eventSeq
+		r.events = append(r.events, event)
+	}
+}
+
+func (r *memoryTaskRepository) TaskEvents(ctx context.Context, taskID string) ([]model.TaskEvent, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	events := []model.TaskEvent{}
+	for _, event := range r.events {
+		if event.TaskID == taskID {
+			events = append(events, event)
+		}
+	}
+	return events, nil
+}
+
+// recordOperation はコンテキストが操作のまとまりを保持する場合に、タスクの保存を取り消しのために記録する
+// データベースと同様に、取り消し済みの操作と、保持する数を超えた古いまとまりの操作を削除する
+// 呼び出し元でロックを取得していること
+func (r *memoryTaskRepository) recordOperation(ctx context.Context, before, after *model.Task, at time.Time) {
+	batchID, command, ok := repository.BatchFrom(ctx)
+	if !ok {
+		return
+	}
+
+	op := model.NewOperation(operationTask(before), operationTask(after))
+	r.operationSeq++
+	op.ID = r.operationSeq
+	op.BatchID = batchID
+	op.Command = command
+	op.RecordedAt = at
+	r.operations = slices.DeleteFunc(r.operations, func(o model.Operation) bool { return o.Undone })
+	r.operations = append(r.operations, op)
+
+	if batches := model.GroupOperations(r.operations); len(batches) > repository.MaxOperationBatches {
+		kept := make(map[string]bool)
+		for _, b := range batches[len(batches)-repository.MaxOperationBatches:] {
+			kept[b.ID] = true
+		}
+		r.operations = slices.DeleteFunc(r.operations, func(o model.Operation) bool { return !kept[o.BatchID] })
+	}
+}
+
+// operationTask は取り消しのために記録する項目のみを持つ、タスクのコピーを作成する（nilの場合はnil）
+func operationTask(task *model.Task) *model.Task {
+	if task == nil {
+		return nil
+	}
+	c := copyTask(task)
+	c.BlockedBy = nil
+	c.Annotations = nil
+	c.Recurrence = nil
+	c.Subtasks = model.Progress{}
+	c.UpdatedAt = time.Time{}
+	return c
+}
+
+func (r *memoryTaskRepository) Operations(ctx context.Context, undone bool) ([]model.Operation, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	ops := []model.Operation{}
+	for _, op := range r.operations {
+		if op.Undone == undone {
+			op.Before = operationTask(op.Before)
+			op.After = operationTask(op.After)
+			ops = append(ops, op)
+		}
+	}
+	return ops, nil
+}
+
+func (r *memoryTaskRepository) ReplayOperations(ctx context.Context, ops []model.Operation, undo bool) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	// データベースのトランザクションと同様に、すべての操作を確認してから変更する
+	// 確認は先の操作を適用した後の状態に対して行う
+	staged := make(map[string]*model.Task)
+	lookup := func(id string) *model.Task {
+		if task, ok := staged[id]; ok {
+			return task
+		}
+		return r.tasks[id]
+	}
+	for _, op := range ops {
+		if err := op.Check(lookup(op.TaskID), undo); err != nil {
+			return err
+		}
+		target := op.Target(undo)
+		if target != nil && target.ParentID != "" && lookup(target.ParentID) == nil && !r.exists(target.ParentID) {
+			return fmt.Errorf("failed to save task: parent task %s does not exist", target.ParentID)
+		}
+		if target != nil && target.RecurrenceID != "" {
+			if _, ok := r.recurrences[target.RecurrenceID]; !ok {
+				return fmt.Errorf("failed to save task: recurrence %s does not exist", target.RecurrenceID)
+			}
+		}
+		staged[op.TaskID] = target
+	}
+
+	// 作成日時と完了日時は操作の前後の値に戻し、更新日時は戻した日時とする
+	// 削除の取り消しはゴミ箱のタスクを元に戻し、完全に削除した後の場合は記録した項目から作成し直す
+	now := time.Now()
+	actor := repository.ActorFrom(ctx)
+	for _, op := range ops {
+		current := r.tasks[op.TaskID]
+		target := op.Target(undo)
+		events := model.NewTaskEvents(current, target, r.workflow, actor, now)
+		if target == nil {
+			r.trashTask(op.TaskID, now)
+		} else {
+			target = copyTask(target)
+			target.UpdatedAt = now
+			target.BlockedBy = []string{}
+			target.Annotations = []model.Annotation{}
+			if trashed, ok := r.trash[op.TaskID]; current == nil && ok {
+				current = r.untrashTask(op.TaskID)
+				events = append([]model.TaskEvent{model.NewTrashEvent(model.TaskRestored, trashed, actor, now)},
+					model.NewTaskEvents(trashed, target, r.workflow, actor, now)...)
+			}
+			if current != nil {
+				target.BlockedBy = append([]string{}, current.BlockedBy...)
+				target.Annotations = append([]model.Annotation{}, current.Annotations...)
+			}
+			r.tasks[op.TaskID] = target
+		}
+		r.recordEvents(events)
+
+		for i := range r.operations {
+			if r.operations[i].ID == op.ID {
+				r.operations[i].Undone = undo
+			}
+		}
+	}
+	return nil
+}
+
+// checkReferences はデータベースの外部キー制約と同様に、親タスクと繰り返しのルールが存在することを確認する
+// 呼び出し元でロックを取得していること
+func (r *memoryTaskRepository) checkReferences(task *model.Task) error {
+	if task.ParentID != "" {
+		if !r.exists(task.ParentID) {
+			return fmt.Errorf("failed to save task: parent task %s does not exist", task.ParentID)
+		}
+	}
+	if task.RecurrenceID != "" {
+		if _, ok := r.recurrences[task.RecurrenceID]; !ok {
+			return fmt.Errorf("failed to save task: recurrence %s does not exist", task.RecurrenceID)
+		}
+	}
+	return nil
+}
+
+// load は保持しているタスクのコピーに、参照している繰り返しのルールを設定して返す
+// データベースと同様に、ゴミ箱のタスクとの依存関係は除く
+// 呼び出し元でロックを取得していること
+func (r *memoryTaskRepository) load(task *model.Task) *model.Task {
+	c := copyTask(task)
+	c.BlockedBy = slices.DeleteFunc(c.BlockedBy, func(id string) bool {
+		_, ok := r.tasks[id]
+		return !ok
+	})
+	c.Recurrence = nil
+	if rule, ok := r.recurrences[task.RecurrenceID]; ok {
+		c.Recurrence = copyRecurrence(&rule)
+	}
+	return c
+}
+
+// tree は保持しているすべてのタスクの親子関係を返す
+// 呼び出し元でロックを取得していること
+func (r *memoryTaskRepository) tree() *service.TaskTree {
+	return service.NewTaskTree(r.all())
+}
+
+// all は保持しているすべてのタスクを返す
+// 呼び出し元でロックを取得していること
+func (r *memoryTaskRepository) all() []*model.Task {
+	tasks := make([]*model.Task, 0, len(r.tasks))
+	for _, task := range r.tasks {
+		tasks = append(tasks, task)
+	}
+	return tasks
+}
+
+func (r *memoryTaskRepository) AddDependency(ctx context.Context, taskID, blockedByID string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	// データベースの外部キー制約と同様に、両方のタスクが存在することを確認する
+	task, ok := r.tasks[taskID]
+	if !ok {
+		return &repository.TaskNotFoundError{ID: taskID}
+	}
+	if _, ok := r.tasks[blockedByID]; !ok {
+		return &repository.TaskNotFoundError{ID: blockedByID}
+	}
+	if taskID == blockedByID {
+		return fmt.Errorf("failed to add dependency: task %s cannot be blocked by itself", taskID)
+	}
+
+	// 既に存在する場合は何もせず、IDの順序を維持して追加する
+	i := sort.SearchStrings(task.BlockedBy, blockedByID)
+	if i < len(task.BlockedBy) && task.BlockedBy[i] == blockedByID {
+		return nil
+	}
+	task.BlockedBy = append(task.BlockedBy[:i], append([]string{blockedByID}, task.BlockedBy[i:]...)...)
+	return nil
+}
+
+func (r *memoryTaskRepository) RemoveDependency(ctx context.Context, taskID, blockedByID string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	task, ok := r.tasks[taskID]
+	if !ok || !slices.Contains(task.BlockedBy, blockedByID) {
+		return &repository.DependencyNotFoundError{TaskID: taskID, BlockedByID: blockedByID}
+	}
+	task.BlockedBy = removeID(task.BlockedBy, blockedByID)
+	return nil
+}
+
+// removeID はIDの一覧から指定したIDを除いた一覧を返す
+func removeID(ids []string, id string) []string {
+	return slices.DeleteFunc(ids, func(v string) bool { return v == id })
+}
+
+func (r *memoryTaskRepository) SaveRecurrence(ctx context.Context, id string, rule model.Recurrence) error {
+	if err := rule.Validate(); err != nil {
+		return fmt.Errorf("validation failed: %w", err)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.recurrences[id] = *copyRecurrence(&rule)
+	return nil
+}
+
+func (r *memoryTaskRepository) DeleteRecurrence(ctx context.Context, id string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.recurrences[id]; !ok {
+		return &repository.RecurrenceNotFoundError{ID: id}
+	}
+	delete(r.recurrences, id)
+
+	// データベースの外部キー制約（ON DELETE SET NULL）と同様に、ゴミ箱のタスクを含めて繰り返さないタスクとなる
+	for _, tasks := range []map[string]*model.Task{r.tasks, r.trash} {
+		for _, task := range tasks {
+			if task.RecurrenceID == id {
+				task.RecurrenceID = ""
+			}
+		}
+	}
+	return nil
+}
+
+func (r *memoryTaskRepository) FindDeleted(ctx context.Context) ([]*model.Task, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	tasks := make([]*model.Task, 0, len(r.trash))
+	for _, task := range r.trash {
+		tasks = append(tasks, r.load(task))
+	}
+	// データベースと同様に、ゴミ箱に移した日時の新しい順、同じ日時の場合はIDの順に並べる
+	sort.Slice(tasks, func(i, j int) bool {
+		if !tasks[i].DeletedAt.Equal(*tasks[j].DeletedAt) {
+			return tasks[i].DeletedAt.After(*tasks[j].DeletedAt)
+		}
+		return tasks[i].ID < tasks[j].ID
+	})
+	return tasks, nil
+}
+
+func (r *memoryTaskRepository) Restore(ctx context.Context, id string) (*model.Task, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.trash[id]; !ok {
+		return nil, &repository.TaskNotFoundError{ID: id}
+	}
+	now := time.Now()
+	task := r.untrashTask(id)
+	r.recordEvents([]model.TaskEvent{model.NewTrashEvent(model.TaskRestored, task, repository.ActorFrom(ctx), now)})
+	// ゴミ箱から戻したタスクは、取り消す場合に再びゴミ箱に移せるよう作成として記録する
+	r.recordOperation(ctx, nil, task, now)
+
+	c := r.load(task)
+	c.Subtasks = r.tree().Progress(id, r.workflow)
+	return c, nil
+}
+
+func (r *memoryTaskRepository) Purge(ctx context.Context, before time.Time) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	// データベースと同様に、ゴミ箱に移した日時の古い順に削除して履歴に記録する
+	var purged []*model.Task
+	for _, task := range r.trash {
+		if task.DeletedAt.Before(before) {
+			purged = append(purged, task)
+		}
+	}
+	sort.Slice(purged, func(i, j int) bool {
+		if !purged[i].DeletedAt.Equal(*purged[j].DeletedAt) {
+			return purged[i].DeletedAt.Before(*purged[j].DeletedAt)
+		}
+		return purged[i].ID < purged[j].ID
+	})
+
+	now := time.Now()
+	actor := repository.ActorFrom(ctx)
+	for _, task := range purged {
+		r.removeTask(task.ID)
+		r.recordEvents([]model.TaskEvent{model.NewTrashEvent(model.TaskPurged, task, actor, now)})
+	}
+	return len(purged), nil
+}
+
+func (r *memoryTaskRepository) AddAnnotation(ctx context.Context, taskID, text string) (*model.Annotation, error) {
+	text, err := model.NormalizeAnnotation(text)
+	if err != nil {
+		return nil, fmt.Errorf("validation failed: %w", err)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	task, ok := r.tasks[taskID]
+	if !ok {
+		return nil, &repository.TaskNotFoundError{ID: taskID}
+	}
+
+	r.annotationSeq++
+	annotation := model.Annotation{ID: r.annotationSeq, Text: text, CreatedAt: time.Now()}
+	task.Annotations = append(task.Annotations, annotation)
+	return &annotation, nil
+}
+
+func (r *memoryTaskRepository) AddTimeEntry(ctx context.Context, entry model.TimeEntry) (*model.TimeEntry, error) {
+	if err := entry.Validate(); err != nil {
+		return nil, fmt.Errorf("validation failed: %w", err)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.tasks[entry.TaskID]; !ok {
+		return nil, &repository.TaskNotFoundError{ID: entry.TaskID}
+	}
+	// データベースの一意制約と同様に、計測中の記録は利用者ごとに1件までとする
+	if entry.Running() {
+		for _, e := range r.timeEntries {
+			if e.User == entry.User && e.Running() {
+				return nil, &model.TimerRunningError{Entry: *copyTimeEntry(e)}
+			}
+		}
+	}
+
+	r.timeEntrySeq++
+	entry.ID = r.timeEntrySeq
+	entry.EndedAt = copyTime(entry.EndedAt)
+	r.timeEntries = append(r.timeEntries, entry)
+	return copyTimeEntry(entry), nil
+}
+
+func (r *memoryTaskRepository) StopTimeEntry(ctx context.Context, id int64, endedAt time.Time) (*model.TimeEntry, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	i := slices.IndexFunc(r.timeEntries, func(e model.TimeEntry) bool {
+		return e.ID == id && e.Running()
+	})
+	if i < 0 {
+		return nil, &repository.TimeEntryNotFoundError{ID: id}
+	}
+
+	entry := r.timeEntries[i]
+	entry.EndedAt = &endedAt
+	if err := entry.Validate(); err != nil {
+		return nil, fmt.Errorf("validation failed: %w", err)
+	}
+	r.timeEntries[i] = entry
+	return copyTimeEntry(entry), nil
+}
+
+func (r *memoryTaskRepository) TimeEntries(ctx context.Context, q repository.TimeEntryQuery) ([]model.TimeEntry, error) {
+	if err := q.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid query: %w", err)
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	entries := []model.TimeEntry{}
+	for _, e := range r.timeEntries {
+		if (q.TaskID != "" && e.TaskID != q.TaskID) ||
+			(q.User != "" && e.User != q.User) ||
+			(q.Running && !e.Running()) ||
+			(q.Since != nil && e.EndedAt != nil && !e.EndedAt.After(*q.Since)) ||
+			(q.Until != nil && !e.StartedAt.Before(*q.Until)) {
+			continue
+		}
+		entries = append(entries, *copyTimeEntry(e))
+	}
+	sort.SliceStable(entries, func(i, j int) bool {
+		return entries[i].StartedAt.Before(entries[j].StartedAt)
+	})
+	return entries, nil
+}
+
+func (r *memoryTaskRepository) Search(ctx context.Context, query string, limit int) ([]model.SearchHit, error) {
+	terms, err := model.SearchTerms(query)
+	if err != nil {
+		return nil, fmt.Errorf("invalid search query: %w", err)
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	// 全文検索の機能はないため、すべてのタスクを部分一致で照合する
+	tree := r.tree()
+	tasks := make([]*model.Task, 0, len(r.tasks))
+	for _, task := range r.tasks {
+		c := r.load(task)
+		c.Subtasks = tree.Progress(task.ID, r.workflow)
+		tasks = append(tasks, c)
+	}
+	return matchSubstring(tasks, terms, limit), nil
+}
+
+func (r *memoryTaskRepository) TagCounts(ctx context.Context) ([]model.TagCount, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	byName := make(map[string]*model.TagCount)
+	for _, task := range r.tasks {
+		for _, tag := range task.Tags {
+			c, ok := byName[tag]
+			if !ok {
+				c = &model.TagCount{Name: tag}
+				byName[tag] = c
+			}
+			c.Total++
+			if !r.workflow.IsClosed(task) {
+				c.Open++
+			}
+		}
+	}
+
+	counts := make([]model.TagCount, 0, len(byName))
+	for _, c := range byName {
+		counts = append(counts, *c)
+	}
+	sort.Slice(counts, func(i, j int) bool {
+		return counts[i].Name < counts[j].Name
+	})
+	return counts, nil
+}
+
+func (r *memoryTaskRepository) ProjectCounts(ctx context.Context) ([]model.ProjectCount, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	byName := make(map[string]*model.ProjectCount)
+	for _, task := range r.tasks {
+		if task.Project == "" {
+			continue
+		}
+		c, ok := byName[task.Project]
+		if !ok {
+			c = &model.ProjectCount{Name: task.Project}
+			byName[task.Project] = c
+		}
+		if r.workflow.IsClosed(task) {
+			c.Closed++
+		} else {
+			c.Open++
+		}
+	}
+
+	counts := make([]model.ProjectCount, 0, len(byName))
+	for _, c := range byName {
+		counts = append(counts, *c)
+	}
+	sort.Slice(counts, func(i, j int) bool {
+		return counts[i].Name < counts[j].Name
+	})
+	return counts, nil
+}
+
+// matchesQuery はタスクが絞り込み条件を満たすかどうかを判定する
+func matchesQuery(task *model.Task, q repository.TaskQuery, now time.Time) bool {
+	if len(q.Statuses) > 0 && !slices.Contains(q.Statuses, task.Status) {
+		return false
+	}
+
+	// 締切で絞り込む場合、締切が未設定のタスクは対象外とする
+	if q.DeadlineBefore != nil && (task.Deadline == nil || !task.Deadline.Before(*q.DeadlineBefore)) {
+		return false
+	}
+	if q.DeadlineAfter != nil && (task.Deadline == nil || task.Deadline.Before(*q.DeadlineAfter)) {
+		return false
+	}
+
+	if q.TitleContains != "" && !strings.Contains(strings.ToLower(task.Title), strings.ToLower(q.TitleContains)) {
+		return false
+	}
+
+	switch q.Waiting {
+	case repository.WaitingHide:
+		if task.IsWaiting(now) {
+			return false
+		}
+	case repository.WaitingOnly:
+		if !task.IsWaiting(now) {
+			return false
+		}
+	}
+
+	// タグはグループごとに、いずれかのタグが付いていることを条件とする
+	for _, group := range q.Tags {
+		if !hasAnyTag(task, group) {
+			return false
+		}
+	}
+	if hasAnyTag(task, q.ExcludeTags) {
+		return false
+	}
+
+	// プロジェクトは配下のプロジェクトも含めて絞り込む
+	if q.Project != "" && !model.InProject(task.Project, q.Project) {
+		return false
+	}
+
+	return true
+}
+
+func hasAnyTag(task *model.Task, tags []string) bool {
+	for _, tag := range tags {
+		if task.HasTag(tag) {
+			return true
+		}
+	}
+	return false
+}
+
+// taskLess は並び替え項目に従ってaがbより前に並ぶかどうかを判定する関数を返す
+// 同値の場合はIDで順序を確定させ、締切が未設定のタスクは締切が無限に遠いものとして扱う
+func taskLess(key repository.SortKey, desc bool) func(a, b *model.Task) bool {
+	return func(a, b *model.Task) bool {
+		c := compareBy(key, a, b)
+		if c == 0 {
+			c = strings.Compare(a.ID, b.ID)
+		}
+		if desc {
+			return c > 0
+		}
+		return c < 0
+	}
+}
+
+func compareBy(key repository.SortKey, a, b *model.Task) int {
+	switch key {
+	case repository.SortByUpdatedAt:
+		return a.UpdatedAt.Compare(b.UpdatedAt)
+	case repository.SortByDeadline:
+		switch {
+		case a.Deadline == nil && b.Deadline == nil:
+			return 0
+		case a.Deadline == nil:
+			return 1
+		case b.Deadline == nil:
+			return -1
+		}
+		return a.Deadline.Compare(*b.Deadline)
+	case repository.SortByTitle:
+		return strings.Compare(strings.ToLower(a.Title), strings.ToLower(b.Title))
+	case repository.SortByPriority:
+		return int(a.Priority) - int(b.Priority)
+	default:
+		return a.CreatedAt.Compare(b.CreatedAt)
+	}
+}
+
+// copyTask はタスクのコピーを作成する
+// 日時、タグ、依存関係、繰り返しのルール、注記、見積もりは参照型のため、値もコピーして共有しないようにする
+func copyTask(task *model.Task) *model.Task {
+	c := *task
+	c.Deadline = copyTime(task.Deadline)
+	c.StartAt = copyTime(task.StartAt)
+	c.WaitUntil = copyTime(task.WaitUntil)
+	c.CompletedAt = copyTime(task.CompletedAt)
+	c.DeletedAt = copyTime(task.DeletedAt)
+	c.Tags = append([]string{}, task.Tags...)
+	c.BlockedBy = append([]string{}, task.BlockedBy...)
+	c.Recurrence = copyRecurrence(task.Recurrence)
+	c.Annotations = append([]model.Annotation{}, task.Annotations...)
+	if task.Estimate != nil {
+		estimate := *task.Estimate
+		c.Estimate = &estimate
+	}
+	return &c
+}
+
+// copyTime は省略可能な日時のコピーを作成する
+func copyTime(t *time.Time) *time.Time {
+	if t == nil {
+		return nil
+	}
+	c := *t
+	return &c
+}
+
+// copyTimeEntry は作業時間の記録のコピーを作成する
+func copyTimeEntry(entry model.TimeEntry) *model.TimeEntry {
+	entry.EndedAt = copyTime(entry.EndedAt)
+	return &entry
+}
+
+// copyRecurrence は繰り返しのルールのコピーを作成する
+func copyRecurrence(rule *model.Recurrence) *model.Recurrence {
+	if rule == nil {
+		return nil
+	}
+	c := *rule
+	c.Weekdays = slices.Clone(rule.Weekdays)
+	return &c
+}
@@ -0,0 +1,45 @@
+package infrastructure
+
+import (
+	"OTakumi/todogo/internal/domain/model"
+	"OTakumi/todogo/internal/repository"
+	"OTakumi/todogo/internal/repository/repositorytest"
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMemoryTaskRepository(t *testing.T) {
+	repositorytest.Run(t, func(t *testing.T) repository.TaskRepository {
+		return NewMemoryTaskRepository(model.DefaultWorkflow())
+	})
+}
+
+func TestMemoryTaskRepository_Concurrent(t *testing.T) {
+	// 複数のゴルーチンから同時に作成しても、すべてのタスクが保存される
+	repo := NewMemoryTaskRepository(model.DefaultWorkflow())
+	ctx := context.Background()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			task, err := repo.Create(ctx, &model.Task{Title: "Concurrent"})
+			if assert.NoError(t, err) {
+				_, err = repo.FindAll(ctx, repository.TaskQuery{})
+				assert.NoError(t, err)
+				task.Status = model.StatusDone
+				_, err = repo.Update(ctx, task)
+				assert.NoError(t, err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	tasks, err := repo.FindAll(ctx, repository.TaskQuery{Statuses: []model.Status{model.StatusDone}})
+	assert.NoError(t, err)
+	assert.Len(t, tasks, 50)
+}
@@ -0,0 +1,59 @@
+package migration
+
+import (
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoad(t *testing.T) {
+	t.Run("バージョン順にupとdownを組にして読み込む", func(t *testing.T) {
+		// Arrange
+		fsys := fstest.MapFS{
+			"000002_add_index.up.sql":      {Data: []byte("CREATE INDEX idx ON tasks (title);")},
+			"000001_create_tasks.up.sql":   {Data: []byte("CREATE TABLE tasks ();")},
+			"000001_create_tasks.down.sql": {Data: []byte("DROP TABLE tasks;")},
+			"migrations.go":                {Data: []byte("package migrations")},
+		}
+
+		// Act
+		migrations, err := Load(fsys)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, []Migration{
+			{Version: 1, Name: "create_tasks", Up: "CREATE TABLE tasks ();", Down: "DROP TABLE tasks;"},
+			{Version: 2, Name: "add_index", Up: "CREATE INDEX idx ON tasks (title);"},
+		}, migrations)
+	})
+
+	t.Run("upファイルがない場合はエラーを返す", func(t *testing.T) {
+		// Arrange
+		fsys := fstest.MapFS{
+			"000001_create_tasks.down.sql": {Data: []byte("DROP TABLE tasks;")},
+		}
+
+		// Act
+		migrations, err := Load(fsys)
+
+		// Assert
+		assert.Error(t, err)
+		assert.Nil(t, migrations)
+	})
+
+	t.Run("同じバージョンで名前が異なる場合はエラーを返す", func(t *testing.T) {
+		// Arrange
+		fsys := fstest.MapFS{
+			"000001_create_tasks.up.sql":   {Data: []byte("CREATE TABLE tasks ();")},
+			"000001_create_todos.down.sql": {Data: []byte("DROP TABLE todos;")},
+		}
+
+		// Act
+		migrations, err := Load(fsys)
+
+		// Assert
+		assert.Error(t, err)
+		assert.Nil(t, migrations)
+	})
+}
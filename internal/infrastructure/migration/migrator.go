@@ -0,0 +1,340 @@
+package migration
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"io/fs"
+	"time"
+)
+
+const (
+	// schemaTable は適用済みのマイグレーションを記録するテーブル
+	schemaTable = "todogo_schema_migrations"
+
+	// legacyTable はgolang-migrate CLIが現在のバージョンを記録するテーブル
+	legacyTable = "schema_migrations"
+
+	// advisoryLockID はマイグレーション実行中に取得するアドバイザリロックのキー
+	// 複数のCLIが同時にマイグレーションを実行しても、順番に処理されるようにする
+	advisoryLockID int64 = 7_310_200_436
+)
+
+// ErrNoDownMigration はロールバック用のSQLが存在しないことを表すエラー
+var ErrNoDownMigration = errors.New("migration has no down file")
+
+// Status は各マイグレーションの適用状況
+type Status struct {
+	Migration
+	Applied   bool
+	AppliedAt *time.Time
+}
+
+// dialect はデータベースごとに異なるマイグレーションの実行方法
+type dialect struct {
+	// timestampType は適用日時の列の型
+	timestampType string
+
+	// lock はマイグレーションの排他制御を行い、解放用の関数を返す
+	lock func(ctx context.Context, conn *sql.Conn) (unlock func(), err error)
+
+	// importLegacy はgolang-migrate CLIの適用状況を引き継ぐかどうか
+	importLegacy bool
+}
+
+var (
+	postgresDialect = dialect{
+		timestampType: "TIMESTAMP WITH TIME ZONE",
+		lock:          advisoryLock,
+		importLegacy:  true,
+	}
+
+	// SQLiteはデータベースファイル単位で書き込みをロックし、各マイグレーションはトランザクション内で
+	// 適用記録と共にコミットされるため、別途ロックは取得しない
+	sqliteDialect = dialect{
+		timestampType: "TIMESTAMP",
+		lock: func(context.Context, *sql.Conn) (func(), error) {
+			return func() {}, nil
+		},
+	}
+)
+
+// Migrator は埋め込まれたマイグレーションをデータベースに適用する
+type Migrator struct {
+	db         *sql.DB
+	dialect    dialect
+	migrations []Migration
+}
+
+// New はファイルシステムからマイグレーションを読み込み、PostgreSQL用のMigratorを生成する
+func New(db *sql.DB, fsys fs.FS) (*Migrator, error) {
+	return newMigrator(db, fsys, postgresDialect)
+}
+
+// NewSQLite はファイルシステムからマイグレーションを読み込み、SQLite用のMigratorを生成する
+func NewSQLite(db *sql.DB, fsys fs.FS) (*Migrator, error) {
+	return newMigrator(db, fsys, sqliteDialect)
+}
+
+func newMigrator(db *sql.DB, fsys fs.FS, d dialect) (*Migrator, error) {
+	migrations, err := Load(fsys)
+	if err != nil {
+		return nil, err
+	}
+	return &Migrator{db: db, dialect: d, migrations: migrations}, nil
+}
+
+// Migrations は読み込まれたマイグレーションをバージョン順に返す
+func (m *Migrator) Migrations() []Migration {
+	return m.migrations
+}
+
+// Up は未適用のマイグレーションをバージョンの昇順に適用する
+// nが0以下の場合はすべての未適用分を、それ以外は最大n件を適用する
+func (m *Migrator) Up(ctx context.Context, n int) ([]Migration, error) {
+	var done []Migration
+	err := m.withLock(ctx, func(conn *sql.Conn) error {
+		applied, err := appliedVersions(ctx, conn)
+		if err != nil {
+			return err
+		}
+
+		for _, mig := range m.migrations {
+			if n > 0 && len(done) >= n {
+				break
+			}
+			if _, ok := applied[mig.Version]; ok {
+				continue
+			}
+
+			// スキーマ変更と適用記録を同一トランザクションで行い、途中失敗時に中途半端な状態を残さない
+			err := inTx(ctx, conn, func(tx *sql.Tx) error {
+				if _, err := tx.ExecContext(ctx, mig.Up); err != nil {
+					return err
+				}
+				_, err := tx.ExecContext(ctx,
+					"INSERT INTO "+schemaTable+" (version, name, applied_at) VALUES ($1, $2, $3)",
+					int64(mig.Version), mig.Name, time.Now().UTC())
+				return err
+			})
+			if err != nil {
+				return fmt.Errorf("failed to apply migration %d_%s: %w", mig.Version, mig.Name, err)
+			}
+			done = append(done, mig)
+		}
+		return nil
+	})
+	return done, err
+}
+
+// Down は適用済みのマイグレーションをバージョンの降順にロールバックする
+// nが0以下の場合はすべての適用済み分を、それ以外は最大n件をロールバックする
+func (m *Migrator) Down(ctx context.Context, n int) ([]Migration, error) {
+	var done []Migration
+	err := m.withLock(ctx, func(conn *sql.Conn) error {
+		applied, err := appliedVersions(ctx, conn)
+		if err != nil {
+			return err
+		}
+
+		for i := len(m.migrations) - 1; i >= 0; i-- {
+			mig := m.migrations[i]
+			if n > 0 && len(done) >= n {
+				break
+			}
+			if _, ok := applied[mig.Version]; !ok {
+				continue
+			}
+			if mig.Down == "" {
+				return fmt.Errorf("cannot roll back migration %d_%s: %w", mig.Version, mig.Name, ErrNoDownMigration)
+			}
+
+			err := inTx(ctx, conn, func(tx *sql.Tx) error {
+				if _, err := tx.ExecContext(ctx, mig.Down); err != nil {
+					return err
+				}
+				_, err := tx.ExecContext(ctx,
+					"DELETE FROM "+schemaTable+" WHERE version = $1", int64(mig.Version))
+				return err
+			})
+			if err != nil {
+				return fmt.Errorf("failed to roll back migration %d_%s: %w", mig.Version, mig.Name, err)
+			}
+			done = append(done, mig)
+		}
+		return nil
+	})
+	return done, err
+}
+
+// Status はすべてのマイグレーションの適用状況をバージョン順に返す
+func (m *Migrator) Status(ctx context.Context) ([]Status, error) {
+	var statuses []Status
+	err := m.withLock(ctx, func(conn *sql.Conn) error {
+		applied, err := appliedVersions(ctx, conn)
+		if err != nil {
+			return err
+		}
+
+		for _, mig := range m.migrations {
+			st := Status{Migration: mig}
+			if at, ok := applied[mig.Version]; ok {
+				st.Applied = true
+				st.AppliedAt = &at
+			}
+			statuses = append(statuses, st)
+		}
+		return nil
+	})
+	return statuses, err
+}
+
+// Version は適用済みの最大のバージョンを返す
+// 1件も適用されていない場合はok=falseを返す
+func (m *Migrator) Version(ctx context.Context) (version uint64, ok bool, err error) {
+	err = m.withLock(ctx, func(conn *sql.Conn) error {
+		applied, err := appliedVersions(ctx, conn)
+		if err != nil {
+			return err
+		}
+		for v := range applied {
+			if !ok || v > version {
+				version, ok = v, true
+			}
+		}
+		return nil
+	})
+	return version, ok, err
+}
+
+// withLock はロックを取得した単一のコネクション上で処理を実行する
+// ロックはセッションに紐づくため、処理はすべて同じコネクションで行う
+func (m *Migrator) withLock(ctx context.Context, fn func(conn *sql.Conn) error) error {
+	conn, err := m.db.Conn(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get connection: %w", err)
+	}
+	defer func() { _ = conn.Close() }()
+
+	unlock, err := m.dialect.lock(ctx, conn)
+	if err != nil {
+		return fmt.Errorf("failed to acquire migration lock: %w", err)
+	}
+	defer unlock()
+
+	if err := m.ensureSchemaTable(ctx, conn); err != nil {
+		return err
+	}
+	return fn(conn)
+}
+
+// advisoryLock はPostgreSQLのアドバイザリロックを取得する
+func advisoryLock(ctx context.Context, conn *sql.Conn) (func(), error) {
+	if _, err := conn.ExecContext(ctx, "SELECT pg_advisory_lock($1)", advisoryLockID); err != nil {
+		return nil, err
+	}
+	return func() {
+		// 呼び出し元のコンテキストがキャンセルされていてもロックは解放する
+		_, _ = conn.ExecContext(context.Background(), "SELECT pg_advisory_unlock($1)", advisoryLockID)
+	}, nil
+}
+
+// ensureSchemaTable は適用記録のテーブルを作成する
+// golang-migrate CLIで適用済みのデータベースの場合は、その時点のバージョンまでを適用済みとして引き継ぐ
+func (m *Migrator) ensureSchemaTable(ctx context.Context, conn *sql.Conn) error {
+	_, err := conn.ExecContext(ctx, `CREATE TABLE IF NOT EXISTS `+schemaTable+` (
+		version BIGINT PRIMARY KEY,
+		name TEXT NOT NULL,
+		applied_at `+m.dialect.timestampType+` NOT NULL
+	)`)
+	if err != nil {
+		return fmt.Errorf("failed to create %s: %w", schemaTable, err)
+	}
+
+	var count int
+	if err := conn.QueryRowContext(ctx, "SELECT COUNT(*) FROM "+schemaTable).Scan(&count); err != nil {
+		return fmt.Errorf("failed to read %s: %w", schemaTable, err)
+	}
+	if count > 0 || !m.dialect.importLegacy {
+		return nil
+	}
+
+	return m.importLegacyVersion(ctx, conn)
+}
+
+// importLegacyVersion はgolang-migrateのschema_migrationsテーブルから現在のバージョンを引き継ぐ
+// テーブルが存在しない場合や、前回の実行が失敗してdirtyな場合は何もしない
+func (m *Migrator) importLegacyVersion(ctx context.Context, conn *sql.Conn) error {
+	var exists bool
+	if err := conn.QueryRowContext(ctx, "SELECT to_regclass($1) IS NOT NULL", legacyTable).Scan(&exists); err != nil {
+		return fmt.Errorf("failed to check %s: %w", legacyTable, err)
+	}
+	if !exists {
+		return nil
+	}
+
+	var (
+		version int64
+		dirty   bool
+	)
+	err := conn.QueryRowContext(ctx, "SELECT version, dirty FROM "+legacyTable+" LIMIT 1").Scan(&version, &dirty)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %w", legacyTable, err)
+	}
+	if dirty {
+		return fmt.Errorf("%s is dirty at version %d: fix the database manually before migrating", legacyTable, version)
+	}
+
+	// golang-migrateはバージョン順に適用するため、記録されたバージョン以下はすべて適用済みとみなす
+	for _, mig := range m.migrations {
+		if mig.Version > uint64(version) {
+			break
+		}
+		_, err := conn.ExecContext(ctx,
+			"INSERT INTO "+schemaTable+" (version, name, applied_at) VALUES ($1, $2, $3)",
+			int64(mig.Version), mig.Name, time.Now().UTC())
+		if err != nil {
+			return fmt.Errorf("failed to import %s: %w", legacyTable, err)
+		}
+	}
+	return nil
+}
+
+// appliedVersions は適用済みのバージョンと適用日時を返す
+func appliedVersions(ctx context.Context, conn *sql.Conn) (map[uint64]time.Time, error) {
+	rows, err := conn.QueryContext(ctx, "SELECT version, applied_at FROM "+schemaTable)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", schemaTable, err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	applied := make(map[uint64]time.Time)
+	for rows.Next() {
+		var (
+			version   int64
+			appliedAt time.Time
+		)
+		if err := rows.Scan(&version, &appliedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan %s row: %w", schemaTable, err)
+		}
+		applied[uint64(version)] = appliedAt
+	}
+	return applied, rows.Err()
+}
+
+// inTx はコネクション上でトランザクションを開始し、処理が成功した場合のみコミットする
+func inTx(ctx context.Context, conn *sql.Conn, fn func(tx *sql.Tx) error) error {
+	tx, err := conn.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	if err := fn(tx); err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+	return tx.Commit()
+}
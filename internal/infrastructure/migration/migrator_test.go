@@ -0,0 +1,219 @@
+package migration
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"testing/fstest"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+// testFS はテスト用の2つのマイグレーションを持つファイルシステム
+var testFS = fstest.MapFS{
+	"000001_create_tasks.up.sql":   {Data: []byte("CREATE TABLE tasks ()")},
+	"000001_create_tasks.down.sql": {Data: []byte("DROP TABLE tasks")},
+	"000002_seed.up.sql":           {Data: []byte("INSERT INTO tasks DEFAULT VALUES")},
+}
+
+// expectLock はロックの取得と適用記録テーブルの準備を期待する
+// appliedCountは適用記録テーブルに既に存在する件数
+func expectLock(mock sqlmock.Sqlmock, appliedCount int) {
+	mock.ExpectExec(`SELECT pg_advisory_lock\(\$1\)`).
+		WithArgs(advisoryLockID).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec("CREATE TABLE IF NOT EXISTS todogo_schema_migrations").
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectQuery(`SELECT COUNT\(\*\) FROM todogo_schema_migrations`).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(appliedCount))
+}
+
+// expectUnlock はロックの解放を期待する
+func expectUnlock(mock sqlmock.Sqlmock) {
+	mock.ExpectExec(`SELECT pg_advisory_unlock\(\$1\)`).
+		WithArgs(advisoryLockID).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+}
+
+func TestMigrator_Up(t *testing.T) {
+	t.Run("未適用のマイグレーションのみを順に適用する", func(t *testing.T) {
+		// Arrange
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer func() { _ = db.Close() }()
+
+		m, err := New(db, testFS)
+		assert.NoError(t, err)
+
+		expectLock(mock, 1)
+		mock.ExpectQuery("SELECT version, applied_at FROM todogo_schema_migrations").
+			WillReturnRows(sqlmock.NewRows([]string{"version", "applied_at"}).AddRow(1, time.Now()))
+		mock.ExpectBegin()
+		mock.ExpectExec("INSERT INTO tasks DEFAULT VALUES").
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec("INSERT INTO todogo_schema_migrations").
+			WithArgs(int64(2), "seed", sqlmock.AnyArg()).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+		expectUnlock(mock)
+
+		// Act
+		applied, err := m.Up(context.Background(), 0)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Len(t, applied, 1)
+		assert.Equal(t, uint64(2), applied[0].Version)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("golang-migrateの適用状況を引き継ぐ", func(t *testing.T) {
+		// Arrange
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer func() { _ = db.Close() }()
+
+		m, err := New(db, testFS)
+		assert.NoError(t, err)
+
+		expectLock(mock, 0)
+		mock.ExpectQuery(`SELECT to_regclass\(\$1\) IS NOT NULL`).
+			WithArgs("schema_migrations").
+			WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(true))
+		mock.ExpectQuery("SELECT version, dirty FROM schema_migrations").
+			WillReturnRows(sqlmock.NewRows([]string{"version", "dirty"}).AddRow(2, false))
+		mock.ExpectExec("INSERT INTO todogo_schema_migrations").
+			WithArgs(int64(1), "create_tasks", sqlmock.AnyArg()).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec("INSERT INTO todogo_schema_migrations").
+			WithArgs(int64(2), "seed", sqlmock.AnyArg()).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectQuery("SELECT version, applied_at FROM todogo_schema_migrations").
+			WillReturnRows(sqlmock.NewRows([]string{"version", "applied_at"}).
+				AddRow(1, time.Now()).
+				AddRow(2, time.Now()))
+		expectUnlock(mock)
+
+		// Act
+		applied, err := m.Up(context.Background(), 0)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Empty(t, applied)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("適用に失敗した場合はロールバックしてロックを解放する", func(t *testing.T) {
+		// Arrange
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer func() { _ = db.Close() }()
+
+		m, err := New(db, testFS)
+		assert.NoError(t, err)
+
+		expectLock(mock, 1)
+		mock.ExpectQuery("SELECT version, applied_at FROM todogo_schema_migrations").
+			WillReturnRows(sqlmock.NewRows([]string{"version", "applied_at"}).AddRow(1, time.Now()))
+		mock.ExpectBegin()
+		mock.ExpectExec("INSERT INTO tasks DEFAULT VALUES").
+			WillReturnError(errors.New("syntax error"))
+		mock.ExpectRollback()
+		expectUnlock(mock)
+
+		// Act
+		applied, err := m.Up(context.Background(), 0)
+
+		// Assert
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "2_seed")
+		assert.Empty(t, applied)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestMigrator_Down(t *testing.T) {
+	t.Run("downファイルがない場合はエラーを返す", func(t *testing.T) {
+		// Arrange
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer func() { _ = db.Close() }()
+
+		m, err := New(db, testFS)
+		assert.NoError(t, err)
+
+		expectLock(mock, 2)
+		mock.ExpectQuery("SELECT version, applied_at FROM todogo_schema_migrations").
+			WillReturnRows(sqlmock.NewRows([]string{"version", "applied_at"}).
+				AddRow(1, time.Now()).
+				AddRow(2, time.Now()))
+		expectUnlock(mock)
+
+		// Act
+		rolledBack, err := m.Down(context.Background(), 1)
+
+		// Assert
+		assert.ErrorIs(t, err, ErrNoDownMigration)
+		assert.Empty(t, rolledBack)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("最新のマイグレーションをロールバックする", func(t *testing.T) {
+		// Arrange
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer func() { _ = db.Close() }()
+
+		m, err := New(db, testFS)
+		assert.NoError(t, err)
+
+		expectLock(mock, 1)
+		mock.ExpectQuery("SELECT version, applied_at FROM todogo_schema_migrations").
+			WillReturnRows(sqlmock.NewRows([]string{"version", "applied_at"}).AddRow(1, time.Now()))
+		mock.ExpectBegin()
+		mock.ExpectExec("DROP TABLE tasks").
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec(`DELETE FROM todogo_schema_migrations WHERE version = \$1`).
+			WithArgs(int64(1)).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+		expectUnlock(mock)
+
+		// Act
+		rolledBack, err := m.Down(context.Background(), 1)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Len(t, rolledBack, 1)
+		assert.Equal(t, uint64(1), rolledBack[0].Version)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestMigrator_Version(t *testing.T) {
+	// Arrange
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer func() { _ = db.Close() }()
+
+	m, err := New(db, testFS)
+	assert.NoError(t, err)
+
+	expectLock(mock, 2)
+	mock.ExpectQuery("SELECT version, applied_at FROM todogo_schema_migrations").
+		WillReturnRows(sqlmock.NewRows([]string{"version", "applied_at"}).
+			AddRow(2, time.Now()).
+			AddRow(1, time.Now()))
+	expectUnlock(mock)
+
+	// Act
+	version, ok, err := m.Version(context.Background())
+
+	// Assert
+	assert.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, uint64(2), version)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
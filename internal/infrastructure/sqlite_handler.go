@@ -0,0 +1,69 @@
+package infrastructure
+
+import (
+	"database/sql"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+
+	_ "modernc.org/sqlite"
+)
+
+type SQLiteHandler struct {
+	DB *sql.DB
+}
+
+// NewSQLiteHandler は指定されたパスのSQLiteデータベースを開く
+// ファイルが存在しない場合は親ディレクトリを含めて作成する
+func NewSQLiteHandler(path string) (*SQLiteHandler, error) {
+	path, err := filepath.Abs(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve sqlite path: %w", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create sqlite directory: %w", err)
+	}
+
+	// busy_timeout: 他のプロセスが書き込み中の場合は待機する
+	// foreign_keys: 外部キー制約を有効にする
+	// _txlock=immediate: トランザクション開始時に書き込みロックを取得し、読み取り後の書き込みで競合しないようにする
+	// _time_format=sqlite: 時刻をSQLiteの日時関数で扱える形式で保存する
+	params := url.Values{}
+	params.Add("_pragma", "busy_timeout(5000)")
+	params.Add("_pragma", "foreign_keys(1)")
+	params.Set("_txlock", "immediate")
+	params.Set("_time_format", "sqlite")
+	dsn := (&url.URL{Scheme: "file", Path: path, RawQuery: params.Encode()}).String()
+
+	db, err := sql.Open("sqlite", dsn)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open sqlite: %w", err)
+	}
+
+	// SQLiteは同時に1つの書き込みしか行えないため、接続を1つに限定する
+	db.SetMaxOpenConns(1)
+
+	// 接続確認
+	if err := db.Ping(); err != nil {
+		_ = db.Close()
+		return nil, fmt.Errorf("failed to ping sqlite: %w", err)
+	}
+
+	return &SQLiteHandler{DB: db}, nil
+}
+
+// DefaultSQLitePath はSQLiteデータベースファイルの既定の保存先を返す
+// $XDG_DATA_HOME/todogo/todogo.db、未設定の場合は ~/.local/share/todogo/todogo.db とする
+func DefaultSQLitePath() (string, error) {
+	dir := os.Getenv("XDG_DATA_HOME")
+	if dir == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return "", fmt.Errorf("failed to get home directory: %w", err)
+		}
+		dir = filepath.Join(home, ".local", "share")
+	}
+	return filepath.Join(dir, "todogo", "todogo.db"), nil
+}
@@ -0,0 +1,70 @@
+package infrastructure
+
+import (
+	"OTakumi/todogo/internal/domain/model"
+	"OTakumi/todogo/internal/infrastructure/migration"
+	"OTakumi/todogo/internal/repository"
+	"OTakumi/todogo/internal/repository/repositorytest"
+	"OTakumi/todogo/migrations"
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newSQLiteTestRepository は一時ディレクトリにスキーマを適用したSQLiteデータベースを作成し、リポジトリを返す
+// 初期データは含めない
+func newSQLiteTestRepository(t *testing.T) repository.TaskRepository {
+	t.Helper()
+
+	handler, err := NewSQLiteHandler(filepath.Join(t.TempDir(), "nested", "todogo.db"))
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = handler.DB.Close() })
+
+	m, err := migration.NewSQLite(handler.DB, migrations.SQLite)
+	require.NoError(t, err)
+	_, err = m.Up(context.Background(), 0)
+	require.NoError(t, err)
+
+	// 初期データのマイグレーションで投入されたタスクを削除する
+	_, err = handler.DB.Exec("DELETE FROM tasks")
+	require.NoError(t, err)
+
+	return NewSQLiteTaskRepository(handler.DB, model.DefaultWorkflow())
+}
+
+func TestSQLiteTaskRepository(t *testing.T) {
+	repositorytest.Run(t, newSQLiteTestRepository)
+}
+
+func TestSQLiteTaskRepository_TimeZones(t *testing.T) {
+	// 時刻は文字列として保存されるため、異なるタイムゾーンの締切でも時刻として比較されることを確認する
+	ctx := context.Background()
+	repo := newSQLiteTestRepository(t)
+
+	jst := time.FixedZone("JST", 9*60*60)
+	base := time.Now().Add(48 * time.Hour).Truncate(time.Second)
+	earlier := base.In(jst) // 文字列では後ろに並ぶが、時刻としては前
+	later := base.Add(time.Hour).In(time.UTC)
+
+	first, err := repo.Create(ctx, &model.Task{Title: "JST", Deadline: &earlier})
+	require.NoError(t, err)
+	second, err := repo.Create(ctx, &model.Task{Title: "UTC", Deadline: &later})
+	require.NoError(t, err)
+
+	tasks, err := repo.FindAll(ctx, repository.TaskQuery{SortBy: repository.SortByDeadline})
+	require.NoError(t, err)
+	require.Len(t, tasks, 2)
+	assert.Equal(t, first.ID, tasks[0].ID)
+	assert.Equal(t, second.ID, tasks[1].ID)
+	assert.True(t, earlier.Equal(*tasks[0].Deadline))
+
+	cutoff := base.Add(30 * time.Minute)
+	tasks, err = repo.FindAll(ctx, repository.TaskQuery{DeadlineBefore: &cutoff})
+	require.NoError(t, err)
+	require.Len(t, tasks, 1)
+	assert.Equal(t, first.ID, tasks[0].ID)
+}
@@ -0,0 +1,88 @@
+package infrastructure
+
+import (
+	"OTakumi/todogo/internal/domain/model"
+	"OTakumi/todogo/internal/repository"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// loadAnnotations は複数のタスクの注記をまとめて読み込み、追加順に各タスクに設定する
+func loadAnnotations(ctx context.Context, q queryer, tasks []*model.Task) error {
+	byID := make(map[string]*model.Task, len(tasks))
+	for _, task := range tasks {
+		task.Annotations = []model.Annotation{}
+		byID[task.ID] = task
+	}
+
+	for start := 0; start < len(tasks); start += loadBatchSize {
+		end := min(start+loadBatchSize, len(tasks))
+
+		b := &queryBuilder{}
+		placeholders := make([]string, 0, end-start)
+		for _, task := range tasks[start:end] {
+			placeholders = append(placeholders, b.arg(task.ID))
+		}
+
+		query := "SELECT task_id, id, text, created_at FROM task_annotations WHERE task_id IN (" +
+			strings.Join(placeholders, ", ") + ") ORDER BY task_id, created_at, id"
+		if err := scanAnnotations(ctx, q, query, b.args, byID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func scanAnnotations(ctx context.Context, q queryer, query string, args []any, byID map[string]*model.Task) error {
+	rows, err := q.QueryContext(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("failed to load annotations: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	for rows.Next() {
+		var taskID string
+		var annotation model.Annotation
+		if err := rows.Scan(&taskID, &annotation.ID, &annotation.Text, &annotation.CreatedAt); err != nil {
+			return fmt.Errorf("failed to scan annotation row: %w", err)
+		}
+		if task, ok := byID[taskID]; ok {
+			task.Annotations = append(task.Annotations, annotation)
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("error occurred during annotation row iteration: %w", err)
+	}
+	return nil
+}
+
+func (r *taskRepository) AddAnnotation(ctx context.Context, taskID, text string) (*model.Annotation, error) {
+	text, err := model.NormalizeAnnotation(text)
+	if err != nil {
+		return nil, fmt.Errorf("validation failed: %w", err)
+	}
+
+	// 存在しないかゴミ箱にあるタスクへの追加は、外部キー制約のエラーではなくNotFoundエラーとして返す
+	var exists int
+	err = r.db.QueryRowContext(ctx, "SELECT 1 FROM tasks WHERE id = $1 AND deleted_at IS NULL", taskID).Scan(&exists)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, &repository.TaskNotFoundError{ID: taskID}
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to find task: %w", err)
+	}
+
+	annotation := &model.Annotation{Text: text, CreatedAt: time.Now()}
+	err = r.db.QueryRowContext(ctx,
+		"INSERT INTO task_annotations (task_id, text, created_at) VALUES ($1, $2, $3) RETURNING id",
+		taskID, annotation.Text, r.dialect.timeValue(annotation.CreatedAt),
+	).Scan(&annotation.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to add annotation: %w", err)
+	}
+	return annotation, nil
+}
@@ -0,0 +1,89 @@
+package infrastructure
+
+import (
+	"OTakumi/todogo/internal/domain/model"
+	"OTakumi/todogo/internal/repository"
+	"context"
+	"fmt"
+	"strings"
+)
+
+// loadDependencies は複数のタスクについて、先に完了する必要があるタスクのIDをまとめて読み込み、各タスクに設定する
+// ゴミ箱のタスクとの依存関係は、元に戻した場合のために削除せず残しておき、読み込む際に除く
+func loadDependencies(ctx context.Context, q queryer, tasks []*model.Task) error {
+	byID := make(map[string]*model.Task, len(tasks))
+	for _, task := range tasks {
+		task.BlockedBy = []string{}
+		byID[task.ID] = task
+	}
+
+	for start := 0; start < len(tasks); start += loadBatchSize {
+		end := min(start+loadBatchSize, len(tasks))
+
+		b := &queryBuilder{}
+		placeholders := make([]string, 0, end-start)
+		for _, task := range tasks[start:end] {
+			placeholders = append(placeholders, b.arg(task.ID))
+		}
+
+		query := "SELECT task_id, blocked_by_id FROM task_dependencies WHERE task_id IN (" +
+			strings.Join(placeholders, ", ") + ") AND blocked_by_id IN (SELECT id FROM tasks WHERE deleted_at IS NULL)" +
+			" ORDER BY task_id, blocked_by_id"
+		if err := scanDependencies(ctx, q, query, b.args, byID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func scanDependencies(ctx context.Context, q queryer, query string, args []any, byID map[string]*model.Task) error {
+	rows, err := q.QueryContext(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("failed to load dependencies: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	for rows.Next() {
+		var taskID, blockedByID string
+		if err := rows.Scan(&taskID, &blockedByID); err != nil {
+			return fmt.Errorf("failed to scan dependency row: %w", err)
+		}
+		if task, ok := byID[taskID]; ok {
+			task.BlockedBy = append(task.BlockedBy, blockedByID)
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("error occurred during dependency row iteration: %w", err)
+	}
+	return nil
+}
+
+func (r *taskRepository) AddDependency(ctx context.Context, taskID, blockedByID string) error {
+	_, err := r.db.ExecContext(ctx,
+		"INSERT INTO task_dependencies (task_id, blocked_by_id) VALUES ($1, $2) ON CONFLICT DO NOTHING",
+		taskID, blockedByID,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to add dependency: %w", err)
+	}
+	return nil
+}
+
+func (r *taskRepository) RemoveDependency(ctx context.Context, taskID, blockedByID string) error {
+	result, err := r.db.ExecContext(ctx,
+		"DELETE FROM task_dependencies WHERE task_id = $1 AND blocked_by_id = $2",
+		taskID, blockedByID,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to remove dependency: %w", err)
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get affected rows: %w", err)
+	}
+	if affected == 0 {
+		return &repository.DependencyNotFoundError{TaskID: taskID, BlockedByID: blockedByID}
+	}
+	return nil
+}
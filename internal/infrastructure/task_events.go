@@ -0,0 +1,45 @@
+package infrastructure
+
+import (
+	"OTakumi/todogo/internal/domain/model"
+	"context"
+	"fmt"
+)
+
+// insertTaskEvents はタスクの履歴を記録する
+// タスクの保存と同じトランザクションで記録し、保存に失敗した操作を履歴に残さないようにする
+func (r *taskRepository) insertTaskEvents(ctx context.Context, e execer, events []model.TaskEvent) error {
+	for _, event := range events {
+		_, err := e.ExecContext(ctx,
+			"INSERT INTO task_events (task_id, kind, field, old_value, new_value, actor, occurred_at) VALUES ($1, $2, $3, $4, $5, $6, $7)",
+			event.TaskID, string(event.Kind), event.Field, event.OldValue, event.NewValue, event.Actor, r.dialect.timeValue(event.OccurredAt),
+		)
+		if err != nil {
+			return fmt.Errorf("failed to record task event: %w", err)
+		}
+	}
+	return nil
+}
+
+func (r *taskRepository) TaskEvents(ctx context.Context, taskID string) ([]model.TaskEvent, error) {
+	rows, err := r.db.QueryContext(ctx,
+		"SELECT id, task_id, kind, field, old_value, new_value, actor, occurred_at FROM task_events WHERE task_id = $1 ORDER BY occurred_at, id",
+		taskID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load task events: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	events := []model.TaskEvent{}
+	for rows.Next() {
+		var event model.TaskEvent
+		if err := rows.Scan(&event.ID, &event.TaskID, &event.Kind, &event.Field, &event.OldValue, &event.NewValue, &event.Actor, &event.OccurredAt); err != nil {
+			return nil, fmt.Errorf("failed to scan task event row: %w", err)
+		}
+		events = append(events, event)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error occurred during task event row iteration: %w", err)
+	}
+	return events, nil
+}
@@ -0,0 +1,246 @@
+package infrastructure
+
+import (
+	"OTakumi/todogo/internal/domain/model"
+	"OTakumi/todogo/internal/repository"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// taskSnapshot は取り消しのために記録するタスクの状態（operations テーブルにJSONで保存する）
+// 保存するのは Operation が保持する項目のみとする
+type taskSnapshot struct {
+	ID              string     `json:"id"`
+	Title           string     `json:"title"`
+	Description     string     `json:"description,omitempty"`
+	Status          string     `json:"status"`
+	Priority        int        `json:"priority,omitempty"`
+	Deadline        *time.Time `json:"deadline,omitempty"`
+	StartAt         *time.Time `json:"start_at,omitempty"`
+	WaitUntil       *time.Time `json:"wait_until,omitempty"`
+	EstimateSeconds *int64     `json:"estimate_seconds,omitempty"`
+	EstimatePoints  *float64   `json:"estimate_points,omitempty"`
+	Tags            []string   `json:"tags,omitempty"`
+	Project         string     `json:"project,omitempty"`
+	ParentID        string     `json:"parent_id,omitempty"`
+	RecurrenceID    string     `json:"recurrence_id,omitempty"`
+	CreatedAt       time.Time  `json:"created_at"`
+	CompletedAt     *time.Time `json:"completed_at,omitempty"`
+}
+
+// encodeSnapshot はタスクをJSONに変換する（nilの場合はNULL）
+func encodeSnapshot(task *model.Task) (any, error) {
+	if task == nil {
+		return nil, nil
+	}
+	s := taskSnapshot{
+		ID:           task.ID,
+		Title:        task.Title,
+		Description:  task.Description,
+		Status:       string(task.Status),
+		Priority:     int(task.Priority),
+		Deadline:     task.Deadline,
+		StartAt:      task.StartAt,
+		WaitUntil:    task.WaitUntil,
+		Tags:         task.Tags,
+		Project:      task.Project,
+		ParentID:     task.ParentID,
+		RecurrenceID: task.RecurrenceID,
+		CreatedAt:    task.CreatedAt,
+		CompletedAt:  task.CompletedAt,
+	}
+	if task.Estimate != nil {
+		if task.Estimate.Kind() == model.EstimatePoints {
+			s.EstimatePoints = &task.Estimate.Points
+		} else {
+			seconds := int64(task.Estimate.Duration / time.Second)
+			s.EstimateSeconds = &seconds
+		}
+	}
+	b, err := json.Marshal(s)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode task: %w", err)
+	}
+	return string(b), nil
+}
+
+// decodeSnapshot はJSONをタスクに変換する（NULLの場合はnil）
+func decodeSnapshot(v sql.NullString) (*model.Task, error) {
+	if !v.Valid {
+		return nil, nil
+	}
+	var s taskSnapshot
+	if err := json.Unmarshal([]byte(v.String), &s); err != nil {
+		return nil, fmt.Errorf("failed to decode task: %w", err)
+	}
+	task := &model.Task{
+		ID:           s.ID,
+		Title:        s.Title,
+		Description:  s.Description,
+		Status:       model.Status(s.Status),
+		Priority:     model.Priority(s.Priority),
+		Deadline:     s.Deadline,
+		StartAt:      s.StartAt,
+		WaitUntil:    s.WaitUntil,
+		Tags:         s.Tags,
+		Project:      s.Project,
+		ParentID:     s.ParentID,
+		RecurrenceID: s.RecurrenceID,
+		CreatedAt:    s.CreatedAt,
+		CompletedAt:  s.CompletedAt,
+	}
+	if task.Tags == nil {
+		task.Tags = []string{}
+	}
+	switch {
+	case s.EstimateSeconds != nil:
+		task.Estimate = &model.Estimate{Duration: time.Duration(*s.EstimateSeconds) * time.Second}
+	case s.EstimatePoints != nil:
+		task.Estimate = &model.Estimate{Points: *s.EstimatePoints}
+	}
+	return task, nil
+}
+
+// recordOperation はコンテキストが操作のまとまりを保持する場合に、タスクの保存を取り消しのために記録する
+// 新しい操作を記録するとやり直しはできなくなるため、取り消し済みの操作を削除し、
+// 保持する数（repository.MaxOperationBatches）を超えた古いまとまりも削除する
+func (r *taskRepository) recordOperation(ctx context.Context, e execer, before, after *model.Task, at time.Time) error {
+	batchID, command, ok := repository.BatchFrom(ctx)
+	if !ok {
+		return nil
+	}
+
+	op := model.NewOperation(before, after)
+	beforeState, err := encodeSnapshot(op.Before)
+	if err != nil {
+		return err
+	}
+	afterState, err := encodeSnapshot(op.After)
+	if err != nil {
+		return err
+	}
+
+	if _, err := e.ExecContext(ctx, "DELETE FROM operations WHERE undone = $1", true); err != nil {
+		return fmt.Errorf("failed to clear undone operations: %w", err)
+	}
+	_, err = e.ExecContext(ctx,
+		"INSERT INTO operations (batch_id, command, kind, task_id, before_state, after_state, undone, recorded_at) VALUES ($1, $2, $3, $4, $5, $6, $7, $8)",
+		batchID, command, string(op.Kind), op.TaskID, beforeState, afterState, false, r.dialect.timeValue(at),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to record operation: %w", err)
+	}
+	_, err = e.ExecContext(ctx,
+		"DELETE FROM operations WHERE batch_id NOT IN (SELECT batch_id FROM operations GROUP BY batch_id ORDER BY MAX(id) DESC LIMIT $1)",
+		repository.MaxOperationBatches,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to prune operations: %w", err)
+	}
+	return nil
+}
+
+func (r *taskRepository) Operations(ctx context.Context, undone bool) ([]model.Operation, error) {
+	rows, err := r.db.QueryContext(ctx,
+		"SELECT id, batch_id, command, kind, task_id, before_state, after_state, undone, recorded_at FROM operations WHERE undone = $1 ORDER BY id",
+		undone)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load operations: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	ops := []model.Operation{}
+	for rows.Next() {
+		var op model.Operation
+		var beforeState, afterState sql.NullString
+		if err := rows.Scan(&op.ID, &op.BatchID, &op.Command, &op.Kind, &op.TaskID, &beforeState, &afterState, &op.Undone, &op.RecordedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan operation row: %w", err)
+		}
+		if op.Before, err = decodeSnapshot(beforeState); err != nil {
+			return nil, err
+		}
+		if op.After, err = decodeSnapshot(afterState); err != nil {
+			return nil, err
+		}
+		ops = append(ops, op)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error occurred during operation row iteration: %w", err)
+	}
+	return ops, nil
+}
+
+func (r *taskRepository) ReplayOperations(ctx context.Context, ops []model.Operation, undo bool) error {
+	// トランザクションを開始
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
+		}
+	}()
+
+	now := time.Now()
+	for _, op := range ops {
+		var current *model.Task
+		if current, err = r.lockTask(ctx, tx, op.TaskID); err != nil {
+			return err
+		}
+		if err = op.Check(current, undo); err != nil {
+			return err
+		}
+
+		// 作成日時と完了日時は操作の前後の値に戻し、更新日時は戻した日時とする
+		var target *model.Task
+		if t := op.Target(undo); t != nil {
+			target = copyTask(t)
+			target.UpdatedAt = now
+		}
+		// 削除の取り消しとゴミ箱から戻したタスクの作成のやり直しは、ゴミ箱のタスクを元に戻す
+		// 完全に削除した後の場合は、記録した項目からタスクを作成し直す
+		actor := repository.ActorFrom(ctx)
+		events := model.NewTaskEvents(current, target, r.workflow, actor, now)
+		switch {
+		case target == nil:
+			err = r.trashTask(ctx, tx, op.TaskID, now)
+		case current == nil:
+			var trashed *model.Task
+			if trashed, err = r.lockTrashedTask(ctx, tx, op.TaskID); err != nil {
+				return err
+			}
+			if trashed == nil {
+				err = r.insertTaskRow(ctx, tx, target)
+				break
+			}
+			if err = r.untrashTask(ctx, tx, op.TaskID); err != nil {
+				return err
+			}
+			err = r.updateTaskRow(ctx, tx, target)
+			events = append([]model.TaskEvent{model.NewTrashEvent(model.TaskRestored, trashed, actor, now)},
+				model.NewTaskEvents(trashed, target, r.workflow, actor, now)...)
+		default:
+			err = r.updateTaskRow(ctx, tx, target)
+		}
+		if err != nil {
+			return err
+		}
+
+		if err = r.insertTaskEvents(ctx, tx, events); err != nil {
+			return err
+		}
+		if _, err = tx.ExecContext(ctx, "UPDATE operations SET undone = $1 WHERE id = $2", undo, op.ID); err != nil {
+			return fmt.Errorf("failed to mark operation: %w", err)
+		}
+	}
+
+	// トランザクションのコミット
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,59 @@
+package infrastructure
+
+import (
+	"OTakumi/todogo/internal/domain/model"
+	"context"
+	"fmt"
+)
+
+// projectValue はプロジェクト名をSQLの引数に変換する（プロジェクトなしの場合はNULL）
+func projectValue(name string) any {
+	if name == "" {
+		return nil
+	}
+	return name
+}
+
+// insertProject は未登録のプロジェクトをprojectsテーブルに追加する
+func insertProject(ctx context.Context, e execer, name string) error {
+	if name == "" {
+		return nil
+	}
+	if _, err := e.ExecContext(ctx, "INSERT INTO projects (name) VALUES ($1) ON CONFLICT (name) DO NOTHING", name); err != nil {
+		return fmt.Errorf("failed to insert project %q: %w", name, err)
+	}
+	return nil
+}
+
+func (r *taskRepository) ProjectCounts(ctx context.Context) ([]model.ProjectCount, error) {
+	// 終了状態のタスクを完了済みとして数える
+	b := &queryBuilder{}
+	closed := "t.status IN " + b.statusList(r.workflow.Terminal)
+	query := `
+		SELECT p.name, SUM(CASE WHEN ` + closed + ` THEN 0 ELSE 1 END), SUM(CASE WHEN ` + closed + ` THEN 1 ELSE 0 END)
+		FROM projects p
+		JOIN tasks t ON t.project_id = p.id
+		WHERE t.deleted_at IS NULL
+		GROUP BY p.name
+		ORDER BY p.name
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, b.args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count projects: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	counts := []model.ProjectCount{}
+	for rows.Next() {
+		var c model.ProjectCount
+		if err := rows.Scan(&c.Name, &c.Open, &c.Closed); err != nil {
+			return nil, fmt.Errorf("failed to scan project count row: %w", err)
+		}
+		counts = append(counts, c)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error occurred during row iteration: %w", err)
+	}
+	return counts, nil
+}
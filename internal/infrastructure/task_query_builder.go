@@ -0,0 +1,146 @@
+package infrastructure
+
+import (
+	"OTakumi/todogo/internal/domain/model"
+	"OTakumi/todogo/internal/repository"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// sortExpressions は並び替え項目に対応するSQL式
+// %[1]s にはテーブルの別名が、%[2]s には締切が未設定であることを表す値が入る
+// 締切が未設定のタスクは、締切が無限に遠いものとして扱う
+var sortExpressions = map[repository.SortKey]string{
+	repository.SortByCreatedAt: "%[1]s.created_at",
+	repository.SortByUpdatedAt: "%[1]s.updated_at",
+	repository.SortByDeadline:  "COALESCE(%[1]s.deadline, %[2]s)",
+	repository.SortByTitle:     "LOWER(%[1]s.title)",
+	repository.SortByPriority:  "%[1]s.priority",
+}
+
+// queryBuilder はWHERE句の条件とプレースホルダの引数を組み立てる
+type queryBuilder struct {
+	conds []string
+	args  []any
+}
+
+// arg は引数を追加し、対応するプレースホルダを返す
+func (b *queryBuilder) arg(v any) string {
+	b.args = append(b.args, v)
+	return fmt.Sprintf("$%d", len(b.args))
+}
+
+func (b *queryBuilder) where(cond string) {
+	b.conds = append(b.conds, cond)
+}
+
+// statusList は状態の一覧を "($1, $2)" のようなプレースホルダの並びに変換する
+func (b *queryBuilder) statusList(statuses []model.Status) string {
+	placeholders := make([]string, len(statuses))
+	for i, status := range statuses {
+		placeholders[i] = b.arg(string(status))
+	}
+	return "(" + strings.Join(placeholders, ", ") + ")"
+}
+
+// tagSubquery はタスクに指定したタグのいずれかが付いている場合に行を返す副問い合わせを返す
+func (b *queryBuilder) tagSubquery(tags []string) string {
+	placeholders := make([]string, len(tags))
+	for i, tag := range tags {
+		placeholders[i] = b.arg(tag)
+	}
+	return "SELECT 1 FROM task_tags tt JOIN tags g ON g.id = tt.tag_id WHERE tt.task_id = t.id AND g.name IN (" +
+		strings.Join(placeholders, ", ") + ")"
+}
+
+// buildFindAllQuery はTaskQueryをパラメータ化されたSELECT文に変換する
+// ユーザー入力はすべてプレースホルダ経由で渡し、SQL文に直接埋め込まない
+// 完了済みかどうかは、ワークフローの終了状態かどうかで判定する
+func buildFindAllQuery(d dialect, wf *model.Workflow, q repository.TaskQuery) (string, []any) {
+	b := &queryBuilder{}
+
+	// ゴミ箱のタスクは取得しない
+	b.where("t.deleted_at IS NULL")
+
+	if len(q.Statuses) > 0 {
+		b.where("t.status IN " + b.statusList(q.Statuses))
+	}
+
+	if q.DeadlineBefore != nil {
+		b.where("t.deadline < " + b.arg(d.timeValue(*q.DeadlineBefore)))
+	}
+	if q.DeadlineAfter != nil {
+		b.where("t.deadline >= " + b.arg(d.timeValue(*q.DeadlineAfter)))
+	}
+
+	if q.TitleContains != "" {
+		pattern := "%" + escapeLike(strings.ToLower(q.TitleContains)) + "%"
+		b.where("LOWER(t.title) LIKE " + b.arg(pattern) + ` ESCAPE '\'`)
+	}
+
+	// 待機中かどうかは、開始日時と待機日時のいずれかが現在時刻より後かどうかで判定する
+	switch q.Waiting {
+	case repository.WaitingHide:
+		now := b.arg(d.timeValue(time.Now()))
+		b.where("(t.start_at IS NULL OR t.start_at <= " + now + ") AND (t.wait_until IS NULL OR t.wait_until <= " + now + ")")
+	case repository.WaitingOnly:
+		now := b.arg(d.timeValue(time.Now()))
+		b.where("(t.start_at > " + now + " OR t.wait_until > " + now + ")")
+	}
+
+	// タグはグループごとに、いずれかのタグが付いていることを条件とする
+	for _, group := range q.Tags {
+		b.where("EXISTS (" + b.tagSubquery(group) + ")")
+	}
+	if len(q.ExcludeTags) > 0 {
+		b.where("NOT EXISTS (" + b.tagSubquery(q.ExcludeTags) + ")")
+	}
+
+	// プロジェクトは配下のプロジェクトも含めて絞り込む
+	if q.Project != "" {
+		b.where("t.project_id IN (SELECT p.id FROM projects p WHERE p.name = " + b.arg(q.Project) +
+			" OR p.name LIKE " + b.arg(escapeLike(q.Project)+".%") + ` ESCAPE '\')`)
+	}
+
+	// 着手可能なタスクは、未完了の先行タスクがない未完了のタスクとする（ゴミ箱の先行タスクは除く）
+	if q.Ready {
+		b.where("t.status NOT IN " + b.statusList(wf.Terminal))
+		b.where("NOT EXISTS (SELECT 1 FROM task_dependencies d JOIN tasks bt ON bt.id = d.blocked_by_id " +
+			"WHERE d.task_id = t.id AND bt.deleted_at IS NULL AND bt.status NOT IN " + b.statusList(wf.Terminal) + ")")
+	}
+
+	sortExpr := sortExpressions[q.EffectiveSortBy()]
+	sortBy := func(alias string) string {
+		return fmt.Sprintf(sortExpr, alias, d.noDeadline)
+	}
+	direction, op := "ASC", ">"
+	if q.SortDesc {
+		direction, op = "DESC", "<"
+	}
+
+	// キーセットページング: カーソルのタスクの (並び替えキー, ID) より後ろの行のみを取得する
+	if q.AfterID != "" {
+		b.where(fmt.Sprintf("(%s, t.id) %s (SELECT %s, c.id FROM tasks c WHERE c.id = %s)",
+			sortBy("t"), op, sortBy("c"), b.arg(q.AfterID)))
+	}
+
+	var sb strings.Builder
+	sb.WriteString("SELECT " + taskColumns + " FROM tasks t")
+	if len(b.conds) > 0 {
+		sb.WriteString(" WHERE ")
+		sb.WriteString(strings.Join(b.conds, " AND "))
+	}
+	fmt.Fprintf(&sb, " ORDER BY %s %s, t.id %s", sortBy("t"), direction, direction)
+	if q.Limit > 0 {
+		sb.WriteString(" LIMIT " + b.arg(q.Limit))
+	}
+
+	return sb.String(), b.args
+}
+
+// escapeLike はLIKE句のワイルドカード文字をエスケープする
+func escapeLike(s string) string {
+	r := strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+	return r.Replace(s)
+}
@@ -0,0 +1,194 @@
+package infrastructure
+
+import (
+	"OTakumi/todogo/internal/domain/model"
+	"OTakumi/todogo/internal/repository"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuildFindAllQuery(t *testing.T) {
+	before := time.Date(2025, 1, 31, 0, 0, 0, 0, time.UTC)
+	after := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name      string
+		query     repository.TaskQuery
+		wantQuery string
+		wantArgs  []any
+	}{
+		{
+			name:      "条件なしの場合は作成日時の昇順で全件を取得する",
+			query:     repository.TaskQuery{},
+			wantQuery: "SELECT id, title, deadline, status, priority, created_at, updated_at, (SELECT p.name FROM projects p WHERE p.id = project_id), parent_id, recurrence_id, (SELECT r.rule FROM recurrences r WHERE r.id = recurrence_id), description, start_at, wait_until, estimate_seconds, estimate_points, completed_at FROM tasks t WHERE t.deleted_at IS NULL ORDER BY t.created_at ASC, t.id ASC",
+			wantArgs:  nil,
+		},
+		{
+			name:      "いずれかの状態のタスクに絞り込む",
+			query:     repository.TaskQuery{Statuses: []model.Status{model.StatusOpen, model.StatusInProgress}},
+			wantQuery: "SELECT id, title, deadline, status, priority, created_at, updated_at, (SELECT p.name FROM projects p WHERE p.id = project_id), parent_id, recurrence_id, (SELECT r.rule FROM recurrences r WHERE r.id = recurrence_id), description, start_at, wait_until, estimate_seconds, estimate_points, completed_at FROM tasks t WHERE t.deleted_at IS NULL AND t.status IN ($1, $2) ORDER BY t.created_at ASC, t.id ASC",
+			wantArgs:  []any{"open", "in_progress"},
+		},
+		{
+			name:      "締切の範囲とタイトルで絞り込む",
+			query:     repository.TaskQuery{DeadlineBefore: &before, DeadlineAfter: &after, TitleContains: "50%_Off"},
+			wantQuery: `SELECT id, title, deadline, status, priority, created_at, updated_at, (SELECT p.name FROM projects p WHERE p.id = project_id), parent_id, recurrence_id, (SELECT r.rule FROM recurrences r WHERE r.id = recurrence_id), description, start_at, wait_until, estimate_seconds, estimate_points, completed_at FROM tasks t WHERE t.deleted_at IS NULL AND t.deadline < $1 AND t.deadline >= $2 AND LOWER(t.title) LIKE $3 ESCAPE '\' ORDER BY t.created_at ASC, t.id ASC`,
+			wantArgs:  []any{before, after, `%50\%\_off%`},
+		},
+		{
+			name:      "締切の降順で件数を制限する",
+			query:     repository.TaskQuery{SortBy: repository.SortByDeadline, SortDesc: true, Limit: 10},
+			wantQuery: "SELECT id, title, deadline, status, priority, created_at, updated_at, (SELECT p.name FROM projects p WHERE p.id = project_id), parent_id, recurrence_id, (SELECT r.rule FROM recurrences r WHERE r.id = recurrence_id), description, start_at, wait_until, estimate_seconds, estimate_points, completed_at FROM tasks t WHERE t.deleted_at IS NULL ORDER BY COALESCE(t.deadline, 'infinity'::timestamptz) DESC, t.id DESC LIMIT $1",
+			wantArgs:  []any{10},
+		},
+		{
+			name:      "優先度の降順で並び替える",
+			query:     repository.TaskQuery{SortBy: repository.SortByPriority, SortDesc: true},
+			wantQuery: "SELECT id, title, deadline, status, priority, created_at, updated_at, (SELECT p.name FROM projects p WHERE p.id = project_id), parent_id, recurrence_id, (SELECT r.rule FROM recurrences r WHERE r.id = recurrence_id), description, start_at, wait_until, estimate_seconds, estimate_points, completed_at FROM tasks t WHERE t.deleted_at IS NULL ORDER BY t.priority DESC, t.id DESC",
+			wantArgs:  nil,
+		},
+		{
+			name:      "タグのグループと除外するタグで絞り込む",
+			query:     repository.TaskQuery{Tags: [][]string{{"work"}, {"home", "errand"}}, ExcludeTags: []string{"someday"}},
+			wantQuery: "SELECT id, title, deadline, status, priority, created_at, updated_at, (SELECT p.name FROM projects p WHERE p.id = project_id), parent_id, recurrence_id, (SELECT r.rule FROM recurrences r WHERE r.id = recurrence_id), description, start_at, wait_until, estimate_seconds, estimate_points, completed_at FROM tasks t WHERE t.deleted_at IS NULL AND EXISTS (SELECT 1 FROM task_tags tt JOIN tags g ON g.id = tt.tag_id WHERE tt.task_id = t.id AND g.name IN ($1)) AND EXISTS (SELECT 1 FROM task_tags tt JOIN tags g ON g.id = tt.tag_id WHERE tt.task_id = t.id AND g.name IN ($2, $3)) AND NOT EXISTS (SELECT 1 FROM task_tags tt JOIN tags g ON g.id = tt.tag_id WHERE tt.task_id = t.id AND g.name IN ($4)) ORDER BY t.created_at ASC, t.id ASC",
+			wantArgs:  []any{"work", "home", "errand", "someday"},
+		},
+		{
+			name:      "配下のプロジェクトを含めて絞り込む",
+			query:     repository.TaskQuery{Project: "work_1"},
+			wantQuery: `SELECT id, title, deadline, status, priority, created_at, updated_at, (SELECT p.name FROM projects p WHERE p.id = project_id), parent_id, recurrence_id, (SELECT r.rule FROM recurrences r WHERE r.id = recurrence_id), description, start_at, wait_until, estimate_seconds, estimate_points, completed_at FROM tasks t WHERE t.deleted_at IS NULL AND t.project_id IN (SELECT p.id FROM projects p WHERE p.name = $1 OR p.name LIKE $2 ESCAPE '\') ORDER BY t.created_at ASC, t.id ASC`,
+			wantArgs:  []any{"work_1", `work\_1.%`},
+		},
+		{
+			name:      "着手可能なタスクに絞り込む",
+			query:     repository.TaskQuery{Ready: true},
+			wantQuery: "SELECT id, title, deadline, status, priority, created_at, updated_at, (SELECT p.name FROM projects p WHERE p.id = project_id), parent_id, recurrence_id, (SELECT r.rule FROM recurrences r WHERE r.id = recurrence_id), description, start_at, wait_until, estimate_seconds, estimate_points, completed_at FROM tasks t WHERE t.deleted_at IS NULL AND t.status NOT IN ($1, $2) AND NOT EXISTS (SELECT 1 FROM task_dependencies d JOIN tasks bt ON bt.id = d.blocked_by_id WHERE d.task_id = t.id AND bt.deleted_at IS NULL AND bt.status NOT IN ($3, $4)) ORDER BY t.created_at ASC, t.id ASC",
+			wantArgs:  []any{"done", "cancelled", "done", "cancelled"},
+		},
+		{
+			name:      "カーソル以降のタスクを取得する",
+			query:     repository.TaskQuery{Statuses: []model.Status{model.StatusDone}, SortBy: repository.SortByTitle, AfterID: "task-1", Limit: 5},
+			wantQuery: "SELECT id, title, deadline, status, priority, created_at, updated_at, (SELECT p.name FROM projects p WHERE p.id = project_id), parent_id, recurrence_id, (SELECT r.rule FROM recurrences r WHERE r.id = recurrence_id), description, start_at, wait_until, estimate_seconds, estimate_points, completed_at FROM tasks t WHERE t.deleted_at IS NULL AND t.status IN ($1) AND (LOWER(t.title), t.id) > (SELECT LOWER(c.title), c.id FROM tasks c WHERE c.id = $2) ORDER BY LOWER(t.title) ASC, t.id ASC LIMIT $3",
+			wantArgs:  []any{"done", "task-1", 5},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			query, args := buildFindAllQuery(postgresDialect, model.DefaultWorkflow(), tt.query)
+
+			assert.Equal(t, tt.wantQuery, query)
+			assert.Equal(t, tt.wantArgs, args)
+		})
+	}
+}
+
+func TestBuildFindAllQuery_Waiting(t *testing.T) {
+	const columns = "SELECT id, title, deadline, status, priority, created_at, updated_at, (SELECT p.name FROM projects p WHERE p.id = project_id), parent_id, recurrence_id, (SELECT r.rule FROM recurrences r WHERE r.id = recurrence_id), description, start_at, wait_until, estimate_seconds, estimate_points, completed_at FROM tasks t"
+
+	tests := []struct {
+		name      string
+		waiting   repository.WaitingFilter
+		wantQuery string
+	}{
+		{
+			name:      "待機中のタスクを除外する",
+			waiting:   repository.WaitingHide,
+			wantQuery: columns + " WHERE t.deleted_at IS NULL AND (t.start_at IS NULL OR t.start_at <= $1) AND (t.wait_until IS NULL OR t.wait_until <= $1) ORDER BY t.created_at ASC, t.id ASC",
+		},
+		{
+			name:      "待機中のタスクのみに絞り込む",
+			waiting:   repository.WaitingOnly,
+			wantQuery: columns + " WHERE t.deleted_at IS NULL AND (t.start_at > $1 OR t.wait_until > $1) ORDER BY t.created_at ASC, t.id ASC",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			query, args := buildFindAllQuery(sqliteDialect, model.DefaultWorkflow(), repository.TaskQuery{Waiting: tt.waiting})
+
+			// 現在時刻はUTCに変換して、1つの引数として渡す
+			assert.Equal(t, tt.wantQuery, query)
+			if assert.Len(t, args, 1) {
+				now, ok := args[0].(time.Time)
+				assert.True(t, ok)
+				assert.Equal(t, time.UTC, now.Location())
+				assert.WithinDuration(t, time.Now(), now, time.Minute)
+			}
+		})
+	}
+}
+
+func TestBuildFindAllQuery_SQLite(t *testing.T) {
+	// 締切はUTCに変換して渡し、未設定の締切は文字列として最大の値で並び替える
+	jst := time.FixedZone("JST", 9*60*60)
+	before := time.Date(2025, 1, 31, 9, 0, 0, 0, jst)
+
+	query, args := buildFindAllQuery(sqliteDialect, model.DefaultWorkflow(), repository.TaskQuery{
+		DeadlineBefore: &before,
+		SortBy:         repository.SortByDeadline,
+		AfterID:        "task-1",
+	})
+
+	assert.Equal(t, "SELECT id, title, deadline, status, priority, created_at, updated_at, (SELECT p.name FROM projects p WHERE p.id = project_id), parent_id, recurrence_id, (SELECT r.rule FROM recurrences r WHERE r.id = recurrence_id), description, start_at, wait_until, estimate_seconds, estimate_points, completed_at FROM tasks t WHERE t.deleted_at IS NULL AND t.deadline < $1 AND (COALESCE(t.deadline, '9999-12-31'), t.id) > (SELECT COALESCE(c.deadline, '9999-12-31'), c.id FROM tasks c WHERE c.id = $2) ORDER BY COALESCE(t.deadline, '9999-12-31') ASC, t.id ASC", query)
+	assert.Equal(t, []any{time.Date(2025, 1, 31, 0, 0, 0, 0, time.UTC), "task-1"}, args)
+}
+
+func TestTaskRepository_FindAll_WithQuery(t *testing.T) {
+	t.Run("条件がパラメータとして渡される", func(t *testing.T) {
+		// Arrange
+		db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+		assert.NoError(t, err)
+		defer func() { _ = db.Close() }()
+
+		repo := NewTaskRepository(db, model.DefaultWorkflow())
+		ctx := context.Background()
+
+		now := time.Now()
+		mock.ExpectQuery("SELECT id, title, deadline, status, priority, created_at, updated_at, (SELECT p.name FROM projects p WHERE p.id = project_id), parent_id, recurrence_id, (SELECT r.rule FROM recurrences r WHERE r.id = recurrence_id), description, start_at, wait_until, estimate_seconds, estimate_points, completed_at FROM tasks t WHERE t.deleted_at IS NULL AND t.status IN ($1) ORDER BY t.created_at ASC, t.id ASC LIMIT $2").
+			WithArgs("open", 2).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "title", "deadline", "status", "priority", "created_at", "updated_at", "project", "parent_id", "recurrence_id", "rule", "description", "start_at", "wait_until", "estimate_seconds", "estimate_points", "completed_at"}).
+				AddRow("1", "Task 1", nil, "open", 0, now, now, nil, nil, nil, nil, "", nil, nil, nil, nil, nil))
+		mock.ExpectQuery("SELECT tt.task_id, g.name FROM task_tags tt JOIN tags g ON g.id = tt.tag_id WHERE tt.task_id IN ($1) ORDER BY tt.task_id, g.name").
+			WithArgs("1").
+			WillReturnRows(sqlmock.NewRows([]string{"task_id", "name"}))
+		mock.ExpectQuery("WITH RECURSIVE subtasks (root_id, id, status) AS (SELECT parent_id, id, status FROM tasks WHERE parent_id IN ($1) AND deleted_at IS NULL UNION ALL SELECT s.root_id, t.id, t.status FROM tasks t JOIN subtasks s ON t.parent_id = s.id WHERE t.deleted_at IS NULL) SELECT root_id, SUM(CASE WHEN status IN ($2, $3) THEN 1 ELSE 0 END), COUNT(*) FROM subtasks GROUP BY root_id").
+			WithArgs("1", "done", "cancelled").
+			WillReturnRows(sqlmock.NewRows([]string{"root_id", "done", "total"}))
+		mock.ExpectQuery("SELECT task_id, blocked_by_id FROM task_dependencies WHERE task_id IN ($1) AND blocked_by_id IN (SELECT id FROM tasks WHERE deleted_at IS NULL) ORDER BY task_id, blocked_by_id").
+			WithArgs("1").
+			WillReturnRows(sqlmock.NewRows([]string{"task_id", "blocked_by_id"}))
+		mock.ExpectQuery("SELECT task_id, id, text, created_at FROM task_annotations WHERE task_id IN ($1) ORDER BY task_id, created_at, id").
+			WithArgs("1").
+			WillReturnRows(sqlmock.NewRows([]string{"task_id", "id", "text", "created_at"}))
+
+		// Act
+		tasks, err := repo.FindAll(ctx, repository.TaskQuery{Statuses: []model.Status{model.StatusOpen}, Limit: 2})
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Len(t, tasks, 1)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("不正な条件の場合はクエリを実行せずにエラーを返す", func(t *testing.T) {
+		// Arrange
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer func() { _ = db.Close() }()
+
+		repo := NewTaskRepository(db, model.DefaultWorkflow())
+
+		// Act
+		tasks, err := repo.FindAll(context.Background(), repository.TaskQuery{SortBy: "priority; DROP TABLE tasks"})
+
+		// Assert
+		assert.Error(t, err)
+		assert.Nil(t, tasks)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
@@ -0,0 +1,47 @@
+package infrastructure
+
+import (
+	"OTakumi/todogo/internal/domain/model"
+	"OTakumi/todogo/internal/repository"
+	"context"
+	"fmt"
+)
+
+// recurrenceValue は繰り返しのルールのIDをSQLの引数に変換する（繰り返さないタスクの場合はNULL）
+func recurrenceValue(id string) any {
+	if id == "" {
+		return nil
+	}
+	return id
+}
+
+func (r *taskRepository) SaveRecurrence(ctx context.Context, id string, rule model.Recurrence) error {
+	if err := rule.Validate(); err != nil {
+		return fmt.Errorf("validation failed: %w", err)
+	}
+
+	_, err := r.db.ExecContext(ctx,
+		"INSERT INTO recurrences (id, rule) VALUES ($1, $2) ON CONFLICT (id) DO UPDATE SET rule = excluded.rule",
+		id, rule.String(),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to save recurrence: %w", err)
+	}
+	return nil
+}
+
+func (r *taskRepository) DeleteRecurrence(ctx context.Context, id string) error {
+	result, err := r.db.ExecContext(ctx, "DELETE FROM recurrences WHERE id = $1", id)
+	if err != nil {
+		return fmt.Errorf("failed to delete recurrence: %w", err)
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get affected rows: %w", err)
+	}
+	if affected == 0 {
+		return &repository.RecurrenceNotFoundError{ID: id}
+	}
+	return nil
+}
@@ -5,23 +5,124 @@ import (
 	"OTakumi/todogo/internal/repository"
 	"context"
 	"database/sql"
+	"errors"
 	"fmt"
 	"time"
 
 	"github.com/google/uuid"
 )
 
+// taskColumns はタスクを取得する際のSELECT句の列（scanTaskの引数の順序と対応する）
+// プロジェクト名と繰り返しのルールは、FROM句の別名に依存しないよう副問い合わせで取得する
+const taskColumns = "id, title, deadline, status, priority, created_at, updated_at, " +
+	"(SELECT p.name FROM projects p WHERE p.id = project_id), parent_id, " +
+	"recurrence_id, (SELECT r.rule FROM recurrences r WHERE r.id = recurrence_id), description, start_at, wait_until, " +
+	"estimate_seconds, estimate_points, completed_at"
+
+// rowScanner は *sql.Row と *sql.Rows に共通する読み取りのインターフェース
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+// scanTask はtaskColumnsの順序で取得した行をタスクに変換する
+// extra はtaskColumnsの後に続けて取得した列の読み取り先
+func scanTask(row rowScanner, extra ...any) (*model.Task, error) {
+	task := &model.Task{}
+	var project, parentID, recurrenceID, rule sql.NullString
+	var estimateSeconds sql.NullInt64
+	var estimatePoints sql.NullFloat64
+	dest := []any{
+		&task.ID,
+		&task.Title,
+		&task.Deadline,
+		&task.Status,
+		&task.Priority,
+		&task.CreatedAt,
+		&task.UpdatedAt,
+		&project,
+		&parentID,
+		&recurrenceID,
+		&rule,
+		&task.Description,
+		&task.StartAt,
+		&task.WaitUntil,
+		&estimateSeconds,
+		&estimatePoints,
+		&task.CompletedAt,
+	}
+	if err := row.Scan(append(dest, extra...)...); err != nil {
+		return nil, err
+	}
+	task.Project = project.String
+	task.ParentID = parentID.String
+	task.RecurrenceID = recurrenceID.String
+	if rule.Valid {
+		recurrence, err := model.ParseRecurrence(rule.String)
+		if err != nil {
+			return nil, err
+		}
+		task.Recurrence = recurrence
+	}
+	switch {
+	case estimateSeconds.Valid:
+		task.Estimate = &model.Estimate{Duration: time.Duration(estimateSeconds.Int64) * time.Second}
+	case estimatePoints.Valid:
+		task.Estimate = &model.Estimate{Points: estimatePoints.Float64}
+	}
+	return task, nil
+}
+
+// estimateValues は見積もりを estimate_seconds と estimate_points 列の値に変換する（設定しない列はNULL）
+func estimateValues(e *model.Estimate) (seconds, points any) {
+	switch {
+	case e == nil:
+		return nil, nil
+	case e.Kind() == model.EstimatePoints:
+		return nil, e.Points
+	default:
+		return int64(e.Duration / time.Second), nil
+	}
+}
+
+// loadRelated はタスクの行以外に保存しているタグ、サブタスクの進捗、依存関係、注記を、
+// 全タスク分をまとめて読み込み、各タスクに設定する
+func loadRelated(ctx context.Context, q queryer, wf *model.Workflow, tasks []*model.Task) error {
+	if err := loadTags(ctx, q, tasks); err != nil {
+		return err
+	}
+	if err := loadProgress(ctx, q, wf, tasks); err != nil {
+		return err
+	}
+	if err := loadDependencies(ctx, q, tasks); err != nil {
+		return err
+	}
+	return loadAnnotations(ctx, q, tasks)
+}
+
 type taskRepository struct {
-	db *sql.DB
+	db      *sql.DB
+	dialect dialect
+	// workflow は状態の検証と、完了済み（終了状態）かどうかの判定に使う
+	workflow *model.Workflow
+}
+
+// NewTaskRepository はPostgreSQLにタスクを保存するリポジトリを生成する
+func NewTaskRepository(db *sql.DB, wf *model.Workflow) repository.TaskRepository {
+	return &taskRepository{db: db, dialect: postgresDialect, workflow: wf}
 }
 
-func NewTaskRepository(db *sql.DB) repository.TaskRepository {
-	return &taskRepository{db: db}
+// NewSQLiteTaskRepository はSQLiteにタスクを保存するリポジトリを生成する
+func NewSQLiteTaskRepository(db *sql.DB, wf *model.Workflow) repository.TaskRepository {
+	return &taskRepository{db: db, dialect: sqliteDialect, workflow: wf}
 }
 
-func (r *taskRepository) FindAll(ctx context.Context) ([]*model.Task, error) {
-	query := "SELECT id, title, deadline, is_complete, created_at, updated_at FROM tasks"
-	rows, err := r.db.QueryContext(ctx, query)
+func (r *taskRepository) FindAll(ctx context.Context, q repository.TaskQuery) ([]*model.Task, error) {
+	if err := q.ValidateStored(); err != nil {
+		return nil, fmt.Errorf("invalid query: %w", err)
+	}
+
+	query, args := buildFindAllQuery(r.dialect, r.workflow, q)
+	rows, err := r.db.QueryContext(ctx, query, args...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to execute query: %w", err)
 	}
@@ -34,15 +135,7 @@ func (r *taskRepository) FindAll(ctx context.Context) ([]*model.Task, error) {
 
 	var tasks []*model.Task
 	for rows.Next() {
-		task := &model.Task{}
-		err := rows.Scan(
-			&task.ID,
-			&task.Title,
-			&task.Deadline,
-			&task.IsComplete,
-			&task.CreatedAt,
-			&task.UpdatedAt,
-		)
+		task, err := scanTask(rows)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan task row: %w", err)
 		}
@@ -53,12 +146,29 @@ func (r *taskRepository) FindAll(ctx context.Context) ([]*model.Task, error) {
 		return nil, fmt.Errorf("error occurred during row iteration: %w", err)
 	}
 
+	if err := loadRelated(ctx, r.db, r.workflow, tasks); err != nil {
+		return nil, err
+	}
+
 	return tasks, nil
 }
 
 func (r *taskRepository) FindByID(ctx context.Context, id string) (*model.Task, error) {
-	// TODO: 実装予定
-	return nil, fmt.Errorf("FindByID not implemented yet")
+	query := "SELECT " + taskColumns + " FROM tasks WHERE id = $1 AND deleted_at IS NULL"
+
+	task, err := scanTask(r.db.QueryRowContext(ctx, query, id))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, &repository.TaskNotFoundError{ID: id}
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to find task: %w", err)
+	}
+
+	if err := loadRelated(ctx, r.db, r.workflow, []*model.Task{task}); err != nil {
+		return nil, err
+	}
+
+	return task, nil
 }
 
 func (r *taskRepository) Create(ctx context.Context, task *model.Task) (*model.Task, error) {
@@ -76,6 +186,15 @@ func (r *taskRepository) Create(ctx context.Context, task *model.Task) (*model.T
 
 	// タスクのコピーを作成（元のオブジェクトを変更しないため）
 	newTask := *task
+	newTask.Tags = append([]string{}, task.Tags...)
+
+	// 状態が未指定の場合は初期状態とし、ワークフローにない状態は受け付けない
+	if newTask.Status == "" {
+		newTask.Status = r.workflow.Initial
+	}
+	if err := r.workflow.CheckStatus(newTask.Status); err != nil {
+		return nil, fmt.Errorf("validation failed: %w", err)
+	}
 
 	// IDの処理
 	if newTask.ID == "" {
@@ -91,6 +210,7 @@ func (r *taskRepository) Create(ctx context.Context, task *model.Task) (*model.T
 	now := time.Now()
 	newTask.CreatedAt = now
 	newTask.UpdatedAt = now
+	newTask.CompletedAt = r.workflow.CompletedAt(nil, newTask.Status, now)
 
 	// トランザクションを開始
 	tx, err := r.db.BeginTx(ctx, nil)
@@ -103,22 +223,16 @@ func (r *taskRepository) Create(ctx context.Context, task *model.Task) (*model.T
 		}
 	}()
 
-	// SQLクエリの実行
-	query := `
-		INSERT INTO tasks (id, title, deadline, is_complete, created_at, updated_at)
-		VALUES ($1, $2, $3, $4, $5, $6)
-	`
+	if err = r.insertTaskRow(ctx, tx, &newTask); err != nil {
+		return nil, err
+	}
 
-	_, err = tx.ExecContext(ctx, query,
-		newTask.ID,
-		newTask.Title,
-		newTask.Deadline,
-		newTask.IsComplete,
-		newTask.CreatedAt,
-		newTask.UpdatedAt,
-	)
-	if err != nil {
-		return nil, fmt.Errorf("failed to insert task: %w", err)
+	if err = r.insertTaskEvents(ctx, tx, model.NewTaskEvents(nil, &newTask, r.workflow, repository.ActorFrom(ctx), now)); err != nil {
+		return nil, err
+	}
+
+	if err = r.recordOperation(ctx, tx, nil, &newTask, now); err != nil {
+		return nil, err
 	}
 
 	// トランザクションのコミット
@@ -130,11 +244,236 @@ func (r *taskRepository) Create(ctx context.Context, task *model.Task) (*model.T
 }
 
 func (r *taskRepository) Update(ctx context.Context, task *model.Task) (*model.Task, error) {
-	// TODO: 実装予定
-	return nil, fmt.Errorf("Update not implemented yet")
+	updated, err := r.UpdateAll(ctx, []*model.Task{task})
+	if err != nil {
+		return nil, err
+	}
+	return updated[0], nil
+}
+
+func (r *taskRepository) UpdateAll(ctx context.Context, tasks []*model.Task) ([]*model.Task, error) {
+	// コンテキストの確認
+	select {
+	case <-ctx.Done():
+		return nil, fmt.Errorf("context cancelled before task update: %w", ctx.Err())
+	default:
+	}
+
+	// トランザクションを開始
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
+		}
+	}()
+
+	now := time.Now()
+	updated := make([]*model.Task, 0, len(tasks))
+	for _, task := range tasks {
+		var updatedTask *model.Task
+		if updatedTask, err = r.updateTask(ctx, tx, task, now); err != nil {
+			return nil, err
+		}
+		updated = append(updated, updatedTask)
+	}
+
+	// トランザクションのコミット
+	if err = tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return updated, nil
+}
+
+// updateTask はトランザクション内でタスクを更新し、操作を履歴と取り消しのために記録する
+func (r *taskRepository) updateTask(ctx context.Context, tx *sql.Tx, task *model.Task, now time.Time) (*model.Task, error) {
+	// 更新対象の行をロックして、変更前のタグを含む現在の状態を取得
+	current, err := r.lockTask(ctx, tx, task.ID)
+	if err != nil {
+		return nil, err
+	}
+	if current == nil {
+		return nil, &repository.TaskNotFoundError{ID: task.ID}
+	}
+
+	// バリデーション
+	if err := task.ValidateUpdate(current); err != nil {
+		return nil, fmt.Errorf("validation failed: %w", err)
+	}
+
+	// タスクのコピーを作成（元のオブジェクトを変更しないため）
+	// 作成日時と、未指定の場合の状態は既存の値を維持する
+	updatedTask := *task
+	updatedTask.Tags = append([]string{}, task.Tags...)
+	updatedTask.CreatedAt = current.CreatedAt
+	updatedTask.UpdatedAt = now
+	if updatedTask.Status == "" {
+		updatedTask.Status = current.Status
+	}
+
+	// 状態の変更は、ワークフローで許可された状態遷移のみを受け付ける
+	if err := r.workflow.CheckTransition(current.Status, updatedTask.Status); err != nil {
+		return nil, fmt.Errorf("validation failed: %w", err)
+	}
+	updatedTask.CompletedAt = r.workflow.CompletedAt(current, updatedTask.Status, now)
+
+	if err := r.updateTaskRow(ctx, tx, &updatedTask); err != nil {
+		return nil, err
+	}
+
+	if err := r.insertTaskEvents(ctx, tx, model.NewTaskEvents(current, &updatedTask, r.workflow, repository.ActorFrom(ctx), now)); err != nil {
+		return nil, err
+	}
+
+	// 項目を変更しなかった場合は、取り消す操作がないため記録しない
+	if len(model.ChangedFields(current, &updatedTask)) > 0 {
+		if err := r.recordOperation(ctx, tx, current, &updatedTask, now); err != nil {
+			return nil, err
+		}
+	}
+
+	return &updatedTask, nil
 }
 
 func (r *taskRepository) Delete(ctx context.Context, id string) error {
-	// TODO: 実装予定
-	return fmt.Errorf("Delete not implemented yet")
+	// トランザクションを開始
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
+		}
+	}()
+
+	// 履歴と取り消しのための操作に記録するため、削除前のタスクをタグを含めて取得する
+	// 削除対象の行が存在しないか、既にゴミ箱にある場合はNotFoundエラーを返す
+	current, err := r.lockTask(ctx, tx, id)
+	if err != nil {
+		return err
+	}
+	if current == nil {
+		err = &repository.TaskNotFoundError{ID: id}
+		return err
+	}
+
+	now := time.Now()
+	if err = r.trashTask(ctx, tx, id, now); err != nil {
+		return err
+	}
+
+	if err = r.insertTaskEvents(ctx, tx, model.NewTaskEvents(current, nil, r.workflow, repository.ActorFrom(ctx), now)); err != nil {
+		return err
+	}
+
+	if err = r.recordOperation(ctx, tx, current, nil, now); err != nil {
+		return err
+	}
+
+	// トランザクションのコミット
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// lockTask は行をロックして、タグを含むタスクの現在の状態を取得する（存在しないかゴミ箱にある場合はnil）
+func (r *taskRepository) lockTask(ctx context.Context, tx *sql.Tx, id string) (*model.Task, error) {
+	task, err := scanTask(tx.QueryRowContext(ctx,
+		"SELECT "+taskColumns+" FROM tasks WHERE id = $1 AND deleted_at IS NULL"+r.dialect.lockRowClause, id))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to find task: %w", err)
+	}
+	if err := loadTags(ctx, tx, []*model.Task{task}); err != nil {
+		return nil, err
+	}
+	return task, nil
+}
+
+// insertTaskRow はタスクの行と、所属するプロジェクト、タグを保存する
+// 作成日時、更新日時、完了日時は設定済みの値をそのまま保存する
+func (r *taskRepository) insertTaskRow(ctx context.Context, tx *sql.Tx, task *model.Task) error {
+	if err := insertProject(ctx, tx, task.Project); err != nil {
+		return err
+	}
+
+	query := `
+		INSERT INTO tasks (id, title, deadline, status, priority, created_at, updated_at, project_id, parent_id, recurrence_id, description, start_at, wait_until, estimate_seconds, estimate_points, completed_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, (SELECT id FROM projects WHERE name = $8), $9, $10, $11, $12, $13, $14, $15, $16)
+	`
+
+	estimateSeconds, estimatePoints := estimateValues(task.Estimate)
+
+	_, err := tx.ExecContext(ctx, query,
+		task.ID,
+		task.Title,
+		r.dialect.deadlineValue(task.Deadline),
+		string(task.Status),
+		int(task.Priority),
+		r.dialect.timeValue(task.CreatedAt),
+		r.dialect.timeValue(task.UpdatedAt),
+		projectValue(task.Project),
+		parentValue(task.ParentID),
+		recurrenceValue(task.RecurrenceID),
+		task.Description,
+		r.dialect.deadlineValue(task.StartAt),
+		r.dialect.deadlineValue(task.WaitUntil),
+		estimateSeconds,
+		estimatePoints,
+		r.dialect.deadlineValue(task.CompletedAt),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to insert task: %w", err)
+	}
+
+	return insertTags(ctx, tx, task.ID, task.Tags)
+}
+
+// updateTaskRow はタスクの行を作成日時以外のすべての列で置き換え、プロジェクトとタグも保存する
+func (r *taskRepository) updateTaskRow(ctx context.Context, tx *sql.Tx, task *model.Task) error {
+	if err := insertProject(ctx, tx, task.Project); err != nil {
+		return err
+	}
+
+	query := `
+		UPDATE tasks
+		SET title = $1, deadline = $2, status = $3, priority = $4, updated_at = $5,
+			project_id = (SELECT id FROM projects WHERE name = $6), parent_id = $7, recurrence_id = $8,
+			description = $9, start_at = $10, wait_until = $11, estimate_seconds = $12, estimate_points = $13,
+			completed_at = $14
+		WHERE id = $15
+	`
+
+	estimateSeconds, estimatePoints := estimateValues(task.Estimate)
+
+	_, err := tx.ExecContext(ctx, query,
+		task.Title,
+		r.dialect.deadlineValue(task.Deadline),
+		string(task.Status),
+		int(task.Priority),
+		r.dialect.timeValue(task.UpdatedAt),
+		projectValue(task.Project),
+		parentValue(task.ParentID),
+		recurrenceValue(task.RecurrenceID),
+		task.Description,
+		r.dialect.deadlineValue(task.StartAt),
+		r.dialect.deadlineValue(task.WaitUntil),
+		estimateSeconds,
+		estimatePoints,
+		r.dialect.deadlineValue(task.CompletedAt),
+		task.ID,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update task: %w", err)
+	}
+
+	return replaceTags(ctx, tx, task.ID, task.Tags)
 }
@@ -19,15 +19,15 @@ func TestTaskRepository_Create(t *testing.T) {
 		assert.NoError(t, err)
 		defer func() { _ = db.Close() }()
 
-		repo := NewTaskRepository(db)
+		repo := NewTaskRepository(db, model.DefaultWorkflow())
 		ctx := context.Background()
 
 		// テスト用のタスクを作成
 		// IDは自動生成されることを想定してnewTaskではIDを設定しない
 		newTask := &model.Task{
-			Title:      "新しいタスク",
-			Deadline:   nil,
-			IsComplete: false,
+			Title:    "新しいタスク",
+			Deadline: nil,
+			Status:   model.StatusOpen,
 		}
 
 		// トランザクションの期待値を設定
@@ -39,11 +39,22 @@ func TestTaskRepository_Create(t *testing.T) {
 				sqlmock.AnyArg(), // ID (UUID)
 				"新しいタスク",         // Title
 				nil,              // Deadline
-				false,            // IsComplete
+				"open",           // Status
+				0,                // Priority
 				sqlmock.AnyArg(), // CreatedAt
 				sqlmock.AnyArg(), // UpdatedAt
+				nil,              // Project
+				nil,              // ParentID
+				nil,              // RecurrenceID
+				"",               // Description
+				nil,              // StartAt
+				nil,              // WaitUntil
+				nil,              // EstimateSeconds
+				nil,              // EstimatePoints
+				nil,              // CompletedAt
 			).
 			WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectExec("INSERT INTO task_events").WillReturnResult(sqlmock.NewResult(1, 1))
 		mock.ExpectCommit()
 
 		// Act
@@ -58,7 +69,7 @@ func TestTaskRepository_Create(t *testing.T) {
 		assert.NotEmpty(t, createdTask.ID) // IDが自動生成されていること
 		assert.Equal(t, "新しいタスク", createdTask.Title)
 		assert.Nil(t, createdTask.Deadline)
-		assert.False(t, createdTask.IsComplete)
+		assert.Equal(t, model.StatusOpen, createdTask.Status)
 		assert.NotZero(t, createdTask.CreatedAt) // CreatedAtが設定されていること
 		assert.NotZero(t, createdTask.UpdatedAt) // UpdatedAtが設定されていること
 
@@ -72,14 +83,14 @@ func TestTaskRepository_Create(t *testing.T) {
 		assert.NoError(t, err)
 		defer func() { _ = db.Close() }()
 
-		repo := NewTaskRepository(db)
+		repo := NewTaskRepository(db, model.DefaultWorkflow())
 		ctx := context.Background()
 
 		// タイトルが空のタスクを作成
 		invalidTask := &model.Task{
-			Title:      "", // 空文字
-			Deadline:   nil,
-			IsComplete: false,
+			Title:    "", // 空文字
+			Deadline: nil,
+			Status:   model.StatusOpen,
 		}
 
 		// DBクエリは実行されないことを期待（バリデーションで弾かれるため）
@@ -103,15 +114,15 @@ func TestTaskRepository_Create(t *testing.T) {
 		assert.NoError(t, err)
 		defer func() { _ = db.Close() }()
 
-		repo := NewTaskRepository(db)
+		repo := NewTaskRepository(db, model.DefaultWorkflow())
 		ctx := context.Background()
 
 		// 期限付きのタスクを作成
 		deadline := time.Now().Add(7 * 24 * time.Hour) // 1週間後
 		taskWithDeadline := &model.Task{
-			Title:      "期限付きタスク",
-			Deadline:   &deadline,
-			IsComplete: false,
+			Title:    "期限付きタスク",
+			Deadline: &deadline,
+			Status:   model.StatusOpen,
 		}
 
 		// トランザクションの期待値を設定
@@ -122,11 +133,22 @@ func TestTaskRepository_Create(t *testing.T) {
 				sqlmock.AnyArg(), // ID (UUID)
 				"期限付きタスク",        // Title
 				deadline,         // Deadline
-				false,            // IsComplete
+				"open",           // Status
+				0,                // Priority
 				sqlmock.AnyArg(), // CreatedAt
 				sqlmock.AnyArg(), // UpdatedAt
+				nil,              // Project
+				nil,              // ParentID
+				nil,              // RecurrenceID
+				"",               // Description
+				nil,              // StartAt
+				nil,              // WaitUntil
+				nil,              // EstimateSeconds
+				nil,              // EstimatePoints
+				nil,              // CompletedAt
 			).
 			WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectExec("INSERT INTO task_events").WillReturnResult(sqlmock.NewResult(1, 1))
 		mock.ExpectCommit()
 
 		// Act
@@ -142,7 +164,7 @@ func TestTaskRepository_Create(t *testing.T) {
 		assert.Equal(t, "期限付きタスク", createdTask.Title)
 		assert.NotNil(t, createdTask.Deadline)
 		assert.Equal(t, deadline.Unix(), createdTask.Deadline.Unix()) // 秒単位で比較
-		assert.False(t, createdTask.IsComplete)
+		assert.Equal(t, model.StatusOpen, createdTask.Status)
 
 		// モックの期待値が満たされていること
 		assert.NoError(t, mock.ExpectationsWereMet())
@@ -154,13 +176,13 @@ func TestTaskRepository_Create(t *testing.T) {
 		assert.NoError(t, err)
 		defer func() { _ = db.Close() }()
 
-		repo := NewTaskRepository(db)
+		repo := NewTaskRepository(db, model.DefaultWorkflow())
 		ctx := context.Background()
 
 		task := &model.Task{
-			Title:      "エラーテスト用タスク",
-			Deadline:   nil,
-			IsComplete: false,
+			Title:    "エラーテスト用タスク",
+			Deadline: nil,
+			Status:   model.StatusOpen,
 		}
 
 		// トランザクションの期待値を設定
@@ -171,9 +193,19 @@ func TestTaskRepository_Create(t *testing.T) {
 				sqlmock.AnyArg(), // ID
 				"エラーテスト用タスク",     // Title
 				nil,              // Deadline
-				false,            // IsComplete
+				"open",           // Status
+				0,                // Priority
 				sqlmock.AnyArg(), // CreatedAt
 				sqlmock.AnyArg(), // UpdatedAt
+				nil,              // Project
+				nil,              // ParentID
+				nil,              // RecurrenceID
+				"",               // Description
+				nil,              // StartAt
+				nil,              // WaitUntil
+				nil,              // EstimateSeconds
+				nil,              // EstimatePoints
+				nil,              // CompletedAt
 			).
 			WillReturnError(sql.ErrConnDone)
 		mock.ExpectRollback()
@@ -197,15 +229,15 @@ func TestTaskRepository_Create(t *testing.T) {
 		assert.NoError(t, err)
 		defer func() { _ = db.Close() }()
 
-		repo := NewTaskRepository(db)
+		repo := NewTaskRepository(db, model.DefaultWorkflow())
 		ctx := context.Background()
 
 		// IDが設定されていないタスクを作成
 		taskWithoutID := &model.Task{
-			ID:         "", // 空のID
-			Title:      "ID自動生成テスト",
-			Deadline:   nil,
-			IsComplete: false,
+			ID:       "", // 空のID
+			Title:    "ID自動生成テスト",
+			Deadline: nil,
+			Status:   model.StatusOpen,
 		}
 
 		// トランザクションの期待値を設定
@@ -217,11 +249,22 @@ func TestTaskRepository_Create(t *testing.T) {
 				sqlmock.AnyArg(), // 自動生成されたID
 				"ID自動生成テスト",      // Title
 				nil,              // Deadline
-				false,            // IsComplete
+				"open",           // Status
+				0,                // Priority
 				sqlmock.AnyArg(), // CreatedAt
 				sqlmock.AnyArg(), // UpdatedAt
+				nil,              // Project
+				nil,              // ParentID
+				nil,              // RecurrenceID
+				"",               // Description
+				nil,              // StartAt
+				nil,              // WaitUntil
+				nil,              // EstimateSeconds
+				nil,              // EstimatePoints
+				nil,              // CompletedAt
 			).
 			WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectExec("INSERT INTO task_events").WillReturnResult(sqlmock.NewResult(1, 1))
 		mock.ExpectCommit()
 
 		// Act
@@ -246,16 +289,16 @@ func TestTaskRepository_Create(t *testing.T) {
 		assert.NoError(t, err)
 		defer func() { _ = db.Close() }()
 
-		repo := NewTaskRepository(db)
+		repo := NewTaskRepository(db, model.DefaultWorkflow())
 		ctx := context.Background()
 
 		// 現在時刻を記録（タスク作成前）
 		beforeCreate := time.Now()
 
 		task := &model.Task{
-			Title:      "タイムスタンプテスト",
-			Deadline:   nil,
-			IsComplete: false,
+			Title:    "タイムスタンプテスト",
+			Deadline: nil,
+			Status:   model.StatusOpen,
 		}
 
 		// トランザクションの期待値を設定
@@ -266,11 +309,22 @@ func TestTaskRepository_Create(t *testing.T) {
 				sqlmock.AnyArg(), // ID
 				"タイムスタンプテスト",     // Title
 				nil,              // Deadline
-				false,            // IsComplete
+				"open",           // Status
+				0,                // Priority
 				sqlmock.AnyArg(), // CreatedAt
 				sqlmock.AnyArg(), // UpdatedAt
+				nil,              // Project
+				nil,              // ParentID
+				nil,              // RecurrenceID
+				"",               // Description
+				nil,              // StartAt
+				nil,              // WaitUntil
+				nil,              // EstimateSeconds
+				nil,              // EstimatePoints
+				nil,              // CompletedAt
 			).
 			WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectExec("INSERT INTO task_events").WillReturnResult(sqlmock.NewResult(1, 1))
 		mock.ExpectCommit()
 
 		// Act
@@ -306,13 +360,13 @@ func TestTaskRepository_Create(t *testing.T) {
 		assert.NoError(t, err)
 		defer func() { _ = db.Close() }()
 
-		repo := NewTaskRepository(db)
+		repo := NewTaskRepository(db, model.DefaultWorkflow())
 		ctx := context.Background()
 
 		task := &model.Task{
-			Title:      "トランザクションエラーテスト",
-			Deadline:   nil,
-			IsComplete: false,
+			Title:    "トランザクションエラーテスト",
+			Deadline: nil,
+			Status:   model.StatusOpen,
 		}
 
 		// トランザクション開始でエラーを返すように設定
@@ -337,13 +391,13 @@ func TestTaskRepository_Create(t *testing.T) {
 		assert.NoError(t, err)
 		defer func() { _ = db.Close() }()
 
-		repo := NewTaskRepository(db)
+		repo := NewTaskRepository(db, model.DefaultWorkflow())
 		ctx := context.Background()
 
 		task := &model.Task{
-			Title:      "コミットエラーテスト",
-			Deadline:   nil,
-			IsComplete: false,
+			Title:    "コミットエラーテスト",
+			Deadline: nil,
+			Status:   model.StatusOpen,
 		}
 
 		// トランザクションの期待値を設定
@@ -353,12 +407,23 @@ func TestTaskRepository_Create(t *testing.T) {
 				sqlmock.AnyArg(), // ID
 				"コミットエラーテスト",     // Title
 				nil,              // Deadline
-				false,            // IsComplete
+				"open",           // Status
+				0,                // Priority
 				sqlmock.AnyArg(), // CreatedAt
 				sqlmock.AnyArg(), // UpdatedAt
+				nil,              // Project
+				nil,              // ParentID
+				nil,              // RecurrenceID
+				"",               // Description
+				nil,              // StartAt
+				nil,              // WaitUntil
+				nil,              // EstimateSeconds
+				nil,              // EstimatePoints
+				nil,              // CompletedAt
 			).
 			WillReturnResult(sqlmock.NewResult(1, 1))
 		// コミットでエラーを返すように設定
+		mock.ExpectExec("INSERT INTO task_events").WillReturnResult(sqlmock.NewResult(1, 1))
 		mock.ExpectCommit().WillReturnError(sql.ErrTxDone)
 
 		// Act
@@ -380,15 +445,15 @@ func TestTaskRepository_Create(t *testing.T) {
 		assert.NoError(t, err)
 		defer func() { _ = db.Close() }()
 
-		repo := NewTaskRepository(db)
+		repo := NewTaskRepository(db, model.DefaultWorkflow())
 		ctx := context.Background()
 
 		// 過去の期限を設定したタスクを作成
 		pastDeadline := time.Now().Add(-24 * time.Hour) // 1日前
 		taskWithPastDeadline := &model.Task{
-			Title:      "過去の期限タスク",
-			Deadline:   &pastDeadline,
-			IsComplete: false,
+			Title:    "過去の期限タスク",
+			Deadline: &pastDeadline,
+			Status:   model.StatusOpen,
 		}
 
 		// DBクエリは実行されないことを期待（バリデーションで弾かれるため）
@@ -405,4 +470,89 @@ func TestTaskRepository_Create(t *testing.T) {
 		// モックの期待値が満たされていること（クエリが実行されていないこと）
 		assert.NoError(t, mock.ExpectationsWereMet())
 	})
+
+	t.Run("タグを登録してタスクに関連付ける", func(t *testing.T) {
+		// Arrange
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer func() { _ = db.Close() }()
+
+		repo := NewTaskRepository(db, model.DefaultWorkflow())
+		ctx := context.Background()
+
+		// 同じトランザクション内で、未登録のタグの追加と関連付けが行われることを期待
+		mock.ExpectBegin()
+		mock.ExpectExec("INSERT INTO tasks").WillReturnResult(sqlmock.NewResult(1, 1))
+		for _, tag := range []string{"home", "work"} {
+			mock.ExpectExec("INSERT INTO tags \\(name\\) VALUES \\(\\$1\\) ON CONFLICT \\(name\\) DO NOTHING").
+				WithArgs(tag).
+				WillReturnResult(sqlmock.NewResult(1, 1))
+			mock.ExpectExec("INSERT INTO task_tags").
+				WithArgs(sqlmock.AnyArg(), tag).
+				WillReturnResult(sqlmock.NewResult(1, 1))
+		}
+		mock.ExpectExec("INSERT INTO task_events").WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectCommit()
+
+		// Act
+		createdTask, err := repo.Create(ctx, &model.Task{Title: "タグ付きタスク", Tags: []string{"home", "work"}})
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"home", "work"}, createdTask.Tags)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("タグの登録に失敗した場合はロールバックする", func(t *testing.T) {
+		// Arrange
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer func() { _ = db.Close() }()
+
+		repo := NewTaskRepository(db, model.DefaultWorkflow())
+		ctx := context.Background()
+
+		mock.ExpectBegin()
+		mock.ExpectExec("INSERT INTO tasks").WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectExec("INSERT INTO tags").WillReturnError(sql.ErrConnDone)
+		mock.ExpectRollback()
+
+		// Act
+		createdTask, err := repo.Create(ctx, &model.Task{Title: "タグ付きタスク", Tags: []string{"work"}})
+
+		// Assert
+		assert.Error(t, err)
+		assert.Nil(t, createdTask)
+		assert.Contains(t, err.Error(), "failed to insert tag")
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("プロジェクトを登録してタスクから参照する", func(t *testing.T) {
+		// Arrange
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer func() { _ = db.Close() }()
+
+		repo := NewTaskRepository(db, model.DefaultWorkflow())
+		ctx := context.Background()
+
+		// 未登録のプロジェクトを追加してから、名前で参照してタスクを登録することを期待
+		mock.ExpectBegin()
+		mock.ExpectExec("INSERT INTO projects \\(name\\) VALUES \\(\\$1\\) ON CONFLICT \\(name\\) DO NOTHING").
+			WithArgs("work.backend").
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectExec("INSERT INTO tasks").
+			WithArgs(sqlmock.AnyArg(), "プロジェクトのタスク", nil, "open", 0, sqlmock.AnyArg(), sqlmock.AnyArg(), "work.backend", nil, nil, "", nil, nil, nil, nil, nil).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectExec("INSERT INTO task_events").WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectCommit()
+
+		// Act
+		createdTask, err := repo.Create(ctx, &model.Task{Title: "プロジェクトのタスク", Project: "work.backend"})
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, "work.backend", createdTask.Project)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
 }
@@ -0,0 +1,148 @@
+package infrastructure
+
+import (
+	"OTakumi/todogo/internal/domain/model"
+	"OTakumi/todogo/internal/repository"
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestTaskRepository_Delete はTaskRepositoryのDeleteメソッドのテストケース
+func TestTaskRepository_Delete(t *testing.T) {
+	columns := []string{"id", "title", "deadline", "status", "priority", "created_at", "updated_at", "project", "parent_id", "recurrence_id", "rule", "description", "start_at", "wait_until", "estimate_seconds", "estimate_points", "completed_at"}
+	createdAt := time.Now().Add(-time.Hour)
+
+	t.Run("タスクを完全には削除せずゴミ箱に移す", func(t *testing.T) {
+		// Arrange
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer func() { _ = db.Close() }()
+
+		repo := NewTaskRepository(db, model.DefaultWorkflow())
+		ctx := context.Background()
+
+		// トランザクション内で削除前の行を取得し、削除日時を設定して履歴を記録することを期待
+		mock.ExpectBegin()
+		mock.ExpectQuery("SELECT (.+) FROM tasks WHERE id = \\$1 AND deleted_at IS NULL FOR UPDATE").
+			WithArgs("task-1").
+			WillReturnRows(sqlmock.NewRows(columns).AddRow("task-1", "タスク", nil, "open", 0, createdAt, createdAt, nil, nil, nil, nil, "", nil, nil, nil, nil, nil))
+		mock.ExpectQuery("SELECT tt.task_id, g.name FROM task_tags").
+			WithArgs("task-1").
+			WillReturnRows(sqlmock.NewRows([]string{"task_id", "name"}))
+		mock.ExpectExec("UPDATE tasks SET deleted_at = \\$1 WHERE id = \\$2").
+			WithArgs(sqlmock.AnyArg(), "task-1").
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec("INSERT INTO task_events").
+			WithArgs("task-1", "deleted", "", "タスク", "", "", sqlmock.AnyArg()).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectCommit()
+
+		// Act
+		err = repo.Delete(ctx, "task-1")
+
+		// Assert
+		assert.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("操作のまとまりを保持する場合は取り消しのために削除前のタスクを記録する", func(t *testing.T) {
+		// Arrange
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer func() { _ = db.Close() }()
+
+		repo := NewTaskRepository(db, model.DefaultWorkflow())
+		ctx := repository.WithBatch(context.Background(), "batch-1", "rm 1")
+
+		// やり直しの対象を削除してから記録し、保持する数を超えたまとまりを削除することを期待
+		mock.ExpectBegin()
+		mock.ExpectQuery("SELECT (.+) FROM tasks WHERE id = \\$1 AND deleted_at IS NULL FOR UPDATE").
+			WithArgs("task-1").
+			WillReturnRows(sqlmock.NewRows(columns).AddRow("task-1", "タスク", nil, "open", 0, createdAt, createdAt, nil, nil, nil, nil, "", nil, nil, nil, nil, nil))
+		mock.ExpectQuery("SELECT tt.task_id, g.name FROM task_tags").
+			WithArgs("task-1").
+			WillReturnRows(sqlmock.NewRows([]string{"task_id", "name"}).AddRow("task-1", "work"))
+		mock.ExpectExec("UPDATE tasks SET deleted_at = \\$1 WHERE id = \\$2").
+			WithArgs(sqlmock.AnyArg(), "task-1").
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec("INSERT INTO task_events").
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectExec("DELETE FROM operations WHERE undone = \\$1").
+			WithArgs(true).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec("INSERT INTO operations").
+			WithArgs("batch-1", "rm 1", "delete", "task-1", sqlmock.AnyArg(), nil, false, sqlmock.AnyArg()).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectExec("DELETE FROM operations WHERE batch_id NOT IN").
+			WithArgs(repository.MaxOperationBatches).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectCommit()
+
+		// Act
+		err = repo.Delete(ctx, "task-1")
+
+		// Assert
+		assert.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("削除対象が存在しない場合にNotFoundエラーを返す", func(t *testing.T) {
+		// Arrange
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer func() { _ = db.Close() }()
+
+		repo := NewTaskRepository(db, model.DefaultWorkflow())
+		ctx := context.Background()
+
+		// 削除対象の行が見つからないことを返す
+		mock.ExpectBegin()
+		mock.ExpectQuery("SELECT (.+) FROM tasks WHERE id = \\$1 AND deleted_at IS NULL FOR UPDATE").
+			WithArgs("missing").
+			WillReturnRows(sqlmock.NewRows(columns))
+		mock.ExpectRollback()
+
+		// Act
+		err = repo.Delete(ctx, "missing")
+
+		// Assert
+		assert.True(t, errors.Is(err, repository.ErrTaskNotFound))
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("DBエラーが発生する", func(t *testing.T) {
+		// Arrange
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer func() { _ = db.Close() }()
+
+		repo := NewTaskRepository(db, model.DefaultWorkflow())
+		ctx := context.Background()
+
+		mock.ExpectBegin()
+		mock.ExpectQuery("SELECT (.+) FROM tasks WHERE id = \\$1 AND deleted_at IS NULL FOR UPDATE").
+			WithArgs("task-1").
+			WillReturnRows(sqlmock.NewRows(columns).AddRow("task-1", "タスク", nil, "open", 0, createdAt, createdAt, nil, nil, nil, nil, "", nil, nil, nil, nil, nil))
+		mock.ExpectQuery("SELECT tt.task_id, g.name FROM task_tags").
+			WithArgs("task-1").
+			WillReturnRows(sqlmock.NewRows([]string{"task_id", "name"}))
+		mock.ExpectExec("UPDATE tasks SET deleted_at = \\$1 WHERE id = \\$2").
+			WithArgs(sqlmock.AnyArg(), "task-1").
+			WillReturnError(sql.ErrConnDone)
+		mock.ExpectRollback()
+
+		// Act
+		err = repo.Delete(ctx, "task-1")
+
+		// Assert
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to delete task")
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
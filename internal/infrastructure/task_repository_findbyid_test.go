@@ -0,0 +1,121 @@
+package infrastructure
+
+import (
+	"OTakumi/todogo/internal/domain/model"
+	"OTakumi/todogo/internal/repository"
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestTaskRepository_FindByID はTaskRepositoryのFindByIDメソッドのテストケース
+func TestTaskRepository_FindByID(t *testing.T) {
+	t.Run("指定したIDのタスクを返す", func(t *testing.T) {
+		// Arrange
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer func() { _ = db.Close() }()
+
+		repo := NewTaskRepository(db, model.DefaultWorkflow())
+		ctx := context.Background()
+
+		now := time.Now()
+		rows := sqlmock.NewRows([]string{"id", "title", "deadline", "status", "priority", "created_at", "updated_at", "project", "parent_id", "recurrence_id", "rule", "description", "start_at", "wait_until", "estimate_seconds", "estimate_points", "completed_at"}).
+			AddRow("task-1", "Task 1", nil, "done", 0, now, now, "work.backend", nil, "rec-1", "FREQ=WEEKLY;BYDAY=MO", "詳細な説明", nil, nil, nil, nil, nil)
+
+		mock.ExpectQuery("SELECT id, title, deadline, status, priority, created_at, updated_at, \\(SELECT p.name FROM projects p WHERE p.id = project_id\\), parent_id, recurrence_id, \\(SELECT r.rule FROM recurrences r WHERE r.id = recurrence_id\\), description, start_at, wait_until, estimate_seconds, estimate_points, completed_at FROM tasks WHERE id = \\$1").
+			WithArgs("task-1").
+			WillReturnRows(rows)
+		mock.ExpectQuery("SELECT tt.task_id, g.name FROM task_tags tt JOIN tags g ON g.id = tt.tag_id WHERE tt.task_id IN \\(\\$1\\)").
+			WithArgs("task-1").
+			WillReturnRows(sqlmock.NewRows([]string{"task_id", "name"}).AddRow("task-1", "home").AddRow("task-1", "work"))
+		mock.ExpectQuery("WITH RECURSIVE subtasks (.+) WHERE parent_id IN \\(\\$1\\)").
+			WithArgs("task-1", "done", "cancelled").
+			WillReturnRows(sqlmock.NewRows([]string{"root_id", "done", "total"}).AddRow("task-1", 3, 5))
+		mock.ExpectQuery("SELECT task_id, blocked_by_id FROM task_dependencies WHERE task_id IN \\(\\$1\\)").
+			WithArgs("task-1").
+			WillReturnRows(sqlmock.NewRows([]string{"task_id", "blocked_by_id"}).AddRow("task-1", "task-0"))
+		mock.ExpectQuery("SELECT task_id, id, text, created_at FROM task_annotations WHERE task_id IN \\(\\$1\\)").
+			WithArgs("task-1").
+			WillReturnRows(sqlmock.NewRows([]string{"task_id", "id", "text", "created_at"}).AddRow("task-1", 3, "業者に電話した", now))
+
+		// Act
+		task, err := repo.FindByID(ctx, "task-1")
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, "task-1", task.ID)
+		assert.Equal(t, "Task 1", task.Title)
+		assert.Nil(t, task.Deadline)
+		assert.Equal(t, model.StatusDone, task.Status)
+		assert.Equal(t, []string{"home", "work"}, task.Tags)
+		assert.Equal(t, "work.backend", task.Project)
+		assert.Equal(t, model.Progress{Done: 3, Total: 5}, task.Subtasks)
+		assert.Equal(t, []string{"task-0"}, task.BlockedBy)
+		assert.Equal(t, "rec-1", task.RecurrenceID)
+		assert.Equal(t, &model.Recurrence{Frequency: model.FrequencyWeekly, Interval: 1, Weekdays: []time.Weekday{time.Monday}}, task.Recurrence)
+		assert.Equal(t, "詳細な説明", task.Description)
+		assert.Equal(t, []model.Annotation{{ID: 3, Text: "業者に電話した", CreatedAt: now}}, task.Annotations)
+
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("タスクが存在しない場合にNotFoundエラーを返す", func(t *testing.T) {
+		// Arrange
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer func() { _ = db.Close() }()
+
+		repo := NewTaskRepository(db, model.DefaultWorkflow())
+		ctx := context.Background()
+
+		mock.ExpectQuery("SELECT id, title, deadline, status, priority, created_at, updated_at, \\(SELECT p.name FROM projects p WHERE p.id = project_id\\), parent_id, recurrence_id, \\(SELECT r.rule FROM recurrences r WHERE r.id = recurrence_id\\), description, start_at, wait_until, estimate_seconds, estimate_points, completed_at FROM tasks WHERE id = \\$1").
+			WithArgs("missing").
+			WillReturnRows(sqlmock.NewRows([]string{"id", "title", "deadline", "status", "priority", "created_at", "updated_at", "project", "parent_id", "recurrence_id", "rule", "description", "start_at", "wait_until", "estimate_seconds", "estimate_points", "completed_at"}))
+
+		// Act
+		task, err := repo.FindByID(ctx, "missing")
+
+		// Assert
+		// 型付きのNotFoundエラーとして判定できること
+		assert.Nil(t, task)
+		assert.True(t, errors.Is(err, repository.ErrTaskNotFound))
+
+		var notFound *repository.TaskNotFoundError
+		assert.True(t, errors.As(err, &notFound))
+		assert.Equal(t, "missing", notFound.ID)
+
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("DBエラーが発生する", func(t *testing.T) {
+		// Arrange
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer func() { _ = db.Close() }()
+
+		repo := NewTaskRepository(db, model.DefaultWorkflow())
+		ctx := context.Background()
+
+		mock.ExpectQuery("SELECT id, title, deadline, status, priority, created_at, updated_at, \\(SELECT p.name FROM projects p WHERE p.id = project_id\\), parent_id, recurrence_id, \\(SELECT r.rule FROM recurrences r WHERE r.id = recurrence_id\\), description, start_at, wait_until, estimate_seconds, estimate_points, completed_at FROM tasks WHERE id = \\$1").
+			WithArgs("task-1").
+			WillReturnError(sql.ErrConnDone)
+
+		// Act
+		task, err := repo.FindByID(ctx, "task-1")
+
+		// Assert
+		// NotFoundではない通常のエラーであること
+		assert.Error(t, err)
+		assert.Nil(t, task)
+		assert.False(t, errors.Is(err, repository.ErrTaskNotFound))
+		assert.Contains(t, err.Error(), "failed to find task")
+
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
@@ -0,0 +1,41 @@
+package infrastructure
+
+import (
+	"OTakumi/todogo/internal/domain/model"
+	"OTakumi/todogo/internal/infrastructure/migration"
+	"OTakumi/todogo/internal/repository"
+	"OTakumi/todogo/internal/repository/repositorytest"
+	"OTakumi/todogo/migrations"
+	"context"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// TestPostgresTaskRepository は実際のPostgreSQLに対して共通のテストを実行する
+// TODOGO_TEST_POSTGRES_DSN に接続先を指定した場合のみ実行され、タスクとタスクの履歴、操作の記録の内容は削除される
+func TestPostgresTaskRepository(t *testing.T) {
+	dsn := os.Getenv("TODOGO_TEST_POSTGRES_DSN")
+	if dsn == "" {
+		t.Skip("TODOGO_TEST_POSTGRES_DSN is not set")
+	}
+
+	handler, err := NewPostgreSQLHandler(dsn)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = handler.DB.Close() })
+
+	m, err := migration.New(handler.DB, migrations.Postgres)
+	require.NoError(t, err)
+	_, err = m.Up(context.Background(), 0)
+	require.NoError(t, err)
+
+	repositorytest.Run(t, func(t *testing.T) repository.TaskRepository {
+		// 各テストはSQLiteと同様に空のデータベースから始まることを前提とする
+		// 履歴と操作の記録はタスクへの外部キー制約を持たず、タスクを削除しても残るため、併せて空にする
+		// タグの付与、依存関係、注記は CASCADE により空になる
+		_, err := handler.DB.Exec("TRUNCATE tasks, task_events, operations, time_entries RESTART IDENTITY CASCADE")
+		require.NoError(t, err)
+		return NewTaskRepository(handler.DB, model.DefaultWorkflow())
+	})
+}
@@ -0,0 +1,181 @@
+package infrastructure
+
+import (
+	"OTakumi/todogo/internal/domain/model"
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestTaskRepository_Search はTaskRepositoryのSearchメソッドのテストケース
+func TestTaskRepository_Search(t *testing.T) {
+	t.Run("全文検索で一致したタスクを関連度の順に返す", func(t *testing.T) {
+		// Arrange
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer func() { _ = db.Close() }()
+
+		repo := NewTaskRepository(db, model.DefaultWorkflow())
+		ctx := context.Background()
+
+		now := time.Now()
+		rows := sqlmock.NewRows([]string{"id", "title", "deadline", "status", "priority", "created_at", "updated_at", "project", "parent_id", "recurrence_id", "rule", "description", "start_at", "wait_until", "estimate_seconds", "estimate_points", "completed_at", "rank", "title_headline", "description_headline"}).
+			AddRow("task-1", "Call the vendor", nil, "open", 0, now, now, nil, nil, nil, nil, "Ask for\na quote", nil, nil, nil, nil, nil, 0.6, "Call the \x01vendor\x02", "Ask for\na quote").
+			AddRow("task-2", "Fix the sink", nil, "open", 0, now, now, nil, nil, nil, nil, "", nil, nil, nil, nil, nil, 0.1, "Fix the sink", "")
+
+		mock.ExpectQuery("WITH query AS \\(SELECT websearch_to_tsquery\\('simple', \\$1\\) AS q\\), ranked AS (.+) "+
+			"SELECT id, (.+), completed_at, ranked.rank, ts_headline\\('simple', title, query.q, \\$2\\), ts_headline\\('simple', description, query.q, \\$3\\) "+
+			"FROM tasks JOIN ranked ON ranked.task_id = id, query WHERE deleted_at IS NULL ORDER BY ranked.rank DESC, updated_at DESC, id LIMIT \\$4").
+			WithArgs("vendor", titleHeadlineOptions, textHeadlineOptions, 10).
+			WillReturnRows(rows)
+		mock.ExpectQuery("SELECT tt.task_id, g.name FROM task_tags tt (.+) WHERE tt.task_id IN \\(\\$1, \\$2\\)").
+			WithArgs("task-1", "task-2").
+			WillReturnRows(sqlmock.NewRows([]string{"task_id", "name"}))
+		mock.ExpectQuery("WITH RECURSIVE subtasks (.+) WHERE parent_id IN \\(\\$1, \\$2\\)").
+			WithArgs("task-1", "task-2", "done", "cancelled").
+			WillReturnRows(sqlmock.NewRows([]string{"root_id", "done", "total"}))
+		mock.ExpectQuery("SELECT task_id, blocked_by_id FROM task_dependencies WHERE task_id IN \\(\\$1, \\$2\\)").
+			WithArgs("task-1", "task-2").
+			WillReturnRows(sqlmock.NewRows([]string{"task_id", "blocked_by_id"}))
+		mock.ExpectQuery("SELECT task_id, id, text, created_at FROM task_annotations WHERE task_id IN \\(\\$1, \\$2\\)").
+			WithArgs("task-1", "task-2").
+			WillReturnRows(sqlmock.NewRows([]string{"task_id", "id", "text", "created_at"}).AddRow("task-2", 1, "vendor is closed", now))
+		mock.ExpectQuery("WITH query AS (.+) SELECT a.task_id, ts_headline\\('simple', a.text, query.q, \\$2\\) "+
+			"FROM task_annotations a, query WHERE a.search_vector @@ query.q AND a.task_id IN \\(\\$3, \\$4\\) ORDER BY a.task_id, a.created_at, a.id").
+			WithArgs("vendor", textHeadlineOptions, "task-1", "task-2").
+			WillReturnRows(sqlmock.NewRows([]string{"task_id", "headline"}).AddRow("task-2", "\x01vendor\x02 is closed"))
+
+		// Act
+		hits, err := repo.Search(ctx, "vendor", 10)
+
+		// Assert
+		assert.NoError(t, err)
+		if assert.Len(t, hits, 2) {
+			assert.Equal(t, "task-1", hits[0].Task.ID)
+			assert.Equal(t, 0.6, hits[0].Rank)
+			// 一致した語を含まない説明は、一致した箇所として扱わない
+			assert.Equal(t, []model.SearchMatch{
+				{Field: model.SearchFieldTitle, Snippet: "Call the vendor", Highlights: []model.Highlight{{Start: 9, End: 15}}},
+			}, hits[0].Matches)
+
+			assert.Equal(t, "task-2", hits[1].Task.ID)
+			assert.Len(t, hits[1].Task.Annotations, 1)
+			assert.Equal(t, []model.SearchMatch{
+				{Field: model.SearchFieldAnnotation, Snippet: "vendor is closed", Highlights: []model.Highlight{{Start: 0, End: 6}}},
+			}, hits[1].Matches)
+		}
+
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("一致しない場合は関連するデータを読み込まずに空を返す", func(t *testing.T) {
+		// Arrange
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer func() { _ = db.Close() }()
+
+		repo := NewTaskRepository(db, model.DefaultWorkflow())
+		ctx := context.Background()
+
+		mock.ExpectQuery("WITH query AS (.+) ORDER BY ranked.rank DESC, updated_at DESC, id$").
+			WithArgs("plumber", titleHeadlineOptions, textHeadlineOptions).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "title", "deadline", "status", "priority", "created_at", "updated_at", "project", "parent_id", "recurrence_id", "rule", "description", "start_at", "wait_until", "estimate_seconds", "estimate_points", "completed_at", "rank", "title_headline", "description_headline"}))
+
+		// Act
+		hits, err := repo.Search(ctx, "plumber", 0)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Empty(t, hits)
+
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("検索語がない場合はクエリを実行せずにエラーを返す", func(t *testing.T) {
+		// Arrange
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer func() { _ = db.Close() }()
+
+		repo := NewTaskRepository(db, model.DefaultWorkflow())
+
+		// Act
+		hits, err := repo.Search(context.Background(), " ", 0)
+
+		// Assert
+		assert.Nil(t, hits)
+		assert.ErrorContains(t, err, "invalid search query")
+
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("DBエラーが発生する", func(t *testing.T) {
+		// Arrange
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer func() { _ = db.Close() }()
+
+		repo := NewTaskRepository(db, model.DefaultWorkflow())
+
+		mock.ExpectQuery("WITH query AS (.+)").WillReturnError(sql.ErrConnDone)
+
+		// Act
+		hits, err := repo.Search(context.Background(), "vendor", 0)
+
+		// Assert
+		assert.Nil(t, hits)
+		assert.ErrorContains(t, err, "failed to search tasks")
+
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestParseHeadline(t *testing.T) {
+	tests := []struct {
+		name           string
+		headline       string
+		wantSnippet    string
+		wantHighlights []model.Highlight
+	}{
+		{
+			name:        "一致した語を囲む文字を範囲に変換する",
+			headline:    "\x01Call\x02 the \x01vendor\x02",
+			wantSnippet: "Call the vendor",
+			wantHighlights: []model.Highlight{
+				{Start: 0, End: 4},
+				{Start: 9, End: 15},
+			},
+		},
+		{
+			name:           "範囲はバイト単位で数える",
+			headline:       "業者に\x01電話\x02した",
+			wantSnippet:    "業者に電話した",
+			wantHighlights: []model.Highlight{{Start: 9, End: 15}},
+		},
+		{
+			name:        "改行を空白に置き換える",
+			headline:    "Ask the\n\x01vendor\x02",
+			wantSnippet: "Ask the vendor",
+			wantHighlights: []model.Highlight{
+				{Start: 8, End: 14},
+			},
+		},
+		{
+			name:        "一致した語がない場合は範囲を返さない",
+			headline:    "Fix the sink",
+			wantSnippet: "Fix the sink",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			snippet, highlights := parseHeadline(tt.headline)
+			assert.Equal(t, tt.wantSnippet, snippet)
+			assert.Equal(t, tt.wantHighlights, highlights)
+		})
+	}
+}
@@ -1,6 +1,8 @@
 package infrastructure
 
 import (
+	"OTakumi/todogo/internal/domain/model"
+	"OTakumi/todogo/internal/repository"
 	"context"
 	"database/sql"
 	"testing"
@@ -22,16 +24,16 @@ func TestTaskRepository_FindAll(t *testing.T) {
 		assert.NoError(t, err)
 		defer func() { _ = db.Close() }()
 
-		repo := NewTaskRepository(db)
+		repo := NewTaskRepository(db, model.DefaultWorkflow())
 		ctx := context.Background()
 
 		// tasksテーブルに対するSELECTクエリの期待値を設定する
 		// このクエリが実行された際、指定したカラムの行をモックが返す
-		mock.ExpectQuery("SELECT id, title, deadline, is_complete, created_at, updated_at FROM tasks").
-			WillReturnRows(sqlmock.NewRows([]string{"id", "title", "deadline", "is_complete", "created_at", "updated_at"}))
+		mock.ExpectQuery("SELECT id, title, deadline, status, priority, created_at, updated_at, \\(SELECT p.name FROM projects p WHERE p.id = project_id\\), parent_id, recurrence_id, \\(SELECT r.rule FROM recurrences r WHERE r.id = recurrence_id\\), description, start_at, wait_until, estimate_seconds, estimate_points, completed_at FROM tasks").
+			WillReturnRows(sqlmock.NewRows([]string{"id", "title", "deadline", "status", "priority", "created_at", "updated_at", "project", "parent_id", "recurrence_id", "rule", "description", "start_at", "wait_until", "estimate_seconds", "estimate_points", "completed_at"}))
 
 		// Act
-		tasks, err := repo.FindAll(ctx)
+		tasks, err := repo.FindAll(ctx, repository.TaskQuery{})
 
 		// Assert
 		// エラーが発生しないこと
@@ -50,19 +52,37 @@ func TestTaskRepository_FindAll(t *testing.T) {
 		assert.NoError(t, err)
 		defer func() { _ = db.Close() }()
 
-		repo := NewTaskRepository(db)
+		repo := NewTaskRepository(db, model.DefaultWorkflow())
 		ctx := context.Background()
 
 		now := time.Now()
-		rows := sqlmock.NewRows([]string{"id", "title", "deadline", "is_complete", "created_at", "updated_at"}).
-			AddRow("1", "Task 1", now, false, now, now).
-			AddRow("2", "Task 2", now.Add(24*time.Hour), true, now, now)
+		rows := sqlmock.NewRows([]string{"id", "title", "deadline", "status", "priority", "created_at", "updated_at", "project", "parent_id", "recurrence_id", "rule", "description", "start_at", "wait_until", "estimate_seconds", "estimate_points", "completed_at"}).
+			AddRow("1", "Task 1", now, "open", 0, now, now, nil, nil, nil, nil, "", nil, nil, nil, nil, nil).
+			AddRow("2", "Task 2", now.Add(24*time.Hour), "done", 0, now, now, nil, "1", nil, nil, "## 手順\n1. 確認する", nil, nil, nil, nil, nil)
 
-		mock.ExpectQuery("SELECT id, title, deadline, is_complete, created_at, updated_at FROM tasks").
+		mock.ExpectQuery("SELECT id, title, deadline, status, priority, created_at, updated_at, \\(SELECT p.name FROM projects p WHERE p.id = project_id\\), parent_id, recurrence_id, \\(SELECT r.rule FROM recurrences r WHERE r.id = recurrence_id\\), description, start_at, wait_until, estimate_seconds, estimate_points, completed_at FROM tasks").
 			WillReturnRows(rows)
+		// タグはタスクごとではなく、1回の問い合わせでまとめて読み込むこと
+		mock.ExpectQuery("SELECT tt.task_id, g.name FROM task_tags tt JOIN tags g ON g.id = tt.tag_id WHERE tt.task_id IN \\(\\$1, \\$2\\)").
+			WithArgs("1", "2").
+			WillReturnRows(sqlmock.NewRows([]string{"task_id", "name"}).AddRow("2", "work"))
+		// サブタスクの進捗も、1回の問い合わせでまとめて読み込むこと
+		mock.ExpectQuery("WITH RECURSIVE subtasks (.+) WHERE parent_id IN \\(\\$1, \\$2\\)").
+			WithArgs("1", "2", "done", "cancelled").
+			WillReturnRows(sqlmock.NewRows([]string{"root_id", "done", "total"}).AddRow("1", 1, 1))
+		// 依存関係も、1回の問い合わせでまとめて読み込むこと
+		mock.ExpectQuery("SELECT task_id, blocked_by_id FROM task_dependencies WHERE task_id IN \\(\\$1, \\$2\\)").
+			WithArgs("1", "2").
+			WillReturnRows(sqlmock.NewRows([]string{"task_id", "blocked_by_id"}).AddRow("1", "2"))
+		// 注記も、1回の問い合わせでまとめて読み込むこと
+		mock.ExpectQuery("SELECT task_id, id, text, created_at FROM task_annotations WHERE task_id IN \\(\\$1, \\$2\\)").
+			WithArgs("1", "2").
+			WillReturnRows(sqlmock.NewRows([]string{"task_id", "id", "text", "created_at"}).
+				AddRow("2", 1, "業者に電話した", now).
+				AddRow("2", 2, "見積もりを受領", now))
 
 		// Act
-		tasks, err := repo.FindAll(ctx)
+		tasks, err := repo.FindAll(ctx, repository.TaskQuery{})
 
 		// Assert
 		// エラーが発生しないこと
@@ -74,10 +94,23 @@ func TestTaskRepository_FindAll(t *testing.T) {
 		// タスクの内容が、登録した内容と一致すること
 		assert.Equal(t, "1", tasks[0].ID)
 		assert.Equal(t, "Task 1", tasks[0].Title)
-		assert.False(t, tasks[0].IsComplete)
+		assert.Equal(t, model.StatusOpen, tasks[0].Status)
 		assert.Equal(t, "2", tasks[1].ID)
 		assert.Equal(t, "Task 2", tasks[1].Title)
-		assert.True(t, tasks[1].IsComplete)
+		assert.Equal(t, model.StatusDone, tasks[1].Status)
+		assert.Empty(t, tasks[0].Tags)
+		assert.Equal(t, []string{"work"}, tasks[1].Tags)
+		assert.Equal(t, "1", tasks[1].ParentID)
+		assert.Equal(t, model.Progress{Done: 1, Total: 1}, tasks[0].Subtasks)
+		assert.Equal(t, []string{"2"}, tasks[0].BlockedBy)
+		assert.Empty(t, tasks[1].BlockedBy)
+		assert.Empty(t, tasks[0].Description)
+		assert.Equal(t, "## 手順\n1. 確認する", tasks[1].Description)
+		assert.Empty(t, tasks[0].Annotations)
+		assert.Equal(t, []model.Annotation{
+			{ID: 1, Text: "業者に電話した", CreatedAt: now},
+			{ID: 2, Text: "見積もりを受領", CreatedAt: now},
+		}, tasks[1].Annotations)
 
 		// 設定したモックの期待値が全て満たされること
 		assert.NoError(t, mock.ExpectationsWereMet())
@@ -89,14 +122,14 @@ func TestTaskRepository_FindAll(t *testing.T) {
 		assert.NoError(t, err)
 		defer func() { _ = db.Close() }()
 
-		repo := NewTaskRepository(db)
+		repo := NewTaskRepository(db, model.DefaultWorkflow())
 		ctx := context.Background()
 
-		mock.ExpectQuery("SELECT id, title, deadline, is_complete, created_at, updated_at FROM tasks").
+		mock.ExpectQuery("SELECT id, title, deadline, status, priority, created_at, updated_at, \\(SELECT p.name FROM projects p WHERE p.id = project_id\\), parent_id, recurrence_id, \\(SELECT r.rule FROM recurrences r WHERE r.id = recurrence_id\\), description, start_at, wait_until, estimate_seconds, estimate_points, completed_at FROM tasks").
 			WillReturnError(sql.ErrConnDone)
 
 		// Act
-		tasks, err := repo.FindAll(ctx)
+		tasks, err := repo.FindAll(ctx, repository.TaskQuery{})
 
 		// Assert
 		// エラーが発生すること
@@ -0,0 +1,50 @@
+package infrastructure
+
+import (
+	"OTakumi/todogo/internal/domain/model"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestTaskRepository_AddTimeEntry はTaskRepositoryのAddTimeEntryメソッドのテストケース
+func TestTaskRepository_AddTimeEntry(t *testing.T) {
+	t.Run("計測中の記録の一意制約の違反は計測中の記録と共にエラーを返す", func(t *testing.T) {
+		// Arrange
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer func() { _ = db.Close() }()
+
+		repo := NewTaskRepository(db, model.DefaultWorkflow())
+		ctx := context.Background()
+		startedAt := time.Now().Add(-time.Hour)
+
+		// 確認から追加までの間に別のプロセスが計測を開始した場合を想定する
+		mock.ExpectQuery("SELECT 1 FROM tasks WHERE id = \\$1 AND deleted_at IS NULL").
+			WithArgs("task-1").
+			WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(1))
+		mock.ExpectQuery("INSERT INTO time_entries").
+			WithArgs("task-1", "alice", sqlmock.AnyArg(), nil).
+			WillReturnError(&pq.Error{Code: "23505"})
+		mock.ExpectQuery("SELECT id, task_id, user_name, started_at, ended_at FROM time_entries WHERE user_name = \\$1 AND ended_at IS NULL").
+			WithArgs("alice").
+			WillReturnRows(sqlmock.NewRows([]string{"id", "task_id", "user_name", "started_at", "ended_at"}).AddRow(7, "task-2", "alice", startedAt, nil))
+
+		// Act
+		_, err = repo.AddTimeEntry(ctx, model.TimeEntry{TaskID: "task-1", User: "alice", StartedAt: time.Now()})
+
+		// Assert
+		var runningErr *model.TimerRunningError
+		if assert.True(t, errors.As(err, &runningErr), "expected TimerRunningError, got %v", err) {
+			assert.Equal(t, int64(7), runningErr.Entry.ID)
+			assert.Equal(t, "task-2", runningErr.Entry.TaskID)
+		}
+		assert.True(t, errors.Is(err, model.ErrTimerRunning))
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
@@ -0,0 +1,226 @@
+package infrastructure
+
+import (
+	"OTakumi/todogo/internal/domain/model"
+	"OTakumi/todogo/internal/repository"
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestTaskRepository_Update はTaskRepositoryのUpdateメソッドのテストケース
+func TestTaskRepository_Update(t *testing.T) {
+	columns := []string{"id", "title", "deadline", "status", "priority", "created_at", "updated_at", "project", "parent_id", "recurrence_id", "rule", "description", "start_at", "wait_until", "estimate_seconds", "estimate_points", "completed_at"}
+
+	t.Run("正常にタスクを更新できる", func(t *testing.T) {
+		// Arrange
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer func() { _ = db.Close() }()
+
+		repo := NewTaskRepository(db, model.DefaultWorkflow())
+		ctx := context.Background()
+
+		createdAt := time.Now().Add(-time.Hour)
+		task := &model.Task{
+			ID:     "task-1",
+			Title:  "更新後のタスク",
+			Status: model.StatusDone,
+		}
+
+		// トランザクション内で現在の行をロックして取得し、更新することを期待
+		mock.ExpectBegin()
+		mock.ExpectQuery("SELECT (.+) FROM tasks WHERE id = \\$1 AND deleted_at IS NULL FOR UPDATE").
+			WithArgs("task-1").
+			WillReturnRows(sqlmock.NewRows(columns).AddRow("task-1", "更新前のタスク", nil, "open", 0, createdAt, createdAt, nil, nil, nil, nil, "", nil, nil, nil, nil, nil))
+		mock.ExpectQuery("SELECT tt.task_id, g.name FROM task_tags").
+			WithArgs("task-1").
+			WillReturnRows(sqlmock.NewRows([]string{"task_id", "name"}))
+		mock.ExpectExec("UPDATE tasks").
+			WithArgs(
+				"更新後のタスク",        // Title
+				nil,              // Deadline
+				"done",           // Status
+				0,                // Priority
+				sqlmock.AnyArg(), // UpdatedAt
+				nil,              // Project
+				nil,              // ParentID
+				nil,              // RecurrenceID
+				"",               // Description
+				nil,              // StartAt
+				nil,              // WaitUntil
+				nil,              // EstimateSeconds
+				nil,              // EstimatePoints
+				sqlmock.AnyArg(), // CompletedAt
+				"task-1",         // ID
+			).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec("DELETE FROM task_tags").
+			WithArgs("task-1").
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		// 変更した項目ごとに履歴を記録することを期待
+		mock.ExpectExec("INSERT INTO task_events").
+			WithArgs("task-1", "changed", "title", "更新前のタスク", "更新後のタスク", "", sqlmock.AnyArg()).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectExec("INSERT INTO task_events").
+			WithArgs("task-1", "completed", "status", "open", "done", "", sqlmock.AnyArg()).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectCommit()
+
+		// Act
+		updatedTask, err := repo.Update(ctx, task)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, "更新後のタスク", updatedTask.Title)
+		assert.Equal(t, model.StatusDone, updatedTask.Status)
+		assert.NotNil(t, updatedTask.CompletedAt)
+
+		// 作成日時は維持され、更新日時は新しくなること
+		assert.Equal(t, createdAt, updatedTask.CreatedAt)
+		assert.True(t, updatedTask.UpdatedAt.After(createdAt))
+
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("タスクが存在しない場合にNotFoundエラーを返す", func(t *testing.T) {
+		// Arrange
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer func() { _ = db.Close() }()
+
+		repo := NewTaskRepository(db, model.DefaultWorkflow())
+		ctx := context.Background()
+
+		mock.ExpectBegin()
+		mock.ExpectQuery("SELECT (.+) FROM tasks WHERE id = \\$1 AND deleted_at IS NULL FOR UPDATE").
+			WithArgs("missing").
+			WillReturnRows(sqlmock.NewRows(columns))
+		mock.ExpectRollback()
+
+		// Act
+		updatedTask, err := repo.Update(ctx, &model.Task{ID: "missing", Title: "タスク"})
+
+		// Assert
+		assert.Nil(t, updatedTask)
+		assert.True(t, errors.Is(err, repository.ErrTaskNotFound))
+
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("期限切れのタスクも締切を変更しなければ更新できる", func(t *testing.T) {
+		// Arrange
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer func() { _ = db.Close() }()
+
+		repo := NewTaskRepository(db, model.DefaultWorkflow())
+		ctx := context.Background()
+
+		pastDeadline := time.Now().Add(-24 * time.Hour)
+		createdAt := time.Now().Add(-48 * time.Hour)
+		task := &model.Task{
+			ID:       "task-1",
+			Title:    "期限切れのタスク",
+			Deadline: &pastDeadline,
+			Status:   model.StatusDone,
+		}
+
+		mock.ExpectBegin()
+		mock.ExpectQuery("SELECT (.+) FROM tasks WHERE id = \\$1 AND deleted_at IS NULL FOR UPDATE").
+			WithArgs("task-1").
+			WillReturnRows(sqlmock.NewRows(columns).AddRow("task-1", "期限切れのタスク", pastDeadline, "open", 0, createdAt, createdAt, nil, nil, nil, nil, "", nil, nil, nil, nil, nil))
+		mock.ExpectQuery("SELECT tt.task_id, g.name FROM task_tags").
+			WithArgs("task-1").
+			WillReturnRows(sqlmock.NewRows([]string{"task_id", "name"}))
+		mock.ExpectExec("UPDATE tasks").
+			WithArgs("期限切れのタスク", pastDeadline, "done", 0, sqlmock.AnyArg(), nil, nil, nil, "", nil, nil, nil, nil, sqlmock.AnyArg(), "task-1").
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec("DELETE FROM task_tags").
+			WithArgs("task-1").
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec("INSERT INTO task_events").
+			WithArgs("task-1", "completed", "status", "open", "done", "", sqlmock.AnyArg()).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectCommit()
+
+		// Act
+		updatedTask, err := repo.Update(ctx, task)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, model.StatusDone, updatedTask.Status)
+
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("締切を過去に変更しようとするとバリデーションエラーが発生する", func(t *testing.T) {
+		// Arrange
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer func() { _ = db.Close() }()
+
+		repo := NewTaskRepository(db, model.DefaultWorkflow())
+		ctx := context.Background()
+
+		pastDeadline := time.Now().Add(-24 * time.Hour)
+		createdAt := time.Now().Add(-48 * time.Hour)
+
+		mock.ExpectBegin()
+		mock.ExpectQuery("SELECT (.+) FROM tasks WHERE id = \\$1 AND deleted_at IS NULL FOR UPDATE").
+			WithArgs("task-1").
+			WillReturnRows(sqlmock.NewRows(columns).AddRow("task-1", "タスク", nil, "open", 0, createdAt, createdAt, nil, nil, nil, nil, "", nil, nil, nil, nil, nil))
+		mock.ExpectQuery("SELECT tt.task_id, g.name FROM task_tags").
+			WithArgs("task-1").
+			WillReturnRows(sqlmock.NewRows([]string{"task_id", "name"}))
+		mock.ExpectRollback()
+
+		// Act
+		updatedTask, err := repo.Update(ctx, &model.Task{ID: "task-1", Title: "タスク", Deadline: &pastDeadline})
+
+		// Assert
+		assert.Error(t, err)
+		assert.Nil(t, updatedTask)
+		assert.Contains(t, err.Error(), "Deadline must be in the future")
+
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("DBへのUPDATEが失敗する", func(t *testing.T) {
+		// Arrange
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer func() { _ = db.Close() }()
+
+		repo := NewTaskRepository(db, model.DefaultWorkflow())
+		ctx := context.Background()
+
+		createdAt := time.Now().Add(-time.Hour)
+
+		mock.ExpectBegin()
+		mock.ExpectQuery("SELECT (.+) FROM tasks WHERE id = \\$1 AND deleted_at IS NULL FOR UPDATE").
+			WithArgs("task-1").
+			WillReturnRows(sqlmock.NewRows(columns).AddRow("task-1", "タスク", nil, "open", 0, createdAt, createdAt, nil, nil, nil, nil, "", nil, nil, nil, nil, nil))
+		mock.ExpectQuery("SELECT tt.task_id, g.name FROM task_tags").
+			WithArgs("task-1").
+			WillReturnRows(sqlmock.NewRows([]string{"task_id", "name"}))
+		mock.ExpectExec("UPDATE tasks").
+			WillReturnError(sql.ErrConnDone)
+		mock.ExpectRollback()
+
+		// Act
+		updatedTask, err := repo.Update(ctx, &model.Task{ID: "task-1", Title: "タスク"})
+
+		// Assert
+		assert.Error(t, err)
+		assert.Nil(t, updatedTask)
+		assert.Contains(t, err.Error(), "failed to update task")
+
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
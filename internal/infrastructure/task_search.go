@@ -0,0 +1,242 @@
+package infrastructure
+
+import (
+	"OTakumi/todogo/internal/domain/model"
+	"OTakumi/todogo/internal/domain/service"
+	"context"
+	"fmt"
+	"strings"
+)
+
+// ts_headline で一致した語を囲む文字（本文に現れない制御文字を使い、取得後に範囲へ変換する）
+const (
+	headlineStart = "\x01"
+	headlineStop  = "\x02"
+)
+
+// ts_headline のオプション
+// タイトルは全体を、説明と注記は一致した箇所の前後を抜粋して返す
+var (
+	titleHeadlineOptions = fmt.Sprintf(`StartSel="%s", StopSel="%s", HighlightAll=TRUE`, headlineStart, headlineStop)
+	textHeadlineOptions  = fmt.Sprintf(`StartSel="%s", StopSel="%s", MaxFragments=2, MinWords=5, MaxWords=20, FragmentDelimiter=" %s "`,
+		headlineStart, headlineStop, snippetEllipsis)
+)
+
+// snippetEllipsis は抜粋の断片の区切りを表す文字列（部分一致の検索の抜粋と揃える）
+const snippetEllipsis = "…"
+
+// searchQueryCTE は検索文字列を tsquery に変換する共通テーブル式
+// websearch_to_tsquery は構文の誤りでエラーにならないため、ユーザーの入力をそのまま渡せる
+const searchQueryCTE = "query AS (SELECT websearch_to_tsquery('simple', $1) AS q)"
+
+// extraColumns は scanTask が読み取る列に続けて、追加の列を読み取る rowScanner
+type extraColumns struct {
+	row  rowScanner
+	dest []any
+}
+
+func (e extraColumns) Scan(dest ...any) error {
+	return e.row.Scan(append(dest, e.dest...)...)
+}
+
+func (r *taskRepository) Search(ctx context.Context, query string, limit int) ([]model.SearchHit, error) {
+	terms, err := model.SearchTerms(query)
+	if err != nil {
+		return nil, fmt.Errorf("invalid search query: %w", err)
+	}
+	if r.dialect.fullTextSearch {
+		return r.searchFullText(ctx, query, limit)
+	}
+	return r.searchSubstring(ctx, terms, limit)
+}
+
+// searchFullText はタイトル、説明、注記の tsvector を全文検索し、関連度の順に返す
+// 関連度はタスクと注記ごとの ts_rank の合計とする
+func (r *taskRepository) searchFullText(ctx context.Context, query string, limit int) ([]model.SearchHit, error) {
+	b := &queryBuilder{}
+	b.arg(query)
+	sqlQuery := "WITH " + searchQueryCTE + ", " +
+		"ranked AS (SELECT task_id, SUM(rank) AS rank FROM (" +
+		"SELECT t.id AS task_id, ts_rank(t.search_vector, query.q) AS rank FROM tasks t, query WHERE t.search_vector @@ query.q " +
+		"UNION ALL " +
+		"SELECT a.task_id, ts_rank(a.search_vector, query.q) FROM task_annotations a, query WHERE a.search_vector @@ query.q" +
+		") matched GROUP BY task_id) " +
+		"SELECT " + taskColumns + ", ranked.rank, " +
+		"ts_headline('simple', title, query.q, " + b.arg(titleHeadlineOptions) + "), " +
+		"ts_headline('simple', description, query.q, " + b.arg(textHeadlineOptions) + ") " +
+		"FROM tasks JOIN ranked ON ranked.task_id = id, query WHERE deleted_at IS NULL " +
+		"ORDER BY ranked.rank DESC, updated_at DESC, id"
+	if limit > 0 {
+		sqlQuery += " LIMIT " + b.arg(limit)
+	}
+
+	rows, err := r.db.QueryContext(ctx, sqlQuery, b.args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to search tasks: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	var hits []model.SearchHit
+	var tasks []*model.Task
+	for rows.Next() {
+		var hit model.SearchHit
+		var title, description string
+		task, err := scanTask(extraColumns{row: rows, dest: []any{&hit.Rank, &title, &description}})
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan task row: %w", err)
+		}
+		hit.Task = task
+		hit.Matches = appendHeadline(hit.Matches, model.SearchFieldTitle, title)
+		hit.Matches = appendHeadline(hit.Matches, model.SearchFieldDescription, description)
+		hits = append(hits, hit)
+		tasks = append(tasks, task)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error occurred during row iteration: %w", err)
+	}
+
+	if err := loadRelated(ctx, r.db, r.workflow, tasks); err != nil {
+		return nil, err
+	}
+	if err := r.loadAnnotationHeadlines(ctx, query, hits); err != nil {
+		return nil, err
+	}
+	return hits, nil
+}
+
+// loadAnnotationHeadlines は検索語に一致した注記の抜粋をまとめて取得し、追加順に各検索結果に加える
+func (r *taskRepository) loadAnnotationHeadlines(ctx context.Context, query string, hits []model.SearchHit) error {
+	byID := make(map[string]*model.SearchHit, len(hits))
+	for i := range hits {
+		byID[hits[i].Task.ID] = &hits[i]
+	}
+
+	for start := 0; start < len(hits); start += loadBatchSize {
+		end := min(start+loadBatchSize, len(hits))
+
+		b := &queryBuilder{}
+		b.arg(query)
+		options := b.arg(textHeadlineOptions)
+		placeholders := make([]string, 0, end-start)
+		for _, hit := range hits[start:end] {
+			placeholders = append(placeholders, b.arg(hit.Task.ID))
+		}
+
+		sqlQuery := "WITH " + searchQueryCTE + " " +
+			"SELECT a.task_id, ts_headline('simple', a.text, query.q, " + options + ") " +
+			"FROM task_annotations a, query WHERE a.search_vector @@ query.q AND a.task_id IN (" +
+			strings.Join(placeholders, ", ") + ") ORDER BY a.task_id, a.created_at, a.id"
+		if err := scanAnnotationHeadlines(ctx, r.db, sqlQuery, b.args, byID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func scanAnnotationHeadlines(ctx context.Context, q queryer, query string, args []any, byID map[string]*model.SearchHit) error {
+	rows, err := q.QueryContext(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("failed to search annotations: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	for rows.Next() {
+		var taskID, headline string
+		if err := rows.Scan(&taskID, &headline); err != nil {
+			return fmt.Errorf("failed to scan annotation row: %w", err)
+		}
+		if hit, ok := byID[taskID]; ok {
+			hit.Matches = appendHeadline(hit.Matches, model.SearchFieldAnnotation, headline)
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("error occurred during annotation row iteration: %w", err)
+	}
+	return nil
+}
+
+// appendHeadline は ts_headline の結果に一致した語が含まれる場合に、抜粋として加える
+// 一致しない項目でも ts_headline は先頭部分を返すため、囲む文字の有無で判定する
+func appendHeadline(matches []model.SearchMatch, field model.SearchField, headline string) []model.SearchMatch {
+	snippet, highlights := parseHeadline(headline)
+	if len(highlights) == 0 {
+		return matches
+	}
+	return append(matches, model.SearchMatch{Field: field, Snippet: snippet, Highlights: highlights})
+}
+
+// parseHeadline は ts_headline の結果から一致した語を囲む文字を取り除き、抜粋と一致した範囲を返す
+// 改行は空白に置き換える
+func parseHeadline(headline string) (string, []model.Highlight) {
+	var sb strings.Builder
+	var highlights []model.Highlight
+	start := -1
+	for _, r := range headline {
+		switch string(r) {
+		case headlineStart:
+			start = sb.Len()
+		case headlineStop:
+			if start >= 0 && sb.Len() > start {
+				highlights = append(highlights, model.Highlight{Start: start, End: sb.Len()})
+			}
+			start = -1
+		case "\n", "\r":
+			sb.WriteByte(' ')
+		default:
+			sb.WriteRune(r)
+		}
+	}
+	return sb.String(), highlights
+}
+
+// searchSubstring はすべての検索語をタイトル、説明、注記のいずれかに含むタスクを部分一致で検索する
+// データベースでは大文字小文字を区別しない LIKE で候補を絞り込み、一致した箇所と関連度はアプリケーションで求める
+func (r *taskRepository) searchSubstring(ctx context.Context, terms []string, limit int) ([]model.SearchHit, error) {
+	b := &queryBuilder{}
+	for _, term := range terms {
+		pattern := b.arg("%" + escapeLike(term) + "%")
+		b.where("(LOWER(t.title) LIKE " + pattern + ` ESCAPE '\'` +
+			" OR LOWER(t.description) LIKE " + pattern + ` ESCAPE '\'` +
+			" OR EXISTS (SELECT 1 FROM task_annotations a WHERE a.task_id = t.id AND LOWER(a.text) LIKE " + pattern + ` ESCAPE '\'))`)
+	}
+	b.where("t.deleted_at IS NULL")
+	query := "SELECT " + taskColumns + " FROM tasks t WHERE " + strings.Join(b.conds, " AND ")
+
+	rows, err := r.db.QueryContext(ctx, query, b.args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to search tasks: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	var tasks []*model.Task
+	for rows.Next() {
+		task, err := scanTask(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan task row: %w", err)
+		}
+		tasks = append(tasks, task)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error occurred during row iteration: %w", err)
+	}
+
+	if err := loadRelated(ctx, r.db, r.workflow, tasks); err != nil {
+		return nil, err
+	}
+	return matchSubstring(tasks, terms, limit), nil
+}
+
+// matchSubstring はタスクを検索語で部分一致により照合し、関連度の順に最大 limit 件返す
+func matchSubstring(tasks []*model.Task, terms []string, limit int) []model.SearchHit {
+	var hits []model.SearchHit
+	for _, task := range tasks {
+		if hit, ok := service.MatchSubstring(task, terms); ok {
+			hits = append(hits, hit)
+		}
+	}
+	service.SortSearchHits(hits)
+	if limit > 0 && len(hits) > limit {
+		hits = hits[:limit]
+	}
+	return hits
+}
@@ -0,0 +1,70 @@
+package infrastructure
+
+import (
+	"OTakumi/todogo/internal/domain/model"
+	"context"
+	"fmt"
+	"strings"
+)
+
+// parentValue は親タスクのIDをSQLの引数に変換する（最上位のタスクの場合はNULL）
+func parentValue(id string) any {
+	if id == "" {
+		return nil
+	}
+	return id
+}
+
+// loadProgress は複数のタスクについて、配下のすべてのサブタスクの進捗をまとめて読み込み、各タスクに設定する
+// 再帰的な共通テーブル式で、各タスクを起点に子孫のタスクをたどって数える（終了状態のサブタスクを完了済みとする）
+// ゴミ箱のサブタスクと、その配下のタスクは数えない
+func loadProgress(ctx context.Context, q queryer, wf *model.Workflow, tasks []*model.Task) error {
+	byID := make(map[string]*model.Task, len(tasks))
+	for _, task := range tasks {
+		task.Subtasks = model.Progress{}
+		byID[task.ID] = task
+	}
+
+	for start := 0; start < len(tasks); start += loadBatchSize {
+		end := min(start+loadBatchSize, len(tasks))
+
+		b := &queryBuilder{}
+		placeholders := make([]string, 0, end-start)
+		for _, task := range tasks[start:end] {
+			placeholders = append(placeholders, b.arg(task.ID))
+		}
+
+		query := "WITH RECURSIVE subtasks (root_id, id, status) AS (" +
+			"SELECT parent_id, id, status FROM tasks WHERE parent_id IN (" + strings.Join(placeholders, ", ") + ") AND deleted_at IS NULL" +
+			" UNION ALL SELECT s.root_id, t.id, t.status FROM tasks t JOIN subtasks s ON t.parent_id = s.id WHERE t.deleted_at IS NULL)" +
+			" SELECT root_id, SUM(CASE WHEN status IN " + b.statusList(wf.Terminal) + " THEN 1 ELSE 0 END), COUNT(*)" +
+			" FROM subtasks GROUP BY root_id"
+		if err := scanProgress(ctx, q, query, b.args, byID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func scanProgress(ctx context.Context, q queryer, query string, args []any, byID map[string]*model.Task) error {
+	rows, err := q.QueryContext(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("failed to load subtask progress: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	for rows.Next() {
+		var taskID string
+		var p model.Progress
+		if err := rows.Scan(&taskID, &p.Done, &p.Total); err != nil {
+			return fmt.Errorf("failed to scan subtask progress row: %w", err)
+		}
+		if task, ok := byID[taskID]; ok {
+			task.Subtasks = p
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("error occurred during subtask progress row iteration: %w", err)
+	}
+	return nil
+}
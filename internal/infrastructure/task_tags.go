@@ -0,0 +1,130 @@
+package infrastructure
+
+import (
+	"OTakumi/todogo/internal/domain/model"
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+)
+
+// loadBatchSize は1回の問い合わせでタグやサブタスクの進捗を読み込むタスクの最大件数
+// プレースホルダの数がデータベースの上限を超えないよう、これを超える場合は分割して問い合わせる
+const loadBatchSize = 500
+
+// queryer は *sql.DB と *sql.Tx に共通する問い合わせのインターフェース
+type queryer interface {
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+}
+
+// execer は *sql.DB と *sql.Tx に共通する更新のインターフェース
+type execer interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+}
+
+// loadTags は複数のタスクのタグをまとめて読み込み、各タスクに設定する
+// タスクごとに問い合わせないよう、IN句でまとめて取得する
+func loadTags(ctx context.Context, q queryer, tasks []*model.Task) error {
+	byID := make(map[string]*model.Task, len(tasks))
+	for _, task := range tasks {
+		task.Tags = []string{}
+		byID[task.ID] = task
+	}
+
+	for start := 0; start < len(tasks); start += loadBatchSize {
+		end := min(start+loadBatchSize, len(tasks))
+
+		b := &queryBuilder{}
+		placeholders := make([]string, 0, end-start)
+		for _, task := range tasks[start:end] {
+			placeholders = append(placeholders, b.arg(task.ID))
+		}
+
+		query := "SELECT tt.task_id, g.name FROM task_tags tt JOIN tags g ON g.id = tt.tag_id WHERE tt.task_id IN (" +
+			strings.Join(placeholders, ", ") + ") ORDER BY tt.task_id, g.name"
+		if err := scanTags(ctx, q, query, b.args, byID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func scanTags(ctx context.Context, q queryer, query string, args []any, byID map[string]*model.Task) error {
+	rows, err := q.QueryContext(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("failed to load tags: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	for rows.Next() {
+		var taskID, name string
+		if err := rows.Scan(&taskID, &name); err != nil {
+			return fmt.Errorf("failed to scan tag row: %w", err)
+		}
+		if task, ok := byID[taskID]; ok {
+			task.Tags = append(task.Tags, name)
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("error occurred during tag row iteration: %w", err)
+	}
+	return nil
+}
+
+// replaceTags はタスクに付いているタグを指定したタグで置き換える
+// 未登録のタグはtagsテーブルに追加する
+func replaceTags(ctx context.Context, e execer, taskID string, tags []string) error {
+	if _, err := e.ExecContext(ctx, "DELETE FROM task_tags WHERE task_id = $1", taskID); err != nil {
+		return fmt.Errorf("failed to clear tags: %w", err)
+	}
+	return insertTags(ctx, e, taskID, tags)
+}
+
+// insertTags はタスクにタグを関連付ける
+func insertTags(ctx context.Context, e execer, taskID string, tags []string) error {
+	for _, tag := range tags {
+		if _, err := e.ExecContext(ctx, "INSERT INTO tags (name) VALUES ($1) ON CONFLICT (name) DO NOTHING", tag); err != nil {
+			return fmt.Errorf("failed to insert tag %q: %w", tag, err)
+		}
+		if _, err := e.ExecContext(ctx,
+			"INSERT INTO task_tags (task_id, tag_id) SELECT $1, id FROM tags WHERE name = $2",
+			taskID, tag,
+		); err != nil {
+			return fmt.Errorf("failed to tag task with %q: %w", tag, err)
+		}
+	}
+	return nil
+}
+
+func (r *taskRepository) TagCounts(ctx context.Context) ([]model.TagCount, error) {
+	// 終了状態のタスクを完了済みとして数える
+	b := &queryBuilder{}
+	query := `
+		SELECT g.name, SUM(CASE WHEN t.status IN ` + b.statusList(r.workflow.Terminal) + ` THEN 0 ELSE 1 END), COUNT(*)
+		FROM tags g
+		JOIN task_tags tt ON tt.tag_id = g.id
+		JOIN tasks t ON t.id = tt.task_id
+		WHERE t.deleted_at IS NULL
+		GROUP BY g.name
+		ORDER BY g.name
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, b.args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count tags: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	counts := []model.TagCount{}
+	for rows.Next() {
+		var c model.TagCount
+		if err := rows.Scan(&c.Name, &c.Open, &c.Total); err != nil {
+			return nil, fmt.Errorf("failed to scan tag count row: %w", err)
+		}
+		counts = append(counts, c)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error occurred during row iteration: %w", err)
+	}
+	return counts, nil
+}
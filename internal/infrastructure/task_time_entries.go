@@ -0,0 +1,137 @@
+package infrastructure
+
+import (
+	"OTakumi/todogo/internal/domain/model"
+	"OTakumi/todogo/internal/repository"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// timeEntryColumns は作業時間の記録を取得する際のSELECT句の列（scanTimeEntryの引数の順序と対応する）
+const timeEntryColumns = "id, task_id, user_name, started_at, ended_at"
+
+func scanTimeEntry(row rowScanner) (*model.TimeEntry, error) {
+	var entry model.TimeEntry
+	if err := row.Scan(&entry.ID, &entry.TaskID, &entry.User, &entry.StartedAt, &entry.EndedAt); err != nil {
+		return nil, err
+	}
+	return &entry, nil
+}
+
+func (r *taskRepository) AddTimeEntry(ctx context.Context, entry model.TimeEntry) (*model.TimeEntry, error) {
+	if err := entry.Validate(); err != nil {
+		return nil, fmt.Errorf("validation failed: %w", err)
+	}
+
+	// 存在しないかゴミ箱にあるタスクへの追加は、外部キー制約のエラーではなくNotFoundエラーとして返す
+	var exists int
+	err := r.db.QueryRowContext(ctx, "SELECT 1 FROM tasks WHERE id = $1 AND deleted_at IS NULL", entry.TaskID).Scan(&exists)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, &repository.TaskNotFoundError{ID: entry.TaskID}
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to find task: %w", err)
+	}
+
+	err = r.db.QueryRowContext(ctx,
+		"INSERT INTO time_entries (task_id, user_name, started_at, ended_at) VALUES ($1, $2, $3, $4) RETURNING id",
+		entry.TaskID, entry.User, r.dialect.timeValue(entry.StartedAt), r.dialect.deadlineValue(entry.EndedAt),
+	).Scan(&entry.ID)
+	// 利用者の計測中の記録が既にある場合は一意制約の違反となるため、計測中の記録と共に返す
+	if err != nil && entry.Running() && isUniqueViolation(err) {
+		running, findErr := r.TimeEntries(ctx, repository.TimeEntryQuery{User: entry.User, Running: true})
+		if findErr != nil {
+			return nil, findErr
+		}
+		if len(running) > 0 {
+			return nil, &model.TimerRunningError{Entry: running[0]}
+		}
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to add time entry: %w", err)
+	}
+	return &entry, nil
+}
+
+func (r *taskRepository) StopTimeEntry(ctx context.Context, id int64, endedAt time.Time) (*model.TimeEntry, error) {
+	row := r.db.QueryRowContext(ctx,
+		"SELECT "+timeEntryColumns+" FROM time_entries WHERE id = $1 AND ended_at IS NULL", id)
+	entry, err := scanTimeEntry(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, &repository.TimeEntryNotFoundError{ID: id}
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to find time entry: %w", err)
+	}
+
+	entry.EndedAt = &endedAt
+	if err := entry.Validate(); err != nil {
+		return nil, fmt.Errorf("validation failed: %w", err)
+	}
+
+	// 確認してから更新するまでの間に別のプロセスが終了した場合も、NotFoundエラーとして返す
+	result, err := r.db.ExecContext(ctx,
+		"UPDATE time_entries SET ended_at = $1 WHERE id = $2 AND ended_at IS NULL",
+		r.dialect.timeValue(endedAt), id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to stop time entry: %w", err)
+	}
+	if n, err := result.RowsAffected(); err != nil {
+		return nil, fmt.Errorf("failed to get affected rows: %w", err)
+	} else if n == 0 {
+		return nil, &repository.TimeEntryNotFoundError{ID: id}
+	}
+	return entry, nil
+}
+
+func (r *taskRepository) TimeEntries(ctx context.Context, q repository.TimeEntryQuery) ([]model.TimeEntry, error) {
+	if err := q.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid query: %w", err)
+	}
+
+	b := &queryBuilder{}
+	if q.TaskID != "" {
+		b.where("task_id = " + b.arg(q.TaskID))
+	}
+	if q.User != "" {
+		b.where("user_name = " + b.arg(q.User))
+	}
+	if q.Running {
+		b.where("ended_at IS NULL")
+	}
+	if q.Since != nil {
+		b.where("(ended_at IS NULL OR ended_at > " + b.arg(r.dialect.timeValue(*q.Since)) + ")")
+	}
+	if q.Until != nil {
+		b.where("started_at < " + b.arg(r.dialect.timeValue(*q.Until)))
+	}
+
+	query := "SELECT " + timeEntryColumns + " FROM time_entries"
+	if len(b.conds) > 0 {
+		query += " WHERE " + strings.Join(b.conds, " AND ")
+	}
+	query += " ORDER BY started_at, id"
+
+	rows, err := r.db.QueryContext(ctx, query, b.args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load time entries: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	entries := []model.TimeEntry{}
+	for rows.Next() {
+		entry, err := scanTimeEntry(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan time entry row: %w", err)
+		}
+		entries = append(entries, *entry)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error occurred during time entry row iteration: %w", err)
+	}
+	return entries, nil
+}
@@ -0,0 +1,180 @@
+package infrastructure
+
+import (
+	"OTakumi/todogo/internal/domain/model"
+	"OTakumi/todogo/internal/repository"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// trashTask はタスクをゴミ箱に移す（削除日時を設定する）
+// サブタスクの親子関係、注記、依存関係、作業時間の記録は元に戻す場合のために残す
+// ゴミ箱にある親のサブタスクは、一覧では最上位のタスクとして扱われる
+func (r *taskRepository) trashTask(ctx context.Context, tx *sql.Tx, id string, at time.Time) error {
+	if _, err := tx.ExecContext(ctx, "UPDATE tasks SET deleted_at = $1 WHERE id = $2", r.dialect.timeValue(at), id); err != nil {
+		return fmt.Errorf("failed to delete task: %w", err)
+	}
+	return nil
+}
+
+// lockTrashedTask は行をロックして、タグを含むゴミ箱のタスクを取得する（ゴミ箱にない場合はnil）
+func (r *taskRepository) lockTrashedTask(ctx context.Context, tx *sql.Tx, id string) (*model.Task, error) {
+	var deletedAt time.Time
+	task, err := scanTask(tx.QueryRowContext(ctx,
+		"SELECT "+taskColumns+", deleted_at FROM tasks WHERE id = $1 AND deleted_at IS NOT NULL"+r.dialect.lockRowClause, id), &deletedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to find task: %w", err)
+	}
+	task.DeletedAt = &deletedAt
+	if err := loadTags(ctx, tx, []*model.Task{task}); err != nil {
+		return nil, err
+	}
+	return task, nil
+}
+
+// untrashTask はゴミ箱のタスクの削除日時を消して、元に戻す
+func (r *taskRepository) untrashTask(ctx context.Context, tx *sql.Tx, id string) error {
+	if _, err := tx.ExecContext(ctx, "UPDATE tasks SET deleted_at = NULL WHERE id = $1", id); err != nil {
+		return fmt.Errorf("failed to restore task: %w", err)
+	}
+	return nil
+}
+
+func (r *taskRepository) FindDeleted(ctx context.Context) ([]*model.Task, error) {
+	rows, err := r.db.QueryContext(ctx,
+		"SELECT "+taskColumns+", deleted_at FROM tasks WHERE deleted_at IS NOT NULL ORDER BY deleted_at DESC, id")
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute query: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	tasks := []*model.Task{}
+	for rows.Next() {
+		var deletedAt time.Time
+		task, err := scanTask(rows, &deletedAt)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan task row: %w", err)
+		}
+		task.DeletedAt = &deletedAt
+		tasks = append(tasks, task)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error occurred during row iteration: %w", err)
+	}
+
+	if err := loadRelated(ctx, r.db, r.workflow, tasks); err != nil {
+		return nil, err
+	}
+	return tasks, nil
+}
+
+func (r *taskRepository) Restore(ctx context.Context, id string) (*model.Task, error) {
+	// トランザクションを開始
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
+		}
+	}()
+
+	trashed, err := r.lockTrashedTask(ctx, tx, id)
+	if err != nil {
+		return nil, err
+	}
+	if trashed == nil {
+		err = &repository.TaskNotFoundError{ID: id}
+		return nil, err
+	}
+
+	if err = r.untrashTask(ctx, tx, id); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	if err = r.insertTaskEvents(ctx, tx, []model.TaskEvent{model.NewTrashEvent(model.TaskRestored, trashed, repository.ActorFrom(ctx), now)}); err != nil {
+		return nil, err
+	}
+
+	// ゴミ箱から戻したタスクは、取り消す場合に再びゴミ箱に移せるよう作成として記録する
+	trashed.DeletedAt = nil
+	if err = r.recordOperation(ctx, tx, nil, trashed, now); err != nil {
+		return nil, err
+	}
+
+	// トランザクションのコミット
+	if err = tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return r.FindByID(ctx, id)
+}
+
+func (r *taskRepository) Purge(ctx context.Context, before time.Time) (int, error) {
+	// トランザクションを開始
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
+		}
+	}()
+
+	// 履歴に記録するため、削除するタスクのタイトルを先に取得する
+	var tasks []*model.Task
+	if tasks, err = r.selectPurged(ctx, tx, before); err != nil {
+		return 0, err
+	}
+
+	// 注記、依存関係、作業時間の記録、タグの付与は外部キー制約（ON DELETE CASCADE）により削除される
+	now := time.Now()
+	actor := repository.ActorFrom(ctx)
+	for _, task := range tasks {
+		if _, err = tx.ExecContext(ctx, "DELETE FROM tasks WHERE id = $1", task.ID); err != nil {
+			return 0, fmt.Errorf("failed to purge task: %w", err)
+		}
+		if err = r.insertTaskEvents(ctx, tx, []model.TaskEvent{model.NewTrashEvent(model.TaskPurged, task, actor, now)}); err != nil {
+			return 0, err
+		}
+	}
+
+	// トランザクションのコミット
+	if err = tx.Commit(); err != nil {
+		return 0, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return len(tasks), nil
+}
+
+// selectPurged は before より前にゴミ箱に移したタスクのIDとタイトルを返す
+func (r *taskRepository) selectPurged(ctx context.Context, tx *sql.Tx, before time.Time) ([]*model.Task, error) {
+	rows, err := tx.QueryContext(ctx,
+		"SELECT id, title FROM tasks WHERE deleted_at IS NOT NULL AND deleted_at < $1 ORDER BY deleted_at, id",
+		r.dialect.timeValue(before))
+	if err != nil {
+		return nil, fmt.Errorf("failed to find deleted tasks: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	var tasks []*model.Task
+	for rows.Next() {
+		task := &model.Task{}
+		if err := rows.Scan(&task.ID, &task.Title); err != nil {
+			return nil, fmt.Errorf("failed to scan task row: %w", err)
+		}
+		tasks = append(tasks, task)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error occurred during row iteration: %w", err)
+	}
+	return tasks, nil
+}
@@ -0,0 +1,205 @@
+package render
+
+import (
+	"OTakumi/todogo/internal/domain/model"
+	"encoding/csv"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// 列の順序はスキーマの一部であり、列を追加する場合は末尾に加える
+var (
+	taskColumns     = []string{"id", "title", "deadline", "status", "created_at", "updated_at", "priority", "urgency", "tags", "project", "parent_id", "subtasks_done", "subtasks_total", "blocked_by", "recurrence_id", "recurrence", "description", "start_at", "wait_until", "closed", "estimate_kind", "estimate_seconds", "estimate_points", "completed_at", "deleted_at"}
+	searchColumns   = []string{"rank", "fields"}
+	resultColumns   = []string{"ref", "id", "ok", "message", "error"}
+	tagColumns      = []string{"name", "open", "total"}
+	projectColumns  = []string{"name", "open", "closed", "total", "completion"}
+	timeColumns     = []string{"id", "title", "project", "seconds", "hours"}
+	estimateColumns = []string{"id", "title", "tags", "estimate_kind", "estimate_seconds", "estimate_points", "actual_seconds", "source", "completed_at", "ratio"}
+	eventColumns    = []string{"id", "task_id", "kind", "field", "old_value", "new_value", "actor", "occurred_at"}
+)
+
+// csvRenderer はヘッダー行付きのCSVで出力する
+// 未設定の値は空文字列となる
+type csvRenderer struct {
+	loc *time.Location
+	now func() time.Time
+	wf  *model.Workflow
+}
+
+func (r *csvRenderer) TaskList(w io.Writer, list TaskList) error {
+	return r.Tasks(w, list.Tasks)
+}
+
+func (r *csvRenderer) Tasks(w io.Writer, tasks []*model.Task) error {
+	rows := [][]string{taskColumns}
+	for _, view := range taskViews(tasks, r.wf, r.loc, r.now()) {
+		rows = append(rows, taskRow(view))
+	}
+	return csv.NewWriter(w).WriteAll(rows)
+}
+
+// SearchResults はタスクの列に続けて、関連度と一致した項目の一覧を出力する
+// 抜粋は一致した範囲を1つの列で表せないため出力しない
+func (r *csvRenderer) SearchResults(w io.Writer, results SearchResults) error {
+	rows := [][]string{append(append([]string{}, taskColumns...), searchColumns...)}
+	for _, view := range searchHitViews(results.Hits, r.wf, r.loc, r.now()) {
+		fields := make([]string, 0, len(view.Matches))
+		for _, m := range view.Matches {
+			if !slices.Contains(fields, m.Field) {
+				fields = append(fields, m.Field)
+			}
+		}
+		rows = append(rows, append(taskRow(view.TaskView),
+			strconv.FormatFloat(view.Rank, 'f', -1, 64),
+			strings.Join(fields, " "),
+		))
+	}
+	return csv.NewWriter(w).WriteAll(rows)
+}
+
+// taskRow はタスクを taskColumns の順序の行に変換する
+func taskRow(view TaskView) []string {
+	deadline, project, parentID, done, total, recurrenceID, rule := "", "", "", "", "", "", ""
+	if view.Deadline != nil {
+		deadline = *view.Deadline
+	}
+	startAt, waitUntil := "", ""
+	if view.StartAt != nil {
+		startAt = *view.StartAt
+	}
+	if view.WaitUntil != nil {
+		waitUntil = *view.WaitUntil
+	}
+	estimate := []string{"", "", ""}
+	if view.Estimate != nil {
+		estimate = estimateCells(*view.Estimate)
+	}
+	completedAt, deletedAt := "", ""
+	if view.CompletedAt != nil {
+		completedAt = *view.CompletedAt
+	}
+	if view.DeletedAt != nil {
+		deletedAt = *view.DeletedAt
+	}
+	if view.Project != nil {
+		project = *view.Project
+	}
+	if view.ParentID != nil {
+		parentID = *view.ParentID
+	}
+	if view.Subtasks != nil {
+		done, total = strconv.Itoa(view.Subtasks.Done), strconv.Itoa(view.Subtasks.Total)
+	}
+	if view.Recurrence != nil {
+		recurrenceID, rule = view.Recurrence.ID, view.Recurrence.Rule
+	}
+	return append([]string{
+		view.ID, view.Title, deadline, view.Status, view.CreatedAt, view.UpdatedAt,
+		view.Priority, strconv.FormatFloat(view.Urgency, 'f', -1, 64),
+		// タグ名は空白を含まないため、空白区切りで1つの列にまとめる
+		strings.Join(view.Tags, " "),
+		project, parentID, done, total,
+		strings.Join(view.BlockedBy, " "),
+		recurrenceID, rule,
+		view.Description,
+		startAt, waitUntil,
+		strconv.FormatBool(view.Closed),
+	}, append(estimate, completedAt, deletedAt)...)
+}
+
+// estimateCells は見積もりを種類、秒数、ポイントの3つの列に変換する
+func estimateCells(view EstimateView) []string {
+	return []string{view.Kind, strconv.FormatInt(view.Seconds, 10), strconv.FormatFloat(view.Points, 'f', -1, 64)}
+}
+
+// TaskDetails は依存関係の連鎖を1行に表せないため、Tasks と同じ列で出力する
+// 直接の依存関係は blocked_by 列に含まれる
+func (r *csvRenderer) TaskDetails(w io.Writer, details []TaskDetail) error {
+	tasks := make([]*model.Task, 0, len(details))
+	for _, detail := range details {
+		tasks = append(tasks, detail.Task)
+	}
+	return r.Tasks(w, tasks)
+}
+
+// Timesheet はタスクごとの作業時間を出力する
+// プロジェクトごとの作業時間は project 列から集計できるため出力しない
+func (r *csvRenderer) Trash(w io.Writer, trash Trash) error {
+	return r.Tasks(w, trash.Tasks)
+}
+
+func (r *csvRenderer) Timesheet(w io.Writer, timesheet Timesheet) error {
+	rows := [][]string{timeColumns}
+	for _, view := range NewTimesheetView(timesheet.Sheet, r.loc).Tasks {
+		project := ""
+		if view.Project != nil {
+			project = *view.Project
+		}
+		rows = append(rows, []string{
+			view.ID, view.Title, project, strconv.FormatInt(view.Seconds, 10),
+			strconv.FormatFloat(view.Hours, 'f', -1, 64),
+		})
+	}
+	return csv.NewWriter(w).WriteAll(rows)
+}
+
+// EstimateReport はタスクごとの見積もりと実績を出力する
+// タグごとの精度は tags 列から集計できるため出力しない
+func (r *csvRenderer) EstimateReport(w io.Writer, report EstimateReport) error {
+	rows := [][]string{estimateColumns}
+	for _, view := range NewEstimateReportView(report.Report, r.loc).Tasks {
+		row := []string{view.ID, view.Title, strings.Join(view.Tags, " ")}
+		row = append(row, estimateCells(view.Estimate)...)
+		rows = append(rows, append(row,
+			strconv.FormatInt(view.ActualSeconds, 10), view.Source, view.CompletedAt,
+			strconv.FormatFloat(view.Ratio, 'f', -1, 64),
+		))
+	}
+	return csv.NewWriter(w).WriteAll(rows)
+}
+
+func (r *csvRenderer) History(w io.Writer, events []model.TaskEvent) error {
+	rows := [][]string{eventColumns}
+	for _, view := range taskEventViews(events, r.loc) {
+		rows = append(rows, []string{
+			strconv.FormatInt(view.ID, 10), view.TaskID, view.Kind, view.Field,
+			view.OldValue, view.NewValue, view.Actor, view.OccurredAt,
+		})
+	}
+	return csv.NewWriter(w).WriteAll(rows)
+}
+
+func (r *csvRenderer) Results(w io.Writer, results []Result) error {
+	rows := [][]string{resultColumns}
+	for _, view := range resultViews(results) {
+		rows = append(rows, []string{view.Ref, view.ID, strconv.FormatBool(view.OK), view.Message, view.Error})
+	}
+	return csv.NewWriter(w).WriteAll(rows)
+}
+
+func (r *csvRenderer) TagCounts(w io.Writer, counts []model.TagCount) error {
+	rows := [][]string{tagColumns}
+	for _, view := range tagCountViews(counts) {
+		rows = append(rows, []string{view.Name, strconv.Itoa(view.Open), strconv.Itoa(view.Total)})
+	}
+	return csv.NewWriter(w).WriteAll(rows)
+}
+
+func (r *csvRenderer) ProjectCounts(w io.Writer, counts []model.ProjectCount) error {
+	rows := [][]string{projectColumns}
+	for _, view := range projectCountViews(counts) {
+		rows = append(rows, []string{
+			view.Name, strconv.Itoa(view.Open), strconv.Itoa(view.Closed), strconv.Itoa(view.Total),
+			strconv.FormatFloat(view.Completion, 'f', -1, 64),
+		})
+	}
+	return csv.NewWriter(w).WriteAll(rows)
+}
+
+func (r *csvRenderer) Message(io.Writer, string) error {
+	return nil
+}
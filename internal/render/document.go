@@ -0,0 +1,112 @@
+package render
+
+import (
+	"OTakumi/todogo/internal/domain/model"
+	"encoding/json"
+	"io"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// documentRenderer は出力全体を1つのJSON/YAMLドキュメントとして出力する
+type documentRenderer struct {
+	loc    *time.Location
+	now    func() time.Time
+	wf     *model.Workflow
+	encode func(w io.Writer, v any) error
+}
+
+func (r *documentRenderer) TaskList(w io.Writer, list TaskList) error {
+	return r.encode(w, taskDocument{
+		SchemaVersion: SchemaVersion,
+		Tasks:         taskViews(list.Tasks, r.wf, r.loc, r.now()),
+		NextAfter:     list.NextAfter,
+	})
+}
+
+func (r *documentRenderer) Tasks(w io.Writer, tasks []*model.Task) error {
+	return r.encode(w, taskDocument{
+		SchemaVersion: SchemaVersion,
+		Tasks:         taskViews(tasks, r.wf, r.loc, r.now()),
+	})
+}
+
+func (r *documentRenderer) TaskDetails(w io.Writer, details []TaskDetail) error {
+	return r.encode(w, taskDetailDocument{
+		SchemaVersion: SchemaVersion,
+		Tasks:         taskDetailViews(details, r.wf, r.loc, r.now()),
+	})
+}
+
+func (r *documentRenderer) SearchResults(w io.Writer, results SearchResults) error {
+	return r.encode(w, searchDocument{
+		SchemaVersion: SchemaVersion,
+		Hits:          searchHitViews(results.Hits, r.wf, r.loc, r.now()),
+	})
+}
+
+func (r *documentRenderer) Trash(w io.Writer, trash Trash) error {
+	return r.Tasks(w, trash.Tasks)
+}
+
+func (r *documentRenderer) Timesheet(w io.Writer, timesheet Timesheet) error {
+	return r.encode(w, timesheetDocument{
+		SchemaVersion: SchemaVersion,
+		TimesheetView: NewTimesheetView(timesheet.Sheet, r.loc),
+	})
+}
+
+func (r *documentRenderer) EstimateReport(w io.Writer, report EstimateReport) error {
+	return r.encode(w, estimateDocument{
+		SchemaVersion:      SchemaVersion,
+		EstimateReportView: NewEstimateReportView(report.Report, r.loc),
+	})
+}
+
+func (r *documentRenderer) History(w io.Writer, events []model.TaskEvent) error {
+	return r.encode(w, historyDocument{
+		SchemaVersion: SchemaVersion,
+		Events:        taskEventViews(events, r.loc),
+	})
+}
+
+func (r *documentRenderer) Results(w io.Writer, results []Result) error {
+	return r.encode(w, resultDocument{
+		SchemaVersion: SchemaVersion,
+		Results:       resultViews(results),
+	})
+}
+
+func (r *documentRenderer) TagCounts(w io.Writer, counts []model.TagCount) error {
+	return r.encode(w, tagDocument{
+		SchemaVersion: SchemaVersion,
+		Tags:          tagCountViews(counts),
+	})
+}
+
+func (r *documentRenderer) ProjectCounts(w io.Writer, counts []model.ProjectCount) error {
+	return r.encode(w, projectDocument{
+		SchemaVersion: SchemaVersion,
+		Projects:      projectCountViews(counts),
+	})
+}
+
+func (r *documentRenderer) Message(io.Writer, string) error {
+	return nil
+}
+
+func encodeJSON(w io.Writer, v any) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(v)
+}
+
+func encodeYAML(w io.Writer, v any) error {
+	enc := yaml.NewEncoder(w)
+	enc.SetIndent(2)
+	if err := enc.Encode(v); err != nil {
+		return err
+	}
+	return enc.Close()
+}
@@ -0,0 +1,166 @@
+package render
+
+import (
+	"OTakumi/todogo/internal/domain/model"
+	"encoding/json"
+	"io"
+	"time"
+)
+
+// ndjsonRenderer は1行に1件のJSONオブジェクトを出力する
+// 各行が単独で解釈できるよう、すべての行にスキーマのバージョンを含める
+type ndjsonRenderer struct {
+	loc *time.Location
+	now func() time.Time
+	wf  *model.Workflow
+}
+
+type ndjsonTask struct {
+	SchemaVersion int `json:"schema_version"`
+	TaskView
+}
+
+type ndjsonTaskDetail struct {
+	SchemaVersion int `json:"schema_version"`
+	TaskDetailView
+}
+
+type ndjsonSearchHit struct {
+	SchemaVersion int `json:"schema_version"`
+	SearchHitView
+}
+
+type ndjsonTagCount struct {
+	SchemaVersion int `json:"schema_version"`
+	TagCountView
+}
+
+type ndjsonProjectCount struct {
+	SchemaVersion int `json:"schema_version"`
+	ProjectCountView
+}
+
+type ndjsonTimesheetTask struct {
+	SchemaVersion int `json:"schema_version"`
+	TimesheetTaskView
+}
+
+type ndjsonEstimatedTask struct {
+	SchemaVersion int `json:"schema_version"`
+	EstimatedTaskView
+}
+
+type ndjsonTaskEvent struct {
+	SchemaVersion int `json:"schema_version"`
+	TaskEventView
+}
+
+type ndjsonResult struct {
+	SchemaVersion int `json:"schema_version"`
+	ResultView
+}
+
+func (r *ndjsonRenderer) TaskList(w io.Writer, list TaskList) error {
+	return r.Tasks(w, list.Tasks)
+}
+
+func (r *ndjsonRenderer) Tasks(w io.Writer, tasks []*model.Task) error {
+	enc := json.NewEncoder(w)
+	for _, view := range taskViews(tasks, r.wf, r.loc, r.now()) {
+		if err := enc.Encode(ndjsonTask{SchemaVersion: SchemaVersion, TaskView: view}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *ndjsonRenderer) TaskDetails(w io.Writer, details []TaskDetail) error {
+	enc := json.NewEncoder(w)
+	for _, view := range taskDetailViews(details, r.wf, r.loc, r.now()) {
+		if err := enc.Encode(ndjsonTaskDetail{SchemaVersion: SchemaVersion, TaskDetailView: view}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *ndjsonRenderer) SearchResults(w io.Writer, results SearchResults) error {
+	enc := json.NewEncoder(w)
+	for _, view := range searchHitViews(results.Hits, r.wf, r.loc, r.now()) {
+		if err := enc.Encode(ndjsonSearchHit{SchemaVersion: SchemaVersion, SearchHitView: view}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Timesheet はタスクごとの作業時間を1行ずつ出力する
+func (r *ndjsonRenderer) Trash(w io.Writer, trash Trash) error {
+	return r.Tasks(w, trash.Tasks)
+}
+
+func (r *ndjsonRenderer) Timesheet(w io.Writer, timesheet Timesheet) error {
+	enc := json.NewEncoder(w)
+	for _, view := range NewTimesheetView(timesheet.Sheet, r.loc).Tasks {
+		if err := enc.Encode(ndjsonTimesheetTask{SchemaVersion: SchemaVersion, TimesheetTaskView: view}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// EstimateReport はタスクごとの見積もりと実績を1行ずつ出力する
+// タグごとの精度は各行の tags から集計できるため出力しない
+func (r *ndjsonRenderer) EstimateReport(w io.Writer, report EstimateReport) error {
+	enc := json.NewEncoder(w)
+	for _, view := range NewEstimateReportView(report.Report, r.loc).Tasks {
+		if err := enc.Encode(ndjsonEstimatedTask{SchemaVersion: SchemaVersion, EstimatedTaskView: view}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *ndjsonRenderer) History(w io.Writer, events []model.TaskEvent) error {
+	enc := json.NewEncoder(w)
+	for _, view := range taskEventViews(events, r.loc) {
+		if err := enc.Encode(ndjsonTaskEvent{SchemaVersion: SchemaVersion, TaskEventView: view}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *ndjsonRenderer) Results(w io.Writer, results []Result) error {
+	enc := json.NewEncoder(w)
+	for _, view := range resultViews(results) {
+		if err := enc.Encode(ndjsonResult{SchemaVersion: SchemaVersion, ResultView: view}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *ndjsonRenderer) TagCounts(w io.Writer, counts []model.TagCount) error {
+	enc := json.NewEncoder(w)
+	for _, view := range tagCountViews(counts) {
+		if err := enc.Encode(ndjsonTagCount{SchemaVersion: SchemaVersion, TagCountView: view}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *ndjsonRenderer) ProjectCounts(w io.Writer, counts []model.ProjectCount) error {
+	enc := json.NewEncoder(w)
+	for _, view := range projectCountViews(counts) {
+		if err := enc.Encode(ndjsonProjectCount{SchemaVersion: SchemaVersion, ProjectCountView: view}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *ndjsonRenderer) Message(io.Writer, string) error {
+	return nil
+}
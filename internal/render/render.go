@@ -0,0 +1,210 @@
+// Package render はタスクやコマンドの実行結果を、指定された形式で出力する
+// 表形式は人が読むためのもので、それ以外の形式はスクリプトから扱えるよう安定したスキーマで出力する
+package render
+
+import (
+	"OTakumi/todogo/internal/domain/model"
+	"OTakumi/todogo/internal/domain/service"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+// Format は出力形式
+type Format string
+
+const (
+	Table  Format = "table"
+	JSON   Format = "json"
+	YAML   Format = "yaml"
+	CSV    Format = "csv"
+	NDJSON Format = "ndjson"
+)
+
+// Formats は指定可能な出力形式の一覧
+var Formats = []Format{Table, JSON, YAML, CSV, NDJSON}
+
+// ParseFormat は出力形式の指定を解釈する（大文字小文字は区別しない、空の場合は表形式）
+func ParseFormat(s string) (Format, error) {
+	if s == "" {
+		return Table, nil
+	}
+
+	f := Format(strings.ToLower(s))
+	for _, format := range Formats {
+		if f == format {
+			return f, nil
+		}
+	}
+
+	names := make([]string, len(Formats))
+	for i, format := range Formats {
+		names[i] = string(format)
+	}
+	return "", fmt.Errorf("invalid output format %q: must be one of %s", s, strings.Join(names, ", "))
+}
+
+// TaskList は一覧として出力するタスク
+type TaskList struct {
+	Tasks []*model.Task
+
+	// ShortIDs は完全なIDから短縮IDへの対応（表形式でのみ使用する）
+	ShortIDs map[string]string
+
+	// NextAfter は次のページを取得するためのカーソルとなるタスクのID（続きがない場合は空）
+	NextAfter string
+
+	// Depths はツリー表示での各タスクの階層の深さ（nilの場合は階層を表示しない、表形式とテンプレートでのみ使用する）
+	Depths map[string]int
+
+	// GroupByStatus は状態ごとに見出しを付けて出力するかどうか（表形式でのみ使用する）
+	// Tasks は状態ごとにまとめて並べておくこと（service.SortByStatus を参照）
+	GroupByStatus bool
+}
+
+// TaskDetail は詳細を出力するタスクと、依存関係でつながるタスク
+type TaskDetail struct {
+	Task *model.Task
+
+	// Upstream はこのタスクより先に完了する必要があるタスク（依存関係を推移的にたどったもの）
+	Upstream []service.ChainLink
+
+	// Downstream はこのタスクの完了を待っているタスク（依存関係を推移的にたどったもの）
+	Downstream []service.ChainLink
+
+	// TimeEntries はこのタスクの作業時間の記録（計測中の記録を含む）
+	TimeEntries []model.TimeEntry
+}
+
+// SearchResults は検索結果として出力するタスク
+type SearchResults struct {
+	// Hits は関連度の高い順に並んだ検索結果
+	Hits []model.SearchHit
+
+	// ShortIDs は完全なIDから短縮IDへの対応（表形式でのみ使用する）
+	ShortIDs map[string]string
+}
+
+// Trash はゴミ箱のタスクとして出力するもの
+type Trash struct {
+	// Tasks はゴミ箱に移した日時の新しい順に並んだタスク
+	Tasks []*model.Task
+
+	// ShortIDs は完全なIDから短縮IDへの対応（表形式でのみ使用する）
+	ShortIDs map[string]string
+}
+
+// Timesheet は作業時間の集計結果として出力するもの
+type Timesheet struct {
+	Sheet model.Timesheet
+
+	// ShortIDs は完全なIDから短縮IDへの対応（表形式でのみ使用する）
+	ShortIDs map[string]string
+}
+
+// EstimateReport は見積もりと実績の比較として出力するもの
+type EstimateReport struct {
+	Report model.EstimateReport
+
+	// ShortIDs は完全なIDから短縮IDへの対応（表形式でのみ使用する）
+	ShortIDs map[string]string
+}
+
+// Result は複数のタスクに対する操作の、1件ごとの結果
+type Result struct {
+	// Ref はユーザーが指定したタスクの参照（短縮IDや番号）
+	Ref string
+
+	// ID は解決後の完全なID（解決できなかった場合は空）
+	ID string
+
+	// Message は成功時のメッセージ
+	Message string
+
+	// Err は失敗時のエラー
+	Err error
+}
+
+// Options は出力の設定
+type Options struct {
+	// Location は日時を出力するタイムゾーン（nilの場合はローカルタイムゾーン）
+	Location *time.Location
+
+	// Now は緊急度の算出に使う現在時刻を返す（nilの場合はtime.Now）
+	Now func() time.Time
+
+	// Color は表形式で検索語に一致した箇所を太字で強調するかどうか（falseの場合は [ ] で囲む）
+	Color bool
+
+	// Workflow は緊急度の算出と完了済みかどうかの判定に使うワークフロー（nilの場合は既定のワークフロー）
+	Workflow *model.Workflow
+}
+
+// Renderer はタスクやコマンドの実行結果を出力する
+type Renderer interface {
+	// TaskList はタスクの一覧を出力する
+	TaskList(w io.Writer, list TaskList) error
+
+	// Tasks はタスクの詳細を出力する
+	Tasks(w io.Writer, tasks []*model.Task) error
+
+	// TaskDetails はタスクの詳細を、依存関係の連鎖と併せて出力する
+	TaskDetails(w io.Writer, details []TaskDetail) error
+
+	// SearchResults は検索結果を、一致した箇所の抜粋と併せて出力する
+	SearchResults(w io.Writer, results SearchResults) error
+
+	// Trash はゴミ箱のタスクを、ゴミ箱に移した日時と併せて出力する
+	Trash(w io.Writer, trash Trash) error
+
+	// Timesheet は期間内の作業時間を、タスクとプロジェクトごとに出力する
+	Timesheet(w io.Writer, timesheet Timesheet) error
+
+	// EstimateReport は完了したタスクの見積もりと実績を、タスクごととタグごとに出力する
+	EstimateReport(w io.Writer, report EstimateReport) error
+
+	// History はタスクの履歴を、記録した順に出力する
+	History(w io.Writer, events []model.TaskEvent) error
+
+	// Results は複数のタスクに対する操作の結果を出力する
+	Results(w io.Writer, results []Result) error
+
+	// TagCounts はタグとタグごとのタスクの件数を出力する
+	TagCounts(w io.Writer, counts []model.TagCount) error
+
+	// ProjectCounts はプロジェクトとプロジェクトごとのタスクの件数を出力する
+	ProjectCounts(w io.Writer, counts []model.ProjectCount) error
+
+	// Message は人向けのメッセージを出力する
+	// 構造化された形式では出力を解析しやすくするため何も出力しない
+	Message(w io.Writer, msg string) error
+}
+
+// New は指定された形式のRendererを生成する
+func New(format Format, opts Options) (Renderer, error) {
+	if opts.Location == nil {
+		opts.Location = time.Local
+	}
+	if opts.Now == nil {
+		opts.Now = time.Now
+	}
+	if opts.Workflow == nil {
+		opts.Workflow = model.DefaultWorkflow()
+	}
+
+	switch format {
+	case Table, "":
+		return &tableRenderer{loc: opts.Location, now: opts.Now, wf: opts.Workflow, color: opts.Color}, nil
+	case JSON:
+		return &documentRenderer{loc: opts.Location, now: opts.Now, wf: opts.Workflow, encode: encodeJSON}, nil
+	case YAML:
+		return &documentRenderer{loc: opts.Location, now: opts.Now, wf: opts.Workflow, encode: encodeYAML}, nil
+	case CSV:
+		return &csvRenderer{loc: opts.Location, now: opts.Now, wf: opts.Workflow}, nil
+	case NDJSON:
+		return &ndjsonRenderer{loc: opts.Location, now: opts.Now, wf: opts.Workflow}, nil
+	default:
+		return nil, fmt.Errorf("unsupported output format %q", format)
+	}
+}
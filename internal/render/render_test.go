@@ -0,0 +1,361 @@
+package render
+
+import (
+	"OTakumi/todogo/internal/domain/model"
+	"OTakumi/todogo/internal/domain/service"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+var (
+	jst = time.FixedZone("JST", 9*60*60)
+
+	deadline = time.Date(2025, 1, 31, 8, 0, 0, 0, time.UTC)
+	created  = time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	startAt  = time.Date(2025, 1, 24, 0, 0, 0, 0, time.UTC)
+	closedAt = time.Date(2025, 1, 1, 2, 0, 0, 0, time.UTC)
+
+	// now は緊急度の算出に使う時刻（openTaskの締切の1週間前）
+	now = time.Date(2025, 1, 24, 8, 0, 0, 0, time.UTC)
+
+	openTask = &model.Task{ID: "id-1", Title: "Write docs", Status: model.StatusOpen, Deadline: &deadline, StartAt: &startAt, Priority: model.PriorityHigh, Tags: []string{"docs", "work"}, Project: "work.docs", Subtasks: model.Progress{Done: 1, Total: 1}, Estimate: &model.Estimate{Points: 3}, RecurrenceID: "rec-1", Recurrence: &model.Recurrence{Frequency: model.FrequencyWeekly, Interval: 1, Weekdays: []time.Weekday{time.Friday}}, CreatedAt: created, UpdatedAt: created}
+	doneTask = &model.Task{ID: "id-2", Title: "Review, then merge", Description: "## Checklist\n\n- tests pass", Status: model.StatusDone, CompletedAt: &closedAt, ParentID: "id-1", BlockedBy: []string{"id-1"}, Annotations: []model.Annotation{{ID: 1, Text: "approved by Sam", CreatedAt: created.Add(time.Hour)}}, CreatedAt: created, UpdatedAt: created}
+)
+
+// historyEvents は履歴の出力に使う、作成から完了、削除、復元、完全な削除までの記録
+var historyEvents = []model.TaskEvent{
+	{ID: 1, TaskID: "id-1", Kind: model.TaskCreated, NewValue: "Write docs", Actor: "alice", OccurredAt: created},
+	{ID: 2, TaskID: "id-1", Kind: model.TaskChanged, Field: "deadline", NewValue: "2025-01-31T08:00:00Z", Actor: "alice", OccurredAt: created.Add(time.Hour)},
+	{ID: 3, TaskID: "id-1", Kind: model.TaskChanged, Field: "description", OldValue: "draft", NewValue: "## Outline\n\n- intro", OccurredAt: created.Add(time.Hour)},
+	{ID: 4, TaskID: "id-1", Kind: model.TaskCompleted, Field: "status", OldValue: "open", NewValue: "done", Actor: "bob", OccurredAt: closedAt},
+	{ID: 5, TaskID: "id-1", Kind: model.TaskDeleted, OldValue: "Write docs", Actor: "bob", OccurredAt: closedAt.Add(time.Hour)},
+	{ID: 6, TaskID: "id-1", Kind: model.TaskRestored, NewValue: "Write docs", Actor: "bob", OccurredAt: closedAt.Add(2 * time.Hour)},
+	{ID: 7, TaskID: "id-1", Kind: model.TaskPurged, OldValue: "Write docs", OccurredAt: closedAt.Add(3 * time.Hour)},
+}
+
+// searchHits は検索結果の出力に使う、タイトルと注記に一致した検索結果
+var searchHits = []model.SearchHit{
+	{Task: doneTask, Rank: 1.23456, Matches: []model.SearchMatch{
+		{Field: model.SearchFieldTitle, Snippet: "Review, then merge", Highlights: []model.Highlight{{Start: 0, End: 6}}},
+		{Field: model.SearchFieldAnnotation, Snippet: "approved by Sam", Highlights: []model.Highlight{{Start: 12, End: 15}}},
+	}},
+	{Task: openTask, Rank: 0.2, Matches: []model.SearchMatch{
+		{Field: model.SearchFieldDescription, Snippet: "…the review of docs", Highlights: []model.Highlight{{Start: 7, End: 13}}},
+	}},
+}
+
+func newTestRenderer(t *testing.T, format Format) Renderer {
+	t.Helper()
+
+	r, err := New(format, Options{Location: jst, Now: func() time.Time { return now }})
+	require.NoError(t, err)
+	return r
+}
+
+func TestParseFormat(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    Format
+		wantErr bool
+	}{
+		{input: "", want: Table},
+		{input: "json", want: JSON},
+		{input: "NDJSON", want: NDJSON},
+		{input: "xml", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := ParseFormat(tt.input)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestJSONRenderer(t *testing.T) {
+	t.Run("タスクの一覧をバージョン付きのドキュメントとして出力する", func(t *testing.T) {
+		var buf bytes.Buffer
+		err := newTestRenderer(t, JSON).TaskList(&buf, TaskList{Tasks: []*model.Task{openTask, doneTask}, NextAfter: "id-2"})
+		require.NoError(t, err)
+
+		assert.JSONEq(t, `{
+			"schema_version": 2,
+			"tasks": [
+				{"id": "id-1", "title": "Write docs", "deadline": "2025-01-31T17:00:00+09:00", "status": "open",
+				 "created_at": "2025-01-01T09:00:00+09:00", "updated_at": "2025-01-01T09:00:00+09:00",
+				 "priority": "high", "urgency": 13.33, "tags": ["docs", "work"], "project": "work.docs",
+				 "parent_id": null, "subtasks": {"done": 1, "total": 1}, "blocked_by": [],
+				 "recurrence": {"id": "rec-1", "rule": "FREQ=WEEKLY;BYDAY=FR", "description": "every week on Fri"},
+				 "description": "", "annotations": [],
+				 "start_at": "2025-01-24T09:00:00+09:00", "wait_until": null,
+				 "estimate": {"kind": "points", "seconds": 0, "points": 3}, "closed": false, "completed_at": null, "deleted_at": null},
+				{"id": "id-2", "title": "Review, then merge", "deadline": null, "status": "done",
+				 "created_at": "2025-01-01T09:00:00+09:00", "updated_at": "2025-01-01T09:00:00+09:00",
+				 "priority": "none", "urgency": 0, "tags": [], "project": null,
+				 "parent_id": "id-1", "subtasks": null, "blocked_by": ["id-1"], "recurrence": null,
+				 "description": "## Checklist\n\n- tests pass",
+				 "annotations": [{"created_at": "2025-01-01T10:00:00+09:00", "text": "approved by Sam"}],
+				 "start_at": null, "wait_until": null, "estimate": null, "closed": true,
+				 "completed_at": "2025-01-01T11:00:00+09:00", "deleted_at": null}
+			],
+			"next_after": "id-2"
+		}`, buf.String())
+	})
+
+	t.Run("タスクの詳細を上流と下流のタスクと共に出力する", func(t *testing.T) {
+		var buf bytes.Buffer
+		err := newTestRenderer(t, JSON).TaskDetails(&buf, []TaskDetail{
+			{Task: doneTask, Upstream: []service.ChainLink{{Task: openTask, Depth: 1}}},
+		})
+		require.NoError(t, err)
+
+		var doc struct {
+			Tasks []map[string]any `json:"tasks"`
+		}
+		require.NoError(t, json.Unmarshal(buf.Bytes(), &doc))
+		require.Len(t, doc.Tasks, 1)
+		assert.Equal(t, "id-2", doc.Tasks[0]["id"])
+		assert.Equal(t, []any{map[string]any{"id": "id-1", "title": "Write docs", "status": "open", "depth": float64(1)}}, doc.Tasks[0]["upstream"])
+		assert.Equal(t, []any{}, doc.Tasks[0]["downstream"])
+	})
+
+	t.Run("タスクがない場合も空の配列を出力する", func(t *testing.T) {
+		var buf bytes.Buffer
+		require.NoError(t, newTestRenderer(t, JSON).TaskList(&buf, TaskList{}))
+
+		assert.JSONEq(t, `{"schema_version": 2, "tasks": []}`, buf.String())
+	})
+
+	t.Run("操作の結果を出力する", func(t *testing.T) {
+		var buf bytes.Buffer
+		err := newTestRenderer(t, JSON).Results(&buf, []Result{
+			{Ref: "1", ID: "id-1", Message: "deleted"},
+			{Ref: "missing", Err: errors.New("task not found")},
+		})
+		require.NoError(t, err)
+
+		assert.JSONEq(t, `{
+			"schema_version": 2,
+			"results": [
+				{"ref": "1", "id": "id-1", "ok": true, "message": "deleted"},
+				{"ref": "missing", "ok": false, "error": "task not found"}
+			]
+		}`, buf.String())
+	})
+
+	t.Run("タグごとの件数を出力する", func(t *testing.T) {
+		var buf bytes.Buffer
+		require.NoError(t, newTestRenderer(t, JSON).TagCounts(&buf, []model.TagCount{{Name: "work", Open: 1, Total: 2}}))
+
+		assert.JSONEq(t, `{"schema_version": 2, "tags": [{"name": "work", "open": 1, "total": 2}]}`, buf.String())
+	})
+
+	t.Run("プロジェクトごとの件数と完了率を出力する", func(t *testing.T) {
+		var buf bytes.Buffer
+		require.NoError(t, newTestRenderer(t, JSON).ProjectCounts(&buf, []model.ProjectCount{{Name: "work", Open: 2, Closed: 1}}))
+
+		assert.JSONEq(t, `{"schema_version": 2, "projects": [{"name": "work", "open": 2, "closed": 1, "total": 3, "completion": 33.3}]}`, buf.String())
+	})
+
+	t.Run("メッセージは出力しない", func(t *testing.T) {
+		var buf bytes.Buffer
+		require.NoError(t, newTestRenderer(t, JSON).Message(&buf, "Task created successfully!"))
+
+		assert.Empty(t, buf.String())
+	})
+}
+
+func TestJSONRenderer_SearchResults(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, newTestRenderer(t, JSON).SearchResults(&buf, SearchResults{Hits: searchHits}))
+
+	var doc struct {
+		SchemaVersion int `json:"schema_version"`
+		Hits          []struct {
+			ID      string            `json:"id"`
+			Rank    float64           `json:"rank"`
+			Matches []SearchMatchView `json:"matches"`
+		} `json:"hits"`
+	}
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &doc))
+	assert.Equal(t, SchemaVersion, doc.SchemaVersion)
+	require.Len(t, doc.Hits, 2)
+
+	// 関連度は丸めて出力し、タスクの項目と一致した箇所を同じオブジェクトに含める
+	assert.Equal(t, "id-2", doc.Hits[0].ID)
+	assert.Equal(t, 1.2346, doc.Hits[0].Rank)
+	assert.Equal(t, []SearchMatchView{
+		{Field: "title", Snippet: "Review, then merge", Highlights: []HighlightView{{Start: 0, End: 6}}},
+		{Field: "annotation", Snippet: "approved by Sam", Highlights: []HighlightView{{Start: 12, End: 15}}},
+	}, doc.Hits[0].Matches)
+	assert.Equal(t, "id-1", doc.Hits[1].ID)
+}
+
+func TestJSONRenderer_TaskDetails_Tracked(t *testing.T) {
+	ended := now.Add(-2 * time.Hour)
+	var buf bytes.Buffer
+	require.NoError(t, newTestRenderer(t, JSON).TaskDetails(&buf, []TaskDetail{{
+		Task:        openTask,
+		TimeEntries: []model.TimeEntry{{ID: 1, StartedAt: now.Add(-3 * time.Hour), EndedAt: &ended}, {ID: 2, StartedAt: now.Add(-30 * time.Minute)}},
+	}}))
+
+	var doc struct {
+		Tasks []TaskDetailView `json:"tasks"`
+	}
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &doc))
+	require.Len(t, doc.Tasks, 1)
+	assert.Equal(t, int64(90*60), doc.Tasks[0].TrackedSeconds)
+	if assert.NotNil(t, doc.Tasks[0].TimerStartedAt) {
+		assert.Equal(t, "2025-01-24T16:30:00+09:00", *doc.Tasks[0].TimerStartedAt)
+	}
+}
+
+func TestJSONRenderer_Timesheet(t *testing.T) {
+	from := time.Date(2025, 1, 20, 0, 0, 0, 0, jst)
+	var buf bytes.Buffer
+	require.NoError(t, newTestRenderer(t, JSON).Timesheet(&buf, Timesheet{Sheet: model.Timesheet{
+		User: "alice", From: from, To: from.AddDate(0, 0, 7),
+		Tasks:    []model.TimesheetTask{{TaskID: "id-1", Title: "Write docs", Project: "work", Duration: 100 * time.Minute}},
+		Projects: []model.TimesheetProject{{Name: "work", Duration: 100 * time.Minute}},
+		Total:    100 * time.Minute,
+	}}))
+
+	var doc struct {
+		SchemaVersion int `json:"schema_version"`
+		TimesheetView
+	}
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &doc))
+	assert.Equal(t, SchemaVersion, doc.SchemaVersion)
+	assert.Equal(t, "2025-01-27T00:00:00+09:00", doc.To)
+	assert.Equal(t, int64(6000), doc.TotalSeconds)
+
+	// 時間単位の作業時間は小数点以下2桁に丸めること
+	work := "work"
+	assert.Equal(t, []TimesheetTaskView{{ID: "id-1", Title: "Write docs", Project: &work, Seconds: 6000, Hours: 1.67}}, doc.Tasks)
+	assert.Equal(t, []TimesheetProjectView{{Name: &work, Seconds: 6000, Hours: 1.67}}, doc.Projects)
+}
+
+func TestJSONRenderer_EstimateReport(t *testing.T) {
+	completed := time.Date(2025, 1, 24, 0, 0, 0, 0, time.UTC)
+	var buf bytes.Buffer
+	require.NoError(t, newTestRenderer(t, JSON).EstimateReport(&buf, EstimateReport{Report: model.EstimateReport{
+		Tasks: []model.EstimatedTask{{
+			TaskID: "id-1", Title: "Write docs", Tags: []string{"docs"}, Estimate: model.Estimate{Points: 3},
+			Actual: 5 * time.Hour, Source: model.ActualTracked, CompletedAt: completed, Ratio: 1,
+		}},
+		Tags:    []model.EstimateAccuracy{{Tag: "docs", Kind: model.EstimatePoints, Tasks: 1, Points: 3, Actual: 5 * time.Hour, PerPoint: 100 * time.Minute, Ratio: 1}},
+		Overall: []model.EstimateAccuracy{{Kind: model.EstimatePoints, Tasks: 1, Points: 3, Actual: 5 * time.Hour, PerPoint: 100 * time.Minute, Ratio: 1}},
+	}}))
+
+	// 全体の精度はタグをnullとして出力すること
+	assert.JSONEq(t, `{
+		"schema_version": 2,
+		"tasks": [{"id": "id-1", "title": "Write docs", "tags": ["docs"],
+			"estimate": {"kind": "points", "seconds": 0, "points": 3},
+			"actual_seconds": 18000, "source": "tracked", "completed_at": "2025-01-24T09:00:00+09:00", "ratio": 1}],
+		"tags": [{"tag": "docs", "kind": "points", "tasks": 1, "estimated_seconds": 0, "points": 3,
+			"actual_seconds": 18000, "per_point_seconds": 6000, "ratio": 1}],
+		"overall": [{"tag": null, "kind": "points", "tasks": 1, "estimated_seconds": 0, "points": 3,
+			"actual_seconds": 18000, "per_point_seconds": 6000, "ratio": 1}]
+	}`, buf.String())
+}
+
+func TestJSONRenderer_History(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, newTestRenderer(t, JSON).History(&buf, historyEvents[3:4]))
+
+	assert.JSONEq(t, `{
+		"schema_version": 2,
+		"events": [
+			{"id": 4, "task_id": "id-1", "kind": "completed", "field": "status", "old_value": "open", "new_value": "done",
+			 "actor": "bob", "occurred_at": "2025-01-01T11:00:00+09:00"}
+		]
+	}`, buf.String())
+}
+
+func TestYAMLRenderer(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, newTestRenderer(t, YAML).Tasks(&buf, []*model.Task{openTask}))
+
+	var doc struct {
+		SchemaVersion int        `yaml:"schema_version"`
+		Tasks         []TaskView `yaml:"tasks"`
+	}
+	require.NoError(t, yaml.Unmarshal(buf.Bytes(), &doc))
+	assert.Equal(t, SchemaVersion, doc.SchemaVersion)
+	assert.Equal(t, []TaskView{NewTaskView(openTask, model.DefaultWorkflow(), jst, now)}, doc.Tasks)
+}
+
+func TestCSVRenderer(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, newTestRenderer(t, CSV).TaskList(&buf, TaskList{Tasks: []*model.Task{openTask, doneTask}}))
+
+	records, err := csv.NewReader(&buf).ReadAll()
+	require.NoError(t, err)
+	assert.Equal(t, [][]string{
+		{"id", "title", "deadline", "status", "created_at", "updated_at", "priority", "urgency", "tags", "project", "parent_id", "subtasks_done", "subtasks_total", "blocked_by", "recurrence_id", "recurrence", "description", "start_at", "wait_until", "closed", "estimate_kind", "estimate_seconds", "estimate_points", "completed_at", "deleted_at"},
+		{"id-1", "Write docs", "2025-01-31T17:00:00+09:00", "open", "2025-01-01T09:00:00+09:00", "2025-01-01T09:00:00+09:00", "high", "13.33", "docs work", "work.docs", "", "1", "1", "", "rec-1", "FREQ=WEEKLY;BYDAY=FR", "", "2025-01-24T09:00:00+09:00", "", "false", "points", "0", "3", "", ""},
+		{"id-2", "Review, then merge", "", "done", "2025-01-01T09:00:00+09:00", "2025-01-01T09:00:00+09:00", "none", "0", "", "", "id-1", "", "", "id-1", "", "", "## Checklist\n\n- tests pass", "", "", "true", "", "", "", "2025-01-01T11:00:00+09:00", ""},
+	}, records)
+}
+
+func TestCSVRenderer_SearchResults(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, newTestRenderer(t, CSV).SearchResults(&buf, SearchResults{Hits: searchHits}))
+
+	records, err := csv.NewReader(&buf).ReadAll()
+	require.NoError(t, err)
+	require.Len(t, records, 3)
+
+	// タスクの列に続けて、関連度と一致した項目を出力する
+	header := records[0]
+	assert.Equal(t, []string{"deleted_at", "rank", "fields"}, header[len(header)-3:])
+	assert.Equal(t, []string{"id-2", "1.2346", "title annotation"}, []string{records[1][0], records[1][len(header)-2], records[1][len(header)-1]})
+	assert.Equal(t, []string{"id-1", "0.2", "description"}, []string{records[2][0], records[2][len(header)-2], records[2][len(header)-1]})
+}
+
+func TestCSVRenderer_TagCounts(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, newTestRenderer(t, CSV).TagCounts(&buf, []model.TagCount{{Name: "home", Open: 0, Total: 1}, {Name: "work", Open: 1, Total: 2}}))
+
+	records, err := csv.NewReader(&buf).ReadAll()
+	require.NoError(t, err)
+	assert.Equal(t, [][]string{
+		{"name", "open", "total"},
+		{"home", "0", "1"},
+		{"work", "1", "2"},
+	}, records)
+}
+
+func TestNDJSONRenderer(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, newTestRenderer(t, NDJSON).TaskList(&buf, TaskList{Tasks: []*model.Task{openTask, doneTask}}))
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	require.Len(t, lines, 2)
+
+	// 各行が単独のJSONとして解釈でき、スキーマのバージョンを含むこと
+	for i, task := range []*model.Task{openTask, doneTask} {
+		var record struct {
+			SchemaVersion int `json:"schema_version"`
+			TaskView
+		}
+		require.NoError(t, json.Unmarshal([]byte(lines[i]), &record))
+		assert.Equal(t, SchemaVersion, record.SchemaVersion)
+		assert.Equal(t, NewTaskView(task, model.DefaultWorkflow(), jst, now), record.TaskView)
+	}
+}
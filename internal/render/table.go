@@ -0,0 +1,604 @@
+package render
+
+import (
+	"OTakumi/todogo/internal/domain/model"
+	"OTakumi/todogo/internal/domain/service"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+	"time"
+)
+
+// tableRenderer は人が読むための表形式で出力する
+type tableRenderer struct {
+	loc   *time.Location
+	now   func() time.Time
+	wf    *model.Workflow
+	color bool
+}
+
+func (r *tableRenderer) TaskList(w io.Writer, list TaskList) error {
+	// タスクが存在しない場合の処理
+	if len(list.Tasks) == 0 {
+		_, err := fmt.Fprintln(w, "No tasks found.")
+		return err
+	}
+
+	now := r.now()
+
+	// 状態ごとにまとめる場合は、状態が変わるごとに見出しを付けて別の表とする
+	// 番号は表をまたいで通しで振る
+	if list.GroupByStatus {
+		for start := 0; start < len(list.Tasks); {
+			status := list.Tasks[start].Status
+			end := start + 1
+			for end < len(list.Tasks) && list.Tasks[end].Status == status {
+				end++
+			}
+			if start > 0 {
+				fmt.Fprintln(w)
+			}
+			fmt.Fprintf(w, "%s (%d)\n", status.Label(), end-start)
+			if err := r.writeTaskRows(w, list, start, end, now); err != nil {
+				return err
+			}
+			start = end
+		}
+	} else if err := r.writeTaskRows(w, list, 0, len(list.Tasks), now); err != nil {
+		return err
+	}
+
+	// タスクの総数を表示
+	fmt.Fprintf(w, "\nTotal: %d task(s)\n", len(list.Tasks))
+
+	// 続きがある場合は次のページの取得方法を案内する
+	if list.NextAfter != "" {
+		fmt.Fprintf(w, "More tasks may be available: use --after %s\n", shortID(list.ShortIDs, list.NextAfter))
+	}
+	return nil
+}
+
+// writeTaskRows は一覧の start から end の直前までのタスクを表として出力する
+func (r *tableRenderer) writeTaskRows(w io.Writer, list TaskList, start, end int, now time.Time) error {
+	// 整形されたテーブル出力のためのtabwriterを作成
+	// パラメータ: 出力先, 最小幅, タブ幅, パディング, パディング文字, フラグ
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+
+	// テーブルヘッダーを出力
+	fmt.Fprintln(tw, "#\tID\tTitle\tSubtasks\tProject\tDeadline\tPriority\tUrgency\tTags\tStatus\tCreated")
+	fmt.Fprintln(tw, "-\t---\t-----\t--------\t-------\t--------\t--------\t-------\t----\t------\t-------")
+
+	// 番号は他のコマンドでタスクを指定する際に使える
+	for i := start; i < end; i++ {
+		task := list.Tasks[i]
+		// 締切日をフォーマット（未設定の場合は"-"を表示）
+		deadlineStr := "-"
+		if task.Deadline != nil {
+			deadlineStr = task.Deadline.In(r.loc).Format("2006-01-02")
+		}
+
+		// ツリー表示では、サブタスクを階層の深さに応じて字下げする
+		title := strings.Repeat("  ", list.Depths[task.ID]) + task.Title
+
+		fmt.Fprintf(tw, "%d\t%s\t%s\t%s\t%s\t%s\t%s\t%.1f\t%s\t%s\t%s\n",
+			i+1,
+			shortID(list.ShortIDs, task.ID),
+			title,
+			progressLabel(task.Subtasks),
+			projectLabel(task.Project),
+			deadlineStr,
+			priorityLabel(task.Priority),
+			service.Urgency(task, r.wf, now),
+			tagsLabel(task.Tags, " "),
+			StatusLabel(task),
+			formatTime(task.CreatedAt, r.loc),
+		)
+	}
+
+	return tw.Flush()
+}
+
+func (r *tableRenderer) Tasks(w io.Writer, tasks []*model.Task) error {
+	now := r.now()
+	for i, task := range tasks {
+		// 2件目以降は空行で区切る
+		if i > 0 {
+			fmt.Fprintln(w)
+		}
+		if err := r.writeTask(w, task, now); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// TaskDetails はタスクの詳細に続けて、上流と下流のタスクを依存関係の深さに応じて字下げして出力する
+func (r *tableRenderer) TaskDetails(w io.Writer, details []TaskDetail) error {
+	now := r.now()
+	for i, detail := range details {
+		if i > 0 {
+			fmt.Fprintln(w)
+		}
+		if err := r.writeTask(w, detail.Task, now); err != nil {
+			return err
+		}
+		if err := r.writeTracked(w, detail.TimeEntries, now); err != nil {
+			return err
+		}
+		if err := writeChain(w, "Upstream:", detail.Upstream); err != nil {
+			return err
+		}
+		if err := writeChain(w, "Downstream:", detail.Downstream); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// writeTask は1件のタスクの詳細を出力する
+func (r *tableRenderer) writeTask(w io.Writer, task *model.Task, now time.Time) error {
+	fmt.Fprintf(w, "ID:       %s\n", task.ID)
+	fmt.Fprintf(w, "Title:    %s\n", task.Title)
+	fmt.Fprintf(w, "Project:  %s\n", projectLabel(task.Project))
+	fmt.Fprintf(w, "Parent:   %s\n", parentLabel(task.ParentID))
+	fmt.Fprintf(w, "Subtasks: %s\n", progressLabel(task.Subtasks))
+	fmt.Fprintf(w, "Blocked:  %s\n", tagsLabel(task.BlockedBy, ", "))
+	fmt.Fprintf(w, "Deadline: %s\n", r.formatDeadline(task.Deadline))
+	fmt.Fprintf(w, "Start:    %s\n", r.formatDeadline(task.StartAt))
+	fmt.Fprintf(w, "Wait:     %s\n", r.formatDeadline(task.WaitUntil))
+	fmt.Fprintf(w, "Repeat:   %s\n", recurrenceLabel(task.Recurrence))
+	fmt.Fprintf(w, "Priority: %s\n", priorityLabel(task.Priority))
+	fmt.Fprintf(w, "Estimate: %s\n", estimateLabel(task.Estimate))
+	fmt.Fprintf(w, "Urgency:  %.1f\n", service.Urgency(task, r.wf, now))
+	fmt.Fprintf(w, "Tags:     %s\n", tagsLabel(task.Tags, ", "))
+	fmt.Fprintf(w, "Status:   %s\n", StatusLabel(task))
+	fmt.Fprintf(w, "Created:  %s\n", formatTime(task.CreatedAt, r.loc))
+	fmt.Fprintf(w, "Updated:  %s\n", formatTime(task.UpdatedAt, r.loc))
+	if _, err := fmt.Fprintf(w, "Closed:   %s\n", r.formatDeadline(task.CompletedAt)); err != nil {
+		return err
+	}
+	return r.writeNotes(w, task)
+}
+
+// writeNotes はタスクの説明と注記を字下げして出力する
+// 複数行になるため、設定されていない場合は項目ごと出力しない
+func (r *tableRenderer) writeNotes(w io.Writer, task *model.Task) error {
+	if task.Description != "" {
+		fmt.Fprintln(w, "Notes:")
+		for _, line := range strings.Split(task.Description, "\n") {
+			if _, err := fmt.Fprintln(w, strings.TrimRight("  "+line, " ")); err != nil {
+				return err
+			}
+		}
+	}
+	if len(task.Annotations) > 0 {
+		fmt.Fprintln(w, "Annotations:")
+		for _, a := range task.Annotations {
+			if _, err := fmt.Fprintf(w, "  %s  %s\n", a.CreatedAt.In(r.loc).Format("2006-01-02 15:04"), a.Text); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// writeTracked は記録された作業時間の合計を出力する（記録がない場合は"-"）
+// 計測中の場合は、計測を開始した日時を併記する
+func (r *tableRenderer) writeTracked(w io.Writer, entries []model.TimeEntry, now time.Time) error {
+	if len(entries) == 0 {
+		_, err := fmt.Fprintln(w, "Tracked:  -")
+		return err
+	}
+	label := FormatDuration(model.TotalDuration(entries, now))
+	if running := runningEntry(entries); running != nil {
+		label += fmt.Sprintf(" (timer running since %s)", running.StartedAt.In(r.loc).Format("2006-01-02 15:04"))
+	}
+	_, err := fmt.Fprintf(w, "Tracked:  %s\n", label)
+	return err
+}
+
+// writeChain は依存関係の連鎖を、起点のタスクからの距離に応じて字下げして出力する（ない場合は"-"）
+func writeChain(w io.Writer, label string, links []service.ChainLink) error {
+	if len(links) == 0 {
+		_, err := fmt.Fprintf(w, "%s -\n", label)
+		return err
+	}
+	fmt.Fprintln(w, label)
+	for _, link := range links {
+		indent := strings.Repeat("  ", link.Depth)
+		if _, err := fmt.Fprintf(w, "%s%s  %s  (%s)\n", indent, link.Task.ID, link.Task.Title, StatusLabel(link.Task)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// SearchResults は関連度の順に、タスクの見出しと一致した箇所の抜粋を出力する
+// 番号は一覧と同様に、他のコマンドでタスクを指定する際に使える
+// タイトルに一致した場合は見出しのタイトルで、説明や注記に一致した場合は字下げした抜粋で一致した箇所を強調する
+func (r *tableRenderer) SearchResults(w io.Writer, results SearchResults) error {
+	if len(results.Hits) == 0 {
+		_, err := fmt.Fprintln(w, "No tasks found.")
+		return err
+	}
+
+	for i, hit := range results.Hits {
+		title := hit.Task.Title
+		var excerpts []model.SearchMatch
+		for _, m := range hit.Matches {
+			if m.Field == model.SearchFieldTitle {
+				title = r.highlight(m.Snippet, m.Highlights)
+				continue
+			}
+			excerpts = append(excerpts, m)
+		}
+
+		fmt.Fprintf(w, "%d  %s  %s  (%s, rank %.2f)\n", i+1, shortID(results.ShortIDs, hit.Task.ID), title, StatusLabel(hit.Task), hit.Rank)
+		for _, m := range excerpts {
+			if _, err := fmt.Fprintf(w, "    %s: %s\n", m.Field, r.highlight(m.Snippet, m.Highlights)); err != nil {
+				return err
+			}
+		}
+	}
+
+	_, err := fmt.Fprintf(w, "\nFound: %d task(s)\n", len(results.Hits))
+	return err
+}
+
+// highlight は抜粋のうち検索語に一致した範囲を、太字または [ ] で囲んで強調する
+func (r *tableRenderer) highlight(snippet string, highlights []model.Highlight) string {
+	start, stop := "[", "]"
+	if r.color {
+		start, stop = "\x1b[1m", "\x1b[0m"
+	}
+
+	var sb strings.Builder
+	pos := 0
+	for _, h := range highlights {
+		sb.WriteString(snippet[pos:h.Start])
+		sb.WriteString(start + snippet[h.Start:h.End] + stop)
+		pos = h.End
+	}
+	sb.WriteString(snippet[pos:])
+	return sb.String()
+}
+
+// Timesheet は期間と、タスクごとの作業時間の表、階層に応じて字下げしたプロジェクトごとの作業時間の表を出力する
+func (r *tableRenderer) Timesheet(w io.Writer, timesheet Timesheet) error {
+	sheet := timesheet.Sheet
+	// 期間の終わりは含まないため、最終日として前日の日付を表示する
+	fmt.Fprintf(w, "Timesheet for %s: %s - %s\n\n", sheet.User,
+		sheet.From.In(r.loc).Format("2006-01-02"), sheet.To.Add(-time.Nanosecond).In(r.loc).Format("2006-01-02"))
+	if len(sheet.Tasks) == 0 {
+		_, err := fmt.Fprintln(w, "No time tracked.")
+		return err
+	}
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "ID\tTitle\tProject\tTime\tHours")
+	fmt.Fprintln(tw, "--\t-----\t-------\t----\t-----")
+	for _, t := range sheet.Tasks {
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%.2f\n",
+			shortID(timesheet.ShortIDs, t.TaskID), t.Title, projectLabel(t.Project), FormatDuration(t.Duration), hours(t.Duration))
+	}
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+
+	fmt.Fprintln(w)
+	tw = tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "Project\tTime\tHours")
+	fmt.Fprintln(tw, "-------\t----\t-----")
+	for _, p := range sheet.Projects {
+		name := "(no project)"
+		if p.Name != "" {
+			// 上位のプロジェクトの下に、末尾の階層の名前のみを字下げして表示する
+			segments := strings.Split(p.Name, model.ProjectSeparator)
+			name = strings.Repeat("  ", len(segments)-1) + segments[len(segments)-1]
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%.2f\n", name, FormatDuration(p.Duration), hours(p.Duration))
+	}
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+
+	_, err := fmt.Fprintf(w, "\nTotal: %s (%.2f hours)\n", FormatDuration(sheet.Total), hours(sheet.Total))
+	return err
+}
+
+// Trash はゴミ箱のタスクを、ゴミ箱に移した日時と併せて表として出力する
+// 番号は一覧の表示番号と紛らわしいため表示しない
+func (r *tableRenderer) Trash(w io.Writer, trash Trash) error {
+	if len(trash.Tasks) == 0 {
+		_, err := fmt.Fprintln(w, "Trash is empty.")
+		return err
+	}
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "ID\tTitle\tProject\tTags\tStatus\tDeleted")
+	fmt.Fprintln(tw, "--\t-----\t-------\t----\t------\t-------")
+	for _, task := range trash.Tasks {
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\t%s\n",
+			shortID(trash.ShortIDs, task.ID), task.Title, projectLabel(task.Project),
+			tagsLabel(task.Tags, " "), StatusLabel(task), r.formatDeadline(task.DeletedAt))
+	}
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+
+	_, err := fmt.Fprintf(w, "\nTotal: %d task(s) in the trash\n", len(trash.Tasks))
+	return err
+}
+
+// EstimateReport はタスクごとの見積もりと実績の表と、タグごとの精度の表を出力する
+// 全体の精度は "(all)" として、タグごとの精度より先に並べる
+func (r *tableRenderer) EstimateReport(w io.Writer, report EstimateReport) error {
+	if len(report.Report.Tasks) == 0 {
+		_, err := fmt.Fprintln(w, "No completed tasks with estimates.")
+		return err
+	}
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "ID\tTitle\tCompleted\tEstimate\tActual\tSource\tRatio")
+	fmt.Fprintln(tw, "--\t-----\t---------\t--------\t------\t------\t-----")
+	for _, t := range report.Report.Tasks {
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\t%s\t%s\n",
+			shortID(report.ShortIDs, t.TaskID), t.Title, t.CompletedAt.In(r.loc).Format("2006-01-02"),
+			t.Estimate.String(), FormatDuration(t.Actual), sourceLabel(t.Source), ratioLabel(t.Ratio))
+	}
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+
+	fmt.Fprintln(w)
+	tw = tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "Tag\tKind\tTasks\tEstimate\tActual\tPer point\tRatio")
+	fmt.Fprintln(tw, "---\t----\t-----\t--------\t------\t---------\t-----")
+	for _, acc := range append(append([]model.EstimateAccuracy{}, report.Report.Overall...), report.Report.Tags...) {
+		tag := acc.Tag
+		if tag == "" {
+			tag = "(all)"
+		}
+		perPoint := "-"
+		if acc.Kind == model.EstimatePoints {
+			perPoint = FormatDuration(acc.PerPoint)
+		}
+		estimate := model.Estimate{Duration: acc.Estimated, Points: acc.Points}
+		fmt.Fprintf(tw, "%s\t%s\t%d\t%s\t%s\t%s\t%s\n",
+			tag, acc.Kind, acc.Tasks, estimate.String(), FormatDuration(acc.Actual), perPoint, ratioLabel(acc.Ratio))
+	}
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+
+	// 比の読み方を案内する
+	_, err := fmt.Fprintln(w, "\nRatio is actual / estimate (above 1.00 means it took longer than estimated).")
+	return err
+}
+
+// History はタスクの履歴を、日時、操作した利用者、操作の内容の表として出力する
+func (r *tableRenderer) History(w io.Writer, events []model.TaskEvent) error {
+	if len(events) == 0 {
+		_, err := fmt.Fprintln(w, "No history recorded.")
+		return err
+	}
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "When\tActor\tChange")
+	fmt.Fprintln(tw, "----\t-----\t------")
+	for _, e := range events {
+		actor := e.Actor
+		if actor == "" {
+			actor = "-"
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\n", e.OccurredAt.In(r.loc).Format("2006-01-02 15:04"), actor, r.eventLabel(e))
+	}
+	return tw.Flush()
+}
+
+// eventLabel は履歴の1件の操作の内容を表示用の文字列に変換する
+func (r *tableRenderer) eventLabel(e model.TaskEvent) string {
+	switch e.Kind {
+	case model.TaskCreated:
+		return fmt.Sprintf("created %q", e.NewValue)
+	case model.TaskDeleted:
+		return fmt.Sprintf("deleted %q", e.OldValue)
+	case model.TaskRestored:
+		return fmt.Sprintf("restored %q", e.NewValue)
+	case model.TaskPurged:
+		return fmt.Sprintf("purged %q", e.OldValue)
+	case model.TaskCompleted:
+		return fmt.Sprintf("completed (status: %s -> %s)", e.OldValue, e.NewValue)
+	}
+	// 説明は複数行になるため、変更したことのみを表示する
+	if e.Field == "description" {
+		return "description changed"
+	}
+	return fmt.Sprintf("%s: %s -> %s", e.Field, r.eventValue(e.Field, e.OldValue), r.eventValue(e.Field, e.NewValue))
+}
+
+// eventValue は履歴に記録された値を表示用の文字列に変換する（未設定の場合は"-"）
+// 日時の項目は設定されたタイムゾーンで表示する
+func (r *tableRenderer) eventValue(field, value string) string {
+	if value == "" {
+		return "-"
+	}
+	switch field {
+	case "deadline", "start_at", "wait_until":
+		if t, err := time.Parse(time.RFC3339, value); err == nil {
+			return t.In(r.loc).Format("2006-01-02 15:04")
+		}
+	}
+	return value
+}
+
+func (r *tableRenderer) Results(w io.Writer, results []Result) error {
+	failed := 0
+	for _, result := range results {
+		label := resultLabel(result)
+		if result.Err != nil {
+			failed++
+			fmt.Fprintf(w, "%s: error: %v\n", label, result.Err)
+			continue
+		}
+		fmt.Fprintf(w, "%s: %s\n", label, result.Message)
+	}
+
+	// 複数件を処理した場合のみ集計を表示する
+	if len(results) > 1 {
+		fmt.Fprintf(w, "\n%d succeeded, %d failed\n", len(results)-failed, failed)
+	}
+	return nil
+}
+
+func (r *tableRenderer) TagCounts(w io.Writer, counts []model.TagCount) error {
+	if len(counts) == 0 {
+		_, err := fmt.Fprintln(w, "No tags found.")
+		return err
+	}
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "Tag\tOpen\tTotal")
+	fmt.Fprintln(tw, "---\t----\t-----")
+	for _, c := range counts {
+		fmt.Fprintf(tw, "%s\t%d\t%d\n", c.Name, c.Open, c.Total)
+	}
+	return tw.Flush()
+}
+
+// ProjectCounts はプロジェクトを階層に応じて字下げした表を出力する
+func (r *tableRenderer) ProjectCounts(w io.Writer, counts []model.ProjectCount) error {
+	if len(counts) == 0 {
+		_, err := fmt.Fprintln(w, "No projects found.")
+		return err
+	}
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "Project\tOpen\tClosed\tComplete")
+	fmt.Fprintln(tw, "-------\t----\t------\t--------")
+	for _, c := range counts {
+		// 上位のプロジェクトの下に、末尾の階層の名前のみを字下げして表示する
+		segments := strings.Split(c.Name, model.ProjectSeparator)
+		name := strings.Repeat("  ", len(segments)-1) + segments[len(segments)-1]
+		fmt.Fprintf(tw, "%s\t%d\t%d\t%.0f%%\n", name, c.Open, c.Closed, c.Completion())
+	}
+	return tw.Flush()
+}
+
+func (r *tableRenderer) Message(w io.Writer, msg string) error {
+	_, err := fmt.Fprintln(w, msg)
+	return err
+}
+
+// formatDeadline は締切日時を表示用の文字列に変換する（未設定の場合は"-"）
+func (r *tableRenderer) formatDeadline(deadline *time.Time) string {
+	if deadline == nil {
+		return "-"
+	}
+	return deadline.In(r.loc).Format("2006-01-02 15:04")
+}
+
+// StatusLabel はタスクの状態を表示用の文字列に変換する
+func StatusLabel(task *model.Task) string {
+	return task.Status.Label()
+}
+
+// FormatDuration は作業時間を "2h30m" や "45m" の形式の文字列に変換する（1分未満は切り捨てる）
+func FormatDuration(d time.Duration) string {
+	d = d.Truncate(time.Minute)
+	h, m := int(d/time.Hour), int(d%time.Hour/time.Minute)
+	if h == 0 {
+		return fmt.Sprintf("%dm", m)
+	}
+	return fmt.Sprintf("%dh%dm", h, m)
+}
+
+// priorityLabel は優先度を表示用の文字列に変換する（未設定の場合は"-"）
+func priorityLabel(p model.Priority) string {
+	if p == model.PriorityNone {
+		return "-"
+	}
+	return p.String()
+}
+
+// estimateLabel は見積もりを表示用の文字列に変換する（未設定の場合は"-"）
+func estimateLabel(e *model.Estimate) string {
+	if e == nil {
+		return "-"
+	}
+	return e.String()
+}
+
+// sourceLabel は実績の求め方を表示用の文字列に変換する
+func sourceLabel(source model.ActualSource) string {
+	if source == model.ActualLeadTime {
+		return "lead time"
+	}
+	return string(source)
+}
+
+// ratioLabel は見積もりに対する実績の比を "1.50x" の形式の文字列に変換する（求められない場合は"-"）
+func ratioLabel(ratio float64) string {
+	if ratio == 0 {
+		return "-"
+	}
+	return fmt.Sprintf("%.2fx", ratio)
+}
+
+// projectLabel はプロジェクト名を表示用の文字列に変換する（未設定の場合は"-"）
+func projectLabel(project string) string {
+	if project == "" {
+		return "-"
+	}
+	return project
+}
+
+// parentLabel は親タスクのIDを表示用の文字列に変換する（サブタスクでない場合は"-"）
+func parentLabel(parentID string) string {
+	if parentID == "" {
+		return "-"
+	}
+	return parentID
+}
+
+// recurrenceLabel は繰り返しのルールを表示用の文字列に変換する（繰り返さないタスクの場合は"-"）
+func recurrenceLabel(rule *model.Recurrence) string {
+	if rule == nil {
+		return "-"
+	}
+	return rule.Describe()
+}
+
+// progressLabel はサブタスクの進捗を "3/5" の形式の文字列に変換する（サブタスクがない場合は"-"）
+func progressLabel(p model.Progress) string {
+	if !p.HasSubtasks() {
+		return "-"
+	}
+	return p.String()
+}
+
+// tagsLabel はタグの一覧を表示用の文字列に変換する（タグがない場合は"-"）
+func tagsLabel(tags []string, sep string) string {
+	if len(tags) == 0 {
+		return "-"
+	}
+	return strings.Join(tags, sep)
+}
+
+// resultLabel は結果出力に使う表示名を返す
+// 短縮IDや番号で指定された場合は、解決後の完全なIDを併記する
+func resultLabel(result Result) string {
+	if result.ID == "" || result.Ref == result.ID {
+		return result.Ref
+	}
+	return fmt.Sprintf("%s (%s)", result.Ref, result.ID)
+}
+
+// shortID は短縮IDを返す（得られない場合は完全なIDを返す）
+func shortID(shortIDs map[string]string, id string) string {
+	if short, ok := shortIDs[id]; ok {
+		return short
+	}
+	return id
+}
@@ -0,0 +1,348 @@
+package render
+
+import (
+	"OTakumi/todogo/internal/domain/model"
+	"OTakumi/todogo/internal/domain/service"
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTableRenderer_TaskList(t *testing.T) {
+	t.Run("番号と短縮IDの表を出力する", func(t *testing.T) {
+		var buf bytes.Buffer
+		err := newTestRenderer(t, Table).TaskList(&buf, TaskList{
+			Tasks:     []*model.Task{openTask, doneTask},
+			ShortIDs:  map[string]string{"id-1": "i1", "id-2": "i2"},
+			NextAfter: "id-2",
+		})
+		require.NoError(t, err)
+
+		out := buf.String()
+		assert.Contains(t, out, "1  i1   Write docs          1/1       work.docs")
+		assert.Contains(t, out, "2025-01-31  high      13.3     docs work  Open")
+		assert.Contains(t, out, "-         0.0      -          Done")
+		assert.Contains(t, out, "2  i2   Review, then merge")
+		assert.Contains(t, out, "Total: 2 task(s)")
+		assert.Contains(t, out, "use --after i2")
+	})
+
+	t.Run("ツリー表示ではサブタスクを字下げする", func(t *testing.T) {
+		var buf bytes.Buffer
+		err := newTestRenderer(t, Table).TaskList(&buf, TaskList{
+			Tasks:    []*model.Task{openTask, doneTask},
+			ShortIDs: map[string]string{"id-1": "i1", "id-2": "i2"},
+			Depths:   map[string]int{"id-1": 0, "id-2": 1},
+		})
+		require.NoError(t, err)
+
+		out := buf.String()
+		assert.Contains(t, out, "1  i1   Write docs            1/1")
+		assert.Contains(t, out, "2  i2     Review, then merge  -")
+	})
+
+	t.Run("状態ごとにまとめる場合は見出しを付けて番号を通しで振る", func(t *testing.T) {
+		var buf bytes.Buffer
+		err := newTestRenderer(t, Table).TaskList(&buf, TaskList{
+			Tasks:         []*model.Task{openTask, doneTask},
+			ShortIDs:      map[string]string{"id-1": "i1", "id-2": "i2"},
+			GroupByStatus: true,
+		})
+		require.NoError(t, err)
+
+		out := buf.String()
+		assert.True(t, strings.HasPrefix(out, "Open (1)\n#  ID"), out)
+		assert.Contains(t, out, "+09:00\n\nDone (1)\n#  ID")
+		assert.Contains(t, out, "\n1  i1   Write docs")
+		assert.Contains(t, out, "\n2  i2   Review, then merge")
+		assert.Contains(t, out, "Total: 2 task(s)")
+	})
+
+	t.Run("タスクがない場合はその旨を表示する", func(t *testing.T) {
+		var buf bytes.Buffer
+		require.NoError(t, newTestRenderer(t, Table).TaskList(&buf, TaskList{}))
+
+		assert.Equal(t, "No tasks found.\n", buf.String())
+	})
+}
+
+func TestTableRenderer_Tasks(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, newTestRenderer(t, Table).Tasks(&buf, []*model.Task{openTask, doneTask}))
+
+	out := buf.String()
+	assert.Contains(t, out, "ID:       id-1\n")
+	assert.Contains(t, out, "Project:  work.docs\nParent:   -\nSubtasks: 1/1\n")
+	assert.Contains(t, out, "Parent:   id-1\nSubtasks: -\nBlocked:  id-1\n")
+	assert.Contains(t, out, "Deadline: 2025-01-31 17:00\nStart:    2025-01-24 09:00\nWait:     -\nRepeat:   every week on Fri\n")
+	assert.Contains(t, out, "Repeat:   -\n")
+	assert.Contains(t, out, "Priority: high\nEstimate: 3pts\nUrgency:  13.3\nTags:     docs, work\n")
+	assert.Contains(t, out, "Priority: -\nEstimate: -\n")
+	assert.Contains(t, out, "Status:   Done\n")
+	assert.Contains(t, out, "\n\nID:       id-2\n")
+
+	// 説明と注記は字下げして出力し、ないタスクでは項目ごと省略すること
+	assert.Contains(t, out, "Updated:  2025-01-01T09:00:00+09:00\nClosed:   2025-01-01 11:00\nNotes:\n  ## Checklist\n\n  - tests pass\nAnnotations:\n  2025-01-01 10:00  approved by Sam\n")
+	assert.Equal(t, 1, strings.Count(out, "Notes:"))
+}
+
+func TestTableRenderer_TaskDetails(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, newTestRenderer(t, Table).TaskDetails(&buf, []TaskDetail{{
+		Task:       openTask,
+		Downstream: []service.ChainLink{{Task: doneTask, Depth: 1}, {Task: &model.Task{ID: "id-3", Title: "Release", Status: model.StatusInProgress}, Depth: 2}},
+	}}))
+
+	// 上流と下流のタスクを、距離に応じて字下げして出力すること
+	assert.Contains(t, buf.String(), "Updated:  2025-01-01T09:00:00+09:00\nClosed:   -\nTracked:  -\nUpstream: -\nDownstream:\n  id-2  Review, then merge  (Done)\n    id-3  Release  (In progress)\n")
+}
+
+func TestTableRenderer_TaskDetails_Tracked(t *testing.T) {
+	ended := now.Add(-2 * time.Hour)
+	var buf bytes.Buffer
+	require.NoError(t, newTestRenderer(t, Table).TaskDetails(&buf, []TaskDetail{{
+		Task: openTask,
+		TimeEntries: []model.TimeEntry{
+			{ID: 1, StartedAt: now.Add(-3 * time.Hour), EndedAt: &ended},
+			{ID: 2, StartedAt: now.Add(-90 * time.Minute)},
+		},
+	}}))
+
+	// 計測中の記録は現在時刻までを合計し、計測を開始した日時を併記すること
+	assert.Contains(t, buf.String(), "\nTracked:  2h30m (timer running since 2025-01-24 15:30)\n")
+}
+
+func TestTableRenderer_Timesheet(t *testing.T) {
+	from := time.Date(2025, 1, 20, 0, 0, 0, 0, jst)
+	sheet := model.Timesheet{
+		User: "alice", From: from, To: from.AddDate(0, 0, 7),
+		Tasks: []model.TimesheetTask{
+			{TaskID: "id-1", Title: "Write docs", Project: "work.docs", Duration: 150 * time.Minute},
+			{TaskID: "id-3", Title: "Mow the lawn", Duration: 45 * time.Minute},
+		},
+		Projects: []model.TimesheetProject{
+			{Name: "work", Duration: 150 * time.Minute},
+			{Name: "work.docs", Duration: 150 * time.Minute},
+			{Name: "", Duration: 45 * time.Minute},
+		},
+		Total: 195 * time.Minute,
+	}
+
+	t.Run("タスクごとの表とプロジェクトごとの表を出力する", func(t *testing.T) {
+		var buf bytes.Buffer
+		require.NoError(t, newTestRenderer(t, Table).Timesheet(&buf, Timesheet{Sheet: sheet, ShortIDs: map[string]string{"id-1": "i1"}}))
+
+		assert.Equal(t, "Timesheet for alice: 2025-01-20 - 2025-01-26\n\n"+
+			"ID    Title         Project    Time   Hours\n"+
+			"--    -----         -------    ----   -----\n"+
+			"i1    Write docs    work.docs  2h30m  2.50\n"+
+			"id-3  Mow the lawn  -          45m    0.75\n"+
+			"\n"+
+			"Project       Time   Hours\n"+
+			"-------       ----   -----\n"+
+			"work          2h30m  2.50\n"+
+			"  docs        2h30m  2.50\n"+
+			"(no project)  45m    0.75\n"+
+			"\nTotal: 3h15m (3.25 hours)\n", buf.String())
+	})
+
+	t.Run("作業時間がない場合はその旨を表示する", func(t *testing.T) {
+		var buf bytes.Buffer
+		require.NoError(t, newTestRenderer(t, Table).Timesheet(&buf, Timesheet{Sheet: model.Timesheet{User: "alice", From: sheet.From, To: sheet.To}}))
+
+		assert.Equal(t, "Timesheet for alice: 2025-01-20 - 2025-01-26\n\nNo time tracked.\n", buf.String())
+	})
+}
+
+func TestTableRenderer_History(t *testing.T) {
+	t.Run("日時、操作した利用者、操作の内容を記録した順に出力する", func(t *testing.T) {
+		var buf bytes.Buffer
+		require.NoError(t, newTestRenderer(t, Table).History(&buf, historyEvents))
+
+		assert.Equal(t, "When              Actor  Change\n"+
+			"----              -----  ------\n"+
+			"2025-01-01 09:00  alice  created \"Write docs\"\n"+
+			"2025-01-01 10:00  alice  deadline: - -> 2025-01-31 17:00\n"+
+			"2025-01-01 10:00  -      description changed\n"+
+			"2025-01-01 11:00  bob    completed (status: open -> done)\n"+
+			"2025-01-01 12:00  bob    deleted \"Write docs\"\n"+
+			"2025-01-01 13:00  bob    restored \"Write docs\"\n"+
+			"2025-01-01 14:00  -      purged \"Write docs\"\n", buf.String())
+	})
+
+	t.Run("履歴がない場合はその旨を表示する", func(t *testing.T) {
+		var buf bytes.Buffer
+		require.NoError(t, newTestRenderer(t, Table).History(&buf, nil))
+
+		assert.Equal(t, "No history recorded.\n", buf.String())
+	})
+}
+
+func TestTableRenderer_Trash(t *testing.T) {
+	t.Run("短縮IDとゴミ箱に移した日時の表を番号なしで出力する", func(t *testing.T) {
+		// Arrange
+		deletedAt := closedAt.Add(time.Hour)
+		trashed := *doneTask
+		trashed.DeletedAt = &deletedAt
+
+		// Act
+		var buf bytes.Buffer
+		err := newTestRenderer(t, Table).Trash(&buf, Trash{Tasks: []*model.Task{&trashed}, ShortIDs: map[string]string{"id-2": "i2"}})
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, "ID  Title               Project  Tags  Status  Deleted\n"+
+			"--  -----               -------  ----  ------  -------\n"+
+			"i2  Review, then merge  -        -     Done    2025-01-01 12:00\n"+
+			"\nTotal: 1 task(s) in the trash\n", buf.String())
+	})
+
+	t.Run("ゴミ箱が空の場合はその旨を表示する", func(t *testing.T) {
+		var buf bytes.Buffer
+		require.NoError(t, newTestRenderer(t, Table).Trash(&buf, Trash{}))
+
+		assert.Equal(t, "Trash is empty.\n", buf.String())
+	})
+}
+
+func TestTableRenderer_EstimateReport(t *testing.T) {
+	completed := time.Date(2025, 1, 24, 0, 0, 0, 0, time.UTC)
+
+	t.Run("タスクごとの表と、全体とタグごとの精度の表を出力する", func(t *testing.T) {
+		report := model.EstimateReport{
+			Tasks: []model.EstimatedTask{
+				{TaskID: "id-1", Title: "Write docs", Tags: []string{"docs"}, Estimate: model.Estimate{Duration: 2 * time.Hour},
+					Actual: 3 * time.Hour, Source: model.ActualTracked, CompletedAt: completed, Ratio: 1.5},
+				{TaskID: "id-2", Title: "Review", Estimate: model.Estimate{Points: 2},
+					Actual: 4 * time.Hour, Source: model.ActualLeadTime, CompletedAt: completed, Ratio: 1},
+			},
+			Tags: []model.EstimateAccuracy{
+				{Tag: "docs", Kind: model.EstimateTime, Tasks: 1, Estimated: 2 * time.Hour, Actual: 3 * time.Hour, Ratio: 1.5},
+			},
+			Overall: []model.EstimateAccuracy{
+				{Kind: model.EstimateTime, Tasks: 1, Estimated: 2 * time.Hour, Actual: 3 * time.Hour, Ratio: 1.5},
+				{Kind: model.EstimatePoints, Tasks: 1, Points: 2, Actual: 4 * time.Hour, PerPoint: 2 * time.Hour, Ratio: 1},
+			},
+		}
+
+		var buf bytes.Buffer
+		require.NoError(t, newTestRenderer(t, Table).EstimateReport(&buf, EstimateReport{Report: report, ShortIDs: map[string]string{"id-1": "i1"}}))
+
+		assert.Equal(t, ""+
+			"ID    Title       Completed   Estimate  Actual  Source     Ratio\n"+
+			"--    -----       ---------   --------  ------  ------     -----\n"+
+			"i1    Write docs  2025-01-24  2h        3h0m    tracked    1.50x\n"+
+			"id-2  Review      2025-01-24  2pts      4h0m    lead time  1.00x\n"+
+			"\n"+
+			"Tag    Kind    Tasks  Estimate  Actual  Per point  Ratio\n"+
+			"---    ----    -----  --------  ------  ---------  -----\n"+
+			"(all)  time    1      2h        3h0m    -          1.50x\n"+
+			"(all)  points  1      2pts      4h0m    2h0m       1.00x\n"+
+			"docs   time    1      2h        3h0m    -          1.50x\n"+
+			"\nRatio is actual / estimate (above 1.00 means it took longer than estimated).\n", buf.String())
+	})
+
+	t.Run("見積もりのある完了したタスクがない場合はその旨を表示する", func(t *testing.T) {
+		var buf bytes.Buffer
+		require.NoError(t, newTestRenderer(t, Table).EstimateReport(&buf, EstimateReport{}))
+
+		assert.Equal(t, "No completed tasks with estimates.\n", buf.String())
+	})
+}
+
+func TestFormatDuration(t *testing.T) {
+	assert.Equal(t, "0m", FormatDuration(59*time.Second))
+	assert.Equal(t, "45m", FormatDuration(45*time.Minute))
+	assert.Equal(t, "2h0m", FormatDuration(2*time.Hour))
+	assert.Equal(t, "26h5m", FormatDuration(26*time.Hour+5*time.Minute+30*time.Second))
+}
+
+func TestTableRenderer_TagCounts(t *testing.T) {
+	t.Run("タグごとの件数の表を出力する", func(t *testing.T) {
+		var buf bytes.Buffer
+		require.NoError(t, newTestRenderer(t, Table).TagCounts(&buf, []model.TagCount{{Name: "home", Open: 0, Total: 1}, {Name: "work", Open: 1, Total: 2}}))
+
+		assert.Equal(t, "Tag   Open  Total\n---   ----  -----\nhome  0     1\nwork  1     2\n", buf.String())
+	})
+
+	t.Run("タグがない場合はその旨を表示する", func(t *testing.T) {
+		var buf bytes.Buffer
+		require.NoError(t, newTestRenderer(t, Table).TagCounts(&buf, nil))
+
+		assert.Equal(t, "No tags found.\n", buf.String())
+	})
+}
+
+func TestTableRenderer_ProjectCounts(t *testing.T) {
+	t.Run("プロジェクトを階層に応じて字下げした表を出力する", func(t *testing.T) {
+		var buf bytes.Buffer
+		require.NoError(t, newTestRenderer(t, Table).ProjectCounts(&buf, []model.ProjectCount{
+			{Name: "work", Open: 1, Closed: 3},
+			{Name: "work.backend", Open: 1, Closed: 1},
+			{Name: "work.backend.api", Closed: 1},
+		}))
+
+		assert.Equal(t, "Project    Open  Closed  Complete\n"+
+			"-------    ----  ------  --------\n"+
+			"work       1     3       75%\n"+
+			"  backend  1     1       50%\n"+
+			"    api    0     1       100%\n", buf.String())
+	})
+
+	t.Run("プロジェクトがない場合はその旨を表示する", func(t *testing.T) {
+		var buf bytes.Buffer
+		require.NoError(t, newTestRenderer(t, Table).ProjectCounts(&buf, nil))
+
+		assert.Equal(t, "No projects found.\n", buf.String())
+	})
+}
+
+func TestTableRenderer_SearchResults(t *testing.T) {
+	t.Run("一致した箇所を [ ] で囲んで強調する", func(t *testing.T) {
+		var buf bytes.Buffer
+		err := newTestRenderer(t, Table).SearchResults(&buf, SearchResults{
+			Hits:     searchHits,
+			ShortIDs: map[string]string{"id-1": "i1", "id-2": "i2"},
+		})
+		require.NoError(t, err)
+
+		assert.Equal(t, "1  i2  [Review], then merge  (Done, rank 1.23)\n"+
+			"    annotation: approved by [Sam]\n"+
+			"2  i1  Write docs  (Open, rank 0.20)\n"+
+			"    description: …the [review] of docs\n"+
+			"\nFound: 2 task(s)\n", buf.String())
+	})
+
+	t.Run("色を付ける場合は太字で強調する", func(t *testing.T) {
+		r, err := New(Table, Options{Location: jst, Color: true})
+		require.NoError(t, err)
+
+		var buf bytes.Buffer
+		require.NoError(t, r.SearchResults(&buf, SearchResults{Hits: searchHits[:1]}))
+
+		assert.Contains(t, buf.String(), "1  id-2  \x1b[1mReview\x1b[0m, then merge")
+	})
+
+	t.Run("一致するタスクがない場合はその旨を表示する", func(t *testing.T) {
+		var buf bytes.Buffer
+		require.NoError(t, newTestRenderer(t, Table).SearchResults(&buf, SearchResults{}))
+
+		assert.Equal(t, "No tasks found.\n", buf.String())
+	})
+}
+
+func TestTableRenderer_Results(t *testing.T) {
+	var buf bytes.Buffer
+	err := newTestRenderer(t, Table).Results(&buf, []Result{
+		{Ref: "1", ID: "id-1", Message: "deleted"},
+		{Ref: "missing", Err: errors.New("task not found")},
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, "1 (id-1): deleted\nmissing: error: task not found\n\n1 succeeded, 1 failed\n", buf.String())
+}
@@ -0,0 +1,306 @@
+package render
+
+import (
+	"OTakumi/todogo/internal/domain/model"
+	"OTakumi/todogo/internal/domain/service"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"text/template"
+	"time"
+	"unicode/utf8"
+)
+
+// TemplateTask はテンプレートに渡すタスクの情報
+type TemplateTask struct {
+	// Number は一覧での表示番号（他のコマンドでタスクを指定する際に使える）
+	Number  int
+	ID      string
+	ShortID string
+	Title   string
+	// Description はMarkdownで記述された説明（未設定の場合は空）
+	Description string
+	Deadline    *time.Time
+	// StartAt は着手できるようになる日時（未設定の場合はnil）
+	StartAt *time.Time
+	// WaitUntil は一覧に表示しない期限（未設定の場合はnil）
+	WaitUntil *time.Time
+	// IsComplete は状態がワークフローの終了状態かどうか
+	IsComplete bool
+	// Status はワークフロー上の状態の名前（既定のワークフローでは open, in_progress, blocked, done, cancelled のいずれか）
+	Status string
+	// Priority は none, low, medium, high, urgent のいずれか
+	Priority string
+	// Urgency は出力時点の緊急度
+	Urgency float64
+	// Tags はタグ名の一覧（名前順）
+	Tags []string
+	// Project はプロジェクト名（未設定の場合は空）
+	Project string
+	// ParentID は親タスクのID（サブタスクでない場合は空）
+	ParentID string
+	// Subtasks は配下のすべてのサブタスクの進捗（{{.Subtasks}} は "3/5" となる）
+	Subtasks model.Progress
+	// BlockedBy は先に完了する必要があるタスクのID（ID順）
+	BlockedBy []string
+	// RecurrenceID は繰り返しのルールのID（繰り返さないタスクの場合は空）
+	RecurrenceID string
+	// Recurrence はRRULE形式の繰り返しのルール（繰り返さないタスクの場合は空）
+	Recurrence string
+	// Depth は list --tree での階層の深さ（最上位は0）
+	Depth     int
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// TemplateOptions はテンプレートの実行環境の設定
+type TemplateOptions struct {
+	// Location は日時を出力するタイムゾーン（nilの場合はローカルタイムゾーン）
+	Location *time.Location
+
+	// Now は相対時刻の基準となる現在時刻を返す（nilの場合はtime.Now）
+	Now func() time.Time
+
+	// NoColor はcolor関数で色を付けないかどうか
+	NoColor bool
+
+	// Workflow は緊急度の算出と完了済みかどうかの判定に使うワークフロー（nilの場合は既定のワークフロー）
+	Workflow *model.Workflow
+}
+
+// Template はGoのテンプレートでタスクを1件ずつ出力する
+// 各タスクの出力の後には改行が付く
+type Template struct {
+	tmpl *template.Template
+	now  func() time.Time
+	wf   *model.Workflow
+}
+
+// NewTemplate はテンプレートを解析する
+func NewTemplate(text string, opts TemplateOptions) (*Template, error) {
+	if opts.Location == nil {
+		opts.Location = time.Local
+	}
+	if opts.Now == nil {
+		opts.Now = time.Now
+	}
+	if opts.Workflow == nil {
+		opts.Workflow = model.DefaultWorkflow()
+	}
+
+	tmpl, err := template.New("format").Option("missingkey=error").Funcs(templateFuncs(opts)).Parse(text)
+	if err != nil {
+		return nil, fmt.Errorf("invalid format template: %w", err)
+	}
+	return &Template{tmpl: tmpl, now: opts.Now, wf: opts.Workflow}, nil
+}
+
+// TaskList はタスクの一覧をテンプレートで出力する
+func (t *Template) TaskList(w io.Writer, list TaskList) error {
+	now := t.now()
+	for i, task := range list.Tasks {
+		data := TemplateTask{
+			Number:       i + 1,
+			ID:           task.ID,
+			ShortID:      shortID(list.ShortIDs, task.ID),
+			Title:        task.Title,
+			Description:  task.Description,
+			Deadline:     task.Deadline,
+			StartAt:      task.StartAt,
+			WaitUntil:    task.WaitUntil,
+			IsComplete:   t.wf.IsClosed(task),
+			Status:       string(task.Status),
+			Priority:     task.Priority.String(),
+			Urgency:      service.Urgency(task, t.wf, now),
+			Tags:         task.Tags,
+			Project:      task.Project,
+			ParentID:     task.ParentID,
+			Subtasks:     task.Subtasks,
+			BlockedBy:    task.BlockedBy,
+			RecurrenceID: task.RecurrenceID,
+			Recurrence:   recurrenceRule(task.Recurrence),
+			Depth:        list.Depths[task.ID],
+			CreatedAt:    task.CreatedAt,
+			UpdatedAt:    task.UpdatedAt,
+		}
+		if err := t.tmpl.Execute(w, data); err != nil {
+			return fmt.Errorf("failed to execute format template: %w", err)
+		}
+		if _, err := fmt.Fprintln(w); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ColorEnabled は出力先 w に色や装飾を付けるかどうかを判定する
+// パイプやファイルにエスケープシーケンスを書き込まないよう、端末に出力する場合のみ色を付ける
+// 端末であっても NO_COLOR（https://no-color.org/）が設定されている場合は色を付けない
+func ColorEnabled(w io.Writer) bool {
+	if _, ok := os.LookupEnv("NO_COLOR"); ok {
+		return false
+	}
+	f, ok := w.(*os.File)
+	if !ok {
+		return false
+	}
+	info, err := f.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
+
+// ansiCodes はcolor関数で指定できる色と装飾
+var ansiCodes = map[string]string{
+	"black":     "30",
+	"red":       "31",
+	"green":     "32",
+	"yellow":    "33",
+	"blue":      "34",
+	"magenta":   "35",
+	"cyan":      "36",
+	"white":     "37",
+	"gray":      "90",
+	"bold":      "1",
+	"dim":       "2",
+	"underline": "4",
+}
+
+// templateFuncs はテンプレートで使える関数
+func templateFuncs(opts TemplateOptions) template.FuncMap {
+	return template.FuncMap{
+		// date は日時を指定されたレイアウト（省略時は "2006-01-02 15:04"）で出力する
+		// 未設定の場合は空文字列となる
+		"date": func(v any, layout ...string) (string, error) {
+			t, ok, err := toTime(v)
+			if err != nil || !ok {
+				return "", err
+			}
+			l := "2006-01-02 15:04"
+			if len(layout) > 0 {
+				l = layout[0]
+			}
+			return t.In(opts.Location).Format(l), nil
+		},
+
+		// relative は現在時刻からの相対時間を "in 3d" や "2h ago" の形式で出力する
+		"relative": func(v any) (string, error) {
+			t, ok, err := toTime(v)
+			if err != nil || !ok {
+				return "", err
+			}
+			return relativeTime(t, opts.Now()), nil
+		},
+
+		// due は締切までの時間を "in 3d" や "2d overdue" の形式で出力する
+		// 締切が未設定の場合は空文字列となる
+		"due": func(v any) (string, error) {
+			t, ok, err := toTime(v)
+			if err != nil || !ok {
+				return "", err
+			}
+			now := opts.Now()
+			if t.Before(now) {
+				return shortDuration(now.Sub(t)) + " overdue", nil
+			}
+			return "in " + shortDuration(t.Sub(now)), nil
+		},
+
+		// color は文字列に色や装飾を付ける（例: {{color "red" .Title}}）
+		"color": func(name string, s any) (string, error) {
+			code, ok := ansiCodes[strings.ToLower(name)]
+			if !ok {
+				return "", fmt.Errorf("unknown color %q", name)
+			}
+			text := fmt.Sprint(s)
+			if opts.NoColor {
+				return text, nil
+			}
+			return "\x1b[" + code + "m" + text + "\x1b[0m", nil
+		},
+
+		// truncate は文字列を指定の文字数に切り詰め、切り詰めた場合は末尾を "…" にする
+		"truncate": func(n int, s any) string {
+			text := fmt.Sprint(s)
+			if n <= 0 || utf8.RuneCountInString(text) <= n {
+				return text
+			}
+			runes := []rune(text)
+			return string(runes[:n-1]) + "…"
+		},
+
+		// join は文字列の一覧を区切り文字で連結する（例: {{join ", " .Tags}}）
+		"join": func(sep string, list []string) string {
+			return strings.Join(list, sep)
+		},
+
+		// pad は文字列の右側を空白で埋めて指定の文字数にする
+		"pad": func(n int, s any) string {
+			text := fmt.Sprint(s)
+			if count := utf8.RuneCountInString(text); count < n {
+				return text + strings.Repeat(" ", n-count)
+			}
+			return text
+		},
+	}
+}
+
+// toTime はテンプレートに渡された日時を取り出す
+// nilのポインタの場合はok=falseを返す
+func toTime(v any) (t time.Time, ok bool, err error) {
+	switch tv := v.(type) {
+	case time.Time:
+		return tv, true, nil
+	case *time.Time:
+		if tv == nil {
+			return time.Time{}, false, nil
+		}
+		return *tv, true, nil
+	case nil:
+		return time.Time{}, false, nil
+	default:
+		return time.Time{}, false, fmt.Errorf("expected a time, got %T", v)
+	}
+}
+
+// relativeTime は基準時刻からの相対時間を出力する
+func relativeTime(t, now time.Time) string {
+	d := t.Sub(now)
+	switch {
+	case d > -time.Minute && d < time.Minute:
+		return "now"
+	case d > 0:
+		return "in " + shortDuration(d)
+	default:
+		return shortDuration(-d) + " ago"
+	}
+}
+
+// shortDuration は期間を最も大きい単位1つで出力する（例: 45m, 3h, 2d, 5w, 1y）
+func shortDuration(d time.Duration) string {
+	const (
+		day  = 24 * time.Hour
+		week = 7 * day
+		year = 365 * day
+	)
+
+	switch {
+	case d < time.Hour:
+		return fmt.Sprintf("%dm", int(d/time.Minute))
+	case d < day:
+		return fmt.Sprintf("%dh", int(d/time.Hour))
+	case d < 2*week:
+		return fmt.Sprintf("%dd", int(d/day))
+	case d < year:
+		return fmt.Sprintf("%dw", int(d/week))
+	default:
+		return fmt.Sprintf("%dy", int(d/year))
+	}
+}
+
+// recurrenceRule は繰り返しのルールをRRULE形式の文字列に変換する（繰り返さないタスクの場合は空）
+func recurrenceRule(rule *model.Recurrence) string {
+	if rule == nil {
+		return ""
+	}
+	return rule.String()
+}
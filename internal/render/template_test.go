@@ -0,0 +1,170 @@
+package render
+
+import (
+	"OTakumi/todogo/internal/domain/model"
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTemplate_TaskList(t *testing.T) {
+	now := time.Date(2025, 1, 29, 8, 0, 0, 0, time.UTC)
+	overdue := now.Add(-50 * time.Hour)
+	japanese := &model.Task{ID: "id-3", Title: "ユニットテストを書く", Status: model.StatusOpen, Deadline: &overdue, CreatedAt: created, UpdatedAt: created}
+	list := TaskList{
+		Tasks:    []*model.Task{openTask, doneTask, japanese},
+		ShortIDs: map[string]string{"id-1": "i1", "id-2": "i2", "id-3": "i3"},
+		Depths:   map[string]int{"id-2": 1},
+	}
+	opts := TemplateOptions{Location: jst, Now: func() time.Time { return now }, NoColor: true}
+
+	tests := []struct {
+		name string
+		text string
+		opts TemplateOptions
+		want string
+	}{
+		{
+			name: "フィールドを出力し、各タスクの後に改行する",
+			text: "{{.Number}} {{.ShortID}} {{.Status}}",
+			opts: opts,
+			want: "1 i1 open\n2 i2 done\n3 i3 open\n",
+		},
+		{
+			name: "締切までの相対時間を出力する",
+			text: "{{.ShortID}}:{{due .Deadline}}",
+			opts: opts,
+			want: "i1:in 2d\ni2:\ni3:2d overdue\n",
+		},
+		{
+			name: "日時を設定されたタイムゾーンで出力する",
+			text: `{{date .Deadline}}|{{date .CreatedAt "01/02"}}|{{relative .CreatedAt}}`,
+			opts: opts,
+			want: "2025-01-31 17:00|01/01|4w ago\n|01/01|4w ago\n2025-01-27 15:00|01/01|4w ago\n",
+		},
+		{
+			name: "優先度と出力時点の緊急度を出力する",
+			text: `{{.Priority}} {{printf "%.1f" .Urgency}}`,
+			opts: opts,
+			want: "high 16.8\nnone 0.0\nnone 16.2\n",
+		},
+		{
+			name: "タグを区切り文字で連結し、プロジェクトを出力する",
+			text: `{{.ShortID}}[{{join "," .Tags}}]{{.Project}}`,
+			opts: opts,
+			want: "i1[docs,work]work.docs\ni2[]\ni3[]\n",
+		},
+		{
+			name: "親タスク、サブタスクの進捗と階層の深さ、先行するタスクを出力する",
+			text: `{{.ShortID}} {{.ParentID}} {{.Subtasks}} {{.Depth}} [{{join "," .BlockedBy}}]`,
+			opts: opts,
+			want: "i1  1/1 0 []\ni2 id-1 0/0 1 [id-1]\ni3  0/0 0 []\n",
+		},
+		{
+			name: "繰り返しのルールを出力する",
+			text: `{{.ShortID}} {{.RecurrenceID}} {{.Recurrence}}`,
+			opts: opts,
+			want: "i1 rec-1 FREQ=WEEKLY;BYDAY=FR\ni2  \ni3  \n",
+		},
+		{
+			name: "説明を出力する",
+			text: `{{.ShortID}} {{printf "%q" .Description}}`,
+			opts: opts,
+			want: "i1 \"\"\ni2 \"## Checklist\\n\\n- tests pass\"\ni3 \"\"\n",
+		},
+		{
+			name: "文字数で切り詰めて埋める",
+			text: "[{{.Title | truncate 8 | pad 9}}]",
+			opts: opts,
+			want: "[Write d… ]\n[Review,… ]\n[ユニットテスト… ]\n",
+		},
+		{
+			name: "色を付ける",
+			text: `{{color "red" .ShortID}}`,
+			opts: TemplateOptions{Location: jst, Now: opts.Now},
+			want: "\x1b[31mi1\x1b[0m\n\x1b[31mi2\x1b[0m\n\x1b[31mi3\x1b[0m\n",
+		},
+		{
+			name: "NoColorの場合は色を付けない",
+			text: `{{color "red" .ShortID}}`,
+			opts: opts,
+			want: "i1\ni2\ni3\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpl, err := NewTemplate(tt.text, tt.opts)
+			require.NoError(t, err)
+
+			var buf bytes.Buffer
+			require.NoError(t, tmpl.TaskList(&buf, list))
+			assert.Equal(t, tt.want, buf.String())
+		})
+	}
+}
+
+func TestTemplate_Errors(t *testing.T) {
+	t.Run("構文エラーは解析時に報告する", func(t *testing.T) {
+		_, err := NewTemplate("{{.Title", TemplateOptions{})
+		assert.ErrorContains(t, err, "invalid format template")
+	})
+
+	t.Run("存在しないフィールドや未知の色は実行時に報告する", func(t *testing.T) {
+		for _, text := range []string{"{{.Assignee}}", `{{color "rainbow" .Title}}`} {
+			tmpl, err := NewTemplate(text, TemplateOptions{})
+			require.NoError(t, err)
+
+			var buf bytes.Buffer
+			assert.Error(t, tmpl.TaskList(&buf, TaskList{Tasks: []*model.Task{openTask}}))
+		}
+	})
+}
+
+func TestShortDuration(t *testing.T) {
+	tests := []struct {
+		d    time.Duration
+		want string
+	}{
+		{d: 45 * time.Minute, want: "45m"},
+		{d: 3 * time.Hour, want: "3h"},
+		{d: 13 * 24 * time.Hour, want: "13d"},
+		{d: 15 * 24 * time.Hour, want: "2w"},
+		{d: 400 * 24 * time.Hour, want: "1y"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.want, func(t *testing.T) {
+			assert.Equal(t, tt.want, shortDuration(tt.d))
+		})
+	}
+}
+
+func TestColorEnabled(t *testing.T) {
+	t.Run("端末でない出力先には色を付けない", func(t *testing.T) {
+		// Arrange
+		var buf bytes.Buffer
+		r, err := New(Table, Options{Location: jst, Now: func() time.Time { return now }, Color: ColorEnabled(&buf)})
+		require.NoError(t, err)
+
+		// Act
+		require.NoError(t, r.SearchResults(&buf, SearchResults{Hits: searchHits}))
+
+		// Assert
+		assert.NotContains(t, buf.String(), "\x1b[")
+		assert.Contains(t, buf.String(), "[Review]")
+	})
+
+	t.Run("ファイルへの出力には色を付けない", func(t *testing.T) {
+		f, err := os.Create(filepath.Join(t.TempDir(), "out.txt"))
+		require.NoError(t, err)
+		defer func() { _ = f.Close() }()
+
+		assert.False(t, ColorEnabled(f))
+	})
+}
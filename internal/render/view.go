@@ -0,0 +1,592 @@
+package render
+
+import (
+	"OTakumi/todogo/internal/domain/model"
+	"OTakumi/todogo/internal/domain/service"
+	"math"
+	"time"
+)
+
+// SchemaVersion は構造化された出力のスキーマのバージョン
+// フィールドの追加は同じバージョンのまま行い、削除や意味の変更を行う場合にのみ上げる
+// 2: status をワークフロー上の状態の名前とし、終了状態かどうかを closed で表す
+const SchemaVersion = 2
+
+// TaskView は構造化された形式で出力するタスク
+// 日時はRFC 3339形式で、設定されたタイムゾーンのオフセット付きで出力する
+type TaskView struct {
+	ID       string  `json:"id" yaml:"id"`
+	Title    string  `json:"title" yaml:"title"`
+	Deadline *string `json:"deadline" yaml:"deadline"`
+	// Status はワークフロー上の状態の名前（既定のワークフローでは open, in_progress, blocked, done, cancelled のいずれか）
+	Status    string `json:"status" yaml:"status"`
+	CreatedAt string `json:"created_at" yaml:"created_at"`
+	UpdatedAt string `json:"updated_at" yaml:"updated_at"`
+
+	// Priority は none, low, medium, high, urgent のいずれか
+	Priority string `json:"priority" yaml:"priority"`
+	// Urgency は出力時点の緊急度（小数点以下2桁に丸める）
+	Urgency float64 `json:"urgency" yaml:"urgency"`
+	// Tags はタグ名の一覧（名前順、タグがない場合は空の配列）
+	Tags []string `json:"tags" yaml:"tags"`
+	// Project はプロジェクト名（未設定の場合はnull）
+	Project *string `json:"project" yaml:"project"`
+	// ParentID は親タスクのID（サブタスクでない場合はnull）
+	ParentID *string `json:"parent_id" yaml:"parent_id"`
+	// Subtasks は配下のすべてのサブタスクの進捗（サブタスクがない場合はnull）
+	Subtasks *ProgressView `json:"subtasks" yaml:"subtasks"`
+	// BlockedBy は先に完了する必要があるタスクのID（ID順、ない場合は空の配列）
+	BlockedBy []string `json:"blocked_by" yaml:"blocked_by"`
+	// Recurrence は繰り返しのルール（繰り返さないタスクの場合はnull）
+	Recurrence *RecurrenceView `json:"recurrence" yaml:"recurrence"`
+	// Description はMarkdownで記述された説明（未設定の場合は空文字列）
+	Description string `json:"description" yaml:"description"`
+	// Annotations は日時付きの注記（追加順、ない場合は空の配列）
+	Annotations []AnnotationView `json:"annotations" yaml:"annotations"`
+	// StartAt は着手できるようになる日時（未設定の場合はnull）
+	StartAt *string `json:"start_at" yaml:"start_at"`
+	// WaitUntil は一覧に表示しない期限（未設定の場合はnull）
+	WaitUntil *string `json:"wait_until" yaml:"wait_until"`
+	// Estimate は見積もり（未設定の場合はnull）
+	Estimate *EstimateView `json:"estimate" yaml:"estimate"`
+	// Closed は状態がワークフローの終了状態（完了済みとして扱う状態）かどうか
+	Closed bool `json:"closed" yaml:"closed"`
+	// CompletedAt は終了状態にした日時（終了状態でない場合はnull）
+	CompletedAt *string `json:"completed_at" yaml:"completed_at"`
+	// DeletedAt はゴミ箱に移した日時（ゴミ箱のタスクでない場合はnull）
+	DeletedAt *string `json:"deleted_at" yaml:"deleted_at"`
+}
+
+// EstimateView は構造化された形式で出力する見積もり
+type EstimateView struct {
+	// Kind は time, points のいずれか
+	Kind string `json:"kind" yaml:"kind"`
+	// Seconds は作業時間による見積もり（秒単位、ポイントで見積もった場合は0）
+	Seconds int64 `json:"seconds" yaml:"seconds"`
+	// Points はストーリーポイントによる見積もり（作業時間で見積もった場合は0）
+	Points float64 `json:"points" yaml:"points"`
+}
+
+// AnnotationView は構造化された形式で出力する注記
+type AnnotationView struct {
+	CreatedAt string `json:"created_at" yaml:"created_at"`
+	Text      string `json:"text" yaml:"text"`
+}
+
+// RecurrenceView は構造化された形式で出力する繰り返しのルール
+type RecurrenceView struct {
+	// ID は同じ繰り返しのタスクが共通して参照するルールのID
+	ID string `json:"id" yaml:"id"`
+	// Rule はRRULE形式のルール（"FREQ=WEEKLY;BYDAY=MO,TH" など）
+	Rule string `json:"rule" yaml:"rule"`
+	// Description は "every week on Mon, Thu" のような表示用の文
+	Description string `json:"description" yaml:"description"`
+}
+
+// TaskDetailView は構造化された形式で出力する、依存関係の連鎖を含むタスクの詳細
+type TaskDetailView struct {
+	TaskView `yaml:",inline"`
+	// Upstream は先に完了する必要があるタスク（依存関係を推移的にたどったもの、ない場合は空の配列）
+	Upstream []ChainLinkView `json:"upstream" yaml:"upstream"`
+	// Downstream は完了を待っているタスク（依存関係を推移的にたどったもの、ない場合は空の配列）
+	Downstream []ChainLinkView `json:"downstream" yaml:"downstream"`
+	// TrackedSeconds は記録された作業時間の合計（秒単位、計測中の記録は出力時点までを含める）
+	TrackedSeconds int64 `json:"tracked_seconds" yaml:"tracked_seconds"`
+	// TimerStartedAt は計測中の記録の開始日時（計測中でない場合はnull）
+	TimerStartedAt *string `json:"timer_started_at" yaml:"timer_started_at"`
+}
+
+// ChainLinkView は構造化された形式で出力する、依存関係の連鎖に含まれるタスク
+type ChainLinkView struct {
+	ID     string `json:"id" yaml:"id"`
+	Title  string `json:"title" yaml:"title"`
+	Status string `json:"status" yaml:"status"`
+	// Depth は詳細を出力するタスクからの距離（直接の依存関係は1）
+	Depth int `json:"depth" yaml:"depth"`
+}
+
+// SearchHitView は構造化された形式で出力する検索結果
+type SearchHitView struct {
+	TaskView `yaml:",inline"`
+	// Rank は関連度（小数点以下4桁に丸める、値の尺度はストレージによって異なる）
+	Rank float64 `json:"rank" yaml:"rank"`
+	// Matches は一致した項目ごとの抜粋（タイトル、説明、注記の順）
+	Matches []SearchMatchView `json:"matches" yaml:"matches"`
+}
+
+// SearchMatchView は構造化された形式で出力する、検索語に一致した項目の抜粋
+type SearchMatchView struct {
+	// Field は title, description, annotation のいずれか
+	Field   string `json:"field" yaml:"field"`
+	Snippet string `json:"snippet" yaml:"snippet"`
+	// Highlights は Snippet のうち検索語に一致した範囲（UTF-8のバイト単位のオフセット）
+	Highlights []HighlightView `json:"highlights" yaml:"highlights"`
+}
+
+// HighlightView は構造化された形式で出力する、検索語に一致した範囲（End は含まない）
+type HighlightView struct {
+	Start int `json:"start" yaml:"start"`
+	End   int `json:"end" yaml:"end"`
+}
+
+// ProgressView は構造化された形式で出力するサブタスクの進捗
+type ProgressView struct {
+	Done  int `json:"done" yaml:"done"`
+	Total int `json:"total" yaml:"total"`
+}
+
+// TagCountView は構造化された形式で出力するタグごとのタスクの件数
+type TagCountView struct {
+	Name  string `json:"name" yaml:"name"`
+	Open  int    `json:"open" yaml:"open"`
+	Total int    `json:"total" yaml:"total"`
+}
+
+// ProjectCountView は構造化された形式で出力するプロジェクトごとのタスクの件数
+// 件数には配下のプロジェクトに属するタスクも含む
+type ProjectCountView struct {
+	Name   string `json:"name" yaml:"name"`
+	Open   int    `json:"open" yaml:"open"`
+	Closed int    `json:"closed" yaml:"closed"`
+	Total  int    `json:"total" yaml:"total"`
+	// Completion は完了済みのタスクの割合（百分率、小数点以下1桁に丸める）
+	Completion float64 `json:"completion" yaml:"completion"`
+}
+
+// TimesheetView は構造化された形式で出力する作業時間の集計結果
+// 期間は From 以上 To 未満を表す
+type TimesheetView struct {
+	User         string                 `json:"user" yaml:"user"`
+	From         string                 `json:"from" yaml:"from"`
+	To           string                 `json:"to" yaml:"to"`
+	TotalSeconds int64                  `json:"total_seconds" yaml:"total_seconds"`
+	Tasks        []TimesheetTaskView    `json:"tasks" yaml:"tasks"`
+	Projects     []TimesheetProjectView `json:"projects" yaml:"projects"`
+}
+
+// TimesheetTaskView は構造化された形式で出力するタスクごとの作業時間
+type TimesheetTaskView struct {
+	ID    string `json:"id" yaml:"id"`
+	Title string `json:"title" yaml:"title"`
+	// Project はプロジェクト名（未設定の場合はnull）
+	Project *string `json:"project" yaml:"project"`
+	Seconds int64   `json:"seconds" yaml:"seconds"`
+	// Hours は時間単位の作業時間（小数点以下2桁に丸める）
+	Hours float64 `json:"hours" yaml:"hours"`
+}
+
+// TimesheetProjectView は構造化された形式で出力するプロジェクトごとの作業時間
+// 作業時間には配下のプロジェクトの時間も含む
+type TimesheetProjectView struct {
+	// Name はプロジェクト名（プロジェクトに属さないタスクの合計の場合はnull）
+	Name    *string `json:"name" yaml:"name"`
+	Seconds int64   `json:"seconds" yaml:"seconds"`
+	// Hours は時間単位の作業時間（小数点以下2桁に丸める）
+	Hours float64 `json:"hours" yaml:"hours"`
+}
+
+// EstimateReportView は構造化された形式で出力する見積もりと実績の比較
+type EstimateReportView struct {
+	// Tasks は見積もりのある完了したタスク（完了の新しい順）
+	Tasks []EstimatedTaskView `json:"tasks" yaml:"tasks"`
+	// Tags はタグと見積もりの種類ごとの精度（タグの名前順）
+	Tags []EstimateAccuracyView `json:"tags" yaml:"tags"`
+	// Overall は見積もりの種類ごとの全体の精度
+	Overall []EstimateAccuracyView `json:"overall" yaml:"overall"`
+}
+
+// EstimatedTaskView は構造化された形式で出力する、1件のタスクの見積もりと実績
+type EstimatedTaskView struct {
+	ID       string       `json:"id" yaml:"id"`
+	Title    string       `json:"title" yaml:"title"`
+	Tags     []string     `json:"tags" yaml:"tags"`
+	Estimate EstimateView `json:"estimate" yaml:"estimate"`
+	// ActualSeconds は実績の時間（秒単位）
+	ActualSeconds int64 `json:"actual_seconds" yaml:"actual_seconds"`
+	// Source は実績の求め方（記録された作業時間の場合は tracked、作成から完了までの時間の場合は lead_time）
+	Source      string `json:"source" yaml:"source"`
+	CompletedAt string `json:"completed_at" yaml:"completed_at"`
+	// Ratio は見積もりに対する実績の比（小数点以下2桁に丸める、求められない場合は0）
+	Ratio float64 `json:"ratio" yaml:"ratio"`
+}
+
+// EstimateAccuracyView は構造化された形式で出力する見積もりの精度
+type EstimateAccuracyView struct {
+	// Tag はタグの名前（全体の精度の場合はnull）
+	Tag   *string `json:"tag" yaml:"tag"`
+	Kind  string  `json:"kind" yaml:"kind"`
+	Tasks int     `json:"tasks" yaml:"tasks"`
+	// EstimatedSeconds と Points は見積もりの合計（Kind に応じていずれか一方のみが0以外となる）
+	EstimatedSeconds int64   `json:"estimated_seconds" yaml:"estimated_seconds"`
+	Points           float64 `json:"points" yaml:"points"`
+	ActualSeconds    int64   `json:"actual_seconds" yaml:"actual_seconds"`
+	// PerPointSeconds は1ポイントあたりの実績の時間（秒単位、作業時間の見積もりの場合は0）
+	PerPointSeconds int64 `json:"per_point_seconds" yaml:"per_point_seconds"`
+	// Ratio は見積もりの合計に対する実績の合計の比（小数点以下2桁に丸める、求められない場合は0）
+	Ratio float64 `json:"ratio" yaml:"ratio"`
+}
+
+// TaskEventView は構造化された形式で出力するタスクの履歴の1件
+type TaskEventView struct {
+	ID     int64  `json:"id" yaml:"id"`
+	TaskID string `json:"task_id" yaml:"task_id"`
+	// Kind は created, changed, completed, deleted のいずれか
+	Kind string `json:"kind" yaml:"kind"`
+	// Field は変更した項目の名前（作成と削除の場合は空文字列）
+	Field string `json:"field" yaml:"field"`
+	// OldValue と NewValue は変更前と変更後の値（日時はUTCのRFC 3339形式、タグは空白区切り、未設定の場合は空文字列）
+	// 作成の場合は new_value に、削除の場合は old_value にタイトルを設定する
+	OldValue string `json:"old_value" yaml:"old_value"`
+	NewValue string `json:"new_value" yaml:"new_value"`
+	// Actor は操作した利用者の名前（不明な場合は空文字列）
+	Actor      string `json:"actor" yaml:"actor"`
+	OccurredAt string `json:"occurred_at" yaml:"occurred_at"`
+}
+
+// ResultView は構造化された形式で出力する操作の結果
+type ResultView struct {
+	Ref     string `json:"ref" yaml:"ref"`
+	ID      string `json:"id,omitempty" yaml:"id,omitempty"`
+	OK      bool   `json:"ok" yaml:"ok"`
+	Message string `json:"message,omitempty" yaml:"message,omitempty"`
+	Error   string `json:"error,omitempty" yaml:"error,omitempty"`
+}
+
+// taskDetailDocument はJSON/YAMLで出力するタスクの詳細の一覧
+type taskDetailDocument struct {
+	SchemaVersion int              `json:"schema_version" yaml:"schema_version"`
+	Tasks         []TaskDetailView `json:"tasks" yaml:"tasks"`
+}
+
+// taskDocument はJSON/YAMLで出力するタスクの一覧
+type taskDocument struct {
+	SchemaVersion int        `json:"schema_version" yaml:"schema_version"`
+	Tasks         []TaskView `json:"tasks" yaml:"tasks"`
+	NextAfter     string     `json:"next_after,omitempty" yaml:"next_after,omitempty"`
+}
+
+// searchDocument はJSON/YAMLで出力する検索結果の一覧
+type searchDocument struct {
+	SchemaVersion int             `json:"schema_version" yaml:"schema_version"`
+	Hits          []SearchHitView `json:"hits" yaml:"hits"`
+}
+
+// projectDocument はJSON/YAMLで出力するプロジェクトの一覧
+type projectDocument struct {
+	SchemaVersion int                `json:"schema_version" yaml:"schema_version"`
+	Projects      []ProjectCountView `json:"projects" yaml:"projects"`
+}
+
+// resultDocument はJSON/YAMLで出力する操作の結果の一覧
+type resultDocument struct {
+	SchemaVersion int          `json:"schema_version" yaml:"schema_version"`
+	Results       []ResultView `json:"results" yaml:"results"`
+}
+
+// timesheetDocument はJSON/YAMLで出力する作業時間の集計結果
+type timesheetDocument struct {
+	SchemaVersion int `json:"schema_version" yaml:"schema_version"`
+	TimesheetView `yaml:",inline"`
+}
+
+// estimateDocument はJSON/YAMLで出力する見積もりと実績の比較
+type estimateDocument struct {
+	SchemaVersion      int `json:"schema_version" yaml:"schema_version"`
+	EstimateReportView `yaml:",inline"`
+}
+
+// historyDocument はJSON/YAMLで出力するタスクの履歴
+type historyDocument struct {
+	SchemaVersion int             `json:"schema_version" yaml:"schema_version"`
+	Events        []TaskEventView `json:"events" yaml:"events"`
+}
+
+// tagDocument はJSON/YAMLで出力するタグの一覧
+type tagDocument struct {
+	SchemaVersion int            `json:"schema_version" yaml:"schema_version"`
+	Tags          []TagCountView `json:"tags" yaml:"tags"`
+}
+
+// NewTaskView はタスクを出力用の形式に変換する
+// 緊急度はnowの時点の値を算出する
+func NewTaskView(task *model.Task, wf *model.Workflow, loc *time.Location, now time.Time) TaskView {
+	view := TaskView{
+		ID:        task.ID,
+		Title:     task.Title,
+		Status:    string(task.Status),
+		CreatedAt: formatTime(task.CreatedAt, loc),
+		UpdatedAt: formatTime(task.UpdatedAt, loc),
+		Priority:  task.Priority.String(),
+		Urgency:   math.Round(service.Urgency(task, wf, now)*100) / 100,
+		Tags:      append([]string{}, task.Tags...),
+		BlockedBy: append([]string{}, task.BlockedBy...),
+		Closed:    wf.IsClosed(task),
+		// 説明と注記は、未設定の場合も空文字列と空の配列として出力する
+		Description: task.Description,
+		Annotations: make([]AnnotationView, 0, len(task.Annotations)),
+	}
+	for _, a := range task.Annotations {
+		view.Annotations = append(view.Annotations, AnnotationView{CreatedAt: formatTime(a.CreatedAt, loc), Text: a.Text})
+	}
+	if task.Deadline != nil {
+		deadline := formatTime(*task.Deadline, loc)
+		view.Deadline = &deadline
+	}
+	if task.StartAt != nil {
+		startAt := formatTime(*task.StartAt, loc)
+		view.StartAt = &startAt
+	}
+	if task.WaitUntil != nil {
+		waitUntil := formatTime(*task.WaitUntil, loc)
+		view.WaitUntil = &waitUntil
+	}
+	if task.CompletedAt != nil {
+		completedAt := formatTime(*task.CompletedAt, loc)
+		view.CompletedAt = &completedAt
+	}
+	if task.DeletedAt != nil {
+		deletedAt := formatTime(*task.DeletedAt, loc)
+		view.DeletedAt = &deletedAt
+	}
+	if task.Estimate != nil {
+		estimate := newEstimateView(*task.Estimate)
+		view.Estimate = &estimate
+	}
+	if task.Project != "" {
+		project := task.Project
+		view.Project = &project
+	}
+	if task.ParentID != "" {
+		parentID := task.ParentID
+		view.ParentID = &parentID
+	}
+	if task.Subtasks.HasSubtasks() {
+		view.Subtasks = &ProgressView{Done: task.Subtasks.Done, Total: task.Subtasks.Total}
+	}
+	if task.Recurrence != nil {
+		view.Recurrence = &RecurrenceView{
+			ID:          task.RecurrenceID,
+			Rule:        task.Recurrence.String(),
+			Description: task.Recurrence.Describe(),
+		}
+	}
+	return view
+}
+
+// NewTaskDetailView はタスクの詳細を、依存関係の連鎖と併せて出力用の形式に変換する
+func NewTaskDetailView(detail TaskDetail, wf *model.Workflow, loc *time.Location, now time.Time) TaskDetailView {
+	view := TaskDetailView{
+		TaskView:       NewTaskView(detail.Task, wf, loc, now),
+		Upstream:       chainLinkViews(detail.Upstream),
+		Downstream:     chainLinkViews(detail.Downstream),
+		TrackedSeconds: seconds(model.TotalDuration(detail.TimeEntries, now)),
+	}
+	if running := runningEntry(detail.TimeEntries); running != nil {
+		startedAt := formatTime(running.StartedAt, loc)
+		view.TimerStartedAt = &startedAt
+	}
+	return view
+}
+
+// NewTimesheetView は作業時間の集計結果を出力用の形式に変換する
+func NewTimesheetView(sheet model.Timesheet, loc *time.Location) TimesheetView {
+	view := TimesheetView{
+		User:         sheet.User,
+		From:         formatTime(sheet.From, loc),
+		To:           formatTime(sheet.To, loc),
+		TotalSeconds: seconds(sheet.Total),
+		Tasks:        make([]TimesheetTaskView, 0, len(sheet.Tasks)),
+		Projects:     make([]TimesheetProjectView, 0, len(sheet.Projects)),
+	}
+	for _, t := range sheet.Tasks {
+		task := TimesheetTaskView{ID: t.TaskID, Title: t.Title, Seconds: seconds(t.Duration), Hours: hours(t.Duration)}
+		if t.Project != "" {
+			project := t.Project
+			task.Project = &project
+		}
+		view.Tasks = append(view.Tasks, task)
+	}
+	for _, p := range sheet.Projects {
+		project := TimesheetProjectView{Seconds: seconds(p.Duration), Hours: hours(p.Duration)}
+		if p.Name != "" {
+			name := p.Name
+			project.Name = &name
+		}
+		view.Projects = append(view.Projects, project)
+	}
+	return view
+}
+
+// NewEstimateReportView は見積もりと実績の比較を出力用の形式に変換する
+func NewEstimateReportView(report model.EstimateReport, loc *time.Location) EstimateReportView {
+	view := EstimateReportView{
+		Tasks:   make([]EstimatedTaskView, 0, len(report.Tasks)),
+		Tags:    estimateAccuracyViews(report.Tags),
+		Overall: estimateAccuracyViews(report.Overall),
+	}
+	for _, t := range report.Tasks {
+		view.Tasks = append(view.Tasks, EstimatedTaskView{
+			ID:            t.TaskID,
+			Title:         t.Title,
+			Tags:          append([]string{}, t.Tags...),
+			Estimate:      newEstimateView(t.Estimate),
+			ActualSeconds: seconds(t.Actual),
+			Source:        string(t.Source),
+			CompletedAt:   formatTime(t.CompletedAt, loc),
+			Ratio:         math.Round(t.Ratio*100) / 100,
+		})
+	}
+	return view
+}
+
+func estimateAccuracyViews(accs []model.EstimateAccuracy) []EstimateAccuracyView {
+	views := make([]EstimateAccuracyView, 0, len(accs))
+	for _, acc := range accs {
+		view := EstimateAccuracyView{
+			Kind:             string(acc.Kind),
+			Tasks:            acc.Tasks,
+			EstimatedSeconds: seconds(acc.Estimated),
+			Points:           acc.Points,
+			ActualSeconds:    seconds(acc.Actual),
+			PerPointSeconds:  seconds(acc.PerPoint),
+			Ratio:            math.Round(acc.Ratio*100) / 100,
+		}
+		if acc.Tag != "" {
+			tag := acc.Tag
+			view.Tag = &tag
+		}
+		views = append(views, view)
+	}
+	return views
+}
+
+func newEstimateView(e model.Estimate) EstimateView {
+	return EstimateView{Kind: string(e.Kind()), Seconds: seconds(e.Duration), Points: e.Points}
+}
+
+// runningEntry は計測中の記録を返す（計測中の記録がない場合はnil）
+func runningEntry(entries []model.TimeEntry) *model.TimeEntry {
+	for i := range entries {
+		if entries[i].Running() {
+			return &entries[i]
+		}
+	}
+	return nil
+}
+
+func seconds(d time.Duration) int64 {
+	return int64(d / time.Second)
+}
+
+func hours(d time.Duration) float64 {
+	return math.Round(d.Hours()*100) / 100
+}
+
+// NewResultView は操作の結果を出力用の形式に変換する
+func NewResultView(result Result) ResultView {
+	view := ResultView{Ref: result.Ref, ID: result.ID, OK: result.Err == nil, Message: result.Message}
+	if result.Err != nil {
+		view.Error = result.Err.Error()
+	}
+	return view
+}
+
+func taskViews(tasks []*model.Task, wf *model.Workflow, loc *time.Location, now time.Time) []TaskView {
+	views := make([]TaskView, 0, len(tasks))
+	for _, task := range tasks {
+		views = append(views, NewTaskView(task, wf, loc, now))
+	}
+	return views
+}
+
+func taskDetailViews(details []TaskDetail, wf *model.Workflow, loc *time.Location, now time.Time) []TaskDetailView {
+	views := make([]TaskDetailView, 0, len(details))
+	for _, detail := range details {
+		views = append(views, NewTaskDetailView(detail, wf, loc, now))
+	}
+	return views
+}
+
+// NewSearchHitView は検索結果を出力用の形式に変換する
+func NewSearchHitView(hit model.SearchHit, wf *model.Workflow, loc *time.Location, now time.Time) SearchHitView {
+	view := SearchHitView{
+		TaskView: NewTaskView(hit.Task, wf, loc, now),
+		Rank:     math.Round(hit.Rank*10000) / 10000,
+		Matches:  make([]SearchMatchView, 0, len(hit.Matches)),
+	}
+	for _, m := range hit.Matches {
+		highlights := make([]HighlightView, 0, len(m.Highlights))
+		for _, h := range m.Highlights {
+			highlights = append(highlights, HighlightView{Start: h.Start, End: h.End})
+		}
+		view.Matches = append(view.Matches, SearchMatchView{Field: string(m.Field), Snippet: m.Snippet, Highlights: highlights})
+	}
+	return view
+}
+
+func searchHitViews(hits []model.SearchHit, wf *model.Workflow, loc *time.Location, now time.Time) []SearchHitView {
+	views := make([]SearchHitView, 0, len(hits))
+	for _, hit := range hits {
+		views = append(views, NewSearchHitView(hit, wf, loc, now))
+	}
+	return views
+}
+
+func chainLinkViews(links []service.ChainLink) []ChainLinkView {
+	views := make([]ChainLinkView, 0, len(links))
+	for _, link := range links {
+		views = append(views, ChainLinkView{ID: link.Task.ID, Title: link.Task.Title, Status: string(link.Task.Status), Depth: link.Depth})
+	}
+	return views
+}
+
+func tagCountViews(counts []model.TagCount) []TagCountView {
+	views := make([]TagCountView, 0, len(counts))
+	for _, c := range counts {
+		views = append(views, TagCountView{Name: c.Name, Open: c.Open, Total: c.Total})
+	}
+	return views
+}
+
+func projectCountViews(counts []model.ProjectCount) []ProjectCountView {
+	views := make([]ProjectCountView, 0, len(counts))
+	for _, c := range counts {
+		views = append(views, ProjectCountView{
+			Name:       c.Name,
+			Open:       c.Open,
+			Closed:     c.Closed,
+			Total:      c.Total(),
+			Completion: math.Round(c.Completion()*10) / 10,
+		})
+	}
+	return views
+}
+
+func taskEventViews(events []model.TaskEvent, loc *time.Location) []TaskEventView {
+	views := make([]TaskEventView, 0, len(events))
+	for _, e := range events {
+		views = append(views, TaskEventView{
+			ID:         e.ID,
+			TaskID:     e.TaskID,
+			Kind:       string(e.Kind),
+			Field:      e.Field,
+			OldValue:   e.OldValue,
+			NewValue:   e.NewValue,
+			Actor:      e.Actor,
+			OccurredAt: formatTime(e.OccurredAt, loc),
+		})
+	}
+	return views
+}
+
+func resultViews(results []Result) []ResultView {
+	views := make([]ResultView, 0, len(results))
+	for _, result := range results {
+		views = append(views, NewResultView(result))
+	}
+	return views
+}
+
+func formatTime(t time.Time, loc *time.Location) string {
+	return t.In(loc).Format(time.RFC3339)
+}
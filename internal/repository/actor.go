@@ -0,0 +1,18 @@
+package repository
+
+import "context"
+
+// actorKey はコンテキストに操作した利用者の名前を保持するためのキー
+type actorKey struct{}
+
+// WithActor は操作した利用者の名前を保持したコンテキストを返す
+// リポジトリはタスクの履歴（TaskEvent）に、コンテキストが保持する利用者を記録する
+func WithActor(ctx context.Context, actor string) context.Context {
+	return context.WithValue(ctx, actorKey{}, actor)
+}
+
+// ActorFrom はコンテキストが保持する、操作した利用者の名前を返す（保持していない場合は空）
+func ActorFrom(ctx context.Context) string {
+	actor, _ := ctx.Value(actorKey{}).(string)
+	return actor
+}
@@ -0,0 +1,29 @@
+package repository
+
+import "context"
+
+// MaxOperationBatches は取り消しのために保持する、コマンドの単位の操作のまとまりの最大数
+// 超えた場合は古いまとまりから削除する
+const MaxOperationBatches = 100
+
+// batchKey はコンテキストに操作のまとまりを保持するためのキー
+type batchKey struct{}
+
+// batch は同じコマンドで行う操作のまとまりのIDと、コマンドの内容
+type batch struct {
+	id      string
+	command string
+}
+
+// WithBatch は操作のまとまりを保持したコンテキストを返す
+// リポジトリは保持している場合のみ、Create、Update、Delete の操作を取り消しのために記録する
+func WithBatch(ctx context.Context, id, command string) context.Context {
+	return context.WithValue(ctx, batchKey{}, batch{id: id, command: command})
+}
+
+// BatchFrom はコンテキストが保持する操作のまとまりのIDとコマンドを返す
+// 保持していない場合、ok はfalseとなる
+func BatchFrom(ctx context.Context) (id, command string, ok bool) {
+	b, ok := ctx.Value(batchKey{}).(batch)
+	return b.id, b.command, ok
+}
@@ -0,0 +1,76 @@
+package repository
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrTaskNotFound は指定されたタスクが存在しないことを表すエラー
+// errors.Is で判定できるよう、TaskNotFoundError はこのエラーとして扱われる
+var ErrTaskNotFound = errors.New("task not found")
+
+// TaskNotFoundError は見つからなかったタスクのIDを保持するエラー型
+type TaskNotFoundError struct {
+	ID string
+}
+
+func (e *TaskNotFoundError) Error() string {
+	return fmt.Sprintf("task not found: %s", e.ID)
+}
+
+// Is は errors.Is(err, ErrTaskNotFound) を成立させるための実装
+func (e *TaskNotFoundError) Is(target error) bool {
+	return target == ErrTaskNotFound
+}
+
+// ErrDependencyNotFound は指定された依存関係が存在しないことを表すエラー
+var ErrDependencyNotFound = errors.New("dependency not found")
+
+// DependencyNotFoundError は見つからなかった依存関係のタスクのIDを保持するエラー型
+type DependencyNotFoundError struct {
+	TaskID      string
+	BlockedByID string
+}
+
+func (e *DependencyNotFoundError) Error() string {
+	return fmt.Sprintf("task %s is not blocked by %s", e.TaskID, e.BlockedByID)
+}
+
+// Is は errors.Is(err, ErrDependencyNotFound) を成立させるための実装
+func (e *DependencyNotFoundError) Is(target error) bool {
+	return target == ErrDependencyNotFound
+}
+
+// ErrRecurrenceNotFound は指定された繰り返しのルールが存在しないことを表すエラー
+var ErrRecurrenceNotFound = errors.New("recurrence not found")
+
+// RecurrenceNotFoundError は見つからなかった繰り返しのルールのIDを保持するエラー型
+type RecurrenceNotFoundError struct {
+	ID string
+}
+
+func (e *RecurrenceNotFoundError) Error() string {
+	return fmt.Sprintf("recurrence not found: %s", e.ID)
+}
+
+// Is は errors.Is(err, ErrRecurrenceNotFound) を成立させるための実装
+func (e *RecurrenceNotFoundError) Is(target error) bool {
+	return target == ErrRecurrenceNotFound
+}
+
+// ErrTimeEntryNotFound は指定された計測中の作業時間の記録が存在しないことを表すエラー
+var ErrTimeEntryNotFound = errors.New("time entry not found")
+
+// TimeEntryNotFoundError は見つからなかった作業時間の記録のIDを保持するエラー型
+type TimeEntryNotFoundError struct {
+	ID int64
+}
+
+func (e *TimeEntryNotFoundError) Error() string {
+	return fmt.Sprintf("running time entry not found: %d", e.ID)
+}
+
+// Is は errors.Is(err, ErrTimeEntryNotFound) を成立させるための実装
+func (e *TimeEntryNotFoundError) Is(target error) bool {
+	return target == ErrTimeEntryNotFound
+}
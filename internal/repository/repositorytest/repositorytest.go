@@ -0,0 +1,1949 @@
+// Package repositorytest はrepository.TaskRepositoryの実装が満たすべき振る舞いを検証するテストスイートを提供する
+// 新しいバックエンドを追加する場合は、そのテストからRunを呼び出すこと
+package repositorytest
+
+import (
+	"OTakumi/todogo/internal/domain/model"
+	"OTakumi/todogo/internal/repository"
+	"context"
+	"errors"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// timePrecision は保存した時刻と読み出した時刻の許容誤差
+// PostgreSQLは時刻をマイクロ秒単位で保存するため、完全一致は求めない
+const timePrecision = time.Millisecond
+
+// Factory は空のリポジトリを生成する関数
+// サブテストごとに呼び出されるため、毎回データが存在しない状態のリポジトリを返すこと
+// リポジトリのワークフローは既定のワークフロー（model.DefaultWorkflow）とすること
+type Factory func(t *testing.T) repository.TaskRepository
+
+// Run はリポジトリの実装に対して共通のテストを実行する
+func Run(t *testing.T, newRepo Factory) {
+	t.Run("Create", func(t *testing.T) { testCreate(t, newRepo) })
+	t.Run("FindByID", func(t *testing.T) { testFindByID(t, newRepo) })
+	t.Run("Update", func(t *testing.T) { testUpdate(t, newRepo) })
+	t.Run("Delete", func(t *testing.T) { testDelete(t, newRepo) })
+	t.Run("FindAll", func(t *testing.T) { testFindAll(t, newRepo) })
+	t.Run("Tags", func(t *testing.T) { testTags(t, newRepo) })
+	t.Run("Projects", func(t *testing.T) { testProjects(t, newRepo) })
+	t.Run("Estimates", func(t *testing.T) { testEstimates(t, newRepo) })
+	t.Run("Subtasks", func(t *testing.T) { testSubtasks(t, newRepo) })
+	t.Run("Dependencies", func(t *testing.T) { testDependencies(t, newRepo) })
+	t.Run("Recurrences", func(t *testing.T) { testRecurrences(t, newRepo) })
+	t.Run("Annotations", func(t *testing.T) { testAnnotations(t, newRepo) })
+	t.Run("Search", func(t *testing.T) { testSearch(t, newRepo) })
+	t.Run("Waiting", func(t *testing.T) { testWaiting(t, newRepo) })
+	t.Run("Status", func(t *testing.T) { testStatus(t, newRepo) })
+	t.Run("TimeEntries", func(t *testing.T) { testTimeEntries(t, newRepo) })
+	t.Run("History", func(t *testing.T) { testHistory(t, newRepo) })
+	t.Run("Operations", func(t *testing.T) { testOperations(t, newRepo) })
+	t.Run("Trash", func(t *testing.T) { testTrash(t, newRepo) })
+}
+
+func testCreate(t *testing.T, newRepo Factory) {
+	ctx := context.Background()
+
+	t.Run("IDとタイムスタンプを設定して保存する", func(t *testing.T) {
+		repo := newRepo(t)
+		deadline := future(24 * time.Hour)
+		before := time.Now()
+
+		created, err := repo.Create(ctx, &model.Task{Title: "Write report", Deadline: &deadline, Priority: model.PriorityHigh})
+		require.NoError(t, err)
+
+		_, err = uuid.Parse(created.ID)
+		assert.NoError(t, err)
+		assert.Equal(t, "Write report", created.Title)
+		assert.Equal(t, model.StatusOpen, created.Status)
+		assert.Equal(t, model.PriorityHigh, created.Priority)
+		assert.WithinDuration(t, deadline, *created.Deadline, timePrecision)
+		assert.WithinDuration(t, before, created.CreatedAt, time.Minute)
+		assert.False(t, created.CreatedAt.Before(before.Add(-timePrecision)))
+		assert.WithinDuration(t, created.CreatedAt, created.UpdatedAt, timePrecision)
+
+		found, err := repo.FindByID(ctx, created.ID)
+		require.NoError(t, err)
+		assertSameTask(t, created, found)
+	})
+
+	t.Run("指定されたIDで保存する", func(t *testing.T) {
+		repo := newRepo(t)
+		id := uuid.New().String()
+
+		created, err := repo.Create(ctx, &model.Task{ID: id, Title: "Buy milk"})
+		require.NoError(t, err)
+		assert.Equal(t, id, created.ID)
+		assert.Nil(t, created.Deadline)
+	})
+
+	t.Run("引数のタスクを変更しない", func(t *testing.T) {
+		repo := newRepo(t)
+		task := &model.Task{Title: "Buy milk"}
+
+		_, err := repo.Create(ctx, task)
+		require.NoError(t, err)
+		assert.Empty(t, task.ID)
+		assert.True(t, task.CreatedAt.IsZero())
+	})
+
+	t.Run("不正なタスクは保存しない", func(t *testing.T) {
+		repo := newRepo(t)
+		past := time.Now().Add(-time.Hour)
+
+		tests := []struct {
+			name string
+			task *model.Task
+		}{
+			{name: "タイトルが空", task: &model.Task{}},
+			{name: "締切が過去", task: &model.Task{Title: "Late", Deadline: &past}},
+			{name: "IDがUUID形式でない", task: &model.Task{ID: "not-a-uuid", Title: "Bad ID"}},
+			{name: "優先度が範囲外", task: &model.Task{Title: "Bad priority", Priority: model.Priority(9)}},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				_, err := repo.Create(ctx, tt.task)
+				assert.Error(t, err)
+			})
+		}
+
+		tasks, err := repo.FindAll(ctx, repository.TaskQuery{})
+		require.NoError(t, err)
+		assert.Empty(t, tasks)
+	})
+
+	t.Run("IDが重複する場合はエラーを返す", func(t *testing.T) {
+		repo := newRepo(t)
+		id := uuid.New().String()
+
+		_, err := repo.Create(ctx, &model.Task{ID: id, Title: "First"})
+		require.NoError(t, err)
+
+		_, err = repo.Create(ctx, &model.Task{ID: id, Title: "Second"})
+		assert.Error(t, err)
+
+		found, err := repo.FindByID(ctx, id)
+		require.NoError(t, err)
+		assert.Equal(t, "First", found.Title)
+	})
+}
+
+func testFindByID(t *testing.T, newRepo Factory) {
+	ctx := context.Background()
+
+	t.Run("存在しない場合はNotFoundエラーを返す", func(t *testing.T) {
+		repo := newRepo(t)
+		id := uuid.New().String()
+
+		task, err := repo.FindByID(ctx, id)
+		assert.Nil(t, task)
+		assertNotFound(t, err, id)
+	})
+
+	t.Run("返されたタスクを変更しても保存内容は変わらない", func(t *testing.T) {
+		repo := newRepo(t)
+		created := mustCreate(t, repo, "Original", nil)
+
+		found, err := repo.FindByID(ctx, created.ID)
+		require.NoError(t, err)
+		found.Title = "Changed"
+
+		found, err = repo.FindByID(ctx, created.ID)
+		require.NoError(t, err)
+		assert.Equal(t, "Original", found.Title)
+	})
+}
+
+func testUpdate(t *testing.T, newRepo Factory) {
+	ctx := context.Background()
+
+	t.Run("内容を更新し作成日時を維持する", func(t *testing.T) {
+		repo := newRepo(t)
+		created := mustCreate(t, repo, "Original", nil)
+		deadline := future(48 * time.Hour)
+
+		// 更新日時が作成日時より後になるよう、時刻を進める
+		time.Sleep(2 * timePrecision)
+
+		change := *created
+		change.Title = "Renamed"
+		change.Description = "## Steps\n\n- first\n- second"
+		change.Deadline = &deadline
+		change.Status = model.StatusDone
+		change.Priority = model.PriorityUrgent
+		change.CreatedAt = time.Time{}
+
+		updated, err := repo.Update(ctx, &change)
+		require.NoError(t, err)
+		assert.Equal(t, "Renamed", updated.Title)
+		assert.Equal(t, model.StatusDone, updated.Status)
+		assert.Equal(t, model.PriorityUrgent, updated.Priority)
+		assert.WithinDuration(t, created.CreatedAt, updated.CreatedAt, timePrecision)
+		assert.True(t, updated.UpdatedAt.After(created.UpdatedAt))
+
+		found, err := repo.FindByID(ctx, created.ID)
+		require.NoError(t, err)
+		assertSameTask(t, updated, found)
+	})
+
+	t.Run("締切を削除できる", func(t *testing.T) {
+		repo := newRepo(t)
+		deadline := future(24 * time.Hour)
+		created := mustCreate(t, repo, "With deadline", &deadline)
+
+		change := *created
+		change.Deadline = nil
+		_, err := repo.Update(ctx, &change)
+		require.NoError(t, err)
+
+		found, err := repo.FindByID(ctx, created.ID)
+		require.NoError(t, err)
+		assert.Nil(t, found.Deadline)
+	})
+
+	t.Run("存在しない場合はNotFoundエラーを返す", func(t *testing.T) {
+		repo := newRepo(t)
+		id := uuid.New().String()
+
+		task, err := repo.Update(ctx, &model.Task{ID: id, Title: "Missing"})
+		assert.Nil(t, task)
+		assertNotFound(t, err, id)
+	})
+
+	t.Run("不正な内容の場合は更新しない", func(t *testing.T) {
+		repo := newRepo(t)
+		created := mustCreate(t, repo, "Original", nil)
+		past := time.Now().Add(-time.Hour)
+
+		for _, change := range []model.Task{
+			{ID: created.ID, Title: ""},
+			{ID: created.ID, Title: "Late", Deadline: &past},
+			{ID: created.ID, Title: "Bad priority", Priority: model.Priority(-1)},
+		} {
+			_, err := repo.Update(ctx, &change)
+			assert.Error(t, err)
+		}
+
+		found, err := repo.FindByID(ctx, created.ID)
+		require.NoError(t, err)
+		assertSameTask(t, created, found)
+	})
+
+	t.Run("複数のタスクを順に更新し、操作を同じまとまりとして記録する", func(t *testing.T) {
+		repo := newRepo(t)
+		parent := mustCreate(t, repo, "Plan the move", nil)
+		child := mustCreateTask(t, repo, &model.Task{Title: "Call the vendor", ParentID: parent.ID})
+
+		childChange, parentChange := *child, *parent
+		childChange.Status = model.StatusDone
+		parentChange.Status = model.StatusDone
+		updated, err := repo.UpdateAll(repository.WithBatch(ctx, "b1", "done --cascade 1"), []*model.Task{&childChange, &parentChange})
+		require.NoError(t, err)
+		require.Len(t, updated, 2)
+		assert.Equal(t, []string{child.ID, parent.ID}, ids(updated))
+		assert.NotNil(t, updated[1].CompletedAt)
+
+		ops, err := repo.Operations(ctx, false)
+		require.NoError(t, err)
+		require.Len(t, ops, 2)
+		for _, op := range ops {
+			assert.Equal(t, "b1", op.BatchID)
+		}
+	})
+
+	t.Run("いずれかのタスクを更新できない場合はいずれも更新しない", func(t *testing.T) {
+		repo := newRepo(t)
+		first := mustCreate(t, repo, "First", nil)
+		blocked := mustCreateTask(t, repo, &model.Task{Title: "Blocked", Status: model.StatusBlocked})
+
+		firstChange, blockedChange := *first, *blocked
+		firstChange.Status = model.StatusDone
+		// 既定のワークフローでは待機中から完了には変更できない
+		blockedChange.Status = model.StatusDone
+		_, err := repo.UpdateAll(ctx, []*model.Task{&firstChange, &blockedChange})
+		assert.ErrorIs(t, err, model.ErrIllegalTransition)
+
+		found, err := repo.FindByID(ctx, first.ID)
+		require.NoError(t, err)
+		assertSameTask(t, first, found)
+		events, err := repo.TaskEvents(ctx, first.ID)
+		require.NoError(t, err)
+		assert.Len(t, events, 1)
+	})
+}
+
+func testDelete(t *testing.T, newRepo Factory) {
+	ctx := context.Background()
+
+	t.Run("削除したタスクは取得できない", func(t *testing.T) {
+		repo := newRepo(t)
+		created := mustCreate(t, repo, "Delete me", nil)
+		kept := mustCreate(t, repo, "Keep me", nil)
+
+		require.NoError(t, repo.Delete(ctx, created.ID))
+
+		_, err := repo.FindByID(ctx, created.ID)
+		assertNotFound(t, err, created.ID)
+
+		tasks, err := repo.FindAll(ctx, repository.TaskQuery{})
+		require.NoError(t, err)
+		assert.Equal(t, []string{kept.ID}, ids(tasks))
+	})
+
+	t.Run("存在しない場合はNotFoundエラーを返す", func(t *testing.T) {
+		repo := newRepo(t)
+		id := uuid.New().String()
+
+		assertNotFound(t, repo.Delete(ctx, id), id)
+	})
+}
+
+func testFindAll(t *testing.T, newRepo Factory) {
+	ctx := context.Background()
+
+	t.Run("タスクがない場合は空を返す", func(t *testing.T) {
+		repo := newRepo(t)
+
+		tasks, err := repo.FindAll(ctx, repository.TaskQuery{})
+		require.NoError(t, err)
+		assert.Empty(t, tasks)
+	})
+
+	t.Run("不正な条件の場合はエラーを返す", func(t *testing.T) {
+		repo := newRepo(t)
+
+		_, err := repo.FindAll(ctx, repository.TaskQuery{SortBy: "size"})
+		assert.Error(t, err)
+	})
+
+	t.Run("緊急度による並び替えは受け付けない", func(t *testing.T) {
+		repo := newRepo(t)
+
+		_, err := repo.FindAll(ctx, repository.TaskQuery{SortBy: repository.SortByUrgency})
+		assert.Error(t, err)
+	})
+
+	// 作成順: report, milk, book, review
+	// 優先度: report（高）, book（低）, それ以外はなし
+	repo := newRepo(t)
+	soon, later := future(24*time.Hour), future(72*time.Hour)
+	report := mustCreateTask(t, repo, &model.Task{Title: "Write report", Deadline: &later, Priority: model.PriorityHigh})
+	milk := mustCreate(t, repo, "buy Milk", &soon)
+	book := mustCreateTask(t, repo, &model.Task{Title: "Read book", Priority: model.PriorityLow})
+	review := mustCreate(t, repo, "Review 50%_off PR", nil)
+
+	done := *milk
+	done.Status = model.StatusDone
+	_, err := repo.Update(ctx, &done)
+	require.NoError(t, err)
+
+	// 締切のないタスク同士、優先度のないタスク同士の順序はIDで決まる
+	noDeadline := sortedByID(book, review)
+	noPriority := sortedByID(milk, review)
+
+	tests := []struct {
+		name  string
+		query repository.TaskQuery
+		want  []*model.Task
+	}{
+		{
+			name:  "既定では作成日時の昇順",
+			query: repository.TaskQuery{},
+			want:  []*model.Task{report, milk, book, review},
+		},
+		{
+			name:  "作成日時の降順",
+			query: repository.TaskQuery{SortDesc: true},
+			want:  []*model.Task{review, book, milk, report},
+		},
+		{
+			name:  "更新日時の降順",
+			query: repository.TaskQuery{SortBy: repository.SortByUpdatedAt, SortDesc: true},
+			want:  []*model.Task{milk, review, book, report},
+		},
+		{
+			name:  "締切の昇順では締切のないタスクが最後",
+			query: repository.TaskQuery{SortBy: repository.SortByDeadline},
+			want:  []*model.Task{milk, report, noDeadline[0], noDeadline[1]},
+		},
+		{
+			name:  "締切の降順では締切のないタスクが最初",
+			query: repository.TaskQuery{SortBy: repository.SortByDeadline, SortDesc: true},
+			want:  []*model.Task{noDeadline[1], noDeadline[0], report, milk},
+		},
+		{
+			name:  "タイトルは大文字小文字を区別せずに並べる",
+			query: repository.TaskQuery{SortBy: repository.SortByTitle},
+			want:  []*model.Task{milk, book, review, report},
+		},
+		{
+			name:  "優先度の昇順",
+			query: repository.TaskQuery{SortBy: repository.SortByPriority},
+			want:  []*model.Task{noPriority[0], noPriority[1], book, report},
+		},
+		{
+			name:  "優先度の降順",
+			query: repository.TaskQuery{SortBy: repository.SortByPriority, SortDesc: true},
+			want:  []*model.Task{report, book, noPriority[1], noPriority[0]},
+		},
+		{
+			name:  "優先度の降順でカーソル以降のタスクを取得する",
+			query: repository.TaskQuery{SortBy: repository.SortByPriority, SortDesc: true, AfterID: book.ID},
+			want:  []*model.Task{noPriority[1], noPriority[0]},
+		},
+		{
+			name:  "未完了のタスクに絞り込む",
+			query: repository.TaskQuery{Statuses: []model.Status{model.StatusOpen}},
+			want:  []*model.Task{report, book, review},
+		},
+		{
+			name:  "完了済みのタスクに絞り込む",
+			query: repository.TaskQuery{Statuses: []model.Status{model.StatusDone}},
+			want:  []*model.Task{milk},
+		},
+		{
+			name:  "締切より前に絞り込むと締切のないタスクは除外される",
+			query: repository.TaskQuery{DeadlineBefore: &later},
+			want:  []*model.Task{milk},
+		},
+		{
+			name:  "締切以降に絞り込む",
+			query: repository.TaskQuery{DeadlineAfter: &later},
+			want:  []*model.Task{report},
+		},
+		{
+			name:  "タイトルの部分一致は大文字小文字を区別しない",
+			query: repository.TaskQuery{TitleContains: "MILK"},
+			want:  []*model.Task{milk},
+		},
+		{
+			name:  "タイトルのワイルドカード文字はそのまま検索する",
+			query: repository.TaskQuery{TitleContains: "50%_"},
+			want:  []*model.Task{review},
+		},
+		{
+			name:  "件数を制限する",
+			query: repository.TaskQuery{Limit: 2},
+			want:  []*model.Task{report, milk},
+		},
+		{
+			name:  "カーソル以降のタスクを取得する",
+			query: repository.TaskQuery{AfterID: milk.ID, Limit: 1},
+			want:  []*model.Task{book},
+		},
+		{
+			name:  "降順でカーソル以降のタスクを取得する",
+			query: repository.TaskQuery{SortBy: repository.SortByDeadline, SortDesc: true, AfterID: report.ID},
+			want:  []*model.Task{milk},
+		},
+		{
+			name:  "締切のないタスクをカーソルにできる",
+			query: repository.TaskQuery{SortBy: repository.SortByDeadline, AfterID: noDeadline[0].ID},
+			want:  []*model.Task{noDeadline[1]},
+		},
+		{
+			name:  "存在しないカーソルの場合は何も返さない",
+			query: repository.TaskQuery{AfterID: uuid.New().String()},
+			want:  nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tasks, err := repo.FindAll(ctx, tt.query)
+			require.NoError(t, err)
+			assert.Equal(t, ids(tt.want), ids(tasks))
+		})
+	}
+}
+
+func testTags(t *testing.T, newRepo Factory) {
+	ctx := context.Background()
+
+	t.Run("タグを付けて保存し、名前順で読み出す", func(t *testing.T) {
+		repo := newRepo(t)
+
+		created := mustCreateTask(t, repo, &model.Task{Title: "Tagged", Tags: []string{"home", "work"}})
+		assert.Equal(t, []string{"home", "work"}, created.Tags)
+
+		found, err := repo.FindByID(ctx, created.ID)
+		require.NoError(t, err)
+		assertSameTask(t, created, found)
+
+		tasks, err := repo.FindAll(ctx, repository.TaskQuery{})
+		require.NoError(t, err)
+		require.Len(t, tasks, 1)
+		assert.Equal(t, []string{"home", "work"}, tasks[0].Tags)
+	})
+
+	t.Run("タグがない場合は空のスライスを返す", func(t *testing.T) {
+		repo := newRepo(t)
+		created := mustCreate(t, repo, "Untagged", nil)
+
+		found, err := repo.FindByID(ctx, created.ID)
+		require.NoError(t, err)
+		assert.NotNil(t, found.Tags)
+		assert.Empty(t, found.Tags)
+	})
+
+	t.Run("更新するとタグを置き換える", func(t *testing.T) {
+		repo := newRepo(t)
+		created := mustCreateTask(t, repo, &model.Task{Title: "Tagged", Tags: []string{"home", "work"}})
+
+		change := *created
+		change.Tags = []string{"errand", "work"}
+		_, err := repo.Update(ctx, &change)
+		require.NoError(t, err)
+
+		found, err := repo.FindByID(ctx, created.ID)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"errand", "work"}, found.Tags)
+	})
+
+	t.Run("正規化されていないタグは保存しない", func(t *testing.T) {
+		repo := newRepo(t)
+
+		_, err := repo.Create(ctx, &model.Task{Title: "Bad tag", Tags: []string{"Work"}})
+		assert.Error(t, err)
+
+		tasks, err := repo.FindAll(ctx, repository.TaskQuery{})
+		require.NoError(t, err)
+		assert.Empty(t, tasks)
+	})
+
+	t.Run("タグで絞り込む", func(t *testing.T) {
+		repo := newRepo(t)
+		work := mustCreateTask(t, repo, &model.Task{Title: "Work", Tags: []string{"work"}})
+		urgentWork := mustCreateTask(t, repo, &model.Task{Title: "Urgent work", Tags: []string{"urgent", "work"}})
+		home := mustCreateTask(t, repo, &model.Task{Title: "Home", Tags: []string{"home"}})
+		untagged := mustCreate(t, repo, "Untagged", nil)
+
+		tests := []struct {
+			name  string
+			query repository.TaskQuery
+			want  []*model.Task
+		}{
+			{
+				name:  "タグが付いたタスク",
+				query: repository.TaskQuery{Tags: [][]string{{"work"}}},
+				want:  []*model.Task{work, urgentWork},
+			},
+			{
+				name:  "グループ同士はすべてのタグを満たす",
+				query: repository.TaskQuery{Tags: [][]string{{"work"}, {"urgent"}}},
+				want:  []*model.Task{urgentWork},
+			},
+			{
+				name:  "グループ内はいずれかのタグを満たす",
+				query: repository.TaskQuery{Tags: [][]string{{"home", "urgent"}}},
+				want:  []*model.Task{urgentWork, home},
+			},
+			{
+				name:  "除外するタグが付いたタスクを除く",
+				query: repository.TaskQuery{ExcludeTags: []string{"work"}},
+				want:  []*model.Task{home, untagged},
+			},
+			{
+				name:  "付けるタグと除外するタグを組み合わせる",
+				query: repository.TaskQuery{Tags: [][]string{{"work"}}, ExcludeTags: []string{"urgent"}},
+				want:  []*model.Task{work},
+			},
+			{
+				name:  "存在しないタグ",
+				query: repository.TaskQuery{Tags: [][]string{{"missing"}}},
+				want:  nil,
+			},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				tasks, err := repo.FindAll(ctx, tt.query)
+				require.NoError(t, err)
+				assert.Equal(t, ids(tt.want), ids(tasks))
+			})
+		}
+	})
+
+	t.Run("タグごとの件数を名前順に集計する", func(t *testing.T) {
+		repo := newRepo(t)
+		mustCreateTask(t, repo, &model.Task{Title: "Open work", Tags: []string{"work"}})
+		mustCreateTask(t, repo, &model.Task{Title: "Done work", Tags: []string{"home", "work"}, Status: model.StatusDone})
+		deleted := mustCreateTask(t, repo, &model.Task{Title: "Deleted", Tags: []string{"someday"}})
+		mustCreate(t, repo, "Untagged", nil)
+		require.NoError(t, repo.Delete(ctx, deleted.ID))
+
+		counts, err := repo.TagCounts(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, []model.TagCount{
+			{Name: "home", Open: 0, Total: 1},
+			{Name: "work", Open: 1, Total: 2},
+		}, counts)
+	})
+
+	t.Run("タグがない場合は空を返す", func(t *testing.T) {
+		repo := newRepo(t)
+
+		counts, err := repo.TagCounts(ctx)
+		require.NoError(t, err)
+		assert.Empty(t, counts)
+	})
+}
+
+func testProjects(t *testing.T, newRepo Factory) {
+	ctx := context.Background()
+
+	t.Run("プロジェクトを設定して保存し、変更・解除できる", func(t *testing.T) {
+		repo := newRepo(t)
+
+		created := mustCreateTask(t, repo, &model.Task{Title: "In project", Project: "work.backend"})
+		assert.Equal(t, "work.backend", created.Project)
+
+		found, err := repo.FindByID(ctx, created.ID)
+		require.NoError(t, err)
+		assertSameTask(t, created, found)
+
+		for _, project := range []string{"home", ""} {
+			change := *found
+			change.Project = project
+			_, err = repo.Update(ctx, &change)
+			require.NoError(t, err)
+
+			found, err = repo.FindByID(ctx, created.ID)
+			require.NoError(t, err)
+			assert.Equal(t, project, found.Project)
+		}
+	})
+
+	t.Run("正規化されていないプロジェクト名は保存しない", func(t *testing.T) {
+		repo := newRepo(t)
+
+		_, err := repo.Create(ctx, &model.Task{Title: "Bad project", Project: "Work..API"})
+		assert.Error(t, err)
+	})
+
+	t.Run("配下のプロジェクトを含めて絞り込む", func(t *testing.T) {
+		repo := newRepo(t)
+		work := mustCreateTask(t, repo, &model.Task{Title: "Work", Project: "work"})
+		api := mustCreateTask(t, repo, &model.Task{Title: "API", Project: "work.backend.api"})
+		// 名前の接頭辞が一致するだけのプロジェクトや、LIKEのワイルドカードに一致するプロジェクトは含めない
+		mustCreateTask(t, repo, &model.Task{Title: "Workshop", Project: "workshop"})
+		mustCreateTask(t, repo, &model.Task{Title: "Wildcard", Project: "work_x.api"})
+		mustCreate(t, repo, "No project", nil)
+
+		tests := []struct {
+			name    string
+			project string
+			want    []*model.Task
+		}{
+			{name: "上位のプロジェクト", project: "work", want: []*model.Task{work, api}},
+			{name: "中間のプロジェクト", project: "work.backend", want: []*model.Task{api}},
+			{name: "末端のプロジェクト", project: "work.backend.api", want: []*model.Task{api}},
+			{name: "存在しないプロジェクト", project: "missing", want: nil},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				tasks, err := repo.FindAll(ctx, repository.TaskQuery{Project: tt.project})
+				require.NoError(t, err)
+				assert.Equal(t, ids(tt.want), ids(tasks))
+			})
+		}
+	})
+
+	t.Run("プロジェクトごとに直接属するタスクの件数を集計する", func(t *testing.T) {
+		repo := newRepo(t)
+		mustCreateTask(t, repo, &model.Task{Title: "Open", Project: "work"})
+		mustCreateTask(t, repo, &model.Task{Title: "Done", Project: "work", Status: model.StatusDone})
+		mustCreateTask(t, repo, &model.Task{Title: "API", Project: "work.api"})
+		moved := mustCreateTask(t, repo, &model.Task{Title: "Moved", Project: "home"})
+		mustCreate(t, repo, "No project", nil)
+
+		// タスクが属さなくなったプロジェクトは集計に含めない
+		change := *moved
+		change.Project = ""
+		_, err := repo.Update(ctx, &change)
+		require.NoError(t, err)
+
+		counts, err := repo.ProjectCounts(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, []model.ProjectCount{
+			{Name: "work", Open: 1, Closed: 1},
+			{Name: "work.api", Open: 1, Closed: 0},
+		}, counts)
+	})
+}
+
+func testEstimates(t *testing.T, newRepo Factory) {
+	ctx := context.Background()
+
+	t.Run("見積もりを保存し、種類を変更・解除できる", func(t *testing.T) {
+		repo := newRepo(t)
+
+		created := mustCreateTask(t, repo, &model.Task{Title: "Estimated", Estimate: &model.Estimate{Duration: 90 * time.Minute}})
+		found, err := repo.FindByID(ctx, created.ID)
+		require.NoError(t, err)
+		assertSameTask(t, created, found)
+
+		for _, estimate := range []*model.Estimate{{Points: 2.5}, {Duration: 3 * time.Hour}, nil} {
+			change := *found
+			change.Estimate = estimate
+			_, err = repo.Update(ctx, &change)
+			require.NoError(t, err)
+
+			found, err = repo.FindByID(ctx, created.ID)
+			require.NoError(t, err)
+			assert.Equal(t, estimate, found.Estimate)
+		}
+	})
+
+	t.Run("不正な見積もりは保存しない", func(t *testing.T) {
+		repo := newRepo(t)
+
+		_, err := repo.Create(ctx, &model.Task{Title: "Estimated", Estimate: &model.Estimate{Duration: time.Hour, Points: 1}})
+		assert.Error(t, err)
+	})
+}
+
+func testSubtasks(t *testing.T, newRepo Factory) {
+	ctx := context.Background()
+
+	t.Run("親タスクを設定して保存し、変更・解除できる", func(t *testing.T) {
+		repo := newRepo(t)
+		parent := mustCreate(t, repo, "Parent", nil)
+		other := mustCreate(t, repo, "Other", nil)
+
+		child := mustCreateTask(t, repo, &model.Task{Title: "Child", ParentID: parent.ID})
+		assert.Equal(t, parent.ID, child.ParentID)
+
+		found, err := repo.FindByID(ctx, child.ID)
+		require.NoError(t, err)
+		assertSameTask(t, child, found)
+
+		for _, parentID := range []string{other.ID, ""} {
+			change := *found
+			change.ParentID = parentID
+			_, err = repo.Update(ctx, &change)
+			require.NoError(t, err)
+
+			found, err = repo.FindByID(ctx, child.ID)
+			require.NoError(t, err)
+			assert.Equal(t, parentID, found.ParentID)
+		}
+	})
+
+	t.Run("存在しない親タスクは保存しない", func(t *testing.T) {
+		repo := newRepo(t)
+
+		_, err := repo.Create(ctx, &model.Task{Title: "Orphan", ParentID: "00000000-0000-0000-0000-000000000000"})
+		assert.Error(t, err)
+	})
+
+	t.Run("配下のすべてのサブタスクの進捗を読み込む", func(t *testing.T) {
+		repo := newRepo(t)
+		root := mustCreate(t, repo, "Root", nil)
+		child := mustCreateTask(t, repo, &model.Task{Title: "Child", ParentID: root.ID})
+		mustCreateTask(t, repo, &model.Task{Title: "Done child", ParentID: root.ID, Status: model.StatusDone})
+		mustCreateTask(t, repo, &model.Task{Title: "Grandchild", ParentID: child.ID, Status: model.StatusDone})
+		leaf := mustCreate(t, repo, "Leaf", nil)
+
+		found, err := repo.FindByID(ctx, root.ID)
+		require.NoError(t, err)
+		assert.Equal(t, model.Progress{Done: 2, Total: 3}, found.Subtasks)
+
+		// 絞り込みで除外されたサブタスクも数える
+		tasks, err := repo.FindAll(ctx, repository.TaskQuery{Statuses: []model.Status{model.StatusOpen}})
+		require.NoError(t, err)
+		progress := make(map[string]model.Progress)
+		for _, task := range tasks {
+			progress[task.ID] = task.Subtasks
+		}
+		assert.Equal(t, map[string]model.Progress{
+			root.ID:  {Done: 2, Total: 3},
+			child.ID: {Done: 1, Total: 1},
+			leaf.ID:  {},
+		}, progress)
+	})
+}
+
+func testDependencies(t *testing.T, newRepo Factory) {
+	ctx := context.Background()
+
+	t.Run("依存関係を追加して読み込み、削除できる", func(t *testing.T) {
+		repo := newRepo(t)
+		task := mustCreate(t, repo, "Deploy", nil)
+		first := mustCreate(t, repo, "Build", nil)
+		second := mustCreate(t, repo, "Test", nil)
+
+		require.NoError(t, repo.AddDependency(ctx, task.ID, second.ID))
+		require.NoError(t, repo.AddDependency(ctx, task.ID, first.ID))
+		// 既に存在する依存関係の追加は何もしない
+		require.NoError(t, repo.AddDependency(ctx, task.ID, first.ID))
+
+		found, err := repo.FindByID(ctx, task.ID)
+		require.NoError(t, err)
+		assert.Equal(t, ids(sortedByID(first, second)), found.BlockedBy)
+
+		// タスクの更新では依存関係を変更しない
+		found.BlockedBy = nil
+		_, err = repo.Update(ctx, found)
+		require.NoError(t, err)
+
+		require.NoError(t, repo.RemoveDependency(ctx, task.ID, first.ID))
+		found, err = repo.FindByID(ctx, task.ID)
+		require.NoError(t, err)
+		assert.Equal(t, []string{second.ID}, found.BlockedBy)
+
+		err = repo.RemoveDependency(ctx, task.ID, first.ID)
+		assert.True(t, errors.Is(err, repository.ErrDependencyNotFound), "expected ErrDependencyNotFound, got %v", err)
+	})
+
+	t.Run("着手可能なタスクのみに絞り込む", func(t *testing.T) {
+		repo := newRepo(t)
+		done := mustCreateTask(t, repo, &model.Task{Title: "Done", Status: model.StatusDone})
+		open := mustCreate(t, repo, "Open", nil)
+		afterDone := mustCreate(t, repo, "After done", nil)
+		afterOpen := mustCreate(t, repo, "After open", nil)
+		require.NoError(t, repo.AddDependency(ctx, afterDone.ID, done.ID))
+		require.NoError(t, repo.AddDependency(ctx, afterOpen.ID, done.ID))
+		require.NoError(t, repo.AddDependency(ctx, afterOpen.ID, open.ID))
+
+		tasks, err := repo.FindAll(ctx, repository.TaskQuery{Ready: true, SortBy: repository.SortByTitle})
+		require.NoError(t, err)
+		assert.Equal(t, []string{afterDone.ID, open.ID}, ids(tasks))
+	})
+
+	t.Run("タスクを削除すると依存関係も削除される", func(t *testing.T) {
+		repo := newRepo(t)
+		task := mustCreate(t, repo, "Task", nil)
+		blocker := mustCreate(t, repo, "Blocker", nil)
+		require.NoError(t, repo.AddDependency(ctx, task.ID, blocker.ID))
+
+		require.NoError(t, repo.Delete(ctx, blocker.ID))
+
+		found, err := repo.FindByID(ctx, task.ID)
+		require.NoError(t, err)
+		assert.Empty(t, found.BlockedBy)
+	})
+}
+
+func testRecurrences(t *testing.T, newRepo Factory) {
+	ctx := context.Background()
+	weekly := model.Recurrence{Frequency: model.FrequencyWeekly, Interval: 1, Weekdays: []time.Weekday{time.Monday, time.Thursday}}
+
+	t.Run("ルールを保存してタスクから参照し、変更できる", func(t *testing.T) {
+		repo := newRepo(t)
+		id := uuid.NewString()
+		require.NoError(t, repo.SaveRecurrence(ctx, id, weekly))
+
+		first := mustCreateTask(t, repo, &model.Task{Title: "Weekly review", RecurrenceID: id})
+		second := mustCreateTask(t, repo, &model.Task{Title: "Weekly review", RecurrenceID: id})
+
+		found, err := repo.FindByID(ctx, first.ID)
+		require.NoError(t, err)
+		assert.Equal(t, id, found.RecurrenceID)
+		assert.Equal(t, &weekly, found.Recurrence)
+
+		// ルールの変更は同じルールを参照するすべてのタスクに反映される
+		monthly := model.Recurrence{Frequency: model.FrequencyMonthly, Interval: 2, MonthDay: 15}
+		require.NoError(t, repo.SaveRecurrence(ctx, id, monthly))
+
+		tasks, err := repo.FindAll(ctx, repository.TaskQuery{})
+		require.NoError(t, err)
+		assert.ElementsMatch(t, []string{first.ID, second.ID}, ids(tasks))
+		for _, task := range tasks {
+			assert.Equal(t, &monthly, task.Recurrence)
+		}
+	})
+
+	t.Run("存在しないルールを参照するタスクは保存しない", func(t *testing.T) {
+		repo := newRepo(t)
+
+		_, err := repo.Create(ctx, &model.Task{Title: "Task", RecurrenceID: uuid.NewString()})
+		assert.Error(t, err)
+	})
+
+	t.Run("不正なルールは保存しない", func(t *testing.T) {
+		repo := newRepo(t)
+
+		err := repo.SaveRecurrence(ctx, uuid.NewString(), model.Recurrence{Frequency: model.FrequencyDaily})
+		assert.Error(t, err)
+	})
+
+	t.Run("ルールを削除するとタスクは繰り返さないタスクとなる", func(t *testing.T) {
+		repo := newRepo(t)
+		id := uuid.NewString()
+		require.NoError(t, repo.SaveRecurrence(ctx, id, weekly))
+		task := mustCreateTask(t, repo, &model.Task{Title: "Weekly review", RecurrenceID: id})
+
+		require.NoError(t, repo.DeleteRecurrence(ctx, id))
+
+		found, err := repo.FindByID(ctx, task.ID)
+		require.NoError(t, err)
+		assert.Empty(t, found.RecurrenceID)
+		assert.Nil(t, found.Recurrence)
+
+		err = repo.DeleteRecurrence(ctx, id)
+		assert.True(t, errors.Is(err, repository.ErrRecurrenceNotFound), "expected ErrRecurrenceNotFound, got %v", err)
+	})
+}
+
+func testAnnotations(t *testing.T, newRepo Factory) {
+	ctx := context.Background()
+
+	t.Run("注記を追加した順に読み出す", func(t *testing.T) {
+		repo := newRepo(t)
+		task := mustCreate(t, repo, "Order parts", nil)
+		other := mustCreate(t, repo, "Other", nil)
+
+		first, err := repo.AddAnnotation(ctx, task.ID, "  called vendor ")
+		require.NoError(t, err)
+		assert.Equal(t, "called vendor", first.Text)
+		assert.WithinDuration(t, time.Now(), first.CreatedAt, time.Minute)
+		second, err := repo.AddAnnotation(ctx, task.ID, "quote received")
+		require.NoError(t, err)
+
+		found, err := repo.FindByID(ctx, task.ID)
+		require.NoError(t, err)
+		if assert.Len(t, found.Annotations, 2) {
+			assert.Equal(t, []int64{first.ID, second.ID}, []int64{found.Annotations[0].ID, found.Annotations[1].ID})
+			assert.Equal(t, "called vendor", found.Annotations[0].Text)
+			assert.Equal(t, "quote received", found.Annotations[1].Text)
+			assert.WithinDuration(t, first.CreatedAt, found.Annotations[0].CreatedAt, timePrecision)
+		}
+
+		tasks, err := repo.FindAll(ctx, repository.TaskQuery{})
+		require.NoError(t, err)
+		for _, task := range tasks {
+			if task.ID == other.ID {
+				assert.Empty(t, task.Annotations)
+			} else {
+				assert.Len(t, task.Annotations, 2)
+			}
+		}
+	})
+
+	t.Run("更新しても注記は維持する", func(t *testing.T) {
+		repo := newRepo(t)
+		task := mustCreate(t, repo, "Order parts", nil)
+		_, err := repo.AddAnnotation(ctx, task.ID, "called vendor")
+		require.NoError(t, err)
+
+		change := *task
+		change.Title = "Order more parts"
+		change.Annotations = nil
+		_, err = repo.Update(ctx, &change)
+		require.NoError(t, err)
+
+		found, err := repo.FindByID(ctx, task.ID)
+		require.NoError(t, err)
+		assert.Len(t, found.Annotations, 1)
+	})
+
+	t.Run("空の注記は追加しない", func(t *testing.T) {
+		repo := newRepo(t)
+		task := mustCreate(t, repo, "Order parts", nil)
+
+		_, err := repo.AddAnnotation(ctx, task.ID, " \n ")
+		assert.Error(t, err)
+	})
+
+	t.Run("存在しないタスクにはNotFoundエラーを返す", func(t *testing.T) {
+		repo := newRepo(t)
+
+		_, err := repo.AddAnnotation(ctx, uuid.NewString(), "called vendor")
+		assert.True(t, errors.Is(err, repository.ErrTaskNotFound), "expected ErrTaskNotFound, got %v", err)
+	})
+
+	t.Run("タスクを完全に削除すると注記も削除する", func(t *testing.T) {
+		repo := newRepo(t)
+		task := mustCreate(t, repo, "Order parts", nil)
+		_, err := repo.AddAnnotation(ctx, task.ID, "called vendor")
+		require.NoError(t, err)
+
+		require.NoError(t, repo.Delete(ctx, task.ID))
+		_, err = repo.Purge(ctx, time.Now().Add(time.Minute))
+		require.NoError(t, err)
+
+		// 同じIDで作成し直しても、削除前の注記は残っていないこと
+		recreated := mustCreateTask(t, repo, &model.Task{ID: task.ID, Title: "Order parts"})
+		found, err := repo.FindByID(ctx, recreated.ID)
+		require.NoError(t, err)
+		assert.Empty(t, found.Annotations)
+	})
+}
+
+// testSearch は全文検索と部分一致のどちらの実装でも成り立つ振る舞いを検証する
+// 検索語はいずれも単語全体とし、1つの項目の中で一致するようにする
+func testSearch(t *testing.T, newRepo Factory) {
+	ctx := context.Background()
+
+	t.Run("タイトル、説明、注記から検索し、関連度の順に返す", func(t *testing.T) {
+		repo := newRepo(t)
+		inTitle := mustCreate(t, repo, "Call the vendor", nil)
+		inDescription := mustCreateTask(t, repo, &model.Task{Title: "Order parts", Description: "Ask the vendor\nfor a quote"})
+		inAnnotation := mustCreate(t, repo, "Fix the sink", nil)
+		_, err := repo.AddAnnotation(ctx, inAnnotation.ID, "vendor is closed today")
+		require.NoError(t, err)
+		mustCreate(t, repo, "Water plants", nil)
+
+		hits, err := repo.Search(ctx, "Vendor", 0)
+		require.NoError(t, err)
+		require.Len(t, hits, 3)
+		assert.Equal(t, []string{inTitle.ID, inDescription.ID, inAnnotation.ID},
+			[]string{hits[0].Task.ID, hits[1].Task.ID, hits[2].Task.ID})
+		assert.Greater(t, hits[0].Rank, hits[1].Rank)
+		assert.Greater(t, hits[1].Rank, hits[2].Rank)
+
+		fields := make([]model.SearchField, 0, len(hits))
+		for _, hit := range hits {
+			require.Len(t, hit.Matches, 1)
+			match := hit.Matches[0]
+			fields = append(fields, match.Field)
+			assert.NotContains(t, match.Snippet, "\n")
+			if assert.NotEmpty(t, match.Highlights) {
+				h := match.Highlights[0]
+				assert.Equal(t, "vendor", match.Snippet[h.Start:h.End])
+			}
+		}
+		assert.Equal(t, []model.SearchField{model.SearchFieldTitle, model.SearchFieldDescription, model.SearchFieldAnnotation}, fields)
+
+		// 検索結果のタスクは関連するデータも読み込んでいること
+		assert.Len(t, hits[2].Task.Annotations, 1)
+	})
+
+	t.Run("すべての検索語を含むタスクを返す", func(t *testing.T) {
+		repo := newRepo(t)
+		both := mustCreate(t, repo, "Call the vendor", nil)
+		mustCreate(t, repo, "Call home", nil)
+
+		hits, err := repo.Search(ctx, "vendor call", 0)
+		require.NoError(t, err)
+		require.Len(t, hits, 1)
+		assert.Equal(t, both.ID, hits[0].Task.ID)
+		assert.Equal(t, "Call the vendor", hits[0].Matches[0].Snippet)
+		assert.Len(t, hits[0].Matches[0].Highlights, 2)
+	})
+
+	t.Run("件数を制限する", func(t *testing.T) {
+		repo := newRepo(t)
+		for _, title := range []string{"Call vendor", "Email vendor", "Visit vendor"} {
+			mustCreate(t, repo, title, nil)
+		}
+
+		hits, err := repo.Search(ctx, "vendor", 2)
+		require.NoError(t, err)
+		assert.Len(t, hits, 2)
+	})
+
+	t.Run("一致しない場合は空を返す", func(t *testing.T) {
+		repo := newRepo(t)
+		mustCreate(t, repo, "Call the vendor", nil)
+
+		hits, err := repo.Search(ctx, "plumber", 0)
+		require.NoError(t, err)
+		assert.Empty(t, hits)
+	})
+
+	t.Run("検索語がない場合はエラーを返す", func(t *testing.T) {
+		repo := newRepo(t)
+
+		_, err := repo.Search(ctx, "  ", 0)
+		assert.Error(t, err)
+	})
+}
+
+func testWaiting(t *testing.T, newRepo Factory) {
+	ctx := context.Background()
+
+	t.Run("開始日時と待機日時を保存し、変更・解除できる", func(t *testing.T) {
+		repo := newRepo(t)
+		start := time.Now().Add(24 * time.Hour).Truncate(time.Second)
+		wait := start.Add(time.Hour)
+		task := mustCreateTask(t, repo, &model.Task{Title: "Plan trip", StartAt: &start, WaitUntil: &wait})
+
+		found, err := repo.FindByID(ctx, task.ID)
+		require.NoError(t, err)
+		assertSameTask(t, task, found)
+
+		change := *found
+		later := wait.Add(72 * time.Hour)
+		change.StartAt = nil
+		change.WaitUntil = &later
+		updated, err := repo.Update(ctx, &change)
+		require.NoError(t, err)
+
+		found, err = repo.FindByID(ctx, task.ID)
+		require.NoError(t, err)
+		assertSameTask(t, updated, found)
+	})
+
+	t.Run("待機中かどうかで絞り込む", func(t *testing.T) {
+		repo := newRepo(t)
+		past := time.Now().Add(-time.Hour)
+		future := time.Now().Add(24 * time.Hour)
+		plain := mustCreate(t, repo, "Plain", nil)
+		started := mustCreateTask(t, repo, &model.Task{Title: "Started", StartAt: &past, WaitUntil: &past})
+		scheduled := mustCreateTask(t, repo, &model.Task{Title: "Scheduled", StartAt: &future})
+		snoozed := mustCreateTask(t, repo, &model.Task{Title: "Snoozed", StartAt: &past, WaitUntil: &future})
+
+		tests := []struct {
+			name    string
+			waiting repository.WaitingFilter
+			want    []*model.Task
+		}{
+			{name: "絞り込まない", waiting: repository.WaitingAny, want: []*model.Task{plain, started, scheduled, snoozed}},
+			{name: "待機中のタスクを除外する", waiting: repository.WaitingHide, want: []*model.Task{plain, started}},
+			{name: "待機中のタスクのみ", waiting: repository.WaitingOnly, want: []*model.Task{scheduled, snoozed}},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				tasks, err := repo.FindAll(ctx, repository.TaskQuery{Waiting: tt.waiting})
+				require.NoError(t, err)
+				assert.ElementsMatch(t, ids(tt.want), ids(tasks))
+			})
+		}
+	})
+
+	t.Run("締切より後の待機日時は保存しない", func(t *testing.T) {
+		repo := newRepo(t)
+		deadline := time.Now().Add(24 * time.Hour)
+		wait := deadline.Add(time.Hour)
+
+		_, err := repo.Create(ctx, &model.Task{Title: "Late", Deadline: &deadline, WaitUntil: &wait})
+		assert.Error(t, err)
+	})
+}
+
+// mustCreate はタスクを作成し、失敗した場合はテストを中断する
+func mustCreate(t *testing.T, repo repository.TaskRepository, title string, deadline *time.Time) *model.Task {
+	t.Helper()
+
+	return mustCreateTask(t, repo, &model.Task{Title: title, Deadline: deadline})
+}
+
+// mustCreateTask は指定した内容のタスクを作成し、失敗した場合はテストを中断する
+func mustCreateTask(t *testing.T, repo repository.TaskRepository, task *model.Task) *model.Task {
+	t.Helper()
+
+	created, err := repo.Create(context.Background(), task)
+	require.NoError(t, err)
+	return created
+}
+
+// future は現在時刻からdだけ後の時刻を秒単位で返す
+func future(d time.Duration) time.Time {
+	return time.Now().Add(d).Truncate(time.Second)
+}
+
+// assertNotFound はエラーが指定したIDのNotFoundエラーであることを検証する
+func assertNotFound(t *testing.T, err error, id string) {
+	t.Helper()
+
+	assert.True(t, errors.Is(err, repository.ErrTaskNotFound), "expected ErrTaskNotFound, got %v", err)
+
+	var notFound *repository.TaskNotFoundError
+	if assert.True(t, errors.As(err, &notFound)) {
+		assert.Equal(t, id, notFound.ID)
+	}
+}
+
+func testStatus(t *testing.T, newRepo Factory) {
+	ctx := context.Background()
+
+	t.Run("状態を指定して作成できる", func(t *testing.T) {
+		repo := newRepo(t)
+		created := mustCreateTask(t, repo, &model.Task{Title: "Started", Status: model.StatusInProgress})
+
+		found, err := repo.FindByID(ctx, created.ID)
+		require.NoError(t, err)
+		assert.Equal(t, model.StatusInProgress, found.Status)
+	})
+
+	t.Run("ワークフローにない状態では作成できない", func(t *testing.T) {
+		repo := newRepo(t)
+
+		_, err := repo.Create(ctx, &model.Task{Title: "Archived", Status: "archived"})
+		assert.ErrorContains(t, err, "unknown status")
+	})
+
+	t.Run("許可されていない状態遷移は型付きのエラーとなり変更されない", func(t *testing.T) {
+		repo := newRepo(t)
+		done := mustCreateTask(t, repo, &model.Task{Title: "Done", Status: model.StatusDone})
+
+		change := *done
+		change.Status = model.StatusInProgress
+		_, err := repo.Update(ctx, &change)
+		var transitionErr *model.TransitionError
+		require.True(t, errors.As(err, &transitionErr), "expected TransitionError, got %v", err)
+		assert.Equal(t, model.StatusDone, transitionErr.From)
+		assert.Equal(t, model.StatusInProgress, transitionErr.To)
+		assert.True(t, errors.Is(err, model.ErrIllegalTransition))
+
+		found, err := repo.FindByID(ctx, done.ID)
+		require.NoError(t, err)
+		assert.Equal(t, model.StatusDone, found.Status)
+	})
+
+	t.Run("複数の状態のいずれかに絞り込む", func(t *testing.T) {
+		repo := newRepo(t)
+		open := mustCreate(t, repo, "Open", nil)
+		started := mustCreateTask(t, repo, &model.Task{Title: "Started", Status: model.StatusInProgress})
+		mustCreateTask(t, repo, &model.Task{Title: "Blocked", Status: model.StatusBlocked})
+
+		tasks, err := repo.FindAll(ctx, repository.TaskQuery{
+			Statuses: []model.Status{model.StatusOpen, model.StatusInProgress},
+			SortBy:   repository.SortByTitle,
+		})
+		require.NoError(t, err)
+		assert.Equal(t, []string{open.ID, started.ID}, ids(tasks))
+	})
+
+	t.Run("すべての終了状態を完了済みとして扱う", func(t *testing.T) {
+		repo := newRepo(t)
+		parent := mustCreateTask(t, repo, &model.Task{Title: "Parent", Project: "work", Tags: []string{"home"}})
+		cancelled := mustCreateTask(t, repo, &model.Task{Title: "Cancelled", ParentID: parent.ID, Project: "work", Tags: []string{"home"}, Status: model.StatusCancelled})
+		blocked := mustCreateTask(t, repo, &model.Task{Title: "Blocked", Status: model.StatusBlocked})
+		require.NoError(t, repo.AddDependency(ctx, blocked.ID, cancelled.ID))
+
+		found, err := repo.FindByID(ctx, parent.ID)
+		require.NoError(t, err)
+		assert.Equal(t, model.Progress{Done: 1, Total: 1}, found.Subtasks)
+
+		ready, err := repo.FindAll(ctx, repository.TaskQuery{Ready: true, SortBy: repository.SortByTitle})
+		require.NoError(t, err)
+		assert.Equal(t, []string{blocked.ID, parent.ID}, ids(ready))
+
+		projects, err := repo.ProjectCounts(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, []model.ProjectCount{{Name: "work", Open: 1, Closed: 1}}, projects)
+
+		tags, err := repo.TagCounts(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, []model.TagCount{{Name: "home", Open: 1, Total: 2}}, tags)
+	})
+}
+
+// assertSameTask は2つのタスクが同じ内容であることを、時刻の精度を考慮して検証する
+func assertSameTask(t *testing.T, want, got *model.Task) {
+	t.Helper()
+
+	assert.Equal(t, want.ID, got.ID)
+	assert.Equal(t, want.Title, got.Title)
+	assert.Equal(t, want.Description, got.Description)
+	assert.Equal(t, want.Status, got.Status)
+	assert.Equal(t, want.Priority, got.Priority)
+	assert.Equal(t, want.Estimate, got.Estimate)
+	assert.Equal(t, want.Tags, got.Tags)
+	assert.Equal(t, want.Project, got.Project)
+	assert.Equal(t, want.ParentID, got.ParentID)
+	assertSameTime(t, want.Deadline, got.Deadline)
+	assertSameTime(t, want.StartAt, got.StartAt)
+	assertSameTime(t, want.WaitUntil, got.WaitUntil)
+	assertSameTime(t, want.CompletedAt, got.CompletedAt)
+	assert.WithinDuration(t, want.CreatedAt, got.CreatedAt, timePrecision)
+	assert.WithinDuration(t, want.UpdatedAt, got.UpdatedAt, timePrecision)
+}
+
+// sortedByID はタスクをIDの昇順に並べて返す
+func sortedByID(tasks ...*model.Task) []*model.Task {
+	sort.Slice(tasks, func(i, j int) bool {
+		return tasks[i].ID < tasks[j].ID
+	})
+	return tasks
+}
+
+// ids はタスクのIDの一覧を返す
+func ids(tasks []*model.Task) []string {
+	result := make([]string, 0, len(tasks))
+	for _, task := range tasks {
+		result = append(result, task.ID)
+	}
+	return result
+}
+
+// assertSameTime は省略可能な日時が、保存の精度の範囲で一致することを検証する
+func assertSameTime(t *testing.T, want, got *time.Time) {
+	t.Helper()
+
+	if want == nil {
+		assert.Nil(t, got)
+	} else if assert.NotNil(t, got) {
+		assert.WithinDuration(t, *want, *got, timePrecision)
+	}
+}
+
+func testTimeEntries(t *testing.T, newRepo Factory) {
+	ctx := context.Background()
+	base := time.Now().Add(-24 * time.Hour).Truncate(time.Second)
+	at := func(hours int) time.Time { return base.Add(time.Duration(hours) * time.Hour) }
+	ended := func(hours int) *time.Time { t := at(hours); return &t }
+
+	t.Run("記録を追加し、開始日時の順に読み出す", func(t *testing.T) {
+		repo := newRepo(t)
+		task := mustCreate(t, repo, "Write report", nil)
+
+		later, err := repo.AddTimeEntry(ctx, model.TimeEntry{TaskID: task.ID, User: "alice", StartedAt: at(2), EndedAt: ended(3)})
+		require.NoError(t, err)
+		earlier, err := repo.AddTimeEntry(ctx, model.TimeEntry{TaskID: task.ID, User: "alice", StartedAt: at(0), EndedAt: ended(1)})
+		require.NoError(t, err)
+		assert.NotEqual(t, later.ID, earlier.ID)
+
+		entries, err := repo.TimeEntries(ctx, repository.TimeEntryQuery{TaskID: task.ID})
+		require.NoError(t, err)
+		if assert.Len(t, entries, 2) {
+			assert.Equal(t, []int64{earlier.ID, later.ID}, []int64{entries[0].ID, entries[1].ID})
+			assert.Equal(t, "alice", entries[0].User)
+			assert.WithinDuration(t, at(0), entries[0].StartedAt, timePrecision)
+			if assert.NotNil(t, entries[0].EndedAt) {
+				assert.WithinDuration(t, at(1), *entries[0].EndedAt, timePrecision)
+			}
+		}
+	})
+
+	t.Run("計測中の記録を終了する", func(t *testing.T) {
+		repo := newRepo(t)
+		task := mustCreate(t, repo, "Write report", nil)
+		running, err := repo.AddTimeEntry(ctx, model.TimeEntry{TaskID: task.ID, User: "alice", StartedAt: at(0)})
+		require.NoError(t, err)
+
+		entries, err := repo.TimeEntries(ctx, repository.TimeEntryQuery{User: "alice", Running: true})
+		require.NoError(t, err)
+		require.Len(t, entries, 1)
+		assert.True(t, entries[0].Running())
+
+		stopped, err := repo.StopTimeEntry(ctx, running.ID, at(2))
+		require.NoError(t, err)
+		assert.Equal(t, 2*time.Hour, stopped.Duration(time.Now()))
+
+		entries, err = repo.TimeEntries(ctx, repository.TimeEntryQuery{User: "alice", Running: true})
+		require.NoError(t, err)
+		assert.Empty(t, entries)
+
+		// 終了済みの記録は再度終了できないこと
+		_, err = repo.StopTimeEntry(ctx, running.ID, at(3))
+		assert.True(t, errors.Is(err, repository.ErrTimeEntryNotFound), "expected ErrTimeEntryNotFound, got %v", err)
+	})
+
+	t.Run("存在しないかゴミ箱にあるタスクには記録せずにNotFoundエラーを返す", func(t *testing.T) {
+		repo := newRepo(t)
+		task := mustCreate(t, repo, "Write report", nil)
+		require.NoError(t, repo.Delete(ctx, task.ID))
+
+		for _, id := range []string{task.ID, uuid.NewString()} {
+			_, err := repo.AddTimeEntry(ctx, model.TimeEntry{TaskID: id, User: "alice", StartedAt: at(0), EndedAt: ended(1)})
+			assertNotFound(t, err, id)
+		}
+		entries, err := repo.TimeEntries(ctx, repository.TimeEntryQuery{TaskID: task.ID})
+		require.NoError(t, err)
+		assert.Empty(t, entries)
+	})
+
+	t.Run("計測中の記録は利用者ごとに1件までとする", func(t *testing.T) {
+		repo := newRepo(t)
+		task := mustCreate(t, repo, "Write report", nil)
+		running, err := repo.AddTimeEntry(ctx, model.TimeEntry{TaskID: task.ID, User: "alice", StartedAt: at(0)})
+		require.NoError(t, err)
+
+		_, err = repo.AddTimeEntry(ctx, model.TimeEntry{TaskID: task.ID, User: "alice", StartedAt: at(1)})
+		var runningErr *model.TimerRunningError
+		if assert.True(t, errors.As(err, &runningErr), "expected TimerRunningError, got %v", err) {
+			assert.Equal(t, running.ID, runningErr.Entry.ID)
+		}
+
+		// 終了済みの記録と、別の利用者の計測中の記録は追加できること
+		_, err = repo.AddTimeEntry(ctx, model.TimeEntry{TaskID: task.ID, User: "alice", StartedAt: at(1), EndedAt: ended(2)})
+		require.NoError(t, err)
+		_, err = repo.AddTimeEntry(ctx, model.TimeEntry{TaskID: task.ID, User: "bob", StartedAt: at(1)})
+		require.NoError(t, err)
+	})
+
+	t.Run("開始より前には終了できない", func(t *testing.T) {
+		repo := newRepo(t)
+		task := mustCreate(t, repo, "Write report", nil)
+		running, err := repo.AddTimeEntry(ctx, model.TimeEntry{TaskID: task.ID, User: "alice", StartedAt: at(2)})
+		require.NoError(t, err)
+
+		_, err = repo.StopTimeEntry(ctx, running.ID, at(1))
+		assert.Error(t, err)
+	})
+
+	t.Run("利用者と期間で絞り込む", func(t *testing.T) {
+		repo := newRepo(t)
+		task := mustCreate(t, repo, "Write report", nil)
+		before, err := repo.AddTimeEntry(ctx, model.TimeEntry{TaskID: task.ID, User: "alice", StartedAt: at(0), EndedAt: ended(1)})
+		require.NoError(t, err)
+		straddling, err := repo.AddTimeEntry(ctx, model.TimeEntry{TaskID: task.ID, User: "alice", StartedAt: at(2), EndedAt: ended(4)})
+		require.NoError(t, err)
+		running, err := repo.AddTimeEntry(ctx, model.TimeEntry{TaskID: task.ID, User: "alice", StartedAt: at(5)})
+		require.NoError(t, err)
+		_, err = repo.AddTimeEntry(ctx, model.TimeEntry{TaskID: task.ID, User: "bob", StartedAt: at(3), EndedAt: ended(4)})
+		require.NoError(t, err)
+		after, err := repo.AddTimeEntry(ctx, model.TimeEntry{TaskID: task.ID, User: "alice", StartedAt: at(8), EndedAt: ended(9)})
+		require.NoError(t, err)
+
+		since, until := at(3), at(8)
+		entries, err := repo.TimeEntries(ctx, repository.TimeEntryQuery{User: "alice", Since: &since, Until: &until})
+		require.NoError(t, err)
+
+		// 期間と一部でも重なる記録と、計測中の記録を含めること
+		got := make([]int64, len(entries))
+		for i, e := range entries {
+			got[i] = e.ID
+		}
+		assert.Equal(t, []int64{straddling.ID, running.ID}, got)
+		assert.NotContains(t, got, before.ID)
+		assert.NotContains(t, got, after.ID)
+	})
+
+	t.Run("存在しないタスクにはNotFoundエラーを返す", func(t *testing.T) {
+		repo := newRepo(t)
+
+		_, err := repo.AddTimeEntry(ctx, model.TimeEntry{TaskID: uuid.NewString(), User: "alice", StartedAt: at(0)})
+		assert.True(t, errors.Is(err, repository.ErrTaskNotFound), "expected ErrTaskNotFound, got %v", err)
+	})
+
+	t.Run("利用者のない記録は追加しない", func(t *testing.T) {
+		repo := newRepo(t)
+		task := mustCreate(t, repo, "Write report", nil)
+
+		_, err := repo.AddTimeEntry(ctx, model.TimeEntry{TaskID: task.ID, StartedAt: at(0)})
+		assert.Error(t, err)
+	})
+
+	t.Run("タスクを完全に削除すると作業時間の記録も削除する", func(t *testing.T) {
+		repo := newRepo(t)
+		task := mustCreate(t, repo, "Write report", nil)
+		_, err := repo.AddTimeEntry(ctx, model.TimeEntry{TaskID: task.ID, User: "alice", StartedAt: at(0), EndedAt: ended(1)})
+		require.NoError(t, err)
+
+		require.NoError(t, repo.Delete(ctx, task.ID))
+		_, err = repo.Purge(ctx, time.Now().Add(time.Minute))
+		require.NoError(t, err)
+
+		entries, err := repo.TimeEntries(ctx, repository.TimeEntryQuery{})
+		require.NoError(t, err)
+		assert.Empty(t, entries)
+	})
+}
+
+func testHistory(t *testing.T, newRepo Factory) {
+	ctx := repository.WithActor(context.Background(), "alice")
+
+	t.Run("作成・変更・完了・削除を操作した利用者とともに記録する", func(t *testing.T) {
+		repo := newRepo(t)
+		created, err := repo.Create(ctx, &model.Task{Title: "Write report"})
+		require.NoError(t, err)
+
+		change := *created
+		change.Title = "Write the report"
+		change.Priority = model.PriorityHigh
+		_, err = repo.Update(ctx, &change)
+		require.NoError(t, err)
+
+		change.Status = model.StatusDone
+		_, err = repo.Update(ctx, &change)
+		require.NoError(t, err)
+
+		require.NoError(t, repo.Delete(ctx, created.ID))
+
+		// 削除したタスクの履歴も読み出せること
+		events, err := repo.TaskEvents(ctx, created.ID)
+		require.NoError(t, err)
+		type summary struct {
+			Kind            model.TaskEventKind
+			Field, Old, New string
+			Actor           string
+		}
+		got := make([]summary, len(events))
+		for i, e := range events {
+			assert.Equal(t, created.ID, e.TaskID)
+			assert.False(t, e.OccurredAt.IsZero())
+			got[i] = summary{e.Kind, e.Field, e.OldValue, e.NewValue, e.Actor}
+		}
+		assert.Equal(t, []summary{
+			{model.TaskCreated, "", "", "Write report", "alice"},
+			{model.TaskChanged, "title", "Write report", "Write the report", "alice"},
+			{model.TaskChanged, "priority", "", "high", "alice"},
+			{model.TaskCompleted, "status", "open", "done", "alice"},
+			{model.TaskDeleted, "", "Write the report", "", "alice"},
+		}, got)
+	})
+
+	t.Run("親タスクをゴミ箱に移して元に戻してもサブタスクは変更しない", func(t *testing.T) {
+		repo := newRepo(t)
+		parent := mustCreate(t, repo, "Plan the move", nil)
+		child := mustCreateTask(t, repo, &model.Task{Title: "Call the vendor", ParentID: parent.ID})
+
+		require.NoError(t, repo.Delete(ctx, parent.ID))
+		_, err := repo.Restore(ctx, parent.ID)
+		require.NoError(t, err)
+
+		events, err := repo.TaskEvents(ctx, child.ID)
+		require.NoError(t, err)
+		if assert.Len(t, events, 1) {
+			assert.Equal(t, model.TaskCreated, events[0].Kind)
+		}
+	})
+
+	t.Run("変更がない更新は記録しない", func(t *testing.T) {
+		repo := newRepo(t)
+		created := mustCreate(t, repo, "Write report", nil)
+
+		_, err := repo.Update(context.Background(), created)
+		require.NoError(t, err)
+
+		events, err := repo.TaskEvents(ctx, created.ID)
+		require.NoError(t, err)
+		if assert.Len(t, events, 1) {
+			assert.Equal(t, model.TaskCreated, events[0].Kind)
+			assert.Empty(t, events[0].Actor)
+		}
+	})
+
+	t.Run("履歴がない場合は空のスライスを返す", func(t *testing.T) {
+		repo := newRepo(t)
+
+		events, err := repo.TaskEvents(ctx, uuid.NewString())
+		require.NoError(t, err)
+		assert.NotNil(t, events)
+		assert.Empty(t, events)
+	})
+
+	t.Run("終了状態にした日時を保存し、再開すると解除する", func(t *testing.T) {
+		repo := newRepo(t)
+		created := mustCreate(t, repo, "Write report", nil)
+		assert.Nil(t, created.CompletedAt)
+
+		change := *created
+		change.Status = model.StatusDone
+		done, err := repo.Update(ctx, &change)
+		require.NoError(t, err)
+		require.NotNil(t, done.CompletedAt)
+
+		found, err := repo.FindByID(ctx, created.ID)
+		require.NoError(t, err)
+		assertSameTime(t, done.CompletedAt, found.CompletedAt)
+
+		// 終了状態の間で変更しても、完了日時は変わらないこと
+		change = *found
+		change.Title = "Write the report"
+		_, err = repo.Update(ctx, &change)
+		require.NoError(t, err)
+		found, err = repo.FindByID(ctx, created.ID)
+		require.NoError(t, err)
+		assertSameTime(t, done.CompletedAt, found.CompletedAt)
+
+		change = *found
+		change.Status = model.StatusOpen
+		_, err = repo.Update(ctx, &change)
+		require.NoError(t, err)
+		found, err = repo.FindByID(ctx, created.ID)
+		require.NoError(t, err)
+		assert.Nil(t, found.CompletedAt)
+	})
+
+	t.Run("終了状態で作成した場合は作成日時を完了日時とする", func(t *testing.T) {
+		repo := newRepo(t)
+		created := mustCreateTask(t, repo, &model.Task{Title: "Done", Status: model.StatusCancelled})
+
+		found, err := repo.FindByID(ctx, created.ID)
+		require.NoError(t, err)
+		if assert.NotNil(t, found.CompletedAt) {
+			assert.WithinDuration(t, created.CreatedAt, *found.CompletedAt, timePrecision)
+		}
+	})
+}
+
+func testOperations(t *testing.T, newRepo Factory) {
+	ctx := context.Background()
+	batch := func(id string) context.Context {
+		return repository.WithBatch(repository.WithActor(ctx, "alice"), id, "cmd "+id)
+	}
+
+	t.Run("操作のまとまりを保持しない場合は記録しない", func(t *testing.T) {
+		repo := newRepo(t)
+		mustCreate(t, repo, "Write report", nil)
+
+		ops, err := repo.Operations(ctx, false)
+		require.NoError(t, err)
+		assert.NotNil(t, ops)
+		assert.Empty(t, ops)
+	})
+
+	t.Run("作成・更新・削除を記録し、取り消しとやり直しで元に戻す", func(t *testing.T) {
+		repo := newRepo(t)
+		deadline := future(48 * time.Hour)
+		created, err := repo.Create(batch("b1"), &model.Task{
+			Title: "Write report", Status: model.StatusInProgress, Deadline: &deadline,
+			Tags: []string{"work"}, Project: "work.docs", Estimate: &model.Estimate{Points: 3},
+		})
+		require.NoError(t, err)
+		change := *created
+		change.Title = "Write the report"
+		change.Status = model.StatusDone
+		updated, err := repo.Update(batch("b2"), &change)
+		require.NoError(t, err)
+		require.NoError(t, repo.Delete(batch("b3"), created.ID))
+
+		ops, err := repo.Operations(ctx, false)
+		require.NoError(t, err)
+		require.Len(t, ops, 3)
+		for i, kind := range []model.OperationKind{model.OperationCreate, model.OperationUpdate, model.OperationDelete} {
+			assert.Equal(t, kind, ops[i].Kind)
+			assert.Equal(t, created.ID, ops[i].TaskID)
+			assert.False(t, ops[i].Undone)
+		}
+		assert.Equal(t, "b2", ops[1].BatchID)
+		assert.Equal(t, "cmd b2", ops[1].Command)
+		assert.Nil(t, ops[0].Before)
+		assert.Nil(t, ops[2].After)
+
+		// 削除と更新を取り消す（完了から作業中への戻しはワークフローの状態遷移によらない）
+		require.NoError(t, repo.ReplayOperations(batch("undo"), []model.Operation{ops[2], ops[1]}, true))
+		found, err := repo.FindByID(ctx, created.ID)
+		require.NoError(t, err)
+		want := *created
+		want.UpdatedAt = found.UpdatedAt
+		assertSameTask(t, &want, found)
+
+		undone, err := repo.Operations(ctx, true)
+		require.NoError(t, err)
+		assert.Len(t, undone, 2)
+
+		// 取り消した更新をやり直す
+		require.NoError(t, repo.ReplayOperations(ctx, []model.Operation{ops[1]}, false))
+		found, err = repo.FindByID(ctx, created.ID)
+		require.NoError(t, err)
+		want = *updated
+		want.UpdatedAt = found.UpdatedAt
+		assertSameTask(t, &want, found)
+
+		// 取り消しとやり直しも履歴に記録すること（削除の取り消しはゴミ箱からの復元とする）
+		events, err := repo.TaskEvents(ctx, created.ID)
+		require.NoError(t, err)
+		assert.Equal(t, model.TaskRestored, events[len(events)-5].Kind)
+		assert.Equal(t, "alice", events[len(events)-5].Actor)
+	})
+
+	t.Run("記録後に変更されたタスクは取り消さずに競合のエラーを返す", func(t *testing.T) {
+		repo := newRepo(t)
+		first, err := repo.Create(batch("b1"), &model.Task{Title: "First"})
+		require.NoError(t, err)
+		second, err := repo.Create(batch("b1"), &model.Task{Title: "Second"})
+		require.NoError(t, err)
+		change := *second
+		change.Title = "Second, edited"
+		_, err = repo.Update(ctx, &change)
+		require.NoError(t, err)
+
+		ops, err := repo.Operations(ctx, false)
+		require.NoError(t, err)
+		require.Len(t, ops, 2)
+
+		err = repo.ReplayOperations(ctx, []model.Operation{ops[1], ops[0]}, true)
+
+		var conflict *model.ConflictError
+		require.True(t, errors.As(err, &conflict))
+		assert.Equal(t, second.ID, conflict.TaskID)
+		assert.Contains(t, conflict.Reason, "title")
+		// いずれの操作も取り消さないこと
+		for _, id := range []string{first.ID, second.ID} {
+			_, err := repo.FindByID(ctx, id)
+			assert.NoError(t, err)
+		}
+		ops, err = repo.Operations(ctx, false)
+		require.NoError(t, err)
+		assert.Len(t, ops, 2)
+	})
+
+	t.Run("一部の操作が競合する場合はいずれも取り消さない", func(t *testing.T) {
+		repo := newRepo(t)
+		first, err := repo.Create(batch("b1"), &model.Task{Title: "First"})
+		require.NoError(t, err)
+		second, err := repo.Create(batch("b1"), &model.Task{Title: "Second"})
+		require.NoError(t, err)
+		require.NoError(t, repo.Delete(ctx, first.ID))
+
+		ops, err := repo.Operations(ctx, false)
+		require.NoError(t, err)
+		require.Len(t, ops, 2)
+
+		err = repo.ReplayOperations(ctx, []model.Operation{ops[1], ops[0]}, true)
+
+		assert.True(t, errors.Is(err, model.ErrConflict))
+		_, err = repo.FindByID(ctx, second.ID)
+		assert.NoError(t, err)
+	})
+
+	t.Run("親タスクの削除を取り消すとサブタスクを含めて削除前の状態に戻す", func(t *testing.T) {
+		repo := newRepo(t)
+		parent := mustCreate(t, repo, "Plan the move", nil)
+		child := mustCreateTask(t, repo, &model.Task{Title: "Call the vendor", ParentID: parent.ID})
+		require.NoError(t, repo.Delete(batch("b1"), parent.ID))
+
+		ops, err := repo.Operations(ctx, false)
+		require.NoError(t, err)
+		require.NoError(t, repo.ReplayOperations(batch("undo"), ops, true))
+
+		found, err := repo.FindByID(ctx, child.ID)
+		require.NoError(t, err)
+		assertSameTask(t, child, found)
+		found, err = repo.FindByID(ctx, parent.ID)
+		require.NoError(t, err)
+		assert.Equal(t, model.Progress{Total: 1}, found.Subtasks)
+	})
+
+	t.Run("新しい操作を記録するとやり直しの対象を削除する", func(t *testing.T) {
+		repo := newRepo(t)
+		created, err := repo.Create(batch("b1"), &model.Task{Title: "Write report"})
+		require.NoError(t, err)
+		ops, err := repo.Operations(ctx, false)
+		require.NoError(t, err)
+		require.NoError(t, repo.ReplayOperations(ctx, ops, true))
+		_, err = repo.FindByID(ctx, created.ID)
+		assertNotFound(t, err, created.ID)
+
+		mustCreateTask(t, repo, &model.Task{Title: "Unrelated"})
+		undone, err := repo.Operations(ctx, true)
+		require.NoError(t, err)
+		assert.Len(t, undone, 1)
+
+		_, err = repo.Create(batch("b2"), &model.Task{Title: "Another"})
+		require.NoError(t, err)
+		undone, err = repo.Operations(ctx, true)
+		require.NoError(t, err)
+		assert.Empty(t, undone)
+	})
+}
+
+func testTrash(t *testing.T, newRepo Factory) {
+	ctx := repository.WithActor(context.Background(), "alice")
+
+	t.Run("ゴミ箱のタスクは一覧、検索、件数、依存関係、サブタスクの進捗に含めない", func(t *testing.T) {
+		repo := newRepo(t)
+		parent := mustCreate(t, repo, "Plan the move", nil)
+		trashed := mustCreateTask(t, repo, &model.Task{
+			Title: "Call the vendor", Tags: []string{"work"}, Project: "home", ParentID: parent.ID,
+		})
+		kept := mustCreateTask(t, repo, &model.Task{Title: "Pack boxes", Tags: []string{"work"}})
+		_, err := repo.AddAnnotation(ctx, trashed.ID, "vendor opens at nine")
+		require.NoError(t, err)
+		require.NoError(t, repo.AddDependency(ctx, kept.ID, trashed.ID))
+
+		require.NoError(t, repo.Delete(ctx, trashed.ID))
+
+		_, err = repo.FindByID(ctx, trashed.ID)
+		assertNotFound(t, err, trashed.ID)
+		for _, q := range []repository.TaskQuery{
+			{},
+			{Ready: true},
+			{Tags: [][]string{{"work"}}},
+			{TitleContains: "vendor"},
+			{SortBy: repository.SortByDeadline, SortDesc: true},
+		} {
+			tasks, err := repo.FindAll(ctx, q)
+			require.NoError(t, err)
+			assert.NotContains(t, ids(tasks), trashed.ID, "query %+v", q)
+		}
+		tasks, err := repo.FindAll(ctx, repository.TaskQuery{Project: "home"})
+		require.NoError(t, err)
+		assert.Empty(t, tasks)
+
+		hits, err := repo.Search(ctx, "vendor", 0)
+		require.NoError(t, err)
+		assert.Empty(t, hits)
+
+		tags, err := repo.TagCounts(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, []model.TagCount{{Name: "work", Open: 1, Total: 1}}, tags)
+		projects, err := repo.ProjectCounts(ctx)
+		require.NoError(t, err)
+		assert.Empty(t, projects)
+
+		// 待っているタスクは着手可能となり、親タスクの進捗にも数えないこと
+		found, err := repo.FindByID(ctx, kept.ID)
+		require.NoError(t, err)
+		assert.Empty(t, found.BlockedBy)
+		found, err = repo.FindByID(ctx, parent.ID)
+		require.NoError(t, err)
+		assert.Equal(t, model.Progress{}, found.Subtasks)
+
+		// ゴミ箱のタスクは変更できないこと
+		change := *trashed
+		change.Title = "Call the vendor again"
+		_, err = repo.Update(ctx, &change)
+		assertNotFound(t, err, trashed.ID)
+		assertNotFound(t, repo.Delete(ctx, trashed.ID), trashed.ID)
+		_, err = repo.AddAnnotation(ctx, trashed.ID, "called")
+		assert.True(t, errors.Is(err, repository.ErrTaskNotFound), "expected ErrTaskNotFound, got %v", err)
+		_, err = repo.AddTimeEntry(ctx, model.TimeEntry{TaskID: trashed.ID, User: "alice", StartedAt: time.Now()})
+		assert.True(t, errors.Is(err, repository.ErrTaskNotFound), "expected ErrTaskNotFound, got %v", err)
+	})
+
+	t.Run("ゴミ箱のタスクを新しい順に返し、元に戻すと注記、依存関係、作業時間の記録も戻る", func(t *testing.T) {
+		repo := newRepo(t)
+		first := mustCreateTask(t, repo, &model.Task{Title: "Call the vendor", Tags: []string{"work"}})
+		second := mustCreate(t, repo, "Order parts", nil)
+		kept := mustCreate(t, repo, "Pack boxes", nil)
+		_, err := repo.AddAnnotation(ctx, first.ID, "vendor opens at nine")
+		require.NoError(t, err)
+		require.NoError(t, repo.AddDependency(ctx, kept.ID, first.ID))
+		started := time.Now().Add(-time.Hour).Truncate(time.Second)
+		ended := started.Add(30 * time.Minute)
+		_, err = repo.AddTimeEntry(ctx, model.TimeEntry{TaskID: first.ID, User: "alice", StartedAt: started, EndedAt: &ended})
+		require.NoError(t, err)
+
+		before := time.Now()
+		require.NoError(t, repo.Delete(ctx, first.ID))
+		require.NoError(t, repo.Delete(ctx, second.ID))
+
+		trash, err := repo.FindDeleted(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, []string{second.ID, first.ID}, ids(trash))
+		require.NotNil(t, trash[1].DeletedAt)
+		assert.WithinDuration(t, before, *trash[1].DeletedAt, time.Minute)
+		assert.Equal(t, []string{"work"}, trash[1].Tags)
+
+		restored, err := repo.Restore(ctx, first.ID)
+		require.NoError(t, err)
+		assert.Equal(t, first.ID, restored.ID)
+		assert.Nil(t, restored.DeletedAt)
+
+		found, err := repo.FindByID(ctx, first.ID)
+		require.NoError(t, err)
+		assert.Equal(t, "Call the vendor", found.Title)
+		assert.Equal(t, []string{"work"}, found.Tags)
+		assert.Len(t, found.Annotations, 1)
+		found, err = repo.FindByID(ctx, kept.ID)
+		require.NoError(t, err)
+		assert.Equal(t, []string{first.ID}, found.BlockedBy)
+		entries, err := repo.TimeEntries(ctx, repository.TimeEntryQuery{TaskID: first.ID})
+		require.NoError(t, err)
+		assert.Len(t, entries, 1)
+
+		trash, err = repo.FindDeleted(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, []string{second.ID}, ids(trash))
+
+		events, err := repo.TaskEvents(ctx, first.ID)
+		require.NoError(t, err)
+		last := events[len(events)-1]
+		assert.Equal(t, model.TaskRestored, last.Kind)
+		assert.Equal(t, "Call the vendor", last.NewValue)
+		assert.Equal(t, "alice", last.Actor)
+	})
+
+	t.Run("親タスクをゴミ箱に移してもサブタスクの親子関係は残し、元に戻すと階層も戻る", func(t *testing.T) {
+		repo := newRepo(t)
+		parent := mustCreate(t, repo, "Plan the move", nil)
+		child := mustCreateTask(t, repo, &model.Task{Title: "Call the vendor", ParentID: parent.ID})
+		mustCreateTask(t, repo, &model.Task{Title: "Ask for a quote", ParentID: child.ID, Status: model.StatusDone})
+
+		require.NoError(t, repo.Delete(ctx, parent.ID))
+
+		// サブタスクは一覧に残り、親を変更しなければ更新できること
+		found, err := repo.FindByID(ctx, child.ID)
+		require.NoError(t, err)
+		assert.Equal(t, parent.ID, found.ParentID)
+		tasks, err := repo.FindAll(ctx, repository.TaskQuery{Ready: true})
+		require.NoError(t, err)
+		assert.Contains(t, ids(tasks), child.ID)
+		found.Title = "Call the vendor today"
+		_, err = repo.Update(ctx, found)
+		require.NoError(t, err)
+
+		_, err = repo.Restore(ctx, parent.ID)
+		require.NoError(t, err)
+
+		found, err = repo.FindByID(ctx, child.ID)
+		require.NoError(t, err)
+		assert.Equal(t, parent.ID, found.ParentID)
+		found, err = repo.FindByID(ctx, parent.ID)
+		require.NoError(t, err)
+		assert.Equal(t, model.Progress{Done: 1, Total: 2}, found.Subtasks)
+	})
+
+	t.Run("ゴミ箱にないタスクの復元はNotFoundエラーを返す", func(t *testing.T) {
+		repo := newRepo(t)
+		task := mustCreate(t, repo, "Pack boxes", nil)
+
+		_, err := repo.Restore(ctx, task.ID)
+		assertNotFound(t, err, task.ID)
+		id := uuid.NewString()
+		_, err = repo.Restore(ctx, id)
+		assertNotFound(t, err, id)
+	})
+
+	t.Run("指定した日時より前にゴミ箱に移したタスクのみを完全に削除する", func(t *testing.T) {
+		repo := newRepo(t)
+		task := mustCreate(t, repo, "Call the vendor", nil)
+		kept := mustCreate(t, repo, "Pack boxes", nil)
+		require.NoError(t, repo.AddDependency(ctx, kept.ID, task.ID))
+		require.NoError(t, repo.Delete(ctx, task.ID))
+
+		purged, err := repo.Purge(ctx, time.Now().Add(-time.Hour))
+		require.NoError(t, err)
+		assert.Equal(t, 0, purged)
+
+		purged, err = repo.Purge(ctx, time.Now().Add(time.Minute))
+		require.NoError(t, err)
+		assert.Equal(t, 1, purged)
+
+		trash, err := repo.FindDeleted(ctx)
+		require.NoError(t, err)
+		assert.Empty(t, trash)
+		_, err = repo.Restore(ctx, task.ID)
+		assertNotFound(t, err, task.ID)
+		tasks, err := repo.FindAll(ctx, repository.TaskQuery{})
+		require.NoError(t, err)
+		assert.Equal(t, []string{kept.ID}, ids(tasks))
+
+		// 完全に削除したタスクの履歴は残ること
+		events, err := repo.TaskEvents(ctx, task.ID)
+		require.NoError(t, err)
+		last := events[len(events)-1]
+		assert.Equal(t, model.TaskPurged, last.Kind)
+		assert.Equal(t, "Call the vendor", last.OldValue)
+	})
+
+	t.Run("削除の取り消しはゴミ箱のタスクを元に戻し、復元の取り消しはゴミ箱に戻す", func(t *testing.T) {
+		repo := newRepo(t)
+		task := mustCreate(t, repo, "Call the vendor", nil)
+		_, err := repo.AddAnnotation(ctx, task.ID, "vendor opens at nine")
+		require.NoError(t, err)
+		require.NoError(t, repo.Delete(repository.WithBatch(ctx, "b1", "rm 1"), task.ID))
+
+		ops, err := repo.Operations(ctx, false)
+		require.NoError(t, err)
+		require.NoError(t, repo.ReplayOperations(ctx, ops, true))
+
+		found, err := repo.FindByID(ctx, task.ID)
+		require.NoError(t, err)
+		assert.Len(t, found.Annotations, 1)
+		trash, err := repo.FindDeleted(ctx)
+		require.NoError(t, err)
+		assert.Empty(t, trash)
+
+		// ゴミ箱から戻したタスクは作成として記録し、取り消すと再びゴミ箱に移す
+		require.NoError(t, repo.Delete(ctx, task.ID))
+		_, err = repo.Restore(repository.WithBatch(ctx, "b2", "restore 1"), task.ID)
+		require.NoError(t, err)
+		ops, err = repo.Operations(ctx, false)
+		require.NoError(t, err)
+		require.Len(t, ops, 1)
+		assert.Equal(t, model.OperationCreate, ops[0].Kind)
+		require.NoError(t, repo.ReplayOperations(ctx, ops, true))
+
+		trash, err = repo.FindDeleted(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, []string{task.ID}, ids(trash))
+	})
+}
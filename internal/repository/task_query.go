@@ -0,0 +1,180 @@
+package repository
+
+import (
+	"OTakumi/todogo/internal/domain/model"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// WaitingFilter は待機中（開始日時または待機日時が未来）かどうかによる絞り込み条件
+// 待機中かどうかは、タスクを取得する時点の現在時刻で判定する
+type WaitingFilter string
+
+const (
+	// WaitingAny は待機中かどうかで絞り込まない
+	WaitingAny WaitingFilter = ""
+	// WaitingHide は待機中のタスクを対象外とする
+	WaitingHide WaitingFilter = "hide"
+	// WaitingOnly は待機中のタスクのみを対象とする
+	WaitingOnly WaitingFilter = "only"
+)
+
+// SortKey はタスク一覧の並び替えに使う項目
+type SortKey string
+
+const (
+	SortByCreatedAt SortKey = "created"
+	SortByUpdatedAt SortKey = "updated"
+	SortByDeadline  SortKey = "deadline"
+	SortByTitle     SortKey = "title"
+	SortByPriority  SortKey = "priority"
+
+	// SortByUrgency は優先度や締切から算出する緊急度による並び替え
+	// 緊急度は現在時刻に依存するため、リポジトリでは扱わずユースケース層で並び替える
+	SortByUrgency SortKey = "urgency"
+)
+
+// SortKeys は指定可能な並び替え項目の一覧
+var SortKeys = []SortKey{SortByCreatedAt, SortByUpdatedAt, SortByDeadline, SortByTitle, SortByPriority, SortByUrgency}
+
+// DefaultDesc は方向を省略した場合に降順で並び替える項目かどうかを返す
+// 優先度と緊急度は、高いものが先頭に並ぶことを既定とする
+func (k SortKey) DefaultDesc() bool {
+	return k == SortByPriority || k == SortByUrgency
+}
+
+// TaskQuery はタスク一覧を取得する際の絞り込み、並び替え、ページングの条件
+// ゼロ値は「全件を作成日時の昇順で取得する」ことを表す
+type TaskQuery struct {
+	// 状態による絞り込み（いずれかの状態のタスクを対象とし、空の場合は絞り込まない）
+	// 状態がワークフローに定義されているかは呼び出し側で検証する
+	Statuses []model.Status
+
+	// 締切による絞り込み（DeadlineBeforeは未満、DeadlineAfterは以上）
+	// いずれかが指定された場合、締切が設定されていないタスクは対象外となる
+	DeadlineBefore *time.Time
+	DeadlineAfter  *time.Time
+
+	// タイトルに含まれる文字列（大文字小文字を区別しない）
+	TitleContains string
+
+	// タグによる絞り込み（タグ名は正規化済みであること）
+	// Tags の各グループについて、いずれかのタグを持つタスクのみを対象とする（グループ内はOR、グループ同士はAND）
+	// ExcludeTags のいずれかのタグを持つタスクは対象外とする
+	Tags        [][]string
+	ExcludeTags []string
+
+	// プロジェクトによる絞り込み（プロジェクト名は正規化済みであること）
+	// 指定したプロジェクトと、その配下のプロジェクトに属するタスクを対象とする
+	Project string
+
+	// 待機中かどうかによる絞り込み
+	Waiting WaitingFilter
+
+	// Ready が true の場合、未完了で、先に完了する必要があるタスクがすべて完了済みのタスクのみを対象とする
+	// 完了済みかどうかはワークフローの終了状態かどうかで判定する
+	Ready bool
+
+	// 並び替えの項目と方向（同値の場合はIDで順序を確定させる）
+	SortBy   SortKey
+	SortDesc bool
+
+	// 取得する最大件数（0の場合は無制限）
+	Limit int
+
+	// キーセットページングのカーソル
+	// 指定したIDのタスクより後ろ（並び順において）のタスクのみを返す
+	AfterID string
+}
+
+// Validate は条件の組み合わせが正しいかを検証する
+func (q TaskQuery) Validate() error {
+	for _, status := range q.Statuses {
+		if status == "" {
+			return errors.New("invalid status filter: empty status")
+		}
+	}
+
+	switch q.Waiting {
+	case WaitingAny, WaitingHide, WaitingOnly:
+	default:
+		return fmt.Errorf("invalid waiting filter %q: must be one of hide, only", q.Waiting)
+	}
+
+	if q.SortBy != "" && !isSortKey(q.SortBy) {
+		return fmt.Errorf("invalid sort key %q: must be one of %s", q.SortBy, joinSortKeys())
+	}
+
+	for _, group := range q.Tags {
+		if len(group) == 0 {
+			return errors.New("invalid tag filter: empty group")
+		}
+	}
+
+	if q.Limit < 0 {
+		return fmt.Errorf("invalid limit %d: must not be negative", q.Limit)
+	}
+
+	return nil
+}
+
+// ValidateStored はリポジトリで処理できる条件かどうかを検証する
+// 緊急度による並び替えは算出が必要なため、リポジトリでは受け付けない
+func (q TaskQuery) ValidateStored() error {
+	if err := q.Validate(); err != nil {
+		return err
+	}
+
+	if q.SortBy == SortByUrgency {
+		return fmt.Errorf("sort key %q must be applied by the caller, not the repository", q.SortBy)
+	}
+	return nil
+}
+
+// EffectiveSortBy は並び替え項目を返す（未指定の場合は作成日時）
+func (q TaskQuery) EffectiveSortBy() SortKey {
+	if q.SortBy == "" {
+		return SortByCreatedAt
+	}
+	return q.SortBy
+}
+
+// ParseSort は "deadline" や "deadline:desc" のような並び替え指定を解釈する
+// 方向を省略した場合は項目ごとの既定の方向（SortKey.DefaultDesc）となる
+func ParseSort(s string) (SortKey, bool, error) {
+	key, dir, _ := strings.Cut(s, ":")
+	sortKey := SortKey(strings.ToLower(key))
+	if !isSortKey(sortKey) {
+		return "", false, fmt.Errorf("invalid sort key %q: must be one of %s", key, joinSortKeys())
+	}
+
+	switch strings.ToLower(dir) {
+	case "":
+		return sortKey, sortKey.DefaultDesc(), nil
+	case "asc":
+		return sortKey, false, nil
+	case "desc":
+		return sortKey, true, nil
+	default:
+		return "", false, fmt.Errorf("invalid sort direction %q: must be asc or desc", dir)
+	}
+}
+
+func isSortKey(k SortKey) bool {
+	for _, key := range SortKeys {
+		if key == k {
+			return true
+		}
+	}
+	return false
+}
+
+func joinSortKeys() string {
+	keys := make([]string, len(SortKeys))
+	for i, k := range SortKeys {
+		keys[i] = string(k)
+	}
+	return strings.Join(keys, ", ")
+}
@@ -0,0 +1,76 @@
+package repository_test
+
+import (
+	"OTakumi/todogo/internal/domain/model"
+	"OTakumi/todogo/internal/repository"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTaskQuery_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		query   repository.TaskQuery
+		wantErr bool
+	}{
+		{name: "ゼロ値は有効", query: repository.TaskQuery{}},
+		{name: "既知の状態と並び替え項目は有効", query: repository.TaskQuery{Statuses: []model.Status{model.StatusDone, model.StatusCancelled}, SortBy: repository.SortByDeadline}},
+		{name: "空の状態は無効", query: repository.TaskQuery{Statuses: []model.Status{model.StatusOpen, ""}}, wantErr: true},
+		{name: "未知の並び替え項目は無効", query: repository.TaskQuery{SortBy: "size"}, wantErr: true},
+		{name: "負の件数は無効", query: repository.TaskQuery{Limit: -1}, wantErr: true},
+		{name: "タグの条件は有効", query: repository.TaskQuery{Tags: [][]string{{"work", "home"}}, ExcludeTags: []string{"someday"}}},
+		{name: "空のタグのグループは無効", query: repository.TaskQuery{Tags: [][]string{{}}}, wantErr: true},
+		{name: "着手可能なタスクの絞り込みは有効", query: repository.TaskQuery{Statuses: []model.Status{model.StatusOpen}, Ready: true}},
+		{name: "待機中のタスクの絞り込みは有効", query: repository.TaskQuery{Waiting: repository.WaitingOnly}},
+		{name: "未知の待機中の絞り込みは無効", query: repository.TaskQuery{Waiting: "soon"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.query.Validate()
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestTaskQuery_ValidateStored(t *testing.T) {
+	// 緊急度による並び替えはリポジトリでは受け付けないこと
+	assert.Error(t, repository.TaskQuery{SortBy: repository.SortByUrgency}.ValidateStored())
+	assert.NoError(t, repository.TaskQuery{SortBy: repository.SortByPriority}.ValidateStored())
+	assert.Error(t, repository.TaskQuery{Limit: -1}.ValidateStored())
+}
+
+func TestParseSort(t *testing.T) {
+	tests := []struct {
+		input    string
+		wantKey  repository.SortKey
+		wantDesc bool
+		wantErr  bool
+	}{
+		{input: "deadline", wantKey: repository.SortByDeadline},
+		{input: "deadline:asc", wantKey: repository.SortByDeadline},
+		{input: "Title:DESC", wantKey: repository.SortByTitle, wantDesc: true},
+		{input: "priority", wantKey: repository.SortByPriority, wantDesc: true},
+		{input: "urgency:asc", wantKey: repository.SortByUrgency},
+		{input: "size", wantErr: true},
+		{input: "created:sideways", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			key, desc, err := repository.ParseSort(tt.input)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantKey, key)
+			assert.Equal(t, tt.wantDesc, desc)
+		})
+	}
+}
@@ -3,12 +3,103 @@ package repository
 import (
 	"OTakumi/todogo/internal/domain/model"
 	"context"
+	"time"
 )
 
+// TaskSearcher はタスクのタイトル、説明、注記を検索する
+// 全文検索の機能を持つデータベースではその機能を使い、持たない場合は部分一致で検索するなど、
+// 実装ごとに利用できる方法で検索する
+type TaskSearcher interface {
+	// Search は検索文字列に一致したタスクを、関連度の高い順に最大 limit 件返す（0以下の場合は制限しない）
+	// 検索文字列に検索語が含まれない場合はエラーを返す
+	Search(ctx context.Context, query string, limit int) ([]model.SearchHit, error)
+}
+
 type TaskRepository interface {
-	FindAll(ctx context.Context) ([]*model.Task, error)
+	TaskSearcher
+
+	// FindAll と FindByID はゴミ箱のタスクを返さない（検索、タグとプロジェクトの件数、依存関係、サブタスクの進捗も同様）
+	FindAll(ctx context.Context, query TaskQuery) ([]*model.Task, error)
 	FindByID(ctx context.Context, id string) (*model.Task, error)
+
+	// Create、Update、Delete はタスクの保存と同時に、操作をタスクの履歴に記録する
+	// 操作した利用者は WithActor でコンテキストに設定する
+	// Create と Update は、状態に応じて完了日時（Workflow.CompletedAt）を設定する
+	// コンテキストが WithBatch で操作のまとまりを保持する場合は、取り消しのために操作も記録する
+	// 操作を記録すると、取り消し済みの操作（やり直しの対象）はすべて削除する
 	Create(ctx context.Context, task *model.Task) (*model.Task, error)
 	Update(ctx context.Context, task *model.Task) (*model.Task, error)
+	// UpdateAll は tasks を順に、1つのトランザクションで更新して更新後のタスクを返す
+	// いずれかのタスクを更新できない場合は、いずれのタスクも更新せずにエラーを返す
+	UpdateAll(ctx context.Context, tasks []*model.Task) ([]*model.Task, error)
+	// Delete はタスクを完全には削除せず、ゴミ箱に移す（ゴミ箱のタスクは ErrTaskNotFound となる）
+	// サブタスクの親子関係、注記、依存関係、作業時間の記録は残す（サブタスクは親がゴミ箱にある間は最上位のタスクとして扱う）
 	Delete(ctx context.Context, id string) error
+
+	// FindDeleted はゴミ箱のタスクを、ゴミ箱に移した日時の新しい順に返す（DeletedAt を設定する）
+	FindDeleted(ctx context.Context) ([]*model.Task, error)
+
+	// Restore はゴミ箱のタスクを元に戻し、戻したタスクを返す
+	// 操作はタスクの履歴に記録し、コンテキストが操作のまとまりを保持する場合は作成として取り消しのために記録する
+	// ゴミ箱にない場合は ErrTaskNotFound として判定できるエラーを返す
+	Restore(ctx context.Context, id string) (*model.Task, error)
+
+	// Purge は before より前にゴミ箱に移したタスクを、注記、依存関係、作業時間の記録と共に完全に削除する
+	// 削除したタスクの件数を返し、操作はタスクの履歴に記録する（取り消しのためには記録しない）
+	Purge(ctx context.Context, before time.Time) (int, error)
+
+	// TaskEvents はタスクの履歴を、記録した順に返す
+	// ゴミ箱のタスクや完全に削除したタスクの履歴も返し、履歴がない場合は空のスライスを返す
+	TaskEvents(ctx context.Context, taskID string) ([]model.TaskEvent, error)
+
+	// Operations は取り消しのために記録した操作のうち、undone に一致するものを記録した順に返す
+	Operations(ctx context.Context, undone bool) ([]model.Operation, error)
+
+	// ReplayOperations は操作を ops の順に、取り消す（undo がtrue）またはやり直す
+	// すべての操作を1つのトランザクションで行い、記録後に変更されたタスクがある場合は、
+	// いずれの操作も行わずに model.ErrConflict として判定できるエラーを返す
+	// 状態の変更はワークフローの状態遷移によらず元に戻し、操作はタスクの履歴にも記録する
+	// 削除の取り消しはゴミ箱のタスクを元に戻し、完全に削除した後の場合は記録した項目から作成し直す
+	ReplayOperations(ctx context.Context, ops []model.Operation, undo bool) error
+
+	// TagCounts はタスクに付いているタグと、その件数を名前順に返す
+	// どのタスクにも付いていないタグは含めない
+	TagCounts(ctx context.Context) ([]model.TagCount, error)
+
+	// ProjectCounts はタスクが属しているプロジェクトと、その件数を返す
+	// 件数はプロジェクトに直接属するタスクのみを数え、上位のプロジェクトへの合算は行わない
+	ProjectCounts(ctx context.Context) ([]model.ProjectCount, error)
+
+	// AddDependency は taskID のタスクが blockedByID のタスクの完了を待つ依存関係を追加する
+	// 既に存在する場合は何もしない。依存関係が循環しないことは呼び出し元で検証すること
+	AddDependency(ctx context.Context, taskID, blockedByID string) error
+
+	// RemoveDependency は依存関係を削除する
+	// 依存関係が存在しない場合は ErrDependencyNotFound として判定できるエラーを返す
+	RemoveDependency(ctx context.Context, taskID, blockedByID string) error
+
+	// SaveRecurrence は繰り返しのルールを指定したIDで保存する（既に存在する場合はルールを置き換える）
+	// タスクは RecurrenceID でルールを参照し、取得時に Recurrence として読み込まれる
+	SaveRecurrence(ctx context.Context, id string, rule model.Recurrence) error
+
+	// DeleteRecurrence は繰り返しのルールを削除し、参照しているタスクを繰り返さないタスクにする
+	// ルールが存在しない場合は ErrRecurrenceNotFound として判定できるエラーを返す
+	DeleteRecurrence(ctx context.Context, id string) error
+
+	// AddAnnotation は taskID のタスクに日時付きの注記を追加し、追加した注記を返す
+	// タスクが存在しない場合は ErrTaskNotFound として判定できるエラーを返す
+	AddAnnotation(ctx context.Context, taskID, text string) (*model.Annotation, error)
+
+	// AddTimeEntry は作業時間の記録を追加し、IDを設定した記録を返す
+	// EndedAt が nil の記録は計測中の記録となり、利用者ごとに1件までとする
+	// 利用者の計測中の記録が既にある場合は model.ErrTimerRunning として判定できるエラーを返す
+	// タスクが存在しないかゴミ箱にある場合は ErrTaskNotFound として判定できるエラーを返す
+	AddTimeEntry(ctx context.Context, entry model.TimeEntry) (*model.TimeEntry, error)
+
+	// StopTimeEntry は計測中の記録を endedAt の日時で終了し、終了した記録を返す
+	// 記録が存在しないか既に終了している場合は ErrTimeEntryNotFound として判定できるエラーを返す
+	StopTimeEntry(ctx context.Context, id int64, endedAt time.Time) (*model.TimeEntry, error)
+
+	// TimeEntries は条件に一致する作業時間の記録を、開始日時の順に返す
+	TimeEntries(ctx context.Context, query TimeEntryQuery) ([]model.TimeEntry, error)
 }
@@ -0,0 +1,32 @@
+package repository
+
+import (
+	"errors"
+	"time"
+)
+
+// TimeEntryQuery は作業時間の記録を取得する際の絞り込み条件
+// ゼロ値は「すべての記録を取得する」ことを表す
+type TimeEntryQuery struct {
+	// TaskID は記録を取得するタスクのID（空の場合は絞り込まない）
+	TaskID string
+
+	// User は記録を取得する利用者の名前（空の場合は絞り込まない）
+	User string
+
+	// Running が true の場合、計測中の記録のみを対象とする
+	Running bool
+
+	// Since と Until は期間による絞り込み（Sinceは以上、Untilは未満）
+	// 期間と一部でも重なる記録を対象とし、計測中の記録は終わりのない記録として扱う
+	Since *time.Time
+	Until *time.Time
+}
+
+// Validate は条件の組み合わせが正しいかを検証する
+func (q TimeEntryQuery) Validate() error {
+	if q.Since != nil && q.Until != nil && !q.Since.Before(*q.Until) {
+		return errors.New("invalid time entry filter: since must be before until")
+	}
+	return nil
+}
@@ -1,9 +1,17 @@
 package usecase_test
 
+// MockIDGenerator は固定のIDを返すIDジェネレータ
+// IDs が設定されている場合は、先頭から順に返し、使い切った後は ID を返す
 type MockIDGenerator struct {
-	ID string
+	ID  string
+	IDs []string
 }
 
 func (m *MockIDGenerator) NewID() string {
+	if len(m.IDs) > 0 {
+		id := m.IDs[0]
+		m.IDs = m.IDs[1:]
+		return id
+	}
 	return m.ID
 }
@@ -2,7 +2,9 @@ package usecase_test
 
 import (
 	"OTakumi/todogo/internal/domain/model"
+	"OTakumi/todogo/internal/repository"
 	"context"
+	"time"
 
 	"github.com/stretchr/testify/mock"
 )
@@ -13,8 +15,8 @@ type MockTaskRepository struct {
 }
 
 // モックがTaskRepositoryインターフェースを実装するように、全てのメソッドを定義する
-func (m *MockTaskRepository) FindAll(ctx context.Context) ([]*model.Task, error) {
-	args := m.Called(ctx)
+func (m *MockTaskRepository) FindAll(ctx context.Context, query repository.TaskQuery) ([]*model.Task, error) {
+	args := m.Called(ctx, query)
 	// 戻り値を設定。args.Get(0)が1番目の戻り値、args.Error(1)が2番目の戻り値(error)
 	// 戻り値がnilの可能性がある場合は型アサーションで安全に取得する
 	var tasks []*model.Task
@@ -52,7 +54,141 @@ func (m *MockTaskRepository) Update(ctx context.Context, task *model.Task) (*mod
 	return updatedTask, args.Error(1)
 }
 
+func (m *MockTaskRepository) UpdateAll(ctx context.Context, tasks []*model.Task) ([]*model.Task, error) {
+	args := m.Called(ctx, tasks)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*model.Task), args.Error(1)
+}
+
 func (m *MockTaskRepository) Delete(ctx context.Context, id string) error {
 	args := m.Called(ctx, id)
 	return args.Error(0)
 }
+
+func (m *MockTaskRepository) FindDeleted(ctx context.Context) ([]*model.Task, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*model.Task), args.Error(1)
+}
+
+func (m *MockTaskRepository) Restore(ctx context.Context, id string) (*model.Task, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.Task), args.Error(1)
+}
+
+func (m *MockTaskRepository) Purge(ctx context.Context, before time.Time) (int, error) {
+	args := m.Called(ctx, before)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockTaskRepository) TagCounts(ctx context.Context) ([]model.TagCount, error) {
+	args := m.Called(ctx)
+	var counts []model.TagCount
+	if args.Get(0) != nil {
+		counts = args.Get(0).([]model.TagCount)
+	}
+	return counts, args.Error(1)
+}
+
+func (m *MockTaskRepository) ProjectCounts(ctx context.Context) ([]model.ProjectCount, error) {
+	args := m.Called(ctx)
+	var counts []model.ProjectCount
+	if args.Get(0) != nil {
+		counts = args.Get(0).([]model.ProjectCount)
+	}
+	return counts, args.Error(1)
+}
+
+func (m *MockTaskRepository) AddDependency(ctx context.Context, taskID, blockedByID string) error {
+	args := m.Called(ctx, taskID, blockedByID)
+	return args.Error(0)
+}
+
+func (m *MockTaskRepository) RemoveDependency(ctx context.Context, taskID, blockedByID string) error {
+	args := m.Called(ctx, taskID, blockedByID)
+	return args.Error(0)
+}
+
+func (m *MockTaskRepository) SaveRecurrence(ctx context.Context, id string, rule model.Recurrence) error {
+	args := m.Called(ctx, id, rule)
+	return args.Error(0)
+}
+
+func (m *MockTaskRepository) DeleteRecurrence(ctx context.Context, id string) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockTaskRepository) AddAnnotation(ctx context.Context, taskID, text string) (*model.Annotation, error) {
+	args := m.Called(ctx, taskID, text)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.Annotation), args.Error(1)
+}
+
+func (m *MockTaskRepository) Search(ctx context.Context, query string, limit int) ([]model.SearchHit, error) {
+	args := m.Called(ctx, query, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]model.SearchHit), args.Error(1)
+}
+
+func (m *MockTaskRepository) AddTimeEntry(ctx context.Context, entry model.TimeEntry) (*model.TimeEntry, error) {
+	args := m.Called(ctx, entry)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.TimeEntry), args.Error(1)
+}
+
+func (m *MockTaskRepository) StopTimeEntry(ctx context.Context, id int64, endedAt time.Time) (*model.TimeEntry, error) {
+	args := m.Called(ctx, id, endedAt)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.TimeEntry), args.Error(1)
+}
+
+func (m *MockTaskRepository) TimeEntries(ctx context.Context, q repository.TimeEntryQuery) ([]model.TimeEntry, error) {
+	args := m.Called(ctx, q)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]model.TimeEntry), args.Error(1)
+}
+
+func (m *MockTaskRepository) TaskEvents(ctx context.Context, taskID string) ([]model.TaskEvent, error) {
+	args := m.Called(ctx, taskID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]model.TaskEvent), args.Error(1)
+}
+
+func (m *MockTaskRepository) Operations(ctx context.Context, undone bool) ([]model.Operation, error) {
+	args := m.Called(ctx, undone)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]model.Operation), args.Error(1)
+}
+
+func (m *MockTaskRepository) ReplayOperations(ctx context.Context, ops []model.Operation, undo bool) error {
+	args := m.Called(ctx, ops, undo)
+	return args.Error(0)
+}
+
+// journaled は取り消しのために操作を記録する（操作のまとまりを保持した）コンテキストに一致する
+var journaled = mock.MatchedBy(func(ctx context.Context) bool {
+	_, _, ok := repository.BatchFrom(ctx)
+	return ok
+})
@@ -5,43 +5,790 @@ import (
 	"OTakumi/todogo/internal/domain/service"
 	"OTakumi/todogo/internal/repository"
 	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
 )
 
+// CreateTaskParams はタスク作成時に指定できる項目
+// Title以外は省略可能
+type CreateTaskParams struct {
+	Title    string
+	Deadline *time.Time
+	Priority model.Priority
+	// Tags は正規化前のタグ名（作成時に正規化し、重複を除く）
+	Tags []string
+	// Project は正規化前のプロジェクト名（空の場合はプロジェクトなし）
+	Project string
+	// ParentID は親タスクのID（空の場合は最上位のタスク）
+	ParentID string
+	// Recurrence は繰り返しのルール（nilの場合は繰り返さないタスク）
+	Recurrence *model.Recurrence
+	// StartAt は着手できるようになる日時（nilの場合はすぐに着手できる）
+	StartAt *time.Time
+	// WaitUntil は一覧に表示しない期限（nilの場合は待機しない）
+	WaitUntil *time.Time
+	// Estimate は見積もり（nilの場合は見積もりなし）
+	Estimate *model.Estimate
+}
+
 type TaskUsecase interface {
-	CreateTask(ctx context.Context, title string) (*model.Task, error)
-	FindAll(ctx context.Context) ([]*model.Task, error)
+	CreateTask(ctx context.Context, params CreateTaskParams) (*model.Task, error)
+	FindAll(ctx context.Context, query repository.TaskQuery) ([]*model.Task, error)
+	GetTask(ctx context.Context, id string) (*model.Task, error)
+	UpdateTask(ctx context.Context, task *model.Task) (*model.Task, error)
+	CompleteTask(ctx context.Context, task *model.Task, loc *time.Location) (next *model.Task, err error)
+	SetStatus(ctx context.Context, id string, status model.Status) (*model.Task, error)
+	SetRecurrence(ctx context.Context, id string, rule *model.Recurrence) (*model.Task, error)
+	Snooze(ctx context.Context, id string, d time.Duration) (*model.Task, error)
+	CompleteTaskWithSubtasks(ctx context.Context, task *model.Task, loc *time.Location) (next *model.Task, completed int, err error)
+	Block(ctx context.Context, id, blockedByID string) error
+	Unblock(ctx context.Context, id, blockedByID string) error
+	Annotate(ctx context.Context, id, text string) (*model.Annotation, error)
+	StartTimer(ctx context.Context, user, id string) (*model.TimeEntry, error)
+	StopTimer(ctx context.Context, user string) (*model.TimeEntry, error)
+	LogTime(ctx context.Context, user, id string, d time.Duration) (*model.TimeEntry, error)
+	TimeEntries(ctx context.Context, id string) ([]model.TimeEntry, error)
+	History(ctx context.Context, id string) ([]model.TaskEvent, error)
+	Undo(ctx context.Context, n int) ([]model.OperationBatch, error)
+	Redo(ctx context.Context, n int) ([]model.OperationBatch, error)
+	Timesheet(ctx context.Context, user string, from, to time.Time) (model.Timesheet, error)
+	EstimateReport(ctx context.Context) (model.EstimateReport, error)
+	Search(ctx context.Context, query string, limit int) ([]model.SearchHit, error)
+	Dependencies(ctx context.Context, id string) (upstream, downstream []service.ChainLink, err error)
+	DeleteTask(ctx context.Context, id string) error
+	Trash(ctx context.Context) ([]*model.Task, error)
+	RestoreTask(ctx context.Context, id string) (*model.Task, error)
+	PurgeTasks(ctx context.Context, olderThan time.Duration) (int, error)
+	ResolveID(ctx context.Context, ref string) (string, error)
+	ResolveDeletedID(ctx context.Context, ref string) (string, error)
+	ShortIDs(ctx context.Context) (map[string]string, error)
+	DeletedShortIDs(ctx context.Context) (map[string]string, error)
+	Tags(ctx context.Context) ([]model.TagCount, error)
+	Projects(ctx context.Context) ([]model.ProjectCount, error)
 }
 
+// shortIDMinLength は短縮IDとして表示する接頭辞の最小文字数
+const shortIDMinLength = 4
+
 type taskUsecase struct {
 	taskRepo    repository.TaskRepository
 	idGenerator service.IDGenerator
+	// workflow は状態の変更と、完了済み（終了状態）かどうかの判定に使う
+	workflow *model.Workflow
+
+	// now は緊急度の算出に使う現在時刻を返す
+	now func() time.Time
+}
+
+// WithCommand はタスクを変更するコマンドの内容を保持したコンテキストを返す
+// このコンテキストで行ったタスクの作成、更新、削除は、まとめて1つのコマンドとして取り消す
+// 保持していない場合は、タスクを変更するメソッドの呼び出しごとに取り消す
+func WithCommand(ctx context.Context, command string) context.Context {
+	return repository.WithBatch(ctx, uuid.NewString(), command)
 }
 
-func NewTaskUsecase(tr repository.TaskRepository, ig service.IDGenerator) TaskUsecase {
+func NewTaskUsecase(tr repository.TaskRepository, ig service.IDGenerator, wf *model.Workflow) TaskUsecase {
 	return &taskUsecase{
 		taskRepo:    tr,
 		idGenerator: ig,
+		workflow:    wf,
+		now:         time.Now,
 	}
 }
 
-func (tu *taskUsecase) CreateTask(ctx context.Context, title string) (*model.Task, error) {
+// journal はタスクの作成、更新、削除を取り消しのために記録するコンテキストを返す
+// コマンドの内容を保持していない場合は、メソッドの呼び出しを1つのまとまりとする
+func journal(ctx context.Context) context.Context {
+	if _, _, ok := repository.BatchFrom(ctx); ok {
+		return ctx
+	}
+	return WithCommand(ctx, "")
+}
+
+func (tu *taskUsecase) CreateTask(ctx context.Context, params CreateTaskParams) (*model.Task, error) {
+	ctx = journal(ctx)
 	// idを取得する
 	id := tu.idGenerator.NewID()
 
 	// タスクを生成
-	task := model.NewTask(id, title)
+	task := model.NewTask(id, params.Title)
+	task.Status = tu.workflow.Initial
+	task.Deadline = params.Deadline
+	task.Priority = params.Priority
+	task.StartAt = params.StartAt
+	task.WaitUntil = params.WaitUntil
+	task.Estimate = params.Estimate
+
+	tags, err := model.NormalizeTags(params.Tags)
+	if err != nil {
+		return nil, err
+	}
+	task.Tags = tags
+
+	project, err := model.NormalizeProject(params.Project)
+	if err != nil {
+		return nil, err
+	}
+	task.Project = project
 
 	if err := task.Validate(); err != nil {
 		return nil, err
 	}
 
-	return tu.taskRepo.Create(ctx, task)
+	if params.ParentID != "" {
+		task.ParentID = params.ParentID
+		if err := tu.validateSubtasks(ctx, task); err != nil {
+			return nil, err
+		}
+	}
+
+	if params.Recurrence == nil {
+		return tu.taskRepo.Create(ctx, task)
+	}
+
+	// 繰り返すタスクは、先に保存したルールを参照して作成する
+	task.RecurrenceID = tu.idGenerator.NewID()
+	if err := tu.taskRepo.SaveRecurrence(ctx, task.RecurrenceID, *params.Recurrence); err != nil {
+		return nil, err
+	}
+	created, err := tu.taskRepo.Create(ctx, task)
+	if err != nil {
+		_ = tu.taskRepo.DeleteRecurrence(ctx, task.RecurrenceID)
+		return nil, err
+	}
+	created.Recurrence = params.Recurrence
+	return created, nil
 }
 
-// FindAll は登録されているすべてのタスクを取得する
+// FindAll は条件に一致するタスクを取得する
+// ゼロ値の条件を渡した場合は、登録されているすべてのタスクを返す
 // リポジトリ層に処理を委譲し、取得したタスクをそのまま返す
-func (tu *taskUsecase) FindAll(ctx context.Context) ([]*model.Task, error) {
+// 緊急度による並び替えは現在時刻に依存するため、取得後にこの層で行う
+func (tu *taskUsecase) FindAll(ctx context.Context, query repository.TaskQuery) ([]*model.Task, error) {
+	// 条件の検証はデータベースへの問い合わせ前に行う
+	if err := query.Validate(); err != nil {
+		return nil, err
+	}
+
+	if query.SortBy == repository.SortByUrgency {
+		return tu.findAllByUrgency(ctx, query)
+	}
+
 	// リポジトリ層のFindAllメソッドを呼び出し
-	// データベースからすべてのタスクを取得する
-	return tu.taskRepo.FindAll(ctx)
+	// データベースから条件に一致するタスクを取得する
+	return tu.taskRepo.FindAll(ctx, query)
+}
+
+// findAllByUrgency は絞り込み条件に一致するタスクを全件取得し、緊急度の順に並び替えてからページングを適用する
+// カーソルのタスクが存在しない場合は、リポジトリと同様に何も返さない
+func (tu *taskUsecase) findAllByUrgency(ctx context.Context, query repository.TaskQuery) ([]*model.Task, error) {
+	stored := query
+	stored.SortBy, stored.SortDesc = repository.SortByCreatedAt, false
+	stored.Limit, stored.AfterID = 0, ""
+
+	tasks, err := tu.taskRepo.FindAll(ctx, stored)
+	if err != nil {
+		return nil, err
+	}
+
+	now := tu.now()
+	less := service.UrgencyLess(tasks, tu.workflow, now, query.SortDesc)
+	sort.SliceStable(tasks, func(i, j int) bool {
+		return less(tasks[i], tasks[j])
+	})
+
+	// キーセットページング: 絞り込みで除外されたタスクもカーソルにできるよう、カーソルは個別に取得する
+	if query.AfterID != "" {
+		cursor, err := tu.taskRepo.FindByID(ctx, query.AfterID)
+		if errors.Is(err, repository.ErrTaskNotFound) {
+			return nil, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		start := sort.Search(len(tasks), func(i int) bool {
+			return less(cursor, tasks[i])
+		})
+		tasks = tasks[start:]
+	}
+
+	if query.Limit > 0 && len(tasks) > query.Limit {
+		tasks = tasks[:query.Limit]
+	}
+	return tasks, nil
+}
+
+// GetTask は指定されたIDのタスクを取得する
+// タスクが存在しない場合は repository.ErrTaskNotFound として判定できるエラーを返す
+func (tu *taskUsecase) GetTask(ctx context.Context, id string) (*model.Task, error) {
+	return tu.taskRepo.FindByID(ctx, id)
+}
+
+// UpdateTask は既存のタスクを更新する
+// 更新内容の検証と更新日時の設定はリポジトリ層のトランザクション内で行われる
+// 親子関係の規則（循環しないこと、未完了のサブタスクがあるタスクを完了しないこと）はこの層で検証する
+func (tu *taskUsecase) UpdateTask(ctx context.Context, task *model.Task) (*model.Task, error) {
+	ctx = journal(ctx)
+	if task.ID == "" {
+		return nil, errors.New("task ID is required")
+	}
+
+	if task.ParentID != "" || tu.workflow.IsClosed(task) {
+		if err := tu.validateSubtasks(ctx, task); err != nil {
+			return nil, err
+		}
+	}
+
+	return tu.taskRepo.Update(ctx, task)
+}
+
+// CompleteTask はタスクをワークフローの完了の状態（Workflow.Done）にする
+// 現在の状態から変更できない場合は model.ErrIllegalTransition として判定できるエラーを返す
+// 繰り返すタスクの場合は、ルールに従った次の締切で同じ内容の新しいタスクを作成して返す（繰り返さない場合はnil）
+// 次の締切の日付は loc のタイムゾーンで計算する
+// 同じ繰り返しの未完了のタスクが他にある場合（完了を取り消して再度完了した場合など）は、新しいタスクを作成しない
+// 開始日時は締切との間隔を保って移動し、待機の期限は引き継がない
+func (tu *taskUsecase) CompleteTask(ctx context.Context, task *model.Task, loc *time.Location) (*model.Task, error) {
+	ctx = journal(ctx)
+	if err := tu.workflow.Transition(task, tu.workflow.Done()); err != nil {
+		return nil, err
+	}
+	if _, err := tu.UpdateTask(ctx, task); err != nil {
+		return nil, err
+	}
+	return tu.nextOccurrence(ctx, task, loc)
+}
+
+// nextOccurrence は完了した繰り返すタスクの次のタスクを作成して返す（作成しない場合はnil）
+func (tu *taskUsecase) nextOccurrence(ctx context.Context, task *model.Task, loc *time.Location) (*model.Task, error) {
+	if task.RecurrenceID == "" || task.Recurrence == nil {
+		return nil, nil
+	}
+
+	tasks, err := tu.taskRepo.FindAll(ctx, repository.TaskQuery{})
+	if err != nil {
+		return nil, err
+	}
+	byID := make(map[string]*model.Task, len(tasks))
+	for _, t := range tasks {
+		if t.RecurrenceID == task.RecurrenceID && t.ID != task.ID && !tu.workflow.IsClosed(t) {
+			return nil, nil
+		}
+		byID[t.ID] = t
+	}
+
+	next := model.NewTask(tu.idGenerator.NewID(), task.Title)
+	next.Status = tu.workflow.Initial
+	deadline := service.NextDeadline(*task.Recurrence, task.Deadline, tu.now().In(loc))
+	next.Deadline = &deadline
+	next.Description = task.Description
+	next.Priority = task.Priority
+	if task.Estimate != nil {
+		estimate := *task.Estimate
+		next.Estimate = &estimate
+	}
+	next.Tags = append([]string{}, task.Tags...)
+	next.Project = task.Project
+	next.RecurrenceID = task.RecurrenceID
+	if task.StartAt != nil && task.Deadline != nil {
+		startAt := deadline.Add(task.StartAt.Sub(*task.Deadline))
+		next.StartAt = &startAt
+	}
+	// 親タスクが完了済みの場合は、サブタスクにできないため最上位のタスクとする
+	if parent, ok := byID[task.ParentID]; ok && !tu.workflow.IsClosed(parent) {
+		next.ParentID = parent.ID
+	}
+
+	created, err := tu.taskRepo.Create(ctx, next)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create next occurrence: %w", err)
+	}
+	created.Recurrence = task.Recurrence
+	return created, nil
+}
+
+// SetStatus はタスクの状態を変更し、更新後のタスクを返す
+// ワークフローで許可されていない変更の場合は model.ErrIllegalTransition として判定できるエラーを返す
+// 終了状態にする場合も CompleteTask と異なり、繰り返すタスクの次のタスクは作成しない
+func (tu *taskUsecase) SetStatus(ctx context.Context, id string, status model.Status) (*model.Task, error) {
+	ctx = journal(ctx)
+	task, err := tu.taskRepo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := tu.workflow.Transition(task, status); err != nil {
+		return nil, err
+	}
+	return tu.UpdateTask(ctx, task)
+}
+
+// SetRecurrence はタスクの繰り返しのルールを設定し、更新後のタスクを返す
+//   - 既に繰り返すタスクの場合は、同じ繰り返しのすべてのタスクが参照するルールを変更する
+//   - 繰り返さないタスクの場合は、新しいルールを作成してタスクから参照する
+//   - rule がnilの場合はルールを削除し、同じ繰り返しのすべてのタスクを繰り返さないタスクにする
+func (tu *taskUsecase) SetRecurrence(ctx context.Context, id string, rule *model.Recurrence) (*model.Task, error) {
+	ctx = journal(ctx)
+	task, err := tu.taskRepo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	switch {
+	case rule == nil:
+		if task.RecurrenceID == "" {
+			return nil, fmt.Errorf("task %s does not repeat", id)
+		}
+		if err := tu.taskRepo.DeleteRecurrence(ctx, task.RecurrenceID); err != nil {
+			return nil, err
+		}
+	case task.RecurrenceID != "":
+		if err := tu.taskRepo.SaveRecurrence(ctx, task.RecurrenceID, *rule); err != nil {
+			return nil, err
+		}
+	default:
+		task.RecurrenceID = tu.idGenerator.NewID()
+		if err := tu.taskRepo.SaveRecurrence(ctx, task.RecurrenceID, *rule); err != nil {
+			return nil, err
+		}
+		if _, err := tu.taskRepo.Update(ctx, task); err != nil {
+			_ = tu.taskRepo.DeleteRecurrence(ctx, task.RecurrenceID)
+			return nil, err
+		}
+	}
+
+	return tu.taskRepo.FindByID(ctx, id)
+}
+
+// Snooze はタスクの待機の期限を d だけ先に延ばし、更新後のタスクを返す
+// 期限を過ぎている場合や待機していない場合は、現在時刻から d 後までとする
+func (tu *taskUsecase) Snooze(ctx context.Context, id string, d time.Duration) (*model.Task, error) {
+	ctx = journal(ctx)
+	task, err := tu.taskRepo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := task.Snooze(d, tu.now()); err != nil {
+		return nil, err
+	}
+	return tu.taskRepo.Update(ctx, task)
+}
+
+// CompleteTaskWithSubtasks は配下にある未完了のサブタスクと共に、タスクをワークフローの完了の状態にする
+// 子より先に孫を、最後にタスク自身を完了させ、すべてを1つのトランザクションと操作のまとまりで更新する
+// タスク自身か、完了の状態に変更できないサブタスクがある場合は、いずれのタスクも変更せずにエラーを返す
+// 完了にしたサブタスクの件数と、CompleteTask と同様に繰り返すタスクの次のタスクを返す
+func (tu *taskUsecase) CompleteTaskWithSubtasks(ctx context.Context, task *model.Task, loc *time.Location) (*model.Task, int, error) {
+	ctx = journal(ctx)
+	tasks, err := tu.taskRepo.FindAll(ctx, repository.TaskQuery{})
+	if err != nil {
+		return nil, 0, err
+	}
+
+	// いずれかのタスクを変更できない場合にタスクの状態を書き換えないよう、先にすべての状態遷移を検証する
+	done := tu.workflow.Done()
+	if err := tu.workflow.CheckTransition(task.Status, done); err != nil {
+		return nil, 0, err
+	}
+	var open []*model.Task
+	for _, sub := range service.NewTaskTree(tasks).Descendants(task.ID) {
+		if tu.workflow.IsClosed(sub) {
+			continue
+		}
+		if err := tu.workflow.CheckTransition(sub.Status, done); err != nil {
+			return nil, 0, fmt.Errorf("cannot complete subtask %s: %w", sub.ID, err)
+		}
+		open = append(open, sub)
+	}
+
+	updates := make([]*model.Task, 0, len(open)+1)
+	for i := len(open) - 1; i >= 0; i-- {
+		open[i].Status = done
+		updates = append(updates, open[i])
+	}
+	task.Status = done
+	updates = append(updates, task)
+	if _, err := tu.taskRepo.UpdateAll(ctx, updates); err != nil {
+		return nil, 0, err
+	}
+
+	next, err := tu.nextOccurrence(ctx, task, loc)
+	return next, len(open), err
+}
+
+// validateSubtasks は作成・更新後のタスクが、登録済みのタスクとの親子関係の規則を満たしているかを検証する
+func (tu *taskUsecase) validateSubtasks(ctx context.Context, task *model.Task) error {
+	tasks, err := tu.taskRepo.FindAll(ctx, repository.TaskQuery{})
+	if err != nil {
+		return err
+	}
+	return service.NewTaskTree(tasks).Validate(task, tu.workflow)
+}
+
+// Block は id のタスクが blockedByID のタスクの完了を待つ依存関係を追加する
+// 依存関係が循環する場合は service.ErrDependencyCycle として判定できるエラーを返す
+func (tu *taskUsecase) Block(ctx context.Context, id, blockedByID string) error {
+	tasks, err := tu.taskRepo.FindAll(ctx, repository.TaskQuery{})
+	if err != nil {
+		return err
+	}
+	if err := service.NewDependencyGraph(tasks).ValidateDependency(id, blockedByID); err != nil {
+		return err
+	}
+	return tu.taskRepo.AddDependency(ctx, id, blockedByID)
+}
+
+// Unblock は id のタスクと blockedByID のタスクの依存関係を削除する
+// 依存関係が存在しない場合は repository.ErrDependencyNotFound として判定できるエラーを返す
+func (tu *taskUsecase) Unblock(ctx context.Context, id, blockedByID string) error {
+	return tu.taskRepo.RemoveDependency(ctx, id, blockedByID)
+}
+
+// Annotate は指定したタスクに日時付きの注記を追加する
+// タスクが存在しない場合は repository.ErrTaskNotFound として判定できるエラーを返す
+func (tu *taskUsecase) Annotate(ctx context.Context, id, text string) (*model.Annotation, error) {
+	return tu.taskRepo.AddAnnotation(ctx, id, text)
+}
+
+// StartTimer は指定したタスクの作業時間の計測を開始し、計測中の記録を返す
+// 計測中の記録は利用者ごとに1件までとし、既に計測中の場合は model.ErrTimerRunning として判定できるエラーを返す
+func (tu *taskUsecase) StartTimer(ctx context.Context, user, id string) (*model.TimeEntry, error) {
+	if _, err := tu.taskRepo.FindByID(ctx, id); err != nil {
+		return nil, err
+	}
+
+	running, err := tu.runningEntry(ctx, user)
+	if err != nil {
+		return nil, err
+	}
+	if running != nil {
+		return nil, &model.TimerRunningError{Entry: *running}
+	}
+
+	return tu.taskRepo.AddTimeEntry(ctx, model.TimeEntry{TaskID: id, User: user, StartedAt: tu.now()})
+}
+
+// StopTimer は利用者の計測中の記録を現在時刻で終了し、終了した記録を返す
+// 計測中の記録がない場合は model.ErrNoTimerRunning を返す
+func (tu *taskUsecase) StopTimer(ctx context.Context, user string) (*model.TimeEntry, error) {
+	running, err := tu.runningEntry(ctx, user)
+	if err != nil {
+		return nil, err
+	}
+	if running == nil {
+		return nil, model.ErrNoTimerRunning
+	}
+
+	return tu.taskRepo.StopTimeEntry(ctx, running.ID, tu.now())
+}
+
+// runningEntry は利用者の計測中の記録を返す（計測中の記録がない場合はnil）
+func (tu *taskUsecase) runningEntry(ctx context.Context, user string) (*model.TimeEntry, error) {
+	entries, err := tu.taskRepo.TimeEntries(ctx, repository.TimeEntryQuery{User: user, Running: true})
+	if err != nil {
+		return nil, err
+	}
+	if len(entries) == 0 {
+		return nil, nil
+	}
+	return &entries[0], nil
+}
+
+// LogTime は指定したタスクに d の作業時間を手入力で記録する
+// 記録は現在時刻に終えた作業として、現在時刻の d 前から現在時刻までの区間とする
+// タスクが存在しないかゴミ箱にある場合は repository.ErrTaskNotFound として判定できるエラーを返す
+func (tu *taskUsecase) LogTime(ctx context.Context, user, id string, d time.Duration) (*model.TimeEntry, error) {
+	if d <= 0 {
+		return nil, fmt.Errorf("duration must be positive: %s", d)
+	}
+
+	now := tu.now()
+	return tu.taskRepo.AddTimeEntry(ctx, model.TimeEntry{TaskID: id, User: user, StartedAt: now.Add(-d), EndedAt: &now})
+}
+
+// TimeEntries は指定したタスクの作業時間の記録を、開始日時の順に返す
+func (tu *taskUsecase) TimeEntries(ctx context.Context, id string) ([]model.TimeEntry, error) {
+	return tu.taskRepo.TimeEntries(ctx, repository.TimeEntryQuery{TaskID: id})
+}
+
+// History は指定したタスクの履歴を、記録した順に返す
+// 削除したタスクの履歴も返し、履歴も存在するタスクもない場合はNotFoundエラーを返す
+func (tu *taskUsecase) History(ctx context.Context, id string) ([]model.TaskEvent, error) {
+	events, err := tu.taskRepo.TaskEvents(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if len(events) > 0 {
+		return events, nil
+	}
+
+	// 履歴の記録を始める前に作成したタスクは、存在する場合は空の履歴を返す
+	if _, err := tu.taskRepo.FindByID(ctx, id); err != nil {
+		return nil, err
+	}
+	return events, nil
+}
+
+// Undo は直近に行った n 件のコマンドによるタスクの作成、更新、削除を取り消し、取り消したコマンドを新しい順に返す
+// 取り消す操作がない場合は空のスライスを返し、n 件に満たない場合はすべてを取り消す
+// 記録後に変更されたタスクがある場合は、いずれも取り消さずに model.ErrConflict として判定できるエラーを返す
+func (tu *taskUsecase) Undo(ctx context.Context, n int) ([]model.OperationBatch, error) {
+	if n < 1 {
+		return nil, fmt.Errorf("invalid count %d: must be at least 1", n)
+	}
+	ops, err := tu.taskRepo.Operations(ctx, false)
+	if err != nil {
+		return nil, err
+	}
+
+	// 新しいコマンドから順に、コマンド内の操作も行った順と逆の順で取り消す
+	batches := model.GroupOperations(ops)
+	if len(batches) > n {
+		batches = batches[len(batches)-n:]
+	}
+	slices.Reverse(batches)
+	var replay []model.Operation
+	for _, b := range batches {
+		for i := len(b.Operations) - 1; i >= 0; i-- {
+			replay = append(replay, b.Operations[i])
+		}
+	}
+	if len(replay) == 0 {
+		return []model.OperationBatch{}, nil
+	}
+
+	if err := tu.taskRepo.ReplayOperations(ctx, replay, true); err != nil {
+		return nil, err
+	}
+	return batches, nil
+}
+
+// Redo は直近に取り消した n 件のコマンドをやり直し、やり直したコマンドを行った順に返す
+// 取り消した後に新たにタスクを変更した場合は、やり直せる操作はない
+// 記録後に変更されたタスクがある場合は、いずれもやり直さずに model.ErrConflict として判定できるエラーを返す
+func (tu *taskUsecase) Redo(ctx context.Context, n int) ([]model.OperationBatch, error) {
+	if n < 1 {
+		return nil, fmt.Errorf("invalid count %d: must be at least 1", n)
+	}
+	ops, err := tu.taskRepo.Operations(ctx, true)
+	if err != nil {
+		return nil, err
+	}
+
+	// 取り消し済みの操作は、最後に取り消したコマンドが最も古いため、古いコマンドから行った順にやり直す
+	batches := model.GroupOperations(ops)
+	if len(batches) > n {
+		batches = batches[:n]
+	}
+	var replay []model.Operation
+	for _, b := range batches {
+		replay = append(replay, b.Operations...)
+	}
+	if len(replay) == 0 {
+		return []model.OperationBatch{}, nil
+	}
+
+	if err := tu.taskRepo.ReplayOperations(ctx, replay, false); err != nil {
+		return nil, err
+	}
+	return batches, nil
+}
+
+// Timesheet は利用者の from から to の直前までの作業時間を、タスクとプロジェクトごとに集計する
+// 計測中の記録は現在時刻まで続いているものとして数える
+func (tu *taskUsecase) Timesheet(ctx context.Context, user string, from, to time.Time) (model.Timesheet, error) {
+	entries, err := tu.taskRepo.TimeEntries(ctx, repository.TimeEntryQuery{User: user, Since: &from, Until: &to})
+	if err != nil {
+		return model.Timesheet{}, err
+	}
+	tasks, err := tu.taskRepo.FindAll(ctx, repository.TaskQuery{})
+	if err != nil {
+		return model.Timesheet{}, err
+	}
+
+	sheet := service.BuildTimesheet(entries, tasks, from, to, tu.now())
+	sheet.User = user
+	return sheet, nil
+}
+
+// EstimateReport は見積もりのある完了したタスクについて、見積もりと実績を比較する
+// 実績は記録された作業時間の合計とし、記録のないタスクは作成から完了までの時間とする
+func (tu *taskUsecase) EstimateReport(ctx context.Context) (model.EstimateReport, error) {
+	tasks, err := tu.taskRepo.FindAll(ctx, repository.TaskQuery{})
+	if err != nil {
+		return model.EstimateReport{}, err
+	}
+	entries, err := tu.taskRepo.TimeEntries(ctx, repository.TimeEntryQuery{})
+	if err != nil {
+		return model.EstimateReport{}, err
+	}
+	return service.BuildEstimateReport(tasks, entries, tu.workflow), nil
+}
+
+// Search はタイトル、説明、注記から検索文字列に一致したタスクを、関連度の高い順に最大 limit 件返す
+// 検索の方法はリポジトリの実装によって異なり、全文検索の機能を持たない場合は部分一致で検索する
+func (tu *taskUsecase) Search(ctx context.Context, query string, limit int) ([]model.SearchHit, error) {
+	return tu.taskRepo.Search(ctx, query, limit)
+}
+
+// Dependencies は指定したタスクの上流（先に完了する必要があるタスク）と下流（完了を待っているタスク）を、
+// 依存関係を推移的にたどって返す
+func (tu *taskUsecase) Dependencies(ctx context.Context, id string) ([]service.ChainLink, []service.ChainLink, error) {
+	tasks, err := tu.taskRepo.FindAll(ctx, repository.TaskQuery{})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	found := false
+	for _, task := range tasks {
+		if task.ID == id {
+			found = true
+			break
+		}
+	}
+	if !found {
+		return nil, nil, &repository.TaskNotFoundError{ID: id}
+	}
+
+	g := service.NewDependencyGraph(tasks)
+	return g.Upstream(id), g.Downstream(id), nil
+}
+
+// DeleteTask は指定されたIDのタスクをゴミ箱に移す
+// ゴミ箱のタスクは RestoreTask で元に戻すか、PurgeTasks で完全に削除する
+func (tu *taskUsecase) DeleteTask(ctx context.Context, id string) error {
+	ctx = journal(ctx)
+	if id == "" {
+		return errors.New("task ID is required")
+	}
+
+	return tu.taskRepo.Delete(ctx, id)
+}
+
+// Trash はゴミ箱のタスクを、ゴミ箱に移した日時の新しい順に返す
+func (tu *taskUsecase) Trash(ctx context.Context) ([]*model.Task, error) {
+	return tu.taskRepo.FindDeleted(ctx)
+}
+
+// RestoreTask はゴミ箱のタスクを元に戻す
+// ゴミ箱にない場合は repository.ErrTaskNotFound として判定できるエラーを返す
+func (tu *taskUsecase) RestoreTask(ctx context.Context, id string) (*model.Task, error) {
+	ctx = journal(ctx)
+	if id == "" {
+		return nil, errors.New("task ID is required")
+	}
+
+	return tu.taskRepo.Restore(ctx, id)
+}
+
+// PurgeTasks は olderThan より前にゴミ箱に移したタスクを完全に削除し、削除した件数を返す
+// 0を指定した場合はゴミ箱のすべてのタスクを削除する
+func (tu *taskUsecase) PurgeTasks(ctx context.Context, olderThan time.Duration) (int, error) {
+	if olderThan < 0 {
+		return 0, errors.New("the age of tasks to purge must not be negative")
+	}
+	return tu.taskRepo.Purge(ctx, tu.now().Add(-olderThan))
+}
+
+// Tags は使用されているタグと、タグごとのタスクの件数を名前順に返す
+func (tu *taskUsecase) Tags(ctx context.Context) ([]model.TagCount, error) {
+	return tu.taskRepo.TagCounts(ctx)
+}
+
+// Projects はタスクが属するプロジェクトとその上位のプロジェクトについて、タスクの件数を階層順に返す
+// 上位のプロジェクトの件数には、配下のプロジェクトに属するタスクも含める
+func (tu *taskUsecase) Projects(ctx context.Context) ([]model.ProjectCount, error) {
+	counts, err := tu.taskRepo.ProjectCounts(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return service.RollUpProjects(counts), nil
+}
+
+// ResolveID はIDまたはIDの接頭辞から、対応するタスクの完全なIDを返す
+// 接頭辞が複数のタスクに一致する場合は候補を含む service.AmbiguousIDError を返す
+func (tu *taskUsecase) ResolveID(ctx context.Context, ref string) (string, error) {
+	ids, err := tu.allIDs(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	return matchID(ids, ref)
+}
+
+// ResolveDeletedID はIDまたはIDの接頭辞から、対応するゴミ箱のタスクの完全なIDを返す
+// 接頭辞が複数のタスクに一致する場合は候補を含む service.AmbiguousIDError を返す
+func (tu *taskUsecase) ResolveDeletedID(ctx context.Context, ref string) (string, error) {
+	ids, err := tu.deletedIDs(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	return matchID(ids, ref)
+}
+
+// matchID はIDの一覧から、接頭辞に一致する唯一のIDを返す
+func matchID(ids []string, ref string) (string, error) {
+	matches := service.MatchIDPrefix(ids, ref)
+	switch len(matches) {
+	case 0:
+		return "", &repository.TaskNotFoundError{ID: ref}
+	case 1:
+		return matches[0], nil
+	default:
+		return "", &service.AmbiguousIDError{Prefix: ref, Candidates: matches}
+	}
+}
+
+// ShortIDs は全タスクのIDと、それを一意に識別できる最短の接頭辞の対応を返す
+func (tu *taskUsecase) ShortIDs(ctx context.Context) (map[string]string, error) {
+	ids, err := tu.allIDs(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return service.ShortestUniquePrefixes(ids, shortIDMinLength), nil
+}
+
+// DeletedShortIDs はゴミ箱のタスクのIDと、ゴミ箱の中でそれを一意に識別できる最短の接頭辞の対応を返す
+func (tu *taskUsecase) DeletedShortIDs(ctx context.Context) (map[string]string, error) {
+	ids, err := tu.deletedIDs(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return service.ShortestUniquePrefixes(ids, shortIDMinLength), nil
+}
+
+// deletedIDs はゴミ箱のタスクのIDを返す
+func (tu *taskUsecase) deletedIDs(ctx context.Context) ([]string, error) {
+	tasks, err := tu.taskRepo.FindDeleted(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make([]string, 0, len(tasks))
+	for _, task := range tasks {
+		ids = append(ids, task.ID)
+	}
+	return ids, nil
+}
+
+// allIDs は登録されている全タスクのIDを返す
+func (tu *taskUsecase) allIDs(ctx context.Context) ([]string, error) {
+	tasks, err := tu.taskRepo.FindAll(ctx, repository.TaskQuery{})
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make([]string, 0, len(tasks))
+	for _, task := range tasks {
+		ids = append(ids, task.ID)
+	}
+	return ids, nil
 }
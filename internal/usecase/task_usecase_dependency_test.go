@@ -0,0 +1,92 @@
+package usecase_test
+
+import (
+	"OTakumi/todogo/internal/domain/model"
+	"OTakumi/todogo/internal/domain/service"
+	"OTakumi/todogo/internal/repository"
+	"OTakumi/todogo/internal/usecase"
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// dependencyTasks は "deploy" が "test" の、"test" が "build" の完了を待っている状態を返す
+func dependencyTasks() []*model.Task {
+	return []*model.Task{
+		{ID: "build", Title: "Build", Status: model.StatusDone},
+		{ID: "test", Title: "Test", BlockedBy: []string{"build"}},
+		{ID: "deploy", Title: "Deploy", BlockedBy: []string{"test"}},
+	}
+}
+
+// 依存関係を追加する場合
+func TestTaskUsecase_Block(t *testing.T) {
+	t.Run("検証した依存関係をリポジトリに渡す", func(t *testing.T) {
+		// Arrange
+		mockRepo := new(MockTaskRepository)
+		mockRepo.On("FindAll", mock.Anything, repository.TaskQuery{}).Return(dependencyTasks(), nil)
+		mockRepo.On("AddDependency", mock.Anything, "deploy", "build").Return(nil)
+
+		taskUsecase := usecase.NewTaskUsecase(mockRepo, &MockIDGenerator{}, model.DefaultWorkflow())
+
+		// Act
+		err := taskUsecase.Block(context.Background(), "deploy", "build")
+
+		// Assert
+		assert.NoError(t, err)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("循環する場合はリポジトリを呼ばずにエラーを返す", func(t *testing.T) {
+		// Arrange
+		mockRepo := new(MockTaskRepository)
+		mockRepo.On("FindAll", mock.Anything, repository.TaskQuery{}).Return(dependencyTasks(), nil)
+
+		taskUsecase := usecase.NewTaskUsecase(mockRepo, &MockIDGenerator{}, model.DefaultWorkflow())
+
+		// Act
+		err := taskUsecase.Block(context.Background(), "build", "deploy")
+
+		// Assert
+		assert.ErrorIs(t, err, service.ErrDependencyCycle)
+		mockRepo.AssertNotCalled(t, "AddDependency", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+// 依存関係をたどる場合
+func TestTaskUsecase_Dependencies(t *testing.T) {
+	t.Run("上流と下流のタスクを返す", func(t *testing.T) {
+		// Arrange
+		mockRepo := new(MockTaskRepository)
+		mockRepo.On("FindAll", mock.Anything, repository.TaskQuery{}).Return(dependencyTasks(), nil)
+
+		taskUsecase := usecase.NewTaskUsecase(mockRepo, &MockIDGenerator{}, model.DefaultWorkflow())
+
+		// Act
+		upstream, downstream, err := taskUsecase.Dependencies(context.Background(), "test")
+
+		// Assert
+		assert.NoError(t, err)
+		if assert.Len(t, upstream, 1) && assert.Len(t, downstream, 1) {
+			assert.Equal(t, "build", upstream[0].Task.ID)
+			assert.Equal(t, "deploy", downstream[0].Task.ID)
+		}
+	})
+
+	t.Run("タスクが存在しない場合はNotFoundエラーを返す", func(t *testing.T) {
+		// Arrange
+		mockRepo := new(MockTaskRepository)
+		mockRepo.On("FindAll", mock.Anything, repository.TaskQuery{}).Return(dependencyTasks(), nil)
+
+		taskUsecase := usecase.NewTaskUsecase(mockRepo, &MockIDGenerator{}, model.DefaultWorkflow())
+
+		// Act
+		_, _, err := taskUsecase.Dependencies(context.Background(), "missing")
+
+		// Assert
+		assert.True(t, errors.Is(err, repository.ErrTaskNotFound))
+	})
+}
@@ -0,0 +1,101 @@
+package usecase_test
+
+import (
+	"OTakumi/todogo/internal/domain/model"
+	"OTakumi/todogo/internal/repository"
+	"OTakumi/todogo/internal/usecase"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// 見積もりを指定してタスクを作成する場合
+func TestTaskUsecase_CreateTask_WithEstimate(t *testing.T) {
+	t.Run("見積もりを設定して保存する", func(t *testing.T) {
+		// Arrange
+		estimate := &model.Estimate{Points: 3}
+		mockRepo := new(MockTaskRepository)
+		mockRepo.On("Create", mock.Anything, mock.MatchedBy(func(task *model.Task) bool {
+			return task.Estimate == estimate
+		})).Return(&model.Task{ID: "new", Title: "Build API", Estimate: estimate}, nil)
+
+		taskUsecase := usecase.NewTaskUsecase(mockRepo, &MockIDGenerator{ID: "new"}, model.DefaultWorkflow())
+
+		// Act
+		task, err := taskUsecase.CreateTask(context.Background(), usecase.CreateTaskParams{Title: "Build API", Estimate: estimate})
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, estimate, task.Estimate)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("作業時間とポイントの両方を指定した場合は保存せずにエラーを返す", func(t *testing.T) {
+		// Arrange
+		mockRepo := new(MockTaskRepository)
+		taskUsecase := usecase.NewTaskUsecase(mockRepo, &MockIDGenerator{ID: "new"}, model.DefaultWorkflow())
+
+		// Act
+		task, err := taskUsecase.CreateTask(context.Background(), usecase.CreateTaskParams{
+			Title: "Build API", Estimate: &model.Estimate{Duration: time.Hour, Points: 3},
+		})
+
+		// Assert
+		assert.ErrorContains(t, err, "not both")
+		assert.Nil(t, task)
+		mockRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+}
+
+// 見積もりと実績を比較する場合
+func TestTaskUsecase_EstimateReport(t *testing.T) {
+	t.Run("完了したタスクの見積もりと記録された作業時間を比較する", func(t *testing.T) {
+		// Arrange
+		created := time.Now().Add(-72 * time.Hour)
+		started := created.Add(time.Hour)
+		ended := started.Add(3 * time.Hour)
+		mockRepo := new(MockTaskRepository)
+		mockRepo.On("FindAll", mock.Anything, repository.TaskQuery{}).Return([]*model.Task{
+			{ID: "done", Title: "Done", Status: model.StatusDone, Tags: []string{"work"}, Estimate: &model.Estimate{Duration: 2 * time.Hour}, CreatedAt: created, UpdatedAt: ended},
+			{ID: "open", Title: "Open", Status: model.StatusOpen, Estimate: &model.Estimate{Duration: time.Hour}, CreatedAt: created, UpdatedAt: created},
+		}, nil)
+		mockRepo.On("TimeEntries", mock.Anything, repository.TimeEntryQuery{}).
+			Return([]model.TimeEntry{{ID: 1, TaskID: "done", User: "alice", StartedAt: started, EndedAt: &ended}}, nil)
+
+		taskUsecase := usecase.NewTaskUsecase(mockRepo, &MockIDGenerator{ID: "unused"}, model.DefaultWorkflow())
+
+		// Act
+		report, err := taskUsecase.EstimateReport(context.Background())
+
+		// Assert
+		require.NoError(t, err)
+		require.Len(t, report.Tasks, 1)
+		assert.Equal(t, "done", report.Tasks[0].TaskID)
+		assert.Equal(t, 3*time.Hour, report.Tasks[0].Actual)
+		assert.Equal(t, model.ActualTracked, report.Tasks[0].Source)
+		assert.InDelta(t, 1.5, report.Tasks[0].Ratio, 1e-9)
+		require.Len(t, report.Tags, 1)
+		assert.Equal(t, "work", report.Tags[0].Tag)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("タスクの取得に失敗した場合はエラーを返す", func(t *testing.T) {
+		// Arrange
+		mockRepo := new(MockTaskRepository)
+		mockRepo.On("FindAll", mock.Anything, repository.TaskQuery{}).Return(nil, errors.New("db error"))
+
+		taskUsecase := usecase.NewTaskUsecase(mockRepo, &MockIDGenerator{ID: "unused"}, model.DefaultWorkflow())
+
+		// Act
+		_, err := taskUsecase.EstimateReport(context.Background())
+
+		// Assert
+		assert.ErrorContains(t, err, "db error")
+		mockRepo.AssertNotCalled(t, "TimeEntries", mock.Anything, mock.Anything)
+	})
+}
@@ -2,6 +2,7 @@ package usecase_test
 
 import (
 	"OTakumi/todogo/internal/domain/model"
+	"OTakumi/todogo/internal/repository"
 	"OTakumi/todogo/internal/usecase"
 	"context"
 	"errors"
@@ -32,13 +33,13 @@ func TestTaskUsecase_FindAll(t *testing.T) {
 		// モックの振る舞いを設定：FindAllが呼ばれたら空の配列を返す
 		// On メソッドで期待される呼び出しを定義
 		// Return メソッドで返却値を指定
-		mockRepo.On("FindAll", ctx).Return(expectedTasks, nil)
+		mockRepo.On("FindAll", ctx, repository.TaskQuery{}).Return(expectedTasks, nil)
 
 		// テスト対象のTaskUsecaseインスタンスを作成
-		taskUsecase := usecase.NewTaskUsecase(mockRepo, mockIDGenerator)
+		taskUsecase := usecase.NewTaskUsecase(mockRepo, mockIDGenerator, model.DefaultWorkflow())
 
 		// Act: テスト対象のメソッドを実行
-		tasks, err := taskUsecase.FindAll(ctx)
+		tasks, err := taskUsecase.FindAll(ctx, repository.TaskQuery{})
 
 		// Assert: 実行結果の検証
 		// エラーが発生していないことを確認
@@ -69,30 +70,30 @@ func TestTaskUsecase_FindAll(t *testing.T) {
 		deadline2 := now.Add(24 * time.Hour)
 		expectedTasks := []*model.Task{
 			{
-				ID:         "1",
-				Title:      "Task 1",
-				Deadline:   &deadline1,
-				IsComplete: false, // 未完了のタスク
-				CreatedAt:  now,
-				UpdatedAt:  now,
+				ID:        "1",
+				Title:     "Task 1",
+				Deadline:  &deadline1,
+				Status:    model.StatusOpen, // 未完了のタスク
+				CreatedAt: now,
+				UpdatedAt: now,
 			},
 			{
-				ID:         "2",
-				Title:      "Task 2",
-				Deadline:   &deadline2,
-				IsComplete: true, // 完了済みのタスク
-				CreatedAt:  now,
-				UpdatedAt:  now,
+				ID:        "2",
+				Title:     "Task 2",
+				Deadline:  &deadline2,
+				Status:    model.StatusDone, // 完了済みのタスク
+				CreatedAt: now,
+				UpdatedAt: now,
 			},
 		}
 
 		// モックの振る舞いを設定：FindAllが呼ばれたら2件のタスクを返す
-		mockRepo.On("FindAll", ctx).Return(expectedTasks, nil)
+		mockRepo.On("FindAll", ctx, repository.TaskQuery{}).Return(expectedTasks, nil)
 
-		taskUsecase := usecase.NewTaskUsecase(mockRepo, mockIDGenerator)
+		taskUsecase := usecase.NewTaskUsecase(mockRepo, mockIDGenerator, model.DefaultWorkflow())
 
 		// Act: テスト対象のメソッドを実行
-		tasks, err := taskUsecase.FindAll(ctx)
+		tasks, err := taskUsecase.FindAll(ctx, repository.TaskQuery{})
 
 		// Assert: 実行結果の検証
 		// エラーが発生していないことを確認
@@ -122,12 +123,12 @@ func TestTaskUsecase_FindAll(t *testing.T) {
 
 		// モックの振る舞いを設定：FindAllが呼ばれたらエラーを返す
 		// 第1引数にnil、第2引数にエラーを指定
-		mockRepo.On("FindAll", ctx).Return(nil, expectedError)
+		mockRepo.On("FindAll", ctx, repository.TaskQuery{}).Return(nil, expectedError)
 
-		taskUsecase := usecase.NewTaskUsecase(mockRepo, mockIDGenerator)
+		taskUsecase := usecase.NewTaskUsecase(mockRepo, mockIDGenerator, model.DefaultWorkflow())
 
 		// Act: テスト対象のメソッドを実行
-		tasks, err := taskUsecase.FindAll(ctx)
+		tasks, err := taskUsecase.FindAll(ctx, repository.TaskQuery{})
 
 		// Assert: 実行結果の検証
 		// エラーが発生していることを確認
@@ -144,3 +145,62 @@ func TestTaskUsecase_FindAll(t *testing.T) {
 		mockRepo.AssertExpectations(t)
 	})
 }
+
+// TestTaskUsecase_FindAll_ByUrgency は緊急度による並び替えがユースケース層で行われることを確認するテスト
+func TestTaskUsecase_FindAll_ByUrgency(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+	overdue := now.Add(-time.Hour)
+
+	// リポジトリからは作成日時の昇順で返される
+	low := &model.Task{ID: "low", Title: "Low", Priority: model.PriorityLow, CreatedAt: now}
+	none := &model.Task{ID: "none", Title: "None", CreatedAt: now}
+	late := &model.Task{ID: "late", Title: "Late", Deadline: &overdue, CreatedAt: now}
+	urgent := &model.Task{ID: "urgent", Title: "Urgent", Priority: model.PriorityUrgent, CreatedAt: now}
+	stored := []*model.Task{low, none, late, urgent}
+
+	// 絞り込み条件のみをリポジトリに渡し、並び替えとページングは行わせないこと
+	storedQuery := repository.TaskQuery{Statuses: []model.Status{model.StatusOpen}, SortBy: repository.SortByCreatedAt}
+
+	newUsecase := func() (*MockTaskRepository, usecase.TaskUsecase) {
+		mockRepo := new(MockTaskRepository)
+		mockRepo.On("FindAll", ctx, storedQuery).Return(append([]*model.Task(nil), stored...), nil)
+		return mockRepo, usecase.NewTaskUsecase(mockRepo, &MockIDGenerator{ID: "test-id"}, model.DefaultWorkflow())
+	}
+
+	t.Run("緊急度の高い順に並び替える", func(t *testing.T) {
+		mockRepo, tu := newUsecase()
+
+		tasks, err := tu.FindAll(ctx, repository.TaskQuery{Statuses: []model.Status{model.StatusOpen}, SortBy: repository.SortByUrgency, SortDesc: true})
+
+		assert.NoError(t, err)
+		assert.Equal(t, []*model.Task{late, urgent, low, none}, tasks)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("カーソルと件数を並び替えた後に適用する", func(t *testing.T) {
+		mockRepo, tu := newUsecase()
+		mockRepo.On("FindByID", ctx, "urgent").Return(urgent, nil)
+
+		tasks, err := tu.FindAll(ctx, repository.TaskQuery{
+			Statuses: []model.Status{model.StatusOpen}, SortBy: repository.SortByUrgency, SortDesc: true,
+			AfterID: "urgent", Limit: 1,
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, []*model.Task{low}, tasks)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("存在しないカーソルの場合は何も返さない", func(t *testing.T) {
+		mockRepo, tu := newUsecase()
+		mockRepo.On("FindByID", ctx, "missing").Return(nil, &repository.TaskNotFoundError{ID: "missing"})
+
+		tasks, err := tu.FindAll(ctx, repository.TaskQuery{
+			Statuses: []model.Status{model.StatusOpen}, SortBy: repository.SortByUrgency, SortDesc: true, AfterID: "missing",
+		})
+
+		assert.NoError(t, err)
+		assert.Empty(t, tasks)
+	})
+}
@@ -0,0 +1,71 @@
+package usecase_test
+
+import (
+	"OTakumi/todogo/internal/domain/model"
+	"OTakumi/todogo/internal/repository"
+	"OTakumi/todogo/internal/usecase"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// タスクの履歴を取得する場合
+func TestTaskUsecase_History(t *testing.T) {
+	t.Run("削除したタスクも記録された履歴を返す", func(t *testing.T) {
+		// Arrange
+		events := []model.TaskEvent{
+			{ID: 1, TaskID: "gone", Kind: model.TaskCreated, NewValue: "Task", OccurredAt: time.Now()},
+			{ID: 2, TaskID: "gone", Kind: model.TaskDeleted, OldValue: "Task", OccurredAt: time.Now()},
+		}
+		mockRepo := new(MockTaskRepository)
+		mockRepo.On("TaskEvents", mock.Anything, "gone").Return(events, nil)
+
+		taskUsecase := usecase.NewTaskUsecase(mockRepo, &MockIDGenerator{ID: "unused"}, model.DefaultWorkflow())
+
+		// Act
+		got, err := taskUsecase.History(context.Background(), "gone")
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, events, got)
+		mockRepo.AssertNotCalled(t, "FindByID", mock.Anything, mock.Anything)
+	})
+
+	t.Run("履歴のない既存のタスクは空の履歴を返す", func(t *testing.T) {
+		// Arrange
+		mockRepo := new(MockTaskRepository)
+		mockRepo.On("TaskEvents", mock.Anything, "old").Return([]model.TaskEvent{}, nil)
+		mockRepo.On("FindByID", mock.Anything, "old").Return(&model.Task{ID: "old", Title: "Old"}, nil)
+
+		taskUsecase := usecase.NewTaskUsecase(mockRepo, &MockIDGenerator{ID: "unused"}, model.DefaultWorkflow())
+
+		// Act
+		got, err := taskUsecase.History(context.Background(), "old")
+
+		// Assert
+		require.NoError(t, err)
+		assert.Empty(t, got)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("履歴もタスクもない場合はNotFoundエラーを返す", func(t *testing.T) {
+		// Arrange
+		mockRepo := new(MockTaskRepository)
+		mockRepo.On("TaskEvents", mock.Anything, "missing").Return([]model.TaskEvent{}, nil)
+		mockRepo.On("FindByID", mock.Anything, "missing").Return(nil, &repository.TaskNotFoundError{ID: "missing"})
+
+		taskUsecase := usecase.NewTaskUsecase(mockRepo, &MockIDGenerator{ID: "unused"}, model.DefaultWorkflow())
+
+		// Act
+		got, err := taskUsecase.History(context.Background(), "missing")
+
+		// Assert
+		assert.Nil(t, got)
+		assert.True(t, errors.Is(err, repository.ErrTaskNotFound), "expected ErrTaskNotFound, got %v", err)
+	})
+}
@@ -0,0 +1,204 @@
+package usecase_test
+
+import (
+	"OTakumi/todogo/internal/domain/model"
+	"OTakumi/todogo/internal/repository"
+	"OTakumi/todogo/internal/usecase"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+var dailyRule = model.Recurrence{Frequency: model.FrequencyDaily, Interval: 1}
+
+// 繰り返すタスクを作成する場合
+func TestTaskUsecase_CreateTask_WithRecurrence(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockTaskRepository)
+	mockRepo.On("SaveRecurrence", mock.Anything, "rec", dailyRule).Return(nil)
+	mockRepo.On("Create", mock.Anything, mock.MatchedBy(func(task *model.Task) bool {
+		return task.ID == "new" && task.RecurrenceID == "rec"
+	})).Return(&model.Task{ID: "new", Title: "Stand-up", RecurrenceID: "rec"}, nil)
+
+	taskUsecase := usecase.NewTaskUsecase(mockRepo, &MockIDGenerator{IDs: []string{"new", "rec"}}, model.DefaultWorkflow())
+
+	// Act
+	rule := dailyRule
+	task, err := taskUsecase.CreateTask(context.Background(), usecase.CreateTaskParams{Title: "Stand-up", Recurrence: &rule})
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, "rec", task.RecurrenceID)
+	assert.Equal(t, &dailyRule, task.Recurrence)
+	mockRepo.AssertExpectations(t)
+}
+
+// タスクを完了する場合
+func TestTaskUsecase_CompleteTask(t *testing.T) {
+	deadline := time.Now().Add(48 * time.Hour).Truncate(time.Second).In(time.UTC)
+
+	t.Run("繰り返さないタスクは完了するのみ", func(t *testing.T) {
+		// Arrange
+		task := &model.Task{ID: "task", Title: "Task"}
+		mockRepo := new(MockTaskRepository)
+		mockRepo.On("FindAll", mock.Anything, repository.TaskQuery{}).Return([]*model.Task{task}, nil)
+		mockRepo.On("Update", mock.Anything, task).Return(task, nil)
+
+		taskUsecase := usecase.NewTaskUsecase(mockRepo, &MockIDGenerator{ID: "next"}, model.DefaultWorkflow())
+
+		// Act
+		next, err := taskUsecase.CompleteTask(context.Background(), task, time.UTC)
+
+		// Assert
+		require.NoError(t, err)
+		assert.Nil(t, next)
+		assert.Equal(t, model.StatusDone, task.Status)
+		mockRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+
+	t.Run("繰り返すタスクは次の締切で同じ内容のタスクを作成する", func(t *testing.T) {
+		// Arrange
+		parent := &model.Task{ID: "parent", Title: "Parent"}
+		startAt := deadline.Add(-2 * time.Hour)
+		waitUntil := deadline.Add(-time.Hour)
+		task := &model.Task{
+			ID: "task", Title: "Stand-up", Description: "Share yesterday's progress", Deadline: &deadline, Priority: model.PriorityHigh,
+			StartAt: &startAt, WaitUntil: &waitUntil,
+			Tags: []string{"team"}, Project: "work", ParentID: "parent", BlockedBy: []string{"other"},
+			RecurrenceID: "rec", Recurrence: &dailyRule,
+		}
+		mockRepo := new(MockTaskRepository)
+		mockRepo.On("FindAll", mock.Anything, repository.TaskQuery{}).Return([]*model.Task{parent, task}, nil)
+		mockRepo.On("Update", mock.Anything, task).Return(task, nil)
+		var saved *model.Task
+		mockRepo.On("Create", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+			saved = args.Get(1).(*model.Task)
+		}).Return(&model.Task{ID: "next", Title: "Stand-up", RecurrenceID: "rec"}, nil)
+
+		taskUsecase := usecase.NewTaskUsecase(mockRepo, &MockIDGenerator{ID: "next"}, model.DefaultWorkflow())
+
+		// Act
+		next, err := taskUsecase.CompleteTask(context.Background(), task, time.UTC)
+
+		// Assert
+		require.NoError(t, err)
+		require.NotNil(t, next)
+		assert.Equal(t, "next", next.ID)
+		assert.Equal(t, &dailyRule, next.Recurrence)
+
+		// 新しいIDで、依存関係以外の内容を引き継いで作成する
+		assert.Equal(t, "next", saved.ID)
+		assert.Equal(t, "Stand-up", saved.Title)
+		assert.Equal(t, "Share yesterday's progress", saved.Description)
+		assert.Equal(t, model.StatusOpen, saved.Status)
+		assert.True(t, deadline.AddDate(0, 0, 1).Equal(*saved.Deadline))
+		assert.Equal(t, model.PriorityHigh, saved.Priority)
+		assert.Equal(t, []string{"team"}, saved.Tags)
+		assert.Equal(t, "work", saved.Project)
+		assert.Equal(t, "parent", saved.ParentID)
+		assert.Empty(t, saved.BlockedBy)
+		assert.Equal(t, "rec", saved.RecurrenceID)
+		// 開始日時は締切との間隔を保ち、待機の期限は引き継がない
+		assert.True(t, startAt.AddDate(0, 0, 1).Equal(*saved.StartAt))
+		assert.Nil(t, saved.WaitUntil)
+	})
+
+	t.Run("同じ繰り返しの未完了のタスクがある場合は作成しない", func(t *testing.T) {
+		// Arrange
+		task := &model.Task{ID: "task", Title: "Stand-up", Deadline: &deadline, RecurrenceID: "rec", Recurrence: &dailyRule}
+		open := &model.Task{ID: "open", Title: "Stand-up", RecurrenceID: "rec"}
+		mockRepo := new(MockTaskRepository)
+		mockRepo.On("FindAll", mock.Anything, repository.TaskQuery{}).Return([]*model.Task{task, open}, nil)
+		mockRepo.On("Update", mock.Anything, task).Return(task, nil)
+
+		taskUsecase := usecase.NewTaskUsecase(mockRepo, &MockIDGenerator{ID: "next"}, model.DefaultWorkflow())
+
+		// Act
+		next, err := taskUsecase.CompleteTask(context.Background(), task, time.UTC)
+
+		// Assert
+		require.NoError(t, err)
+		assert.Nil(t, next)
+		mockRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+}
+
+// 繰り返しのルールを設定する場合
+func TestTaskUsecase_SetRecurrence(t *testing.T) {
+	weekly := model.Recurrence{Frequency: model.FrequencyWeekly, Interval: 1}
+
+	t.Run("繰り返さないタスクには新しいルールを作成して参照させる", func(t *testing.T) {
+		// Arrange
+		mockRepo := new(MockTaskRepository)
+		mockRepo.On("FindByID", mock.Anything, "task").Return(&model.Task{ID: "task", Title: "Task"}, nil).Once()
+		mockRepo.On("SaveRecurrence", mock.Anything, "rec", weekly).Return(nil)
+		mockRepo.On("Update", mock.Anything, mock.MatchedBy(func(task *model.Task) bool {
+			return task.ID == "task" && task.RecurrenceID == "rec"
+		})).Return(&model.Task{ID: "task", Title: "Task", RecurrenceID: "rec"}, nil)
+		mockRepo.On("FindByID", mock.Anything, "task").Return(&model.Task{ID: "task", Title: "Task", RecurrenceID: "rec", Recurrence: &weekly}, nil).Once()
+
+		taskUsecase := usecase.NewTaskUsecase(mockRepo, &MockIDGenerator{ID: "rec"}, model.DefaultWorkflow())
+
+		// Act
+		task, err := taskUsecase.SetRecurrence(context.Background(), "task", &weekly)
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, &weekly, task.Recurrence)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("繰り返すタスクは参照しているルールを変更する", func(t *testing.T) {
+		// Arrange
+		task := &model.Task{ID: "task", Title: "Task", RecurrenceID: "rec", Recurrence: &dailyRule}
+		mockRepo := new(MockTaskRepository)
+		mockRepo.On("FindByID", mock.Anything, "task").Return(task, nil)
+		mockRepo.On("SaveRecurrence", mock.Anything, "rec", weekly).Return(nil)
+
+		taskUsecase := usecase.NewTaskUsecase(mockRepo, &MockIDGenerator{ID: "unused"}, model.DefaultWorkflow())
+
+		// Act
+		_, err := taskUsecase.SetRecurrence(context.Background(), "task", &weekly)
+
+		// Assert
+		require.NoError(t, err)
+		mockRepo.AssertExpectations(t)
+		mockRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+	})
+
+	t.Run("ルールにnilを指定すると繰り返しを停止する", func(t *testing.T) {
+		// Arrange
+		task := &model.Task{ID: "task", Title: "Task", RecurrenceID: "rec", Recurrence: &dailyRule}
+		mockRepo := new(MockTaskRepository)
+		mockRepo.On("FindByID", mock.Anything, "task").Return(task, nil)
+		mockRepo.On("DeleteRecurrence", mock.Anything, "rec").Return(nil)
+
+		taskUsecase := usecase.NewTaskUsecase(mockRepo, &MockIDGenerator{}, model.DefaultWorkflow())
+
+		// Act
+		_, err := taskUsecase.SetRecurrence(context.Background(), "task", nil)
+
+		// Assert
+		require.NoError(t, err)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("繰り返さないタスクの停止はエラーを返す", func(t *testing.T) {
+		// Arrange
+		mockRepo := new(MockTaskRepository)
+		mockRepo.On("FindByID", mock.Anything, "task").Return(&model.Task{ID: "task", Title: "Task"}, nil)
+
+		taskUsecase := usecase.NewTaskUsecase(mockRepo, &MockIDGenerator{}, model.DefaultWorkflow())
+
+		// Act
+		_, err := taskUsecase.SetRecurrence(context.Background(), "task", nil)
+
+		// Assert
+		assert.ErrorContains(t, err, "does not repeat")
+		mockRepo.AssertNotCalled(t, "DeleteRecurrence", mock.Anything, mock.Anything)
+	})
+}
@@ -0,0 +1,99 @@
+package usecase_test
+
+import (
+	"OTakumi/todogo/internal/domain/model"
+	"OTakumi/todogo/internal/domain/service"
+	"OTakumi/todogo/internal/repository"
+	"OTakumi/todogo/internal/usecase"
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestTaskUsecase_ResolveID は TaskUsecase の ResolveID メソッドのテスト
+func TestTaskUsecase_ResolveID(t *testing.T) {
+	tasks := []*model.Task{
+		{ID: "f47ac10b-58cc-4372-a567-0e02b2c3d479"},
+		{ID: "f4aa0000-0000-0000-0000-000000000000"},
+		{ID: "7d6d370d-a4f1-430b-06c7-d4a363341564"},
+	}
+
+	t.Run("一意な接頭辞から完全なIDを返す", func(t *testing.T) {
+		// Arrange
+		mockRepo := new(MockTaskRepository)
+		ctx := context.Background()
+		mockRepo.On("FindAll", ctx, repository.TaskQuery{}).Return(tasks, nil)
+
+		taskUsecase := usecase.NewTaskUsecase(mockRepo, &MockIDGenerator{}, model.DefaultWorkflow())
+
+		// Act
+		id, err := taskUsecase.ResolveID(ctx, "7d")
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, "7d6d370d-a4f1-430b-06c7-d4a363341564", id)
+	})
+
+	t.Run("曖昧な接頭辞は候補を含むエラーを返す", func(t *testing.T) {
+		// Arrange
+		mockRepo := new(MockTaskRepository)
+		ctx := context.Background()
+		mockRepo.On("FindAll", ctx, repository.TaskQuery{}).Return(tasks, nil)
+
+		taskUsecase := usecase.NewTaskUsecase(mockRepo, &MockIDGenerator{}, model.DefaultWorkflow())
+
+		// Act
+		id, err := taskUsecase.ResolveID(ctx, "f4")
+
+		// Assert
+		assert.Empty(t, id)
+		var ambiguous *service.AmbiguousIDError
+		assert.True(t, errors.As(err, &ambiguous))
+		assert.Len(t, ambiguous.Candidates, 2)
+		assert.Contains(t, err.Error(), "f47ac10b-58cc-4372-a567-0e02b2c3d479")
+		assert.Contains(t, err.Error(), "f4aa0000-0000-0000-0000-000000000000")
+	})
+
+	t.Run("一致しない接頭辞はNotFoundエラーを返す", func(t *testing.T) {
+		// Arrange
+		mockRepo := new(MockTaskRepository)
+		ctx := context.Background()
+		mockRepo.On("FindAll", ctx, repository.TaskQuery{}).Return(tasks, nil)
+
+		taskUsecase := usecase.NewTaskUsecase(mockRepo, &MockIDGenerator{}, model.DefaultWorkflow())
+
+		// Act
+		_, err := taskUsecase.ResolveID(ctx, "zz")
+
+		// Assert
+		assert.True(t, errors.Is(err, repository.ErrTaskNotFound))
+	})
+}
+
+// TestTaskUsecase_ShortIDs は TaskUsecase の ShortIDs メソッドのテスト
+func TestTaskUsecase_ShortIDs(t *testing.T) {
+	t.Run("全タスクの中で一意な接頭辞を返す", func(t *testing.T) {
+		// Arrange
+		mockRepo := new(MockTaskRepository)
+		ctx := context.Background()
+		mockRepo.On("FindAll", ctx, repository.TaskQuery{}).Return([]*model.Task{
+			{ID: "f47ac10b-58cc-4372-a567-0e02b2c3d479"},
+			{ID: "f47aa000-0000-0000-0000-000000000000"},
+			{ID: "7d6d370d-a4f1-430b-06c7-d4a363341564"},
+		}, nil)
+
+		taskUsecase := usecase.NewTaskUsecase(mockRepo, &MockIDGenerator{}, model.DefaultWorkflow())
+
+		// Act
+		shortIDs, err := taskUsecase.ShortIDs(ctx)
+
+		// Assert
+		// 最小4文字、かつ他と区別できる長さであること
+		assert.NoError(t, err)
+		assert.Equal(t, "f47ac", shortIDs["f47ac10b-58cc-4372-a567-0e02b2c3d479"])
+		assert.Equal(t, "f47aa", shortIDs["f47aa000-0000-0000-0000-000000000000"])
+		assert.Equal(t, "7d6d", shortIDs["7d6d370d-a4f1-430b-06c7-d4a363341564"])
+	})
+}
@@ -0,0 +1,132 @@
+package usecase_test
+
+import (
+	"OTakumi/todogo/internal/domain/model"
+	"OTakumi/todogo/internal/domain/service"
+	"OTakumi/todogo/internal/repository"
+	"OTakumi/todogo/internal/usecase"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// 状態を変更する場合
+func TestTaskUsecase_SetStatus(t *testing.T) {
+	t.Run("許可された状態に変更してリポジトリに渡す", func(t *testing.T) {
+		// Arrange
+		mockRepo := new(MockTaskRepository)
+		mockRepo.On("FindByID", mock.Anything, "task-1").Return(&model.Task{ID: "task-1", Title: "Task", Status: model.StatusOpen}, nil)
+		mockRepo.On("Update", mock.Anything, mock.MatchedBy(func(task *model.Task) bool {
+			return task.Status == model.StatusInProgress
+		})).Return(&model.Task{ID: "task-1", Title: "Task", Status: model.StatusInProgress}, nil)
+
+		taskUsecase := usecase.NewTaskUsecase(mockRepo, &MockIDGenerator{}, model.DefaultWorkflow())
+
+		// Act
+		task, err := taskUsecase.SetStatus(context.Background(), "task-1", model.StatusInProgress)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, model.StatusInProgress, task.Status)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("許可されていない状態遷移の場合はリポジトリを更新せずに型付きのエラーを返す", func(t *testing.T) {
+		// Arrange
+		mockRepo := new(MockTaskRepository)
+		mockRepo.On("FindByID", mock.Anything, "task-1").Return(&model.Task{ID: "task-1", Title: "Task", Status: model.StatusDone}, nil)
+
+		taskUsecase := usecase.NewTaskUsecase(mockRepo, &MockIDGenerator{}, model.DefaultWorkflow())
+
+		// Act
+		_, err := taskUsecase.SetStatus(context.Background(), "task-1", model.StatusBlocked)
+
+		// Assert
+		var transitionErr *model.TransitionError
+		if assert.True(t, errors.As(err, &transitionErr)) {
+			assert.Equal(t, []model.Status{model.StatusOpen}, transitionErr.Allowed)
+		}
+		mockRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+	})
+
+	t.Run("未完了のサブタスクがある場合は終了状態にできない", func(t *testing.T) {
+		// Arrange
+		mockRepo := new(MockTaskRepository)
+		mockRepo.On("FindByID", mock.Anything, "parent").Return(&model.Task{ID: "parent", Title: "Parent"}, nil)
+		mockRepo.On("FindAll", mock.Anything, repository.TaskQuery{}).Return(subtaskTasks(), nil)
+
+		taskUsecase := usecase.NewTaskUsecase(mockRepo, &MockIDGenerator{}, model.DefaultWorkflow())
+
+		// Act
+		_, err := taskUsecase.SetStatus(context.Background(), "parent", model.StatusCancelled)
+
+		// Assert
+		var openErr *service.OpenSubtasksError
+		assert.True(t, errors.As(err, &openErr))
+		mockRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+	})
+}
+
+// 完了の状態に変更できない場合
+func TestTaskUsecase_Complete_IllegalTransition(t *testing.T) {
+	t.Run("変更できない状態のタスクは完了できない", func(t *testing.T) {
+		// Arrange
+		mockRepo := new(MockTaskRepository)
+		task := &model.Task{ID: "task-1", Title: "Task", Status: model.StatusBlocked}
+
+		taskUsecase := usecase.NewTaskUsecase(mockRepo, &MockIDGenerator{}, model.DefaultWorkflow())
+
+		// Act
+		_, err := taskUsecase.CompleteTask(context.Background(), task, time.UTC)
+
+		// Assert
+		assert.ErrorIs(t, err, model.ErrIllegalTransition)
+		assert.Equal(t, model.StatusBlocked, task.Status)
+		mockRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+	})
+
+	t.Run("変更できないサブタスクがある場合はいずれのサブタスクも変更しない", func(t *testing.T) {
+		// Arrange
+		mockRepo := new(MockTaskRepository)
+		tasks := subtaskTasks()
+		tasks[1].Status = model.StatusBlocked
+		mockRepo.On("FindAll", mock.Anything, repository.TaskQuery{}).Return(tasks, nil)
+		parent := &model.Task{ID: "parent", Title: "Parent"}
+
+		taskUsecase := usecase.NewTaskUsecase(mockRepo, &MockIDGenerator{}, model.DefaultWorkflow())
+
+		// Act
+		_, completed, err := taskUsecase.CompleteTaskWithSubtasks(context.Background(), parent, time.UTC)
+
+		// Assert
+		assert.ErrorIs(t, err, model.ErrIllegalTransition)
+		assert.Equal(t, 0, completed)
+		assert.Equal(t, model.Status(""), parent.Status)
+		assert.Equal(t, model.Status(""), tasks[2].Status)
+		mockRepo.AssertNotCalled(t, "UpdateAll", mock.Anything, mock.Anything)
+	})
+
+	t.Run("親のタスクを完了の状態に変更できない場合はいずれのサブタスクも変更しない", func(t *testing.T) {
+		// Arrange
+		mockRepo := new(MockTaskRepository)
+		tasks := subtaskTasks()
+		tasks[0].Status = model.StatusBlocked
+		mockRepo.On("FindAll", mock.Anything, repository.TaskQuery{}).Return(tasks, nil)
+
+		taskUsecase := usecase.NewTaskUsecase(mockRepo, &MockIDGenerator{}, model.DefaultWorkflow())
+
+		// Act
+		_, completed, err := taskUsecase.CompleteTaskWithSubtasks(context.Background(), tasks[0], time.UTC)
+
+		// Assert
+		assert.ErrorIs(t, err, model.ErrIllegalTransition)
+		assert.Equal(t, 0, completed)
+		assert.Equal(t, model.Status(""), tasks[1].Status)
+		assert.Equal(t, model.Status(""), tasks[2].Status)
+		mockRepo.AssertNotCalled(t, "UpdateAll", mock.Anything, mock.Anything)
+	})
+}
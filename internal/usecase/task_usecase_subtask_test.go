@@ -0,0 +1,127 @@
+package usecase_test
+
+import (
+	"OTakumi/todogo/internal/domain/model"
+	"OTakumi/todogo/internal/domain/service"
+	"OTakumi/todogo/internal/repository"
+	"OTakumi/todogo/internal/usecase"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// subtaskTasks は親タスク "parent" の下に、未完了の "child" と、その下の未完了の "grandchild" がある状態を返す
+func subtaskTasks() []*model.Task {
+	return []*model.Task{
+		{ID: "parent", Title: "Parent"},
+		{ID: "child", Title: "Child", ParentID: "parent"},
+		{ID: "grandchild", Title: "Grandchild", ParentID: "child"},
+	}
+}
+
+// サブタスクを作成する場合
+func TestTaskUsecase_CreateTask_WithParent(t *testing.T) {
+	t.Run("親タスクを設定してリポジトリに渡す", func(t *testing.T) {
+		// Arrange
+		mockRepo := new(MockTaskRepository)
+		mockRepo.On("FindAll", mock.Anything, repository.TaskQuery{}).Return(subtaskTasks(), nil)
+		mockRepo.On("Create", mock.Anything, mock.MatchedBy(func(task *model.Task) bool {
+			return task.ParentID == "child"
+		})).Return(&model.Task{ID: "new", Title: "Subtask", ParentID: "child"}, nil)
+
+		taskUsecase := usecase.NewTaskUsecase(mockRepo, &MockIDGenerator{ID: "new"}, model.DefaultWorkflow())
+
+		// Act
+		task, err := taskUsecase.CreateTask(context.Background(), usecase.CreateTaskParams{Title: "Subtask", ParentID: "child"})
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, "child", task.ParentID)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("存在しない親タスクの場合はリポジトリを呼ばずにエラーを返す", func(t *testing.T) {
+		// Arrange
+		mockRepo := new(MockTaskRepository)
+		mockRepo.On("FindAll", mock.Anything, repository.TaskQuery{}).Return(subtaskTasks(), nil)
+
+		taskUsecase := usecase.NewTaskUsecase(mockRepo, &MockIDGenerator{ID: "new"}, model.DefaultWorkflow())
+
+		// Act
+		_, err := taskUsecase.CreateTask(context.Background(), usecase.CreateTaskParams{Title: "Subtask", ParentID: "missing"})
+
+		// Assert
+		assert.ErrorContains(t, err, "not found")
+		mockRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+}
+
+// 親子関係を変更・完了する場合
+func TestTaskUsecase_UpdateTask_Subtasks(t *testing.T) {
+	t.Run("配下のサブタスクを親タスクにする場合は循環としてエラーを返す", func(t *testing.T) {
+		// Arrange
+		mockRepo := new(MockTaskRepository)
+		mockRepo.On("FindAll", mock.Anything, repository.TaskQuery{}).Return(subtaskTasks(), nil)
+
+		taskUsecase := usecase.NewTaskUsecase(mockRepo, &MockIDGenerator{}, model.DefaultWorkflow())
+
+		// Act
+		_, err := taskUsecase.UpdateTask(context.Background(), &model.Task{ID: "parent", Title: "Parent", ParentID: "grandchild"})
+
+		// Assert
+		assert.ErrorIs(t, err, service.ErrParentCycle)
+		mockRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+	})
+
+	t.Run("未完了のサブタスクがある場合は完了できない", func(t *testing.T) {
+		// Arrange
+		mockRepo := new(MockTaskRepository)
+		mockRepo.On("FindAll", mock.Anything, repository.TaskQuery{}).Return(subtaskTasks(), nil)
+
+		taskUsecase := usecase.NewTaskUsecase(mockRepo, &MockIDGenerator{}, model.DefaultWorkflow())
+
+		// Act
+		_, err := taskUsecase.UpdateTask(context.Background(), &model.Task{ID: "parent", Title: "Parent", Status: model.StatusDone})
+
+		// Assert
+		var openErr *service.OpenSubtasksError
+		if assert.True(t, errors.As(err, &openErr)) {
+			assert.Equal(t, []string{"child", "grandchild"}, openErr.Open)
+		}
+		mockRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+	})
+}
+
+// 配下のサブタスクと共にタスクを完了する場合
+func TestTaskUsecase_CompleteTaskWithSubtasks(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockTaskRepository)
+	tasks := subtaskTasks()
+	mockRepo.On("FindAll", mock.Anything, repository.TaskQuery{}).Return(tasks, nil)
+
+	// 子より先に孫を、最後にタスク自身を、1回の呼び出しで同じ操作のまとまりとして完了すること
+	var order []string
+	mockRepo.On("UpdateAll", journaled, mock.Anything).Run(func(args mock.Arguments) {
+		for _, task := range args.Get(1).([]*model.Task) {
+			assert.Equal(t, model.StatusDone, task.Status)
+			order = append(order, task.ID)
+		}
+	}).Return([]*model.Task{}, nil)
+
+	taskUsecase := usecase.NewTaskUsecase(mockRepo, &MockIDGenerator{}, model.DefaultWorkflow())
+
+	// Act
+	next, completed, err := taskUsecase.CompleteTaskWithSubtasks(context.Background(), &model.Task{ID: "parent", Title: "Parent"}, time.UTC)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Nil(t, next)
+	assert.Equal(t, 2, completed)
+	assert.Equal(t, []string{"grandchild", "child", "parent"}, order)
+	mockRepo.AssertNumberOfCalls(t, "UpdateAll", 1)
+	mockRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+}
@@ -6,6 +6,7 @@ import (
 	"context"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -31,10 +32,10 @@ func TestTaskUsecase_CreateTask_Failure(t *testing.T) {
 	).Return(nil, errors.New("error"))
 
 	// UsecaseにRepositoryとIDGeneratorのモックを注入
-	taskUsecase := usecase.NewTaskUsecase(mockRepo, mockIDGenerator)
+	taskUsecase := usecase.NewTaskUsecase(mockRepo, mockIDGenerator, model.DefaultWorkflow())
 
 	// Act
-	_, err := taskUsecase.CreateTask(context.Background(), expectedTitle)
+	_, err := taskUsecase.CreateTask(context.Background(), usecase.CreateTaskParams{Title: expectedTitle})
 
 	// Assert
 	// エラーがあることを確認
@@ -42,3 +43,180 @@ func TestTaskUsecase_CreateTask_Failure(t *testing.T) {
 
 	mockRepo.AssertExpectations(t)
 }
+
+// 締切を指定してタスクを作成する場合
+func TestTaskUsecase_CreateTask_WithDeadline(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockTaskRepository)
+
+	expectedUUID := "f47ac10b-58cc-4372-a567-0e02b2c3d479"
+	deadline := time.Now().Add(48 * time.Hour)
+
+	mockIDGenerator := &MockIDGenerator{ID: expectedUUID}
+
+	// 締切がそのままリポジトリに渡されること
+	mockRepo.On(
+		"Create",
+		mock.Anything,
+		mock.MatchedBy(func(task *model.Task) bool {
+			return task.ID == expectedUUID && task.Deadline != nil && task.Deadline.Equal(deadline)
+		}),
+	).Return(&model.Task{ID: expectedUUID, Title: "締切付きタスク", Deadline: &deadline}, nil)
+
+	taskUsecase := usecase.NewTaskUsecase(mockRepo, mockIDGenerator, model.DefaultWorkflow())
+
+	// Act
+	task, err := taskUsecase.CreateTask(context.Background(), usecase.CreateTaskParams{
+		Title:    "締切付きタスク",
+		Deadline: &deadline,
+	})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, expectedUUID, task.ID)
+	mockRepo.AssertExpectations(t)
+}
+
+// 優先度を指定してタスクを作成する場合
+func TestTaskUsecase_CreateTask_WithPriority(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockTaskRepository)
+
+	expectedUUID := "f47ac10b-58cc-4372-a567-0e02b2c3d479"
+	mockIDGenerator := &MockIDGenerator{ID: expectedUUID}
+
+	// 優先度がそのままリポジトリに渡されること
+	mockRepo.On(
+		"Create",
+		mock.Anything,
+		mock.MatchedBy(func(task *model.Task) bool {
+			return task.ID == expectedUUID && task.Priority == model.PriorityHigh
+		}),
+	).Return(&model.Task{ID: expectedUUID, Title: "優先度付きタスク", Priority: model.PriorityHigh}, nil)
+
+	taskUsecase := usecase.NewTaskUsecase(mockRepo, mockIDGenerator, model.DefaultWorkflow())
+
+	// Act
+	task, err := taskUsecase.CreateTask(context.Background(), usecase.CreateTaskParams{
+		Title:    "優先度付きタスク",
+		Priority: model.PriorityHigh,
+	})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, model.PriorityHigh, task.Priority)
+	mockRepo.AssertExpectations(t)
+}
+
+// タグを指定してタスクを作成する場合
+func TestTaskUsecase_CreateTask_WithTags(t *testing.T) {
+	expectedUUID := "f47ac10b-58cc-4372-a567-0e02b2c3d479"
+
+	t.Run("タグが正規化されてリポジトリに渡されること", func(t *testing.T) {
+		// Arrange
+		mockRepo := new(MockTaskRepository)
+		mockRepo.On(
+			"Create",
+			mock.Anything,
+			mock.MatchedBy(func(task *model.Task) bool {
+				return assert.ObjectsAreEqual([]string{"urgent", "work"}, task.Tags)
+			}),
+		).Return(&model.Task{ID: expectedUUID, Title: "タグ付きタスク", Tags: []string{"urgent", "work"}}, nil)
+
+		taskUsecase := usecase.NewTaskUsecase(mockRepo, &MockIDGenerator{ID: expectedUUID}, model.DefaultWorkflow())
+
+		// Act
+		task, err := taskUsecase.CreateTask(context.Background(), usecase.CreateTaskParams{
+			Title: "タグ付きタスク",
+			Tags:  []string{"Work", "urgent", "work"},
+		})
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"urgent", "work"}, task.Tags)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("不正なタグの場合はリポジトリを呼び出さないこと", func(t *testing.T) {
+		// Arrange
+		mockRepo := new(MockTaskRepository)
+		taskUsecase := usecase.NewTaskUsecase(mockRepo, &MockIDGenerator{ID: expectedUUID}, model.DefaultWorkflow())
+
+		// Act
+		task, err := taskUsecase.CreateTask(context.Background(), usecase.CreateTaskParams{
+			Title: "タグ付きタスク",
+			Tags:  []string{"two words"},
+		})
+
+		// Assert
+		assert.Error(t, err)
+		assert.Nil(t, task)
+		mockRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+}
+
+// タグの一覧を取得する場合
+func TestTaskUsecase_Tags(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockTaskRepository)
+	counts := []model.TagCount{{Name: "work", Open: 1, Total: 2}}
+	mockRepo.On("TagCounts", mock.Anything).Return(counts, nil)
+
+	taskUsecase := usecase.NewTaskUsecase(mockRepo, &MockIDGenerator{}, model.DefaultWorkflow())
+
+	// Act
+	result, err := taskUsecase.Tags(context.Background())
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, counts, result)
+	mockRepo.AssertExpectations(t)
+}
+
+// プロジェクトを指定してタスクを作成する場合
+func TestTaskUsecase_CreateTask_WithProject(t *testing.T) {
+	// Arrange
+	expectedUUID := "f47ac10b-58cc-4372-a567-0e02b2c3d479"
+	mockRepo := new(MockTaskRepository)
+	// プロジェクト名が正規化されてリポジトリに渡されること
+	mockRepo.On("Create", mock.Anything, mock.MatchedBy(func(task *model.Task) bool {
+		return task.Project == "work.backend"
+	})).Return(&model.Task{ID: expectedUUID, Title: "プロジェクトのタスク", Project: "work.backend"}, nil)
+
+	taskUsecase := usecase.NewTaskUsecase(mockRepo, &MockIDGenerator{ID: expectedUUID}, model.DefaultWorkflow())
+
+	// Act
+	task, err := taskUsecase.CreateTask(context.Background(), usecase.CreateTaskParams{
+		Title:   "プロジェクトのタスク",
+		Project: " Work.Backend",
+	})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, "work.backend", task.Project)
+	mockRepo.AssertExpectations(t)
+}
+
+// プロジェクトの一覧を取得する場合
+func TestTaskUsecase_Projects(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockTaskRepository)
+	mockRepo.On("ProjectCounts", mock.Anything).Return([]model.ProjectCount{
+		{Name: "work.api", Open: 1},
+		{Name: "work", Closed: 1},
+	}, nil)
+
+	taskUsecase := usecase.NewTaskUsecase(mockRepo, &MockIDGenerator{}, model.DefaultWorkflow())
+
+	// Act
+	result, err := taskUsecase.Projects(context.Background())
+
+	// Assert
+	// 上位のプロジェクトに配下の件数が合算されること
+	assert.NoError(t, err)
+	assert.Equal(t, []model.ProjectCount{
+		{Name: "work", Open: 1, Closed: 1},
+		{Name: "work.api", Open: 1},
+	}, result)
+	mockRepo.AssertExpectations(t)
+}
@@ -0,0 +1,191 @@
+package usecase_test
+
+import (
+	"OTakumi/todogo/internal/domain/model"
+	"OTakumi/todogo/internal/repository"
+	"OTakumi/todogo/internal/usecase"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+var runningQuery = repository.TimeEntryQuery{User: "alice", Running: true}
+
+// 作業時間の計測を開始する場合
+func TestTaskUsecase_StartTimer(t *testing.T) {
+	t.Run("計測中の記録がなければ現在時刻から計測を開始する", func(t *testing.T) {
+		// Arrange
+		before := time.Now()
+		mockRepo := new(MockTaskRepository)
+		mockRepo.On("FindByID", mock.Anything, "task").Return(&model.Task{ID: "task", Title: "Task"}, nil)
+		mockRepo.On("TimeEntries", mock.Anything, runningQuery).Return([]model.TimeEntry{}, nil)
+		mockRepo.On("AddTimeEntry", mock.Anything, mock.MatchedBy(func(e model.TimeEntry) bool {
+			return e.TaskID == "task" && e.User == "alice" && e.Running() && !e.StartedAt.Before(before)
+		})).Return(&model.TimeEntry{ID: 1, TaskID: "task", User: "alice", StartedAt: before}, nil)
+
+		taskUsecase := usecase.NewTaskUsecase(mockRepo, &MockIDGenerator{ID: "unused"}, model.DefaultWorkflow())
+
+		// Act
+		entry, err := taskUsecase.StartTimer(context.Background(), "alice", "task")
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), entry.ID)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("既に計測中の場合は記録を追加せずにエラーを返す", func(t *testing.T) {
+		// Arrange
+		running := model.TimeEntry{ID: 1, TaskID: "other", User: "alice", StartedAt: time.Now().Add(-time.Hour)}
+		mockRepo := new(MockTaskRepository)
+		mockRepo.On("FindByID", mock.Anything, "task").Return(&model.Task{ID: "task", Title: "Task"}, nil)
+		mockRepo.On("TimeEntries", mock.Anything, runningQuery).Return([]model.TimeEntry{running}, nil)
+
+		taskUsecase := usecase.NewTaskUsecase(mockRepo, &MockIDGenerator{ID: "unused"}, model.DefaultWorkflow())
+
+		// Act
+		entry, err := taskUsecase.StartTimer(context.Background(), "alice", "task")
+
+		// Assert
+		assert.Nil(t, entry)
+		assert.True(t, errors.Is(err, model.ErrTimerRunning), "expected ErrTimerRunning, got %v", err)
+		assert.ErrorContains(t, err, "task other")
+		mockRepo.AssertNotCalled(t, "AddTimeEntry", mock.Anything, mock.Anything)
+	})
+
+	t.Run("タスクが存在しない場合はNotFoundエラーを返す", func(t *testing.T) {
+		// Arrange
+		mockRepo := new(MockTaskRepository)
+		mockRepo.On("FindByID", mock.Anything, "missing").Return(nil, &repository.TaskNotFoundError{ID: "missing"})
+
+		taskUsecase := usecase.NewTaskUsecase(mockRepo, &MockIDGenerator{ID: "unused"}, model.DefaultWorkflow())
+
+		// Act
+		_, err := taskUsecase.StartTimer(context.Background(), "alice", "missing")
+
+		// Assert
+		assert.True(t, errors.Is(err, repository.ErrTaskNotFound))
+		mockRepo.AssertNotCalled(t, "AddTimeEntry", mock.Anything, mock.Anything)
+	})
+}
+
+// 作業時間の計測を終了する場合
+func TestTaskUsecase_StopTimer(t *testing.T) {
+	t.Run("計測中の記録を現在時刻で終了する", func(t *testing.T) {
+		// Arrange
+		before := time.Now()
+		running := model.TimeEntry{ID: 7, TaskID: "task", User: "alice", StartedAt: before.Add(-time.Hour)}
+		mockRepo := new(MockTaskRepository)
+		mockRepo.On("TimeEntries", mock.Anything, runningQuery).Return([]model.TimeEntry{running}, nil)
+		mockRepo.On("StopTimeEntry", mock.Anything, int64(7), mock.MatchedBy(func(at time.Time) bool {
+			return !at.Before(before)
+		})).Return(&model.TimeEntry{ID: 7, TaskID: "task", User: "alice", StartedAt: running.StartedAt, EndedAt: &before}, nil)
+
+		taskUsecase := usecase.NewTaskUsecase(mockRepo, &MockIDGenerator{ID: "unused"}, model.DefaultWorkflow())
+
+		// Act
+		entry, err := taskUsecase.StopTimer(context.Background(), "alice")
+
+		// Assert
+		require.NoError(t, err)
+		assert.False(t, entry.Running())
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("計測中の記録がない場合はエラーを返す", func(t *testing.T) {
+		// Arrange
+		mockRepo := new(MockTaskRepository)
+		mockRepo.On("TimeEntries", mock.Anything, runningQuery).Return([]model.TimeEntry{}, nil)
+
+		taskUsecase := usecase.NewTaskUsecase(mockRepo, &MockIDGenerator{ID: "unused"}, model.DefaultWorkflow())
+
+		// Act
+		_, err := taskUsecase.StopTimer(context.Background(), "alice")
+
+		// Assert
+		assert.ErrorIs(t, err, model.ErrNoTimerRunning)
+		mockRepo.AssertNotCalled(t, "StopTimeEntry", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+// 作業時間を手入力で記録する場合
+func TestTaskUsecase_LogTime(t *testing.T) {
+	t.Run("現在時刻に終えた区間として記録する", func(t *testing.T) {
+		// Arrange
+		mockRepo := new(MockTaskRepository)
+		mockRepo.On("AddTimeEntry", mock.Anything, mock.MatchedBy(func(e model.TimeEntry) bool {
+			return e.TaskID == "task" && e.User == "alice" && e.EndedAt != nil &&
+				e.EndedAt.Sub(e.StartedAt) == 45*time.Minute
+		})).Return(&model.TimeEntry{ID: 1}, nil)
+
+		taskUsecase := usecase.NewTaskUsecase(mockRepo, &MockIDGenerator{ID: "unused"}, model.DefaultWorkflow())
+
+		// Act
+		_, err := taskUsecase.LogTime(context.Background(), "alice", "task", 45*time.Minute)
+
+		// Assert
+		require.NoError(t, err)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("時間が正でない場合は記録せずにエラーを返す", func(t *testing.T) {
+		// Arrange
+		mockRepo := new(MockTaskRepository)
+
+		taskUsecase := usecase.NewTaskUsecase(mockRepo, &MockIDGenerator{ID: "unused"}, model.DefaultWorkflow())
+
+		// Act
+		_, err := taskUsecase.LogTime(context.Background(), "alice", "task", 0)
+
+		// Assert
+		assert.Error(t, err)
+		mockRepo.AssertNotCalled(t, "AddTimeEntry", mock.Anything, mock.Anything)
+	})
+
+	t.Run("存在しないタスクの場合はリポジトリのNotFoundエラーを返す", func(t *testing.T) {
+		// Arrange
+		// タスクが存在しないかゴミ箱にあるかはリポジトリが判定する
+		mockRepo := new(MockTaskRepository)
+		mockRepo.On("AddTimeEntry", mock.Anything, mock.Anything).Return(nil, &repository.TaskNotFoundError{ID: "missing"})
+
+		taskUsecase := usecase.NewTaskUsecase(mockRepo, &MockIDGenerator{ID: "unused"}, model.DefaultWorkflow())
+
+		// Act
+		entry, err := taskUsecase.LogTime(context.Background(), "alice", "missing", time.Hour)
+
+		// Assert
+		assert.Nil(t, entry)
+		assert.True(t, errors.Is(err, repository.ErrTaskNotFound), "expected ErrTaskNotFound, got %v", err)
+		mockRepo.AssertNotCalled(t, "FindByID", mock.Anything, mock.Anything)
+	})
+}
+
+// 期間内の作業時間を集計する場合
+func TestTaskUsecase_Timesheet(t *testing.T) {
+	// Arrange
+	to := time.Now().Truncate(time.Hour)
+	from := to.AddDate(0, 0, -7)
+	ended := from.Add(3 * time.Hour)
+	mockRepo := new(MockTaskRepository)
+	mockRepo.On("TimeEntries", mock.Anything, repository.TimeEntryQuery{User: "alice", Since: &from, Until: &to}).
+		Return([]model.TimeEntry{{ID: 1, TaskID: "task", User: "alice", StartedAt: from.Add(time.Hour), EndedAt: &ended}}, nil)
+	mockRepo.On("FindAll", mock.Anything, repository.TaskQuery{}).
+		Return([]*model.Task{{ID: "task", Title: "Task", Project: "work"}}, nil)
+
+	taskUsecase := usecase.NewTaskUsecase(mockRepo, &MockIDGenerator{ID: "unused"}, model.DefaultWorkflow())
+
+	// Act
+	sheet, err := taskUsecase.Timesheet(context.Background(), "alice", from, to)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, "alice", sheet.User)
+	assert.Equal(t, 2*time.Hour, sheet.Total)
+	assert.Equal(t, []model.TimesheetProject{{Name: "work", Duration: 2 * time.Hour}}, sheet.Projects)
+	mockRepo.AssertExpectations(t)
+}
@@ -0,0 +1,89 @@
+package usecase_test
+
+import (
+	"OTakumi/todogo/internal/domain/model"
+	"OTakumi/todogo/internal/repository"
+	"OTakumi/todogo/internal/usecase"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// TestTaskUsecase_ResolveDeletedID は TaskUsecase の ResolveDeletedID メソッドのテスト
+func TestTaskUsecase_ResolveDeletedID(t *testing.T) {
+	t.Run("ゴミ箱のタスクの中から接頭辞に一致するIDを返す", func(t *testing.T) {
+		// Arrange
+		mockRepo := new(MockTaskRepository)
+		ctx := context.Background()
+		mockRepo.On("FindDeleted", ctx).Return([]*model.Task{
+			{ID: "f47ac10b-58cc-4372-a567-0e02b2c3d479"},
+			{ID: "7d6d370d-a4f1-430b-06c7-d4a363341564"},
+		}, nil)
+
+		taskUsecase := usecase.NewTaskUsecase(mockRepo, &MockIDGenerator{}, model.DefaultWorkflow())
+
+		// Act
+		id, err := taskUsecase.ResolveDeletedID(ctx, "f4")
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, "f47ac10b-58cc-4372-a567-0e02b2c3d479", id)
+		mockRepo.AssertNotCalled(t, "FindAll", mock.Anything, mock.Anything)
+	})
+
+	t.Run("ゴミ箱にないタスクはNotFoundエラーを返す", func(t *testing.T) {
+		// Arrange
+		mockRepo := new(MockTaskRepository)
+		ctx := context.Background()
+		mockRepo.On("FindDeleted", ctx).Return([]*model.Task{}, nil)
+
+		taskUsecase := usecase.NewTaskUsecase(mockRepo, &MockIDGenerator{}, model.DefaultWorkflow())
+
+		// Act
+		_, err := taskUsecase.ResolveDeletedID(ctx, "f4")
+
+		// Assert
+		assert.True(t, errors.Is(err, repository.ErrTaskNotFound))
+	})
+}
+
+// TestTaskUsecase_PurgeTasks は TaskUsecase の PurgeTasks メソッドのテスト
+func TestTaskUsecase_PurgeTasks(t *testing.T) {
+	t.Run("指定された期間より前にゴミ箱に移したタスクを削除する", func(t *testing.T) {
+		// Arrange
+		before := time.Now()
+		mockRepo := new(MockTaskRepository)
+		mockRepo.On("Purge", mock.Anything, mock.MatchedBy(func(cutoff time.Time) bool {
+			// 現在時刻から30日前を境界とすること
+			return !cutoff.Before(before.Add(-30*24*time.Hour)) && !cutoff.After(time.Now().Add(-30*24*time.Hour))
+		})).Return(2, nil)
+
+		taskUsecase := usecase.NewTaskUsecase(mockRepo, &MockIDGenerator{}, model.DefaultWorkflow())
+
+		// Act
+		n, err := taskUsecase.PurgeTasks(context.Background(), 30*24*time.Hour)
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, 2, n)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("負の期間はタスクを削除せずにエラーを返す", func(t *testing.T) {
+		// Arrange
+		mockRepo := new(MockTaskRepository)
+		taskUsecase := usecase.NewTaskUsecase(mockRepo, &MockIDGenerator{}, model.DefaultWorkflow())
+
+		// Act
+		_, err := taskUsecase.PurgeTasks(context.Background(), -time.Hour)
+
+		// Assert
+		assert.Error(t, err)
+		mockRepo.AssertNotCalled(t, "Purge", mock.Anything, mock.Anything)
+	})
+}
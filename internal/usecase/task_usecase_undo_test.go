@@ -0,0 +1,143 @@
+package usecase_test
+
+import (
+	"OTakumi/todogo/internal/domain/model"
+	"OTakumi/todogo/internal/repository"
+	"OTakumi/todogo/internal/usecase"
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// 取り消しのために操作を記録する場合
+func TestTaskUsecase_Journal(t *testing.T) {
+	t.Run("同じコマンドで行った操作は同じまとまりとして記録する", func(t *testing.T) {
+		// Arrange
+		var batches, commands []string
+		mockRepo := new(MockTaskRepository)
+		mockRepo.On("Delete", journaled, mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+			id, command, _ := repository.BatchFrom(args.Get(0).(context.Context))
+			batches = append(batches, id)
+			commands = append(commands, command)
+		})
+
+		taskUsecase := usecase.NewTaskUsecase(mockRepo, &MockIDGenerator{ID: "unused"}, model.DefaultWorkflow())
+		ctx := usecase.WithCommand(context.Background(), "rm 1 2")
+
+		// Act
+		require.NoError(t, taskUsecase.DeleteTask(ctx, "id-1"))
+		require.NoError(t, taskUsecase.DeleteTask(ctx, "id-2"))
+		require.NoError(t, taskUsecase.DeleteTask(context.Background(), "id-3"))
+
+		// Assert
+		require.Len(t, batches, 3)
+		assert.Equal(t, batches[0], batches[1])
+		assert.NotEqual(t, batches[0], batches[2])
+		assert.Equal(t, []string{"rm 1 2", "rm 1 2", ""}, commands)
+	})
+}
+
+// 直近のコマンドを取り消す場合
+func TestTaskUsecase_Undo(t *testing.T) {
+	ops := []model.Operation{
+		{ID: 1, BatchID: "a", Command: "new", TaskID: "id-1"},
+		{ID: 2, BatchID: "b", Command: "rm 2 3", TaskID: "id-2"},
+		{ID: 3, BatchID: "b", Command: "rm 2 3", TaskID: "id-3"},
+		{ID: 4, BatchID: "c", Command: "edit 4", TaskID: "id-4"},
+	}
+
+	t.Run("新しいコマンドから順に、操作を行った順と逆の順で取り消す", func(t *testing.T) {
+		// Arrange
+		mockRepo := new(MockTaskRepository)
+		mockRepo.On("Operations", mock.Anything, false).Return(ops, nil)
+		mockRepo.On("ReplayOperations", mock.Anything, []model.Operation{ops[3], ops[2], ops[1]}, true).Return(nil)
+
+		taskUsecase := usecase.NewTaskUsecase(mockRepo, &MockIDGenerator{ID: "unused"}, model.DefaultWorkflow())
+
+		// Act
+		batches, err := taskUsecase.Undo(context.Background(), 2)
+
+		// Assert
+		require.NoError(t, err)
+		require.Len(t, batches, 2)
+		assert.Equal(t, "edit 4", batches[0].Command)
+		assert.Equal(t, "rm 2 3", batches[1].Command)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("取り消す操作がない場合は何もしない", func(t *testing.T) {
+		// Arrange
+		mockRepo := new(MockTaskRepository)
+		mockRepo.On("Operations", mock.Anything, false).Return([]model.Operation{}, nil)
+
+		taskUsecase := usecase.NewTaskUsecase(mockRepo, &MockIDGenerator{ID: "unused"}, model.DefaultWorkflow())
+
+		// Act
+		batches, err := taskUsecase.Undo(context.Background(), 1)
+
+		// Assert
+		require.NoError(t, err)
+		assert.Empty(t, batches)
+		mockRepo.AssertNotCalled(t, "ReplayOperations", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("記録後に変更されたタスクがある場合は競合のエラーを返す", func(t *testing.T) {
+		// Arrange
+		mockRepo := new(MockTaskRepository)
+		mockRepo.On("Operations", mock.Anything, false).Return(ops, nil)
+		mockRepo.On("ReplayOperations", mock.Anything, mock.Anything, true).
+			Return(&model.ConflictError{TaskID: "id-4", Command: "edit 4", Reason: "title changed"})
+
+		taskUsecase := usecase.NewTaskUsecase(mockRepo, &MockIDGenerator{ID: "unused"}, model.DefaultWorkflow())
+
+		// Act
+		batches, err := taskUsecase.Undo(context.Background(), 1)
+
+		// Assert
+		assert.True(t, errors.Is(err, model.ErrConflict))
+		assert.Nil(t, batches)
+	})
+
+	t.Run("件数が1未満の場合はエラーを返す", func(t *testing.T) {
+		// Arrange
+		mockRepo := new(MockTaskRepository)
+		taskUsecase := usecase.NewTaskUsecase(mockRepo, &MockIDGenerator{ID: "unused"}, model.DefaultWorkflow())
+
+		// Act
+		_, err := taskUsecase.Undo(context.Background(), 0)
+
+		// Assert
+		assert.ErrorContains(t, err, "at least 1")
+		mockRepo.AssertNotCalled(t, "Operations", mock.Anything, mock.Anything)
+	})
+}
+
+// 取り消したコマンドをやり直す場合
+func TestTaskUsecase_Redo(t *testing.T) {
+	t.Run("最後に取り消したコマンドから、操作を行った順にやり直す", func(t *testing.T) {
+		// Arrange
+		ops := []model.Operation{
+			{ID: 2, BatchID: "b", Command: "rm 2 3", TaskID: "id-2", Undone: true},
+			{ID: 3, BatchID: "b", Command: "rm 2 3", TaskID: "id-3", Undone: true},
+			{ID: 4, BatchID: "c", Command: "edit 4", TaskID: "id-4", Undone: true},
+		}
+		mockRepo := new(MockTaskRepository)
+		mockRepo.On("Operations", mock.Anything, true).Return(ops, nil)
+		mockRepo.On("ReplayOperations", mock.Anything, ops[:2], false).Return(nil)
+
+		taskUsecase := usecase.NewTaskUsecase(mockRepo, &MockIDGenerator{ID: "unused"}, model.DefaultWorkflow())
+
+		// Act
+		batches, err := taskUsecase.Redo(context.Background(), 1)
+
+		// Assert
+		require.NoError(t, err)
+		require.Len(t, batches, 1)
+		assert.Equal(t, "rm 2 3", batches[0].Command)
+		mockRepo.AssertExpectations(t)
+	})
+}
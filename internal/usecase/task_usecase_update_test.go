@@ -0,0 +1,136 @@
+package usecase_test
+
+import (
+	"OTakumi/todogo/internal/domain/model"
+	"OTakumi/todogo/internal/repository"
+	"OTakumi/todogo/internal/usecase"
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// TestTaskUsecase_GetTask は TaskUsecase の GetTask メソッドのテスト
+func TestTaskUsecase_GetTask(t *testing.T) {
+	t.Run("指定したIDのタスクを返す", func(t *testing.T) {
+		// Arrange
+		mockRepo := new(MockTaskRepository)
+		mockIDGenerator := &MockIDGenerator{ID: "test-id"}
+		ctx := context.Background()
+
+		expectedTask := &model.Task{ID: "task-1", Title: "Task 1"}
+		mockRepo.On("FindByID", ctx, "task-1").Return(expectedTask, nil)
+
+		taskUsecase := usecase.NewTaskUsecase(mockRepo, mockIDGenerator, model.DefaultWorkflow())
+
+		// Act
+		task, err := taskUsecase.GetTask(ctx, "task-1")
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, expectedTask, task)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("タスクが存在しない場合はNotFoundエラーを返す", func(t *testing.T) {
+		// Arrange
+		mockRepo := new(MockTaskRepository)
+		mockIDGenerator := &MockIDGenerator{ID: "test-id"}
+		ctx := context.Background()
+
+		mockRepo.On("FindByID", ctx, "missing").Return(nil, &repository.TaskNotFoundError{ID: "missing"})
+
+		taskUsecase := usecase.NewTaskUsecase(mockRepo, mockIDGenerator, model.DefaultWorkflow())
+
+		// Act
+		task, err := taskUsecase.GetTask(ctx, "missing")
+
+		// Assert
+		assert.Nil(t, task)
+		assert.True(t, errors.Is(err, repository.ErrTaskNotFound))
+		mockRepo.AssertExpectations(t)
+	})
+}
+
+// TestTaskUsecase_UpdateTask は TaskUsecase の UpdateTask メソッドのテスト
+func TestTaskUsecase_UpdateTask(t *testing.T) {
+	t.Run("リポジトリに更新を委譲する", func(t *testing.T) {
+		// Arrange
+		mockRepo := new(MockTaskRepository)
+		mockIDGenerator := &MockIDGenerator{ID: "test-id"}
+		ctx := context.Background()
+
+		task := &model.Task{ID: "task-1", Title: "Updated", Status: model.StatusDone}
+		// 完了する場合は、未完了のサブタスクがないことを確認するためにタスクの一覧を取得する
+		// 取り消しのために操作を記録するよう、操作のまとまりを保持したコンテキストで保存する
+		mockRepo.On("FindAll", mock.Anything, repository.TaskQuery{}).Return([]*model.Task{{ID: "task-1", Title: "Before"}}, nil)
+		mockRepo.On("Update", journaled, task).Return(task, nil)
+
+		taskUsecase := usecase.NewTaskUsecase(mockRepo, mockIDGenerator, model.DefaultWorkflow())
+
+		// Act
+		updatedTask, err := taskUsecase.UpdateTask(ctx, task)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, task, updatedTask)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("IDが空の場合はリポジトリを呼ばずにエラーを返す", func(t *testing.T) {
+		// Arrange
+		mockRepo := new(MockTaskRepository)
+		mockIDGenerator := &MockIDGenerator{ID: "test-id"}
+
+		taskUsecase := usecase.NewTaskUsecase(mockRepo, mockIDGenerator, model.DefaultWorkflow())
+
+		// Act
+		updatedTask, err := taskUsecase.UpdateTask(context.Background(), &model.Task{Title: "No ID"})
+
+		// Assert
+		assert.Error(t, err)
+		assert.Nil(t, updatedTask)
+		mockRepo.AssertNotCalled(t, "Update")
+	})
+}
+
+// TestTaskUsecase_DeleteTask は TaskUsecase の DeleteTask メソッドのテスト
+func TestTaskUsecase_DeleteTask(t *testing.T) {
+	t.Run("リポジトリに削除を委譲する", func(t *testing.T) {
+		// Arrange
+		mockRepo := new(MockTaskRepository)
+		mockIDGenerator := &MockIDGenerator{ID: "test-id"}
+		ctx := context.Background()
+
+		mockRepo.On("Delete", journaled, "task-1").Return(nil)
+
+		taskUsecase := usecase.NewTaskUsecase(mockRepo, mockIDGenerator, model.DefaultWorkflow())
+
+		// Act
+		err := taskUsecase.DeleteTask(ctx, "task-1")
+
+		// Assert
+		assert.NoError(t, err)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("削除対象が存在しない場合はNotFoundエラーを返す", func(t *testing.T) {
+		// Arrange
+		mockRepo := new(MockTaskRepository)
+		mockIDGenerator := &MockIDGenerator{ID: "test-id"}
+		ctx := context.Background()
+
+		mockRepo.On("Delete", mock.Anything, "missing").Return(&repository.TaskNotFoundError{ID: "missing"})
+
+		taskUsecase := usecase.NewTaskUsecase(mockRepo, mockIDGenerator, model.DefaultWorkflow())
+
+		// Act
+		err := taskUsecase.DeleteTask(ctx, "missing")
+
+		// Assert
+		assert.True(t, errors.Is(err, repository.ErrTaskNotFound))
+		mockRepo.AssertExpectations(t)
+	})
+}
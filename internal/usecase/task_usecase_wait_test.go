@@ -0,0 +1,91 @@
+package usecase_test
+
+import (
+	"OTakumi/todogo/internal/domain/model"
+	"OTakumi/todogo/internal/repository"
+	"OTakumi/todogo/internal/usecase"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// 開始日時と待機の期限を指定してタスクを作成する場合
+func TestTaskUsecase_CreateTask_WithWait(t *testing.T) {
+	// Arrange
+	startAt := time.Now().Add(24 * time.Hour)
+	waitUntil := time.Now().Add(48 * time.Hour)
+	mockRepo := new(MockTaskRepository)
+	mockRepo.On("Create", mock.Anything, mock.MatchedBy(func(task *model.Task) bool {
+		return task.StartAt == &startAt && task.WaitUntil == &waitUntil
+	})).Return(&model.Task{ID: "new", Title: "Renew passport", StartAt: &startAt, WaitUntil: &waitUntil}, nil)
+
+	taskUsecase := usecase.NewTaskUsecase(mockRepo, &MockIDGenerator{ID: "new"}, model.DefaultWorkflow())
+
+	// Act
+	task, err := taskUsecase.CreateTask(context.Background(), usecase.CreateTaskParams{
+		Title: "Renew passport", StartAt: &startAt, WaitUntil: &waitUntil,
+	})
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, &waitUntil, task.WaitUntil)
+	mockRepo.AssertExpectations(t)
+}
+
+// 待機の期限を延ばす場合
+func TestTaskUsecase_Snooze(t *testing.T) {
+	t.Run("待機の期限を延ばして更新する", func(t *testing.T) {
+		// Arrange
+		waitUntil := time.Now().Add(24 * time.Hour)
+		task := &model.Task{ID: "task", Title: "Task", WaitUntil: &waitUntil}
+		mockRepo := new(MockTaskRepository)
+		mockRepo.On("FindByID", mock.Anything, "task").Return(task, nil)
+		mockRepo.On("Update", mock.Anything, task).Return(task, nil)
+
+		taskUsecase := usecase.NewTaskUsecase(mockRepo, &MockIDGenerator{ID: "unused"}, model.DefaultWorkflow())
+
+		// Act
+		snoozed, err := taskUsecase.Snooze(context.Background(), "task", 72*time.Hour)
+
+		// Assert
+		require.NoError(t, err)
+		assert.True(t, waitUntil.Add(72*time.Hour).Equal(*snoozed.WaitUntil))
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("期間が正でない場合は更新せずにエラーを返す", func(t *testing.T) {
+		// Arrange
+		mockRepo := new(MockTaskRepository)
+		mockRepo.On("FindByID", mock.Anything, "task").Return(&model.Task{ID: "task", Title: "Task"}, nil)
+
+		taskUsecase := usecase.NewTaskUsecase(mockRepo, &MockIDGenerator{ID: "unused"}, model.DefaultWorkflow())
+
+		// Act
+		snoozed, err := taskUsecase.Snooze(context.Background(), "task", 0)
+
+		// Assert
+		assert.Error(t, err)
+		assert.Nil(t, snoozed)
+		mockRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+	})
+
+	t.Run("タスクが存在しない場合はNotFoundエラーを返す", func(t *testing.T) {
+		// Arrange
+		mockRepo := new(MockTaskRepository)
+		mockRepo.On("FindByID", mock.Anything, "missing").Return(nil, &repository.TaskNotFoundError{ID: "missing"})
+
+		taskUsecase := usecase.NewTaskUsecase(mockRepo, &MockIDGenerator{ID: "unused"}, model.DefaultWorkflow())
+
+		// Act
+		snoozed, err := taskUsecase.Snooze(context.Background(), "missing", time.Hour)
+
+		// Assert
+		assert.Nil(t, snoozed)
+		assert.True(t, errors.Is(err, repository.ErrTaskNotFound))
+	})
+}
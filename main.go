@@ -2,16 +2,31 @@ package main
 
 import (
 	"OTakumi/todogo/cmd"
+	"OTakumi/todogo/internal/domain/model"
 	"OTakumi/todogo/internal/infrastructure"
 	"OTakumi/todogo/internal/infrastructure/generator"
+	"OTakumi/todogo/internal/infrastructure/migration"
+	"OTakumi/todogo/internal/repository"
 	"OTakumi/todogo/internal/usecase"
+	"OTakumi/todogo/migrations"
+	"database/sql"
+	"errors"
 	"fmt"
 	"log"
 	"os"
 
 	"github.com/joho/godotenv"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
 )
 
+// storage は設定されたバックエンドに接続した結果
+type storage struct {
+	db       *sql.DB
+	taskRepo repository.TaskRepository
+	migrator *migration.Migrator
+}
+
 func main() {
 	// dotenvファイルから環境変数を読み込む
 	// 実行環境（本番環境など）に.envファイルがない場合でも、
@@ -21,6 +36,54 @@ func main() {
 		log.Println(".env file not found")
 	}
 
+	// storage.driver は設定ファイルから読み込まれるため、
+	// 設定の読み込みが終わった後（コマンドの実行直前）に依存関係を構築する
+	var st *storage
+	cobra.OnInitialize(func() {
+		// タスクの状態のワークフローも設定ファイルから読み込む
+		wf, err := cmd.LoadWorkflow()
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		st, err = openStorage(viper.GetString("storage.driver"), wf)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		// アプリケーションの依存関係を構築
+		// リポジトリ、IDジェネレータ、ユースケースを初期化
+		idGen := generator.NewUUIDGenerator()
+		taskUsecase := usecase.NewTaskUsecase(st.taskRepo, idGen, wf)
+
+		// cmdパッケージに依存関係を注入
+		// これにより、各コマンドは共通の依存関係を利用できる
+		cmd.SetupDependencies(st.db, taskUsecase, st.migrator, wf)
+	})
+
+	// コマンドを実行
+	err := cmd.Execute()
+	if st != nil {
+		_ = st.db.Close()
+	}
+	if err != nil {
+		log.Fatal(err)
+	}
+}
+
+// openStorage は指定されたドライバのデータベースに接続し、リポジトリとマイグレーションの実行器を生成する
+func openStorage(driver string, wf *model.Workflow) (*storage, error) {
+	switch driver {
+	case "postgres":
+		return openPostgres(wf)
+	case "sqlite":
+		return openSQLite(viper.GetString("storage.path"), wf)
+	default:
+		return nil, fmt.Errorf("unknown storage.driver %q: must be postgres or sqlite", driver)
+	}
+}
+
+func openPostgres(wf *model.Workflow) (*storage, error) {
 	// DBパラメータを環境変数から読み込む
 	dbUser := os.Getenv("POSTGRES_USER")
 	dbPassword := os.Getenv("POSTGRES_PASSWORD")
@@ -30,7 +93,7 @@ func main() {
 
 	// 必須の環境変数が設定されているか確認
 	if dbUser == "" || dbPassword == "" || dbHost == "" || dbPort == "" || dbName == "" {
-		log.Fatal("Database environment variables are not set correctly")
+		return nil, errors.New("Database environment variables are not set correctly")
 	}
 
 	dsn := fmt.Sprintf("host=%s port=%s user=%s password=%s dbname=%s sslmode=disable",
@@ -38,22 +101,45 @@ func main() {
 
 	dbHandler, err := infrastructure.NewPostgreSQLHandler(dsn)
 	if err != nil {
-		log.Fatalf("Failed to connect to database: %v", err)
+		return nil, fmt.Errorf("Failed to connect to database: %w", err)
 	}
-	defer dbHandler.DB.Close()
 
-	// アプリケーションの依存関係を構築
-	// リポジトリ、IDジェネレータ、ユースケースを初期化
-	taskRepo := infrastructure.NewTaskRepository(dbHandler.DB)
-	idGen := generator.NewUUIDGenerator()
-	taskUsecase := usecase.NewTaskUsecase(taskRepo, idGen)
+	// バイナリに埋め込まれたマイグレーションを読み込む
+	migrator, err := migration.New(dbHandler.DB, migrations.Postgres)
+	if err != nil {
+		_ = dbHandler.DB.Close()
+		return nil, fmt.Errorf("Failed to load migrations: %w", err)
+	}
 
-	// cmdパッケージに依存関係を注入
-	// これにより、各コマンドは共通の依存関係を利用できる
-	cmd.SetupDependencies(dbHandler.DB, taskUsecase)
+	return &storage{
+		db:       dbHandler.DB,
+		taskRepo: infrastructure.NewTaskRepository(dbHandler.DB, wf),
+		migrator: migrator,
+	}, nil
+}
 
-	// コマンドを実行
-	if err := cmd.Execute(); err != nil {
-		log.Fatal(err)
+func openSQLite(path string, wf *model.Workflow) (*storage, error) {
+	if path == "" {
+		var err error
+		if path, err = infrastructure.DefaultSQLitePath(); err != nil {
+			return nil, err
+		}
 	}
+
+	dbHandler, err := infrastructure.NewSQLiteHandler(path)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to open database: %w", err)
+	}
+
+	migrator, err := migration.NewSQLite(dbHandler.DB, migrations.SQLite)
+	if err != nil {
+		_ = dbHandler.DB.Close()
+		return nil, fmt.Errorf("Failed to load migrations: %w", err)
+	}
+
+	return &storage{
+		db:       dbHandler.DB,
+		taskRepo: infrastructure.NewSQLiteTaskRepository(dbHandler.DB, wf),
+		migrator: migrator,
+	}, nil
 }
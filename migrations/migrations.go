@@ -0,0 +1,30 @@
+// Package migrations はデータベースのスキーマ定義をSQLファイルとして保持し、バイナリに埋め込む
+package migrations
+
+import (
+	"embed"
+	"io/fs"
+)
+
+// files はデータベースごとのディレクトリに置かれたマイグレーションファイル
+// ファイル名は NNNNNN_name.up.sql / NNNNNN_name.down.sql の形式とし、
+// どのデータベースでも同じバージョンが同じスキーマになるよう番号と名前を揃える
+//
+//go:embed postgres/*.sql sqlite/*.sql
+var files embed.FS
+
+var (
+	// Postgres はPostgreSQL用のマイグレーションファイル
+	Postgres = sub("postgres")
+
+	// SQLite はSQLite用のマイグレーションファイル
+	SQLite = sub("sqlite")
+)
+
+func sub(dir string) fs.FS {
+	fsys, err := fs.Sub(files, dir)
+	if err != nil {
+		panic(err)
+	}
+	return fsys
+}
@@ -0,0 +1,4 @@
+-- タスクの優先度を削除
+DROP INDEX IF EXISTS idx_tasks_priority;
+
+ALTER TABLE tasks DROP COLUMN IF EXISTS priority;
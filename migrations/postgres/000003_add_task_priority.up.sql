@@ -0,0 +1,7 @@
+-- タスクの優先度を追加
+-- 0: なし, 1: 低, 2: 中, 3: 高, 4: 緊急
+ALTER TABLE tasks ADD COLUMN priority SMALLINT NOT NULL DEFAULT 0
+    CONSTRAINT tasks_priority_check CHECK (priority BETWEEN 0 AND 4);
+
+-- 優先度でのソートを高速化
+CREATE INDEX idx_tasks_priority ON tasks(priority);
@@ -0,0 +1,4 @@
+-- タグとタスクの関連を削除
+DROP TABLE IF EXISTS task_tags;
+
+DROP TABLE IF EXISTS tags;
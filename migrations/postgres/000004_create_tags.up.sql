@@ -0,0 +1,20 @@
+-- タグとタスクの多対多の関連を追加
+-- タグ名は正規化（小文字）された状態で保存する
+CREATE TABLE IF NOT EXISTS tags (
+    -- 主キー: タグの連番ID
+    id SERIAL PRIMARY KEY,
+
+    -- タグ名（一意）
+    name VARCHAR(64) NOT NULL UNIQUE
+);
+
+-- タスクとタグの関連
+-- タスクまたはタグを削除すると関連も削除される
+CREATE TABLE IF NOT EXISTS task_tags (
+    task_id VARCHAR(36) NOT NULL REFERENCES tasks(id) ON DELETE CASCADE,
+    tag_id INTEGER NOT NULL REFERENCES tags(id) ON DELETE CASCADE,
+    PRIMARY KEY (task_id, tag_id)
+);
+
+-- タグによる絞り込みと件数の集計を高速化
+CREATE INDEX idx_task_tags_tag_id ON task_tags(tag_id);
@@ -0,0 +1,17 @@
+-- プロジェクトを追加
+-- プロジェクト名は "work.backend.api" のようにドットで階層を区切り、正規化（小文字）された状態で保存する
+-- 上位のプロジェクトは名前から導出するため、タスクが属するプロジェクトのみを保存する
+CREATE TABLE IF NOT EXISTS projects (
+    -- 主キー: プロジェクトの連番ID
+    id SERIAL PRIMARY KEY,
+
+    -- プロジェクト名（一意）
+    name VARCHAR(255) NOT NULL UNIQUE
+);
+
+-- タスクが属するプロジェクト（NULLの場合はプロジェクトなし）
+-- プロジェクトを削除した場合、タスクはプロジェクトなしとなる
+ALTER TABLE tasks ADD COLUMN project_id INTEGER REFERENCES projects(id) ON DELETE SET NULL;
+
+-- プロジェクトによる絞り込みと件数の集計を高速化
+CREATE INDEX idx_tasks_project_id ON tasks(project_id);
@@ -0,0 +1,8 @@
+-- サブタスクのための親タスクを追加
+-- 親タスク（NULLの場合は最上位のタスク）
+-- 親タスクを削除した場合、サブタスクは最上位のタスクとなる
+-- 親子関係が循環しないことはアプリケーションで検証する
+ALTER TABLE tasks ADD COLUMN parent_id VARCHAR(36) REFERENCES tasks(id) ON DELETE SET NULL;
+
+-- サブタスクの取得を高速化
+CREATE INDEX idx_tasks_parent_id ON tasks(parent_id);
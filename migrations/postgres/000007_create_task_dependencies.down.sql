@@ -0,0 +1,2 @@
+-- タスク同士の依存関係を削除
+DROP TABLE IF EXISTS task_dependencies;
@@ -0,0 +1,17 @@
+-- 繰り返しのルール（テンプレート）を追加
+-- ルールは "FREQ=WEEKLY;BYDAY=MO,TH" のようなRRULE形式で保存する
+-- 同じ繰り返しから作成されたタスクは同じルールを参照し、ルールの変更や停止は一箇所で行う
+CREATE TABLE IF NOT EXISTS recurrences (
+    -- 主キー: UUID形式の文字列
+    id VARCHAR(36) PRIMARY KEY,
+
+    -- 繰り返しのルール
+    rule VARCHAR(255) NOT NULL
+);
+
+-- タスクが参照する繰り返しのルール（NULLの場合は繰り返さないタスク）
+-- ルールを削除した場合、タスクは繰り返さないタスクとなる
+ALTER TABLE tasks ADD COLUMN recurrence_id VARCHAR(36) REFERENCES recurrences(id) ON DELETE SET NULL;
+
+-- 同じ繰り返しのタスクの取得を高速化
+CREATE INDEX idx_tasks_recurrence_id ON tasks(recurrence_id);
@@ -0,0 +1,22 @@
+-- タスクの説明と注記を追加
+-- 説明はタイトルに収まらない詳細をMarkdownで記述する（未設定の場合は空文字列）
+ALTER TABLE tasks ADD COLUMN description TEXT NOT NULL DEFAULT '';
+
+-- 注記はタスクに追記していく日時付きのメモ（"業者に電話した" など）
+-- タスクを削除すると注記も削除される
+CREATE TABLE IF NOT EXISTS task_annotations (
+    -- 主キー: 注記の連番ID（同じ日時の注記の順序にも使う）
+    id SERIAL PRIMARY KEY,
+
+    -- 注記を付けたタスク
+    task_id VARCHAR(36) NOT NULL REFERENCES tasks(id) ON DELETE CASCADE,
+
+    -- 注記の本文
+    text TEXT NOT NULL,
+
+    -- 注記を追加した日時
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+-- タスクごとの注記の取得を高速化
+CREATE INDEX idx_task_annotations_task_id ON task_annotations(task_id);
@@ -0,0 +1,6 @@
+-- タスクの全文検索の索引を削除
+DROP INDEX IF EXISTS idx_task_annotations_search_vector;
+ALTER TABLE task_annotations DROP COLUMN IF EXISTS search_vector;
+
+DROP INDEX IF EXISTS idx_tasks_search_vector;
+ALTER TABLE tasks DROP COLUMN IF EXISTS search_vector;
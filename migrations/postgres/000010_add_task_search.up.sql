@@ -0,0 +1,17 @@
+-- タスクの全文検索の索引を追加
+-- 言語ごとの語形の変化を扱わず、日本語など空白で区切らない言語でも語を落とさないよう 'simple' 設定を使う
+-- 関連度の重みはタイトル（A）、説明（B）、注記（C）の順に高くする
+ALTER TABLE tasks ADD COLUMN search_vector tsvector GENERATED ALWAYS AS (
+    setweight(to_tsvector('simple', title), 'A') ||
+    setweight(to_tsvector('simple', description), 'B')
+) STORED;
+
+-- タスクの検索を高速化
+CREATE INDEX idx_tasks_search_vector ON tasks USING GIN (search_vector);
+
+ALTER TABLE task_annotations ADD COLUMN search_vector tsvector GENERATED ALWAYS AS (
+    setweight(to_tsvector('simple', text), 'C')
+) STORED;
+
+-- 注記の検索を高速化
+CREATE INDEX idx_task_annotations_search_vector ON task_annotations USING GIN (search_vector);
@@ -0,0 +1,3 @@
+-- タスクの開始日時と待機日時を削除
+ALTER TABLE tasks DROP COLUMN IF EXISTS wait_until;
+ALTER TABLE tasks DROP COLUMN IF EXISTS start_at;
@@ -0,0 +1,2 @@
+-- タスクの作業時間の記録を削除
+DROP TABLE IF EXISTS time_entries;
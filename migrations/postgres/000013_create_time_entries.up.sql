@@ -0,0 +1,26 @@
+-- タスクの作業時間の記録を追加
+-- start と stop で計測した区間と、log で手入力した区間を記録する
+-- ended_at が NULL の記録は計測中であり、利用者ごとに1件までとする規則はアプリケーションで守る
+-- タスクを削除すると作業時間の記録も削除される
+CREATE TABLE IF NOT EXISTS time_entries (
+    -- 主キー: 記録の連番ID
+    id SERIAL PRIMARY KEY,
+
+    -- 作業したタスク
+    task_id VARCHAR(36) NOT NULL REFERENCES tasks(id) ON DELETE CASCADE,
+
+    -- 作業した利用者の名前（"user" は予約語のため user_name とする）
+    user_name TEXT NOT NULL,
+
+    -- 作業を始めた日時と終えた日時（計測中の場合は終えた日時が NULL）
+    started_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    ended_at TIMESTAMP WITH TIME ZONE,
+
+    CHECK (ended_at IS NULL OR ended_at >= started_at)
+);
+
+-- タスクごとの記録の取得を高速化
+CREATE INDEX idx_time_entries_task_id ON time_entries(task_id);
+
+-- 利用者ごとの期間の集計を高速化
+CREATE INDEX idx_time_entries_user_started_at ON time_entries(user_name, started_at);
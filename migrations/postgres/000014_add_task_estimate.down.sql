@@ -0,0 +1,4 @@
+-- タスクの見積もりを削除
+ALTER TABLE tasks DROP CONSTRAINT IF EXISTS tasks_estimate_check;
+ALTER TABLE tasks DROP COLUMN IF EXISTS estimate_points;
+ALTER TABLE tasks DROP COLUMN IF EXISTS estimate_seconds;
@@ -0,0 +1,8 @@
+-- タスクの見積もりを追加
+-- 作業時間（秒）とストーリーポイントのいずれか一方のみを設定する（未設定の場合はいずれもNULL）
+ALTER TABLE tasks ADD COLUMN estimate_seconds BIGINT
+    CONSTRAINT tasks_estimate_seconds_check CHECK (estimate_seconds > 0);
+ALTER TABLE tasks ADD COLUMN estimate_points DOUBLE PRECISION
+    CONSTRAINT tasks_estimate_points_check CHECK (estimate_points > 0);
+ALTER TABLE tasks ADD CONSTRAINT tasks_estimate_check
+    CHECK (estimate_seconds IS NULL OR estimate_points IS NULL);
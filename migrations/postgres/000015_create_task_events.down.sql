@@ -0,0 +1,3 @@
+-- タスクの履歴と完了日時を削除
+DROP TABLE IF EXISTS task_events;
+ALTER TABLE tasks DROP COLUMN IF EXISTS completed_at;
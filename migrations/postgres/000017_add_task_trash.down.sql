@@ -0,0 +1,10 @@
+-- タスクの削除日時を削除
+-- ゴミ箱のタスクは元に戻らないよう、完全に削除する
+DELETE FROM task_events WHERE kind IN ('restored', 'purged');
+ALTER TABLE task_events DROP CONSTRAINT IF EXISTS task_events_kind_check;
+ALTER TABLE task_events ADD CONSTRAINT task_events_kind_check
+    CHECK (kind IN ('created', 'changed', 'completed', 'deleted'));
+
+DELETE FROM tasks WHERE deleted_at IS NOT NULL;
+DROP INDEX IF EXISTS idx_tasks_deleted_at;
+ALTER TABLE tasks DROP COLUMN IF EXISTS deleted_at;
@@ -0,0 +1,12 @@
+-- タスクの削除日時を追加し、削除したタスクをゴミ箱に残す
+-- 削除日時が設定されたタスクは削除済みとして、一覧や検索には表示しない（削除していない場合はNULL）
+-- ゴミ箱のタスクは restore で元に戻すか、purge で完全に削除する
+ALTER TABLE tasks ADD COLUMN deleted_at TIMESTAMP WITH TIME ZONE;
+
+-- ゴミ箱の一覧と、古いタスクの完全な削除を高速化
+CREATE INDEX idx_tasks_deleted_at ON tasks(deleted_at);
+
+-- タスクの履歴に、ゴミ箱からの復元と完全な削除を記録する
+ALTER TABLE task_events DROP CONSTRAINT IF EXISTS task_events_kind_check;
+ALTER TABLE task_events ADD CONSTRAINT task_events_kind_check
+    CHECK (kind IN ('created', 'changed', 'completed', 'deleted', 'restored', 'purged'));
@@ -0,0 +1,3 @@
+-- 計測中の記録（ended_at が NULL の記録）を利用者ごとに1件までとする
+-- 同時に start を実行した場合も、後から追加した記録は一意制約の違反となる
+CREATE UNIQUE INDEX idx_time_entries_running_user ON time_entries(user_name) WHERE ended_at IS NULL;
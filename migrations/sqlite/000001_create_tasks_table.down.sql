@@ -0,0 +1,10 @@
+-- マイグレーションのロールバック用SQL
+-- テーブル作成の逆操作を定義
+
+-- インデックスの削除
+DROP INDEX IF EXISTS idx_tasks_created_at;
+DROP INDEX IF EXISTS idx_tasks_is_complete;
+DROP INDEX IF EXISTS idx_tasks_deadline;
+
+-- tasksテーブルの削除
+DROP TABLE IF EXISTS tasks;
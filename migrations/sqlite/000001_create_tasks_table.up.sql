@@ -0,0 +1,32 @@
+-- tasksテーブルの作成
+-- PostgreSQL版と同じ構造をSQLiteの型で定義する
+-- 日時の列はドライバが時刻として読み書きできるようTIMESTAMP型とし、値はUTCで保存する
+CREATE TABLE IF NOT EXISTS tasks (
+    -- 主キー: UUID形式のタスクID
+    id TEXT PRIMARY KEY,
+
+    -- タスクのタイトル（必須）
+    title TEXT NOT NULL,
+
+    -- タスクの締切日時（NULL許可）
+    deadline TIMESTAMP,
+
+    -- タスクの完了状態（デフォルトは未完了）
+    is_complete BOOLEAN NOT NULL DEFAULT FALSE,
+
+    -- レコードの作成日時
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    -- レコードの更新日時
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+-- インデックスの作成
+-- 締切日でのソートや検索を高速化
+CREATE INDEX idx_tasks_deadline ON tasks(deadline);
+
+-- 完了状態での絞り込みを高速化
+CREATE INDEX idx_tasks_is_complete ON tasks(is_complete);
+
+-- 作成日時でのソートを高速化
+CREATE INDEX idx_tasks_created_at ON tasks(created_at);
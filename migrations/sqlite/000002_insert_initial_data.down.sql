@@ -0,0 +1,11 @@
+-- 初期データの削除
+-- マイグレーションロールバック時に初期データを削除
+
+-- 特定のIDで挿入したサンプルデータを削除
+DELETE FROM tasks WHERE id IN (
+    'f47ac10b-58cc-4372-a567-0e02b2c3d479',
+    'e874dcb4-f042-5910-9ab7-0321a60c45ac',
+    '7d6d370d-a4f1-430b-06c7-d4a363341564',
+    'f2f3dbc2-6985-dc25-ecfb-4838a4643e9e',
+    '99350b0f-65ef-470f-ed59-62808d763b78'
+);
\ No newline at end of file
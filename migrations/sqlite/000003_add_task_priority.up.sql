@@ -0,0 +1,7 @@
+-- タスクの優先度を追加
+-- 0: なし, 1: 低, 2: 中, 3: 高, 4: 緊急
+-- SQLiteではCHECK制約を持つ列を削除できないため、値の範囲はアプリケーションで検証する
+ALTER TABLE tasks ADD COLUMN priority INTEGER NOT NULL DEFAULT 0;
+
+-- 優先度でのソートを高速化
+CREATE INDEX idx_tasks_priority ON tasks(priority);
@@ -0,0 +1,20 @@
+-- タグとタスクの多対多の関連を追加
+-- タグ名は正規化（小文字）された状態で保存する
+CREATE TABLE IF NOT EXISTS tags (
+    -- 主キー: タグの連番ID
+    id INTEGER PRIMARY KEY,
+
+    -- タグ名（一意）
+    name TEXT NOT NULL UNIQUE
+);
+
+-- タスクとタグの関連
+-- タスクまたはタグを削除すると関連も削除される（外部キー制約は接続時に有効化している）
+CREATE TABLE IF NOT EXISTS task_tags (
+    task_id TEXT NOT NULL REFERENCES tasks(id) ON DELETE CASCADE,
+    tag_id INTEGER NOT NULL REFERENCES tags(id) ON DELETE CASCADE,
+    PRIMARY KEY (task_id, tag_id)
+);
+
+-- タグによる絞り込みと件数の集計を高速化
+CREATE INDEX idx_task_tags_tag_id ON task_tags(tag_id);
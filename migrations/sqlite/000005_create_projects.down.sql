@@ -0,0 +1,6 @@
+-- プロジェクトを削除
+DROP INDEX IF EXISTS idx_tasks_project_id;
+
+ALTER TABLE tasks DROP COLUMN project_id;
+
+DROP TABLE IF EXISTS projects;
@@ -0,0 +1,13 @@
+-- タスク同士の依存関係を追加
+-- task_id のタスクは blocked_by_id のタスクが完了するまで着手できない
+-- どちらかのタスクを削除すると依存関係も削除される（外部キー制約は接続時に有効化している）
+-- 依存関係が循環しないことはアプリケーションで検証する
+CREATE TABLE IF NOT EXISTS task_dependencies (
+    task_id TEXT NOT NULL REFERENCES tasks(id) ON DELETE CASCADE,
+    blocked_by_id TEXT NOT NULL REFERENCES tasks(id) ON DELETE CASCADE,
+    PRIMARY KEY (task_id, blocked_by_id),
+    CHECK (task_id <> blocked_by_id)
+);
+
+-- 下流のタスクの取得を高速化
+CREATE INDEX idx_task_dependencies_blocked_by_id ON task_dependencies(blocked_by_id);
@@ -0,0 +1,6 @@
+-- 繰り返しのルールを削除
+DROP INDEX IF EXISTS idx_tasks_recurrence_id;
+
+ALTER TABLE tasks DROP COLUMN recurrence_id;
+
+DROP TABLE IF EXISTS recurrences;
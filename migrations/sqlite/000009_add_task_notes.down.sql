@@ -0,0 +1,4 @@
+-- タスクの説明と注記を削除
+DROP TABLE IF EXISTS task_annotations;
+
+ALTER TABLE tasks DROP COLUMN description;
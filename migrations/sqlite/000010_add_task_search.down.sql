@@ -0,0 +1,2 @@
+-- タスクの全文検索の索引を削除
+-- SQLiteでは追加したものがないため、削除するものはない
@@ -0,0 +1,3 @@
+-- タスクの全文検索の索引を追加
+-- SQLiteではタイトル、説明、注記の部分一致で検索するため、追加するものはない
+-- マイグレーションのバージョンをPostgreSQLと揃えるために置いている
@@ -0,0 +1,5 @@
+-- タスクの開始日時と待機日時を追加
+-- 開始日時は着手できるようになる日時、待機日時は snooze で先送りした日時
+-- いずれかが未来のタスクは待機中として、既定の一覧には表示しない（未設定の場合はNULL、UTCで保存する）
+ALTER TABLE tasks ADD COLUMN start_at TIMESTAMP;
+ALTER TABLE tasks ADD COLUMN wait_until TIMESTAMP;
@@ -0,0 +1,8 @@
+-- タスクの状態を完了フラグに戻す
+-- 既定のワークフローの終了状態（done と cancelled）を完了済みとする
+ALTER TABLE tasks ADD COLUMN is_complete BOOLEAN NOT NULL DEFAULT FALSE;
+UPDATE tasks SET is_complete = status IN ('done', 'cancelled');
+
+DROP INDEX IF EXISTS idx_tasks_status;
+ALTER TABLE tasks DROP COLUMN status;
+CREATE INDEX idx_tasks_is_complete ON tasks(is_complete);
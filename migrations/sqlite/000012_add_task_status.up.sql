@@ -0,0 +1,10 @@
+-- タスクの完了フラグをワークフロー上の状態に置き換える
+-- 状態の名前と状態遷移は設定ファイルの workflow で定義するため、データベースでは値を制約しない
+-- 既存のタスクは、完了済みを done、未完了を open とする
+-- SQLiteでは索引が残っている列を削除できないため、索引を先に削除する
+ALTER TABLE tasks ADD COLUMN status TEXT NOT NULL DEFAULT 'open';
+UPDATE tasks SET status = CASE WHEN is_complete THEN 'done' ELSE 'open' END;
+
+DROP INDEX IF EXISTS idx_tasks_is_complete;
+ALTER TABLE tasks DROP COLUMN is_complete;
+CREATE INDEX idx_tasks_status ON tasks(status);
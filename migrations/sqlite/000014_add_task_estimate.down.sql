@@ -0,0 +1,3 @@
+-- タスクの見積もりを削除
+ALTER TABLE tasks DROP COLUMN estimate_points;
+ALTER TABLE tasks DROP COLUMN estimate_seconds;
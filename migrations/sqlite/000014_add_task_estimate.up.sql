@@ -0,0 +1,5 @@
+-- タスクの見積もりを追加
+-- 作業時間（秒）とストーリーポイントのいずれか一方のみを設定する（未設定の場合はいずれもNULL）
+-- SQLiteではCHECK制約を持つ列を削除できないため、値はアプリケーションで検証する
+ALTER TABLE tasks ADD COLUMN estimate_seconds INTEGER;
+ALTER TABLE tasks ADD COLUMN estimate_points REAL;
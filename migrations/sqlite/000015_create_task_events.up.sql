@@ -0,0 +1,33 @@
+-- タスクの完了日時と、タスクの履歴（監査ログ）を追加
+-- 完了日時は終了状態に変更した日時（終了状態でないタスクは NULL、UTCで保存する）
+-- 終了状態は設定ファイルの workflow で定義するため、既存のタスクは既定のワークフローの終了状態について、
+-- 最後に更新した日時を完了日時とする
+ALTER TABLE tasks ADD COLUMN completed_at TIMESTAMP;
+UPDATE tasks SET completed_at = updated_at WHERE status IN ('done', 'cancelled');
+
+-- タスクの作成、項目の変更、完了、削除を記録する
+-- 削除したタスクの履歴も残すため、タスクへの外部キー制約は設けない
+CREATE TABLE IF NOT EXISTS task_events (
+    -- 主キー: 記録の連番ID（同じ日時の記録は ID の順に並べる）
+    id INTEGER PRIMARY KEY,
+
+    -- 操作したタスク
+    task_id TEXT NOT NULL,
+
+    -- 操作の種類
+    kind TEXT NOT NULL CHECK (kind IN ('created', 'changed', 'completed', 'deleted')),
+
+    -- 変更した項目の名前と、変更前と変更後の値（作成と削除の場合、項目の名前は空）
+    field TEXT NOT NULL DEFAULT '',
+    old_value TEXT NOT NULL DEFAULT '',
+    new_value TEXT NOT NULL DEFAULT '',
+
+    -- 操作した利用者の名前（不明な場合は空）
+    actor TEXT NOT NULL DEFAULT '',
+
+    -- 操作した日時（UTCで保存する）
+    occurred_at TIMESTAMP NOT NULL
+);
+
+-- タスクごとの履歴の取得を高速化
+CREATE INDEX idx_task_events_task_id ON task_events(task_id, occurred_at);
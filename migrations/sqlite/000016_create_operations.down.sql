@@ -0,0 +1,2 @@
+-- 取り消しのための操作の記録を削除
+DROP TABLE IF EXISTS operations;
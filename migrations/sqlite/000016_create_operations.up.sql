@@ -0,0 +1,32 @@
+-- 取り消し（undo）とやり直し（redo）のために、タスクの作成、更新、削除を記録する
+-- 変更前と変更後のタスクはJSONで保存し、取り消す場合は変更後から変更前に戻す
+-- 削除したタスクの操作も残すため、タスクへの外部キー制約は設けない
+CREATE TABLE IF NOT EXISTS operations (
+    -- 主キー: 記録の連番ID（取り消しとやり直しは ID の順序で行う）
+    id INTEGER PRIMARY KEY,
+
+    -- 同じコマンドで行った操作に共通するID（取り消しとやり直しはコマンドの単位で行う）
+    batch_id TEXT NOT NULL,
+
+    -- 操作を行ったコマンド（不明な場合は空）
+    command TEXT NOT NULL DEFAULT '',
+
+    -- 操作の種類
+    kind TEXT NOT NULL CHECK (kind IN ('create', 'update', 'delete')),
+
+    -- 操作したタスク
+    task_id TEXT NOT NULL,
+
+    -- 変更前と変更後のタスク（作成の場合は変更前、削除の場合は変更後が NULL）
+    before_state TEXT,
+    after_state TEXT,
+
+    -- 取り消し済みかどうか（取り消し済みの操作はやり直しの対象となる）
+    undone BOOLEAN NOT NULL DEFAULT FALSE,
+
+    -- 操作した日時（UTCで保存する）
+    recorded_at TIMESTAMP NOT NULL
+);
+
+-- コマンドの単位での操作の取得と削除を高速化
+CREATE INDEX idx_operations_batch_id ON operations(batch_id);
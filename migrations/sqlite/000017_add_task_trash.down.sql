@@ -0,0 +1,22 @@
+-- タスクの削除日時を削除
+-- ゴミ箱のタスクは元に戻らないよう、完全に削除する
+-- SQLiteでは制約を変更できないため、履歴のテーブルを作り直す
+CREATE TABLE task_events_old (
+    id INTEGER PRIMARY KEY,
+    task_id TEXT NOT NULL,
+    kind TEXT NOT NULL CHECK (kind IN ('created', 'changed', 'completed', 'deleted')),
+    field TEXT NOT NULL DEFAULT '',
+    old_value TEXT NOT NULL DEFAULT '',
+    new_value TEXT NOT NULL DEFAULT '',
+    actor TEXT NOT NULL DEFAULT '',
+    occurred_at TIMESTAMP NOT NULL
+);
+INSERT INTO task_events_old SELECT id, task_id, kind, field, old_value, new_value, actor, occurred_at
+    FROM task_events WHERE kind NOT IN ('restored', 'purged');
+DROP TABLE task_events;
+ALTER TABLE task_events_old RENAME TO task_events;
+CREATE INDEX idx_task_events_task_id ON task_events(task_id, occurred_at);
+
+DELETE FROM tasks WHERE deleted_at IS NOT NULL;
+DROP INDEX IF EXISTS idx_tasks_deleted_at;
+ALTER TABLE tasks DROP COLUMN deleted_at;
@@ -0,0 +1,24 @@
+-- タスクの削除日時を追加し、削除したタスクをゴミ箱に残す
+-- 削除日時が設定されたタスクは削除済みとして、一覧や検索には表示しない（削除していない場合はNULL、UTCで保存する）
+-- ゴミ箱のタスクは restore で元に戻すか、purge で完全に削除する
+ALTER TABLE tasks ADD COLUMN deleted_at TIMESTAMP;
+
+-- ゴミ箱の一覧と、古いタスクの完全な削除を高速化
+CREATE INDEX idx_tasks_deleted_at ON tasks(deleted_at);
+
+-- タスクの履歴に、ゴミ箱からの復元と完全な削除を記録する
+-- SQLiteでは制約を変更できないため、テーブルを作り直す
+CREATE TABLE task_events_new (
+    id INTEGER PRIMARY KEY,
+    task_id TEXT NOT NULL,
+    kind TEXT NOT NULL CHECK (kind IN ('created', 'changed', 'completed', 'deleted', 'restored', 'purged')),
+    field TEXT NOT NULL DEFAULT '',
+    old_value TEXT NOT NULL DEFAULT '',
+    new_value TEXT NOT NULL DEFAULT '',
+    actor TEXT NOT NULL DEFAULT '',
+    occurred_at TIMESTAMP NOT NULL
+);
+INSERT INTO task_events_new SELECT id, task_id, kind, field, old_value, new_value, actor, occurred_at FROM task_events;
+DROP TABLE task_events;
+ALTER TABLE task_events_new RENAME TO task_events;
+CREATE INDEX idx_task_events_task_id ON task_events(task_id, occurred_at);
@@ -0,0 +1,2 @@
+-- 計測中の記録を利用者ごとに1件までとする一意制約を削除
+DROP INDEX IF EXISTS idx_time_entries_running_user;